@@ -104,6 +104,13 @@ func (p *mockStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (p *mockStatusProvider) GetCaptureLoads(ctx context.Context) (
+	map[model.CaptureID]*model.CaptureLoad, error,
+) {
+	args := p.Called(ctx)
+	return args.Get(0).(map[model.CaptureID]*model.CaptureLoad), args.Error(1)
+}
+
 func newRouter(c capture.Capture, p owner.StatusProvider) *gin.Engine {
 	router := gin.New()
 	RegisterOpenAPIRoutes(router, NewOpenAPI4Test(c, p))
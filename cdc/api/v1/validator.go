@@ -168,6 +168,14 @@ func verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, err
 	}
+	err = validator.ValidateDispatchRules(sinkURIParsed, replicaConfig, tableInfos)
+	if err != nil {
+		return nil, err
+	}
+	err = validator.ValidateColumnSelectors(replicaConfig, tableInfos)
+	if err != nil {
+		return nil, err
+	}
 	if !replicaConfig.ForceReplicate && !changefeedConfig.IgnoreIneligibleTable {
 		if len(ineligibleTables) != 0 {
 			return nil, cerror.ErrTableIneligible.GenWithStackByArgs(ineligibleTables)
@@ -16,6 +16,7 @@ package v2
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
@@ -72,6 +73,7 @@ type APIV2Helpers interface {
 		cfg *ChangefeedConfig,
 		oldInfo *model.ChangeFeedInfo,
 		oldUpInfo *model.UpstreamInfo,
+		statusProvider owner.StatusProvider,
 		kvStorage tidbkv.Storage,
 		checkpointTs uint64,
 	) (*model.ChangeFeedInfo, *model.UpstreamInfo, error)
@@ -205,6 +207,11 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, err
 	}
+	err = verifyFollower(ctx, statusProvider,
+		model.ChangeFeedID{Namespace: cfg.Namespace, ID: cfg.ID}, replicaCfg)
+	if err != nil {
+		return nil, err
+	}
 
 	f, err := filter.NewFilter(replicaCfg, "")
 	if err != nil {
@@ -218,6 +225,12 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
+	if err := validator.ValidateDispatchRules(sinkURIParsed, replicaCfg, tableInfos); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateColumnSelectors(replicaCfg, tableInfos); err != nil {
+		return nil, err
+	}
 	if !replicaCfg.ForceReplicate && !cfg.ReplicaConfig.IgnoreIneligibleTable {
 		if err != nil {
 			return nil, err
@@ -278,6 +291,48 @@ func (h APIV2HelpersImpl) verifyUpstream(ctx context.Context,
 	return nil
 }
 
+// verifyFollower checks that the changefeed does not follow itself, directly
+// or through the changefeeds it follows, because the changefeeds in such a
+// cycle wait for each other and never advance.
+func verifyFollower(
+	ctx context.Context,
+	statusProvider owner.StatusProvider,
+	id model.ChangeFeedID,
+	cfg *config.ReplicaConfig,
+) error {
+	if cfg.Follower == nil {
+		return nil
+	}
+	if cfg.Follower.LeaderChangefeedID == id.ID {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"a changefeed can not follow itself")
+	}
+	infos, err := statusProvider.GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := []string{id.ID}
+	leaderID := cfg.Follower.LeaderChangefeedID
+	for {
+		path = append(path, leaderID)
+		if leaderID == id.ID {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("changefeeds can not follow each other in a cycle: %s",
+					strings.Join(path, " -> ")))
+		}
+		info, ok := infos[model.ChangeFeedID{Namespace: id.Namespace, ID: leaderID}]
+		if !ok || info.Config == nil || info.Config.Follower == nil {
+			return nil
+		}
+		leaderID = info.Config.Follower.LeaderChangefeedID
+		// The existing changefeeds may already form a cycle without this
+		// changefeed, which is not caused by this config.
+		if len(path) > len(infos)+1 {
+			return nil
+		}
+	}
+}
+
 // verifyUpdateChangefeedConfig verifies config to update
 // a changefeed and returns a changefeedInfo
 func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
@@ -285,6 +340,7 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 	cfg *ChangefeedConfig,
 	oldInfo *model.ChangeFeedInfo,
 	oldUpInfo *model.UpstreamInfo,
+	statusProvider owner.StatusProvider,
 	kvStorage tidbkv.Storage,
 	checkpointTs uint64,
 ) (*model.ChangeFeedInfo, *model.UpstreamInfo, error) {
@@ -353,6 +409,19 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 		if err != nil {
 			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
 		}
+		err = verifyFollower(ctx, statusProvider,
+			model.ChangeFeedID{Namespace: newInfo.Namespace, ID: newInfo.ID}, newInfo.Config)
+		if err != nil {
+			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+		err = validator.ValidateDispatchRules(sinkURIParsed, newInfo.Config, tableInfos)
+		if err != nil {
+			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+		err = validator.ValidateColumnSelectors(newInfo.Config, tableInfos)
+		if err != nil {
+			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
 
 		if err := validator.Validate(ctx,
 			model.ChangeFeedID{Namespace: cfg.Namespace, ID: cfg.ID},
@@ -104,6 +104,26 @@ func TestVerifyCreateChangefeedConfig(t *testing.T) {
 	cfg.ReplicaConfig.ForceReplicate = true
 	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
 	require.Error(t, cerror.ErrOldValueNotEnabled, err)
+
+	// a changefeed can not follow itself
+	cfg.ReplicaConfig.ForceReplicate = false
+	cfg.ID = "follower"
+	cfg.ReplicaConfig.Follower = &FollowerConfig{LeaderChangefeedID: "follower"}
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.ErrorContains(t, err, "a changefeed can not follow itself")
+	provider.err = nil
+	cfg.ReplicaConfig.Follower.LeaderChangefeedID = "leader"
+	cfInfo, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.NoError(t, err)
+	require.Equal(t, "leader", cfInfo.Config.Follower.LeaderChangefeedID)
+	// changefeeds can not follow each other in a cycle
+	leaderCfg := config.GetDefaultReplicaConfig()
+	leaderCfg.Follower = &config.FollowerConfig{LeaderChangefeedID: "follower"}
+	provider.changefeedInfos = map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		model.DefaultChangeFeedID("leader"): {Config: leaderCfg},
+	}
+	_, err = h.verifyCreateChangefeedConfig(ctx, cfg, pdClient, provider, "en", storage)
+	require.ErrorContains(t, err, "follower -> leader -> follower")
 }
 
 func TestVerifyUpdateChangefeedConfig(t *testing.T) {
@@ -113,18 +133,19 @@ func TestVerifyUpdateChangefeedConfig(t *testing.T) {
 		Config: config.GetDefaultReplicaConfig(),
 	}
 	oldUpInfo := &model.UpstreamInfo{}
+	provider := &mockStatusProvider{}
 	helper := entry.NewSchemaTestHelper(t)
 	helper.Tk().MustExec("use test;")
 	storage := helper.Storage()
 	h := &APIV2HelpersImpl{}
-	newCfInfo, newUpInfo, err := h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err := h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.NotNil(t, err)
 	require.Nil(t, newCfInfo)
 	require.Nil(t, newUpInfo)
 	// namespace and id can not be updated
 	cfg.Namespace = "abc"
 	cfg.ID = "1234"
-	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.NotNil(t, err)
 	require.Nil(t, newCfInfo)
 	require.Nil(t, newUpInfo)
@@ -138,7 +159,7 @@ func TestVerifyUpdateChangefeedConfig(t *testing.T) {
 	cfg.KeyPath = "p3"
 	cfg.SinkURI = "blackhole://"
 	cfg.CertAllowedCN = []string{"c", "d"}
-	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.Nil(t, err)
 	// startTs can not be updated
 	require.Equal(t, uint64(0), newCfInfo.StartTs)
@@ -153,18 +174,39 @@ func TestVerifyUpdateChangefeedConfig(t *testing.T) {
 	require.Equal(t, "blackhole://", newCfInfo.SinkURI)
 	oldInfo.StartTs = 10
 	cfg.TargetTs = 9
-	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.NotNil(t, err)
 
 	cfg.StartTs = 0
 	cfg.TargetTs = 0
 	cfg.ReplicaConfig.EnableOldValue = true
 	cfg.SinkURI = "blackhole://127.0.0.1:9092/test?protocol=avro"
-	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.NoError(t, err)
 	require.False(t, newCfInfo.Config.EnableOldValue)
 
 	cfg.ReplicaConfig.ForceReplicate = true
-	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, storage, 0)
+	newCfInfo, newUpInfo, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
 	require.Error(t, cerror.ErrOldValueNotEnabled, err)
+
+	// changefeeds can not follow each other in a cycle
+	cfg.ReplicaConfig.ForceReplicate = false
+	oldInfo.ID = "a"
+	oldInfo.Namespace = model.DefaultNamespace
+	cfg.ReplicaConfig.Follower = &FollowerConfig{LeaderChangefeedID: "b"}
+	bCfg := config.GetDefaultReplicaConfig()
+	bCfg.Follower = &config.FollowerConfig{LeaderChangefeedID: "c"}
+	cCfg := config.GetDefaultReplicaConfig()
+	cCfg.Follower = &config.FollowerConfig{LeaderChangefeedID: "a"}
+	provider.changefeedInfos = map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		model.DefaultChangeFeedID("a"): oldInfo,
+		model.DefaultChangeFeedID("b"): {Config: bCfg},
+		model.DefaultChangeFeedID("c"): {Config: cCfg},
+	}
+	_, _, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
+	require.ErrorContains(t, err, "a -> b -> c -> a")
+	cCfg.Follower = nil
+	newCfInfo, _, err = h.verifyUpdateChangefeedConfig(ctx, cfg, oldInfo, oldUpInfo, provider, storage, 0)
+	require.NoError(t, err)
+	require.Equal(t, "b", newCfInfo.Config.Follower.LeaderChangefeedID)
 }
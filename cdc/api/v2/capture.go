@@ -90,7 +90,8 @@ func (h *OpenAPIV2) drainCapture(c *gin.Context) {
 // @Router	/api/v2/captures [get]
 func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 	ctx := c.Request.Context()
-	captureInfos, err := h.capture.StatusProvider().GetCaptures(ctx)
+	statusProvider := h.capture.StatusProvider()
+	captureInfos, err := statusProvider.GetCaptures(ctx)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -101,6 +102,11 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 		return
 	}
 	ownerID := info.ID
+	loads, err := statusProvider.GetCaptureLoads(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	etcdClient := h.capture.GetEtcdClient()
 
@@ -113,6 +119,8 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 				IsOwner:       isOwner,
 				AdvertiseAddr: c.AdvertiseAddr,
 				ClusterID:     etcdClient.GetClusterID(),
+				Labels:        c.Labels,
+				Load:          toAPICaptureLoad(loads[c.ID]),
 			})
 	}
 	resp := &ListResponse[Capture]{
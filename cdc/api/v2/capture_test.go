@@ -103,11 +103,16 @@ func TestListCaptures(t *testing.T) {
 			{
 				ID:            "capture-id",
 				AdvertiseAddr: "add2",
+				Labels:        map[string]string{"zone": "z1"},
 			},
 		}, nil)
 		cp.EXPECT().Info().Return(model.CaptureInfo{
 			ID: "owner-id",
 		}, nil)
+		statusProvider.EXPECT().GetCaptureLoads(gomock.Any()).Return(
+			map[model.CaptureID]*model.CaptureLoad{
+				"owner-id": {TableCount: 2, RegionCount: 3, SinkRowsPerSecond: 10, Load: 6},
+			}, nil)
 		etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
 		etcdClient.EXPECT().GetClusterID().AnyTimes().Return("cdc-cluster-id")
 		cp.EXPECT().GetEtcdClient().AnyTimes().Return(etcdClient)
@@ -133,10 +138,15 @@ func TestListCaptures(t *testing.T) {
 				require.True(t, item.IsOwner)
 				require.Equal(t, "add1", item.AdvertiseAddr)
 				require.Equal(t, "cdc-cluster-id", item.ClusterID)
+				require.Equal(t, &CaptureLoad{
+					TableCount: 2, RegionCount: 3, SinkRowsPerSecond: 10, Load: 6,
+				}, item.Load)
 			} else {
 				require.False(t, item.IsOwner)
 				require.Equal(t, "add2", item.AdvertiseAddr)
 				require.Equal(t, "cdc-cluster-id", item.ClusterID)
+				require.Nil(t, item.Load)
+				require.Equal(t, map[string]string{"zone": "z1"}, item.Labels)
 			}
 		}
 	}
@@ -16,6 +16,7 @@ package v2
 import (
 	"context"
 	"net/http"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -26,7 +27,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/api"
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
 	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
@@ -359,6 +362,8 @@ func (h *OpenAPIV2) verifyTable(c *gin.Context) {
 // Can only update a changefeed's: TargetTs, SinkURI,
 // ReplicaConfig, PDAddrs, CAPath, CertPath, KeyPath,
 // SyncPointEnabled, SyncPointInterval
+// The changefeed must be stopped or failed, except that only the
+// replica_config.throughput_limit is updated.
 // UpdateChangefeed updates a changefeed
 // @Summary Update a changefeed
 // @Description Update a changefeed
@@ -390,6 +395,11 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 
 	switch oldCfInfo.State {
 	case model.StateStopped, model.StateFailed:
+	case model.StateNormal, model.StateError:
+		// The throughput limit and the filter rules can be updated without
+		// stopping the changefeed.
+		h.updateRunningChangefeed(c, changefeedID)
+		return
 	default:
 		_ = c.Error(
 			cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
@@ -450,7 +460,8 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		_ = c.Error(errors.Trace(err))
 	}
 	newCfInfo, newUpInfo, err := h.helpers.verifyUpdateChangefeedConfig(ctx,
-		updateCfConfig, oldCfInfo, OldUpInfo, storage, cfStatus.CheckpointTs)
+		updateCfConfig, oldCfInfo, OldUpInfo, h.capture.StatusProvider(),
+		storage, cfStatus.CheckpointTs)
 	if err != nil {
 		_ = c.Error(errors.Trace(err))
 		return
@@ -470,6 +481,106 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		cfStatus.ResolvedTs, cfStatus.CheckpointTs, nil, true))
 }
 
+// updateRunningChangefeed updates the throughput limit and the table filter
+// rules of a running changefeed. The request is refused if it updates
+// anything else.
+func (h *OpenAPIV2) updateRunningChangefeed(c *gin.Context, changefeedID model.ChangeFeedID) {
+	ctx := c.Request.Context()
+
+	updateCfConfig := &ChangefeedConfig{}
+	if err := c.ShouldBindJSON(updateCfConfig); err != nil ||
+		!isOnlineUpdatable(updateCfConfig) {
+		_ = c.Error(
+			cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+				"can only update changefeed config when it is stopped or failed, " +
+					"except the throughput limit and the filter rules",
+			),
+		)
+		return
+	}
+
+	cfStatus, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	limit := updateCfConfig.ReplicaConfig.ThroughputLimit
+	filterCfg := updateCfConfig.ReplicaConfig.Filter
+	if filterCfg != nil {
+		if _, err := filter.VerifyTableRules(
+			&config.FilterConfig{Rules: filterCfg.Rules}); err != nil {
+			_ = c.Error(errors.Trace(err))
+			return
+		}
+		if updateCfConfig.StartTs != 0 && updateCfConfig.StartTs < cfStatus.CheckpointTs {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+				"start_ts %d is less than the checkpoint ts %d of the changefeed",
+				updateCfConfig.StartTs, cfStatus.CheckpointTs))
+			return
+		}
+	}
+	newCfInfo, err := h.capture.GetEtcdClient().PatchChangeFeedInfo(ctx, changefeedID,
+		func(info *model.ChangeFeedInfo) error {
+			if limit != nil {
+				info.Config.ThroughputLimit = &config.ThroughputLimitConfig{
+					RowsPerSecond:  limit.RowsPerSecond,
+					BytesPerSecond: limit.BytesPerSecond,
+				}
+			}
+			if filterCfg != nil {
+				if info.Config.Filter == nil {
+					info.Config.Filter = &config.FilterConfig{}
+				}
+				// Keep the filter that is currently applied by the owner
+				// if the previous update is not applied yet.
+				if info.PrevFilter == nil {
+					prevFilter := *info.Config.Filter
+					info.PrevFilter = &prevFilter
+				}
+				info.Config.Filter.Rules = filterCfg.Rules
+				info.FilterUpdateTs = updateCfConfig.StartTs
+			}
+			return nil
+		})
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	log.Info("running changefeed config is updated",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID),
+		zap.Any("throughputLimit", limit),
+		zap.Any("filter", filterCfg),
+		zap.Uint64("startTs", updateCfConfig.StartTs))
+
+	c.JSON(http.StatusOK, toAPIModel(newCfInfo,
+		cfStatus.ResolvedTs, cfStatus.CheckpointTs, nil, true))
+}
+
+// isOnlineUpdatable returns true if the request only updates the throughput
+// limit or the filter rules. The start ts of the tables added by the new
+// filter rules can be specified by StartTs.
+func isOnlineUpdatable(cfg *ChangefeedConfig) bool {
+	if cfg.ReplicaConfig == nil {
+		return false
+	}
+	limit, filterCfg := cfg.ReplicaConfig.ThroughputLimit, cfg.ReplicaConfig.Filter
+	if limit == nil && filterCfg == nil {
+		return false
+	}
+	expected := &ChangefeedConfig{
+		Namespace:     cfg.Namespace,
+		ID:            cfg.ID,
+		ReplicaConfig: &ReplicaConfig{ThroughputLimit: limit},
+	}
+	if filterCfg != nil {
+		expected.StartTs = cfg.StartTs
+		expected.ReplicaConfig.Filter = &FilterConfig{Rules: filterCfg.Rules}
+	}
+	return reflect.DeepEqual(cfg, expected)
+}
+
 // getChangefeed get detailed info of a changefeed
 // @Summary Get changefeed
 // @Description get detail information of a changefeed
@@ -447,7 +447,7 @@ func TestUpdateChangefeed(t *testing.T) {
 		Return(nil, nil).
 		AnyTimes()
 	helpers.EXPECT().
-		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&model.ChangeFeedInfo{}, &model.UpstreamInfo{}, cerrors.ErrChangefeedUpdateRefused).
 		Times(1)
 
@@ -466,7 +466,7 @@ func TestUpdateChangefeed(t *testing.T) {
 
 	// case 7: update transaction failed
 	helpers.EXPECT().
-		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&model.ChangeFeedInfo{}, &model.UpstreamInfo{}, nil).
 		Times(1)
 	etcdClient.EXPECT().
@@ -485,7 +485,7 @@ func TestUpdateChangefeed(t *testing.T) {
 
 	// case 8: success
 	helpers.EXPECT().
-		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(oldCfInfo, &model.UpstreamInfo{}, nil).
 		Times(1)
 	etcdClient.EXPECT().
@@ -501,7 +501,7 @@ func TestUpdateChangefeed(t *testing.T) {
 	// case 9: success with ChangeFeed.State equal to StateFailed
 	oldCfInfo.State = "failed"
 	helpers.EXPECT().
-		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		verifyUpdateChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(oldCfInfo, &model.UpstreamInfo{}, nil).
 		Times(1)
 	etcdClient.EXPECT().
@@ -513,6 +513,125 @@ func TestUpdateChangefeed(t *testing.T) {
 		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
 	router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
+
+	// case 10: update the throughput limit of a running changefeed
+	oldCfInfo.State = "normal"
+	etcdClient.EXPECT().
+		PatchChangeFeedInfo(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ model.ChangeFeedID,
+			patch func(info *model.ChangeFeedInfo) error,
+		) (*model.ChangeFeedInfo, error) {
+			info, err := oldCfInfo.Clone()
+			require.NoError(t, err)
+			require.NoError(t, patch(info))
+			return info, nil
+		}).Times(1)
+	body, err = json.Marshal(&ChangefeedConfig{
+		ReplicaConfig: &ReplicaConfig{
+			ThroughputLimit: &ThroughputLimitConfig{RowsPerSecond: 100, BytesPerSecond: 1024},
+		},
+	})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), update.method,
+		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ChangeFeedInfo{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, &ThroughputLimitConfig{RowsPerSecond: 100, BytesPerSecond: 1024},
+		resp.Config.ThroughputLimit)
+
+	// case 11: other configs of a running changefeed can't be updated
+	body, err = json.Marshal(&ChangefeedConfig{
+		SinkURI: "blackhole://",
+		ReplicaConfig: &ReplicaConfig{
+			ThroughputLimit: &ThroughputLimitConfig{RowsPerSecond: 100},
+		},
+	})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), update.method,
+		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrChangefeedUpdateRefused")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// case 12: update the filter rules of a running changefeed
+	statusProvider.changefeedStatus = &model.ChangeFeedStatusForAPI{
+		CheckpointTs: 100,
+	}
+	var patched *model.ChangeFeedInfo
+	etcdClient.EXPECT().
+		PatchChangeFeedInfo(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ model.ChangeFeedID,
+			patch func(info *model.ChangeFeedInfo) error,
+		) (*model.ChangeFeedInfo, error) {
+			info, err := oldCfInfo.Clone()
+			require.NoError(t, err)
+			require.NoError(t, patch(info))
+			patched = info
+			return info, nil
+		}).Times(1)
+	body, err = json.Marshal(&ChangefeedConfig{
+		StartTs: 200,
+		ReplicaConfig: &ReplicaConfig{
+			Filter: &FilterConfig{Rules: []string{"test.*"}},
+		},
+	})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), update.method,
+		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"test.*"}, patched.Config.Filter.Rules)
+	require.Equal(t, uint64(200), patched.FilterUpdateTs)
+	require.NotNil(t, patched.PrevFilter)
+	require.Empty(t, patched.PrevFilter.Rules)
+
+	// case 13: the start ts of the new filter rules can't be less than the checkpoint
+	body, err = json.Marshal(&ChangefeedConfig{
+		StartTs: 50,
+		ReplicaConfig: &ReplicaConfig{
+			Filter: &FilterConfig{Rules: []string{"test.*"}},
+		},
+	})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), update.method,
+		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// case 14: other filter configs of a running changefeed can't be updated
+	body, err = json.Marshal(&ChangefeedConfig{
+		ReplicaConfig: &ReplicaConfig{
+			Filter: &FilterConfig{
+				Rules:            []string{"test.*"},
+				IgnoreTxnStartTs: []uint64{1},
+			},
+		},
+	})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), update.method,
+		fmt.Sprintf(update.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrChangefeedUpdateRefused")
+	require.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestListChangeFeeds(t *testing.T) {
@@ -155,6 +155,7 @@ func (d *JSONDuration) UnmarshalJSON(b []byte) error {
 // ReplicaConfig is a duplicate of  config.ReplicaConfig
 type ReplicaConfig struct {
 	MemoryQuota           uint64 `json:"memory_quota"`
+	EnableLargeTxnSpill   bool   `json:"enable_large_txn_spill"`
 	CaseSensitive         bool   `json:"case_sensitive"`
 	EnableOldValue        bool   `json:"enable_old_value"`
 	ForceReplicate        bool   `json:"force_replicate"`
@@ -172,6 +173,10 @@ type ReplicaConfig struct {
 	Consistent *ConsistentConfig          `json:"consistent,omitempty"`
 	Scheduler  *ChangefeedSchedulerConfig `json:"scheduler"`
 	Integrity  *IntegrityConfig           `json:"integrity"`
+
+	ErrorRetryPolicy *ErrorRetryPolicyConfig `json:"error_retry_policy"`
+	ThroughputLimit  *ThroughputLimitConfig  `json:"throughput_limit"`
+	Follower         *FollowerConfig         `json:"follower,omitempty"`
 }
 
 // ToInternalReplicaConfig coverts *v2.ReplicaConfig into *config.ReplicaConfig
@@ -184,6 +189,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	res *config.ReplicaConfig,
 ) *config.ReplicaConfig {
 	res.MemoryQuota = c.MemoryQuota
+	res.EnableLargeTxnSpill = c.EnableLargeTxnSpill
 	res.CaseSensitive = c.CaseSensitive
 	res.EnableOldValue = c.EnableOldValue
 	res.ForceReplicate = c.ForceReplicate
@@ -244,6 +250,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			FlushIntervalInMs: c.Consistent.FlushIntervalInMs,
 			Storage:           c.Consistent.Storage,
 			UseFileBackend:    c.Consistent.UseFileBackend,
+			EncryptionKeyFile: c.Consistent.EncryptionKeyFile,
+			AllowPlaintext:    c.Consistent.AllowPlaintext,
 		}
 	}
 	if c.Sink != nil {
@@ -253,14 +261,33 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				Matcher:        rule.Matcher,
 				DispatcherRule: "",
 				PartitionRule:  rule.PartitionRule,
+				Columns:        rule.Columns,
 				TopicRule:      rule.TopicRule,
 			})
 		}
+		var routeRules []*config.RouteRule
+		for _, rule := range c.Sink.RouteRules {
+			routeRules = append(routeRules, &config.RouteRule{
+				Matcher:      rule.Matcher,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 		var columnSelectors []*config.ColumnSelector
 		for _, selector := range c.Sink.ColumnSelectors {
+			var masks []*config.ColumnMask
+			for _, mask := range selector.Masks {
+				masks = append(masks, &config.ColumnMask{
+					Columns: mask.Columns,
+					Action:  mask.Action,
+					Salt:    mask.Salt,
+					Length:  mask.Length,
+				})
+			}
 			columnSelectors = append(columnSelectors, &config.ColumnSelector{
 				Matcher: selector.Matcher,
 				Columns: selector.Columns,
+				Masks:   masks,
 			})
 		}
 		var csvConfig *config.CSVConfig
@@ -322,6 +349,24 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				CodecConfig:                  codeConfig,
 			}
 		}
+		var pulsarConfig *config.PulsarConfig
+		if c.Sink.PulsarConfig != nil {
+			pulsarConfig = &config.PulsarConfig{
+				MaxMessageBytes:            c.Sink.PulsarConfig.MaxMessageBytes,
+				Compression:                c.Sink.PulsarConfig.Compression,
+				ConnectionTimeout:          c.Sink.PulsarConfig.ConnectionTimeout,
+				OperationTimeout:           c.Sink.PulsarConfig.OperationTimeout,
+				SendTimeout:                c.Sink.PulsarConfig.SendTimeout,
+				BatchingMaxMessages:        c.Sink.PulsarConfig.BatchingMaxMessages,
+				BatchingMaxPublishDelay:    c.Sink.PulsarConfig.BatchingMaxPublishDelay,
+				AuthenticationToken:        c.Sink.PulsarConfig.AuthenticationToken,
+				TokenFromFile:              c.Sink.PulsarConfig.TokenFromFile,
+				BasicUserName:              c.Sink.PulsarConfig.BasicUserName,
+				BasicPassword:              c.Sink.PulsarConfig.BasicPassword,
+				TLSTrustCertsFilePath:      c.Sink.PulsarConfig.TLSTrustCertsFilePath,
+				TLSAllowInsecureConnection: c.Sink.PulsarConfig.TLSAllowInsecureConnection,
+			}
+		}
 		var mysqlConfig *config.MySQLConfig
 		if c.Sink.MySQLConfig != nil {
 			mysqlConfig = &config.MySQLConfig{
@@ -342,6 +387,15 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
 			}
 		}
+		var schemaRegistryConfig *config.SchemaRegistryConfig
+		if c.Sink.SchemaRegistryConfig != nil {
+			schemaRegistryConfig = &config.SchemaRegistryConfig{
+				Type:                c.Sink.SchemaRegistryConfig.Type,
+				SubjectNameStrategy: c.Sink.SchemaRegistryConfig.SubjectNameStrategy,
+				GlueRegion:          c.Sink.SchemaRegistryConfig.GlueRegion,
+				GlueRegistryName:    c.Sink.SchemaRegistryConfig.GlueRegistryName,
+			}
+		}
 		var cloudStorageConfig *config.CloudStorageConfig
 		if c.Sink.CloudStorageConfig != nil {
 			cloudStorageConfig = &config.CloudStorageConfig{
@@ -353,10 +407,12 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 
 		res.Sink = &config.SinkConfig{
 			DispatchRules:                    dispatchRules,
+			RouteRules:                       routeRules,
 			Protocol:                         c.Sink.Protocol,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
 			SchemaRegistry:                   c.Sink.SchemaRegistry,
+			SchemaRegistryConfig:             schemaRegistryConfig,
 			EncoderConcurrency:               c.Sink.EncoderConcurrency,
 			Terminator:                       c.Sink.Terminator,
 			DateSeparator:                    c.Sink.DateSeparator,
@@ -366,7 +422,9 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			OnlyOutputUpdatedColumns:         c.Sink.OnlyOutputUpdatedColumns,
 			DeleteOnlyOutputHandleKeyColumns: c.Sink.DeleteOnlyOutputHandleKeyColumns,
 			LargeMessageOnlyHandleKeyColumns: c.Sink.LargeMessageOnlyHandleKeyColumns,
+			LargeMessageClaimCheckStorageURI: c.Sink.LargeMessageClaimCheckStorageURI,
 			KafkaConfig:                      kafkaConfig,
+			PulsarConfig:                     pulsarConfig,
 			MySQLConfig:                      mysqlConfig,
 			CloudStorageConfig:               cloudStorageConfig,
 			SafeMode:                         c.Sink.SafeMode,
@@ -387,6 +445,9 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			EnableTableAcrossNodes: c.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        c.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
+			WriteRowThreshold:      c.Scheduler.WriteRowThreshold,
+			RequiredLabels:         c.Scheduler.RequiredLabels,
+			PreferredLabels:        c.Scheduler.PreferredLabels,
 		}
 	}
 	if c.Integrity != nil {
@@ -395,6 +456,37 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CorruptionHandleLevel: c.Integrity.CorruptionHandleLevel,
 		}
 	}
+	if c.ErrorRetryPolicy != nil {
+		// the unspecified intervals inherit from the origin config.
+		if res.ErrorRetryPolicy == nil {
+			res.ErrorRetryPolicy = config.GetDefaultReplicaConfig().ErrorRetryPolicy
+		}
+		res.ErrorRetryPolicy.FatalErrors = c.ErrorRetryPolicy.FatalErrors
+		res.ErrorRetryPolicy.RetryableErrors = c.ErrorRetryPolicy.RetryableErrors
+		if c.ErrorRetryPolicy.InitialInterval != nil {
+			res.ErrorRetryPolicy.InitialInterval = c.ErrorRetryPolicy.InitialInterval.duration
+		}
+		if c.ErrorRetryPolicy.MaxInterval != nil {
+			res.ErrorRetryPolicy.MaxInterval = c.ErrorRetryPolicy.MaxInterval.duration
+		}
+		if c.ErrorRetryPolicy.MaxElapsedTime != nil {
+			res.ErrorRetryPolicy.MaxElapsedTime = c.ErrorRetryPolicy.MaxElapsedTime.duration
+		}
+	}
+	if c.ThroughputLimit != nil {
+		res.ThroughputLimit = &config.ThroughputLimitConfig{
+			RowsPerSecond:  c.ThroughputLimit.RowsPerSecond,
+			BytesPerSecond: c.ThroughputLimit.BytesPerSecond,
+		}
+	}
+	if c.Follower != nil {
+		res.Follower = &config.FollowerConfig{
+			LeaderChangefeedID: c.Follower.LeaderChangefeedID,
+		}
+		if c.Follower.MaxLag != nil {
+			res.Follower.MaxLag = c.Follower.MaxLag.duration
+		}
+	}
 	return res
 }
 
@@ -404,6 +496,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 
 	res := &ReplicaConfig{
 		MemoryQuota:           cloned.MemoryQuota,
+		EnableLargeTxnSpill:   cloned.EnableLargeTxnSpill,
 		CaseSensitive:         cloned.CaseSensitive,
 		EnableOldValue:        cloned.EnableOldValue,
 		ForceReplicate:        cloned.ForceReplicate,
@@ -468,14 +561,33 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			dispatchRules = append(dispatchRules, &DispatchRule{
 				Matcher:       rule.Matcher,
 				PartitionRule: rule.PartitionRule,
+				Columns:       rule.Columns,
 				TopicRule:     rule.TopicRule,
 			})
 		}
+		var routeRules []*RouteRule
+		for _, rule := range cloned.Sink.RouteRules {
+			routeRules = append(routeRules, &RouteRule{
+				Matcher:      rule.Matcher,
+				TargetSchema: rule.TargetSchema,
+				TargetTable:  rule.TargetTable,
+			})
+		}
 		var columnSelectors []*ColumnSelector
 		for _, selector := range cloned.Sink.ColumnSelectors {
+			var masks []*ColumnMask
+			for _, mask := range selector.Masks {
+				masks = append(masks, &ColumnMask{
+					Columns: mask.Columns,
+					Action:  mask.Action,
+					Salt:    mask.Salt,
+					Length:  mask.Length,
+				})
+			}
 			columnSelectors = append(columnSelectors, &ColumnSelector{
 				Matcher: selector.Matcher,
 				Columns: selector.Columns,
+				Masks:   masks,
 			})
 		}
 		var csvConfig *CSVConfig
@@ -537,6 +649,24 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				CodecConfig:                  codeConfig,
 			}
 		}
+		var pulsarConfig *PulsarConfig
+		if cloned.Sink.PulsarConfig != nil {
+			pulsarConfig = &PulsarConfig{
+				MaxMessageBytes:            cloned.Sink.PulsarConfig.MaxMessageBytes,
+				Compression:                cloned.Sink.PulsarConfig.Compression,
+				ConnectionTimeout:          cloned.Sink.PulsarConfig.ConnectionTimeout,
+				OperationTimeout:           cloned.Sink.PulsarConfig.OperationTimeout,
+				SendTimeout:                cloned.Sink.PulsarConfig.SendTimeout,
+				BatchingMaxMessages:        cloned.Sink.PulsarConfig.BatchingMaxMessages,
+				BatchingMaxPublishDelay:    cloned.Sink.PulsarConfig.BatchingMaxPublishDelay,
+				AuthenticationToken:        cloned.Sink.PulsarConfig.AuthenticationToken,
+				TokenFromFile:              cloned.Sink.PulsarConfig.TokenFromFile,
+				BasicUserName:              cloned.Sink.PulsarConfig.BasicUserName,
+				BasicPassword:              cloned.Sink.PulsarConfig.BasicPassword,
+				TLSTrustCertsFilePath:      cloned.Sink.PulsarConfig.TLSTrustCertsFilePath,
+				TLSAllowInsecureConnection: cloned.Sink.PulsarConfig.TLSAllowInsecureConnection,
+			}
+		}
 		var mysqlConfig *MySQLConfig
 		if cloned.Sink.MySQLConfig != nil {
 			mysqlConfig = &MySQLConfig{
@@ -557,6 +687,15 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
 			}
 		}
+		var schemaRegistryConfig *SchemaRegistryConfig
+		if cloned.Sink.SchemaRegistryConfig != nil {
+			schemaRegistryConfig = &SchemaRegistryConfig{
+				Type:                cloned.Sink.SchemaRegistryConfig.Type,
+				SubjectNameStrategy: cloned.Sink.SchemaRegistryConfig.SubjectNameStrategy,
+				GlueRegion:          cloned.Sink.SchemaRegistryConfig.GlueRegion,
+				GlueRegistryName:    cloned.Sink.SchemaRegistryConfig.GlueRegistryName,
+			}
+		}
 		var cloudStorageConfig *CloudStorageConfig
 		if cloned.Sink.CloudStorageConfig != nil {
 			cloudStorageConfig = &CloudStorageConfig{
@@ -569,7 +708,9 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		res.Sink = &SinkConfig{
 			Protocol:                         cloned.Sink.Protocol,
 			SchemaRegistry:                   cloned.Sink.SchemaRegistry,
+			SchemaRegistryConfig:             schemaRegistryConfig,
 			DispatchRules:                    dispatchRules,
+			RouteRules:                       routeRules,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
 			EncoderConcurrency:               cloned.Sink.EncoderConcurrency,
@@ -581,7 +722,9 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			OnlyOutputUpdatedColumns:         cloned.Sink.OnlyOutputUpdatedColumns,
 			DeleteOnlyOutputHandleKeyColumns: cloned.Sink.DeleteOnlyOutputHandleKeyColumns,
 			LargeMessageOnlyHandleKeyColumns: cloned.Sink.LargeMessageOnlyHandleKeyColumns,
+			LargeMessageClaimCheckStorageURI: cloned.Sink.LargeMessageClaimCheckStorageURI,
 			KafkaConfig:                      kafkaConfig,
+			PulsarConfig:                     pulsarConfig,
 			MySQLConfig:                      mysqlConfig,
 			CloudStorageConfig:               cloudStorageConfig,
 			SafeMode:                         cloned.Sink.SafeMode,
@@ -598,6 +741,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			FlushIntervalInMs: cloned.Consistent.FlushIntervalInMs,
 			Storage:           cloned.Consistent.Storage,
 			UseFileBackend:    cloned.Consistent.UseFileBackend,
+			EncryptionKeyFile: cloned.Consistent.EncryptionKeyFile,
+			AllowPlaintext:    cloned.Consistent.AllowPlaintext,
 		}
 	}
 	if cloned.Mounter != nil {
@@ -610,6 +755,9 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			EnableTableAcrossNodes: cloned.Scheduler.EnableTableAcrossNodes,
 			RegionThreshold:        cloned.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
+			WriteRowThreshold:      cloned.Scheduler.WriteRowThreshold,
+			RequiredLabels:         cloned.Scheduler.RequiredLabels,
+			PreferredLabels:        cloned.Scheduler.PreferredLabels,
 		}
 	}
 
@@ -620,6 +768,30 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		}
 	}
 
+	if cloned.ErrorRetryPolicy != nil {
+		res.ErrorRetryPolicy = &ErrorRetryPolicyConfig{
+			InitialInterval: &JSONDuration{cloned.ErrorRetryPolicy.InitialInterval},
+			MaxInterval:     &JSONDuration{cloned.ErrorRetryPolicy.MaxInterval},
+			MaxElapsedTime:  &JSONDuration{cloned.ErrorRetryPolicy.MaxElapsedTime},
+			FatalErrors:     cloned.ErrorRetryPolicy.FatalErrors,
+			RetryableErrors: cloned.ErrorRetryPolicy.RetryableErrors,
+		}
+	}
+
+	if cloned.ThroughputLimit != nil {
+		res.ThroughputLimit = &ThroughputLimitConfig{
+			RowsPerSecond:  cloned.ThroughputLimit.RowsPerSecond,
+			BytesPerSecond: cloned.ThroughputLimit.BytesPerSecond,
+		}
+	}
+
+	if cloned.Follower != nil {
+		res.Follower = &FollowerConfig{
+			LeaderChangefeedID: cloned.Follower.LeaderChangefeedID,
+			MaxLag:             &JSONDuration{cloned.Follower.MaxLag},
+		}
+	}
+
 	return res
 }
 
@@ -723,25 +895,29 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                         *string             `json:"protocol,omitempty"`
-	SchemaRegistry                   *string             `json:"schema_registry,omitempty"`
-	CSVConfig                        *CSVConfig          `json:"csv,omitempty"`
-	DispatchRules                    []*DispatchRule     `json:"dispatchers,omitempty"`
-	ColumnSelectors                  []*ColumnSelector   `json:"column_selectors,omitempty"`
-	TxnAtomicity                     *string             `json:"transaction_atomicity,omitempty"`
-	EncoderConcurrency               *int                `json:"encoder_concurrency,omitempty"`
-	Terminator                       *string             `json:"terminator,omitempty"`
-	DateSeparator                    *string             `json:"date_separator,omitempty"`
-	EnablePartitionSeparator         *bool               `json:"enable_partition_separator,omitempty"`
-	FileIndexWidth                   *int                `json:"file_index_width,omitempty"`
-	EnableKafkaSinkV2                *bool               `json:"enable_kafka_sink_v2,omitempty"`
-	OnlyOutputUpdatedColumns         *bool               `json:"only_output_updated_columns,omitempty"`
-	DeleteOnlyOutputHandleKeyColumns *bool               `json:"delete_only_output_handle_key_columns"`
-	LargeMessageOnlyHandleKeyColumns *bool               `json:"large_message_only_handle_key_columns"`
-	SafeMode                         *bool               `json:"safe_mode,omitempty"`
-	KafkaConfig                      *KafkaConfig        `json:"kafka_config,omitempty"`
-	MySQLConfig                      *MySQLConfig        `json:"mysql_config,omitempty"`
-	CloudStorageConfig               *CloudStorageConfig `json:"cloud_storage_config,omitempty"`
+	Protocol                         *string               `json:"protocol,omitempty"`
+	SchemaRegistry                   *string               `json:"schema_registry,omitempty"`
+	SchemaRegistryConfig             *SchemaRegistryConfig `json:"schema_registry_config,omitempty"`
+	CSVConfig                        *CSVConfig            `json:"csv,omitempty"`
+	DispatchRules                    []*DispatchRule       `json:"dispatchers,omitempty"`
+	RouteRules                       []*RouteRule          `json:"route_rules,omitempty"`
+	ColumnSelectors                  []*ColumnSelector     `json:"column_selectors,omitempty"`
+	TxnAtomicity                     *string               `json:"transaction_atomicity,omitempty"`
+	EncoderConcurrency               *int                  `json:"encoder_concurrency,omitempty"`
+	Terminator                       *string               `json:"terminator,omitempty"`
+	DateSeparator                    *string               `json:"date_separator,omitempty"`
+	EnablePartitionSeparator         *bool                 `json:"enable_partition_separator,omitempty"`
+	FileIndexWidth                   *int                  `json:"file_index_width,omitempty"`
+	EnableKafkaSinkV2                *bool                 `json:"enable_kafka_sink_v2,omitempty"`
+	OnlyOutputUpdatedColumns         *bool                 `json:"only_output_updated_columns,omitempty"`
+	DeleteOnlyOutputHandleKeyColumns *bool                 `json:"delete_only_output_handle_key_columns"`
+	LargeMessageOnlyHandleKeyColumns *bool                 `json:"large_message_only_handle_key_columns"`
+	LargeMessageClaimCheckStorageURI *string               `json:"large_message_claim_check_storage_uri,omitempty"`
+	SafeMode                         *bool                 `json:"safe_mode,omitempty"`
+	KafkaConfig                      *KafkaConfig          `json:"kafka_config,omitempty"`
+	PulsarConfig                     *PulsarConfig         `json:"pulsar_config,omitempty"`
+	MySQLConfig                      *MySQLConfig          `json:"mysql_config,omitempty"`
+	CloudStorageConfig               *CloudStorageConfig   `json:"cloud_storage_config,omitempty"`
 }
 
 // CSVConfig denotes the csv config
@@ -758,14 +934,33 @@ type CSVConfig struct {
 type DispatchRule struct {
 	Matcher       []string `json:"matcher,omitempty"`
 	PartitionRule string   `json:"partition"`
+	Columns       []string `json:"columns,omitempty"`
 	TopicRule     string   `json:"topic"`
 }
 
+// RouteRule represents a rule to route tables to the downstream
+// This is a duplicate of config.RouteRule
+type RouteRule struct {
+	Matcher      []string `json:"matcher,omitempty"`
+	TargetSchema string   `json:"target_schema,omitempty"`
+	TargetTable  string   `json:"target_table,omitempty"`
+}
+
 // ColumnSelector represents a column selector for a table.
 // This is a duplicate of config.ColumnSelector
 type ColumnSelector struct {
-	Matcher []string `json:"matcher,omitempty"`
+	Matcher []string      `json:"matcher,omitempty"`
+	Columns []string      `json:"columns,omitempty"`
+	Masks   []*ColumnMask `json:"masks,omitempty"`
+}
+
+// ColumnMask represents a masking rule for the matched columns.
+// This is a duplicate of config.ColumnMask
+type ColumnMask struct {
 	Columns []string `json:"columns,omitempty"`
+	Action  string   `json:"action,omitempty"`
+	Salt    string   `json:"salt,omitempty"`
+	Length  int      `json:"length,omitempty"`
 }
 
 // ConsistentConfig represents replication consistency config for a changefeed
@@ -776,6 +971,8 @@ type ConsistentConfig struct {
 	FlushIntervalInMs int64  `json:"flush_interval"`
 	Storage           string `json:"storage,omitempty"`
 	UseFileBackend    bool   `json:"use_file_backend"`
+	EncryptionKeyFile string `json:"encryption_key_file,omitempty"`
+	AllowPlaintext    bool   `json:"allow_plaintext,omitempty"`
 }
 
 // ChangefeedSchedulerConfig is per changefeed scheduler settings.
@@ -788,6 +985,13 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region_threshold" json:"region_threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
+	// WriteRowThreshold is the rows written per second threshold of
+	// splitting a table while the changefeed is running.
+	WriteRowThreshold int `toml:"write_row_threshold" json:"write_row_threshold"`
+	// RequiredLabels are the labels a capture must have to replicate tables.
+	RequiredLabels map[string]string `toml:"required_labels" json:"required_labels,omitempty"`
+	// PreferredLabels are the labels of the captures preferred to replicate tables.
+	PreferredLabels map[string]string `toml:"preferred_labels" json:"preferred_labels,omitempty"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -797,6 +1001,32 @@ type IntegrityConfig struct {
 	CorruptionHandleLevel string `json:"corruption_handle_level"`
 }
 
+// ErrorRetryPolicyConfig is the policy to restart a changefeed when it meets errors.
+// This is a duplicate of config.ErrorRetryPolicyConfig
+type ErrorRetryPolicyConfig struct {
+	InitialInterval *JSONDuration `json:"initial_interval,omitempty" swaggertype:"string"`
+	MaxInterval     *JSONDuration `json:"max_interval,omitempty" swaggertype:"string"`
+	// MaxElapsedTime 0 means the changefeed retries forever.
+	MaxElapsedTime  *JSONDuration `json:"max_elapsed_time,omitempty" swaggertype:"string"`
+	FatalErrors     []string      `json:"fatal_errors,omitempty"`
+	RetryableErrors []string      `json:"retryable_errors,omitempty"`
+}
+
+// ThroughputLimitConfig limits the write speed of a changefeed, 0 means no limit.
+// This is a duplicate of config.ThroughputLimitConfig
+type ThroughputLimitConfig struct {
+	RowsPerSecond  uint64 `json:"rows_per_second"`
+	BytesPerSecond uint64 `json:"bytes_per_second"`
+}
+
+// FollowerConfig caps the progress of a changefeed at the checkpoint of
+// another changefeed.
+// This is a duplicate of config.FollowerConfig
+type FollowerConfig struct {
+	LeaderChangefeedID string        `json:"leader_changefeed_id"`
+	MaxLag             *JSONDuration `json:"max_lag,omitempty" swaggertype:"string"`
+}
+
 // EtcdData contains key/value pair of etcd data
 type EtcdData struct {
 	Key   string `json:"key,omitempty"`
@@ -911,10 +1141,34 @@ type ServerStatus struct {
 
 // Capture holds common information of a capture in cdc
 type Capture struct {
-	ID            string `json:"id"`
-	IsOwner       bool   `json:"is_owner"`
-	AdvertiseAddr string `json:"address"`
-	ClusterID     string `json:"cluster_id"`
+	ID            string            `json:"id"`
+	IsOwner       bool              `json:"is_owner"`
+	AdvertiseAddr string            `json:"address"`
+	ClusterID     string            `json:"cluster_id"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Load          *CaptureLoad      `json:"load,omitempty"`
+}
+
+// CaptureLoad is the replication load of a capture, summed over all
+// changefeeds. Load is the estimated cost used by the scheduler to balance
+// tables among captures.
+type CaptureLoad struct {
+	TableCount        int     `json:"table_count"`
+	RegionCount       uint64  `json:"region_count"`
+	SinkRowsPerSecond float64 `json:"sink_rows_per_second"`
+	Load              uint64  `json:"load"`
+}
+
+func toAPICaptureLoad(load *model.CaptureLoad) *CaptureLoad {
+	if load == nil {
+		return nil
+	}
+	return &CaptureLoad{
+		TableCount:        load.TableCount,
+		RegionCount:       load.RegionCount,
+		SinkRowsPerSecond: load.SinkRowsPerSecond,
+		Load:              load.Load,
+	}
 }
 
 // CodecConfig represents a MQ codec configuration
@@ -964,6 +1218,23 @@ type KafkaConfig struct {
 	CodecConfig                  *CodecConfig `json:"codec_config,omitempty"`
 }
 
+// PulsarConfig represents a pulsar sink configuration
+type PulsarConfig struct {
+	MaxMessageBytes            *int    `json:"max_message_bytes,omitempty"`
+	Compression                *string `json:"compression,omitempty"`
+	ConnectionTimeout          *string `json:"connection_timeout,omitempty"`
+	OperationTimeout           *string `json:"operation_timeout,omitempty"`
+	SendTimeout                *string `json:"send_timeout,omitempty"`
+	BatchingMaxMessages        *uint   `json:"batching_max_messages,omitempty"`
+	BatchingMaxPublishDelay    *string `json:"batching_max_publish_delay,omitempty"`
+	AuthenticationToken        *string `json:"authentication_token,omitempty"`
+	TokenFromFile              *string `json:"token_from_file,omitempty"`
+	BasicUserName              *string `json:"basic_user_name,omitempty"`
+	BasicPassword              *string `json:"basic_password,omitempty"`
+	TLSTrustCertsFilePath      *string `json:"tls_trust_certs_file_path,omitempty"`
+	TLSAllowInsecureConnection *bool   `json:"tls_allow_insecure_connection,omitempty"`
+}
+
 // MySQLConfig represents a MySQL sink configuration
 type MySQLConfig struct {
 	WorkerCount                  *int    `json:"worker_count,omitempty"`
@@ -983,6 +1254,15 @@ type MySQLConfig struct {
 	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
 }
 
+// SchemaRegistryConfig represents the options of the schema registry
+// This is the same as config.SchemaRegistryConfig
+type SchemaRegistryConfig struct {
+	Type                *string `json:"type,omitempty"`
+	SubjectNameStrategy *string `json:"subject_name_strategy,omitempty"`
+	GlueRegion          *string `json:"glue_region,omitempty"`
+	GlueRegistryName    *string `json:"glue_registry_name,omitempty"`
+}
+
 // CloudStorageConfig represents a cloud storage sink configuration
 type CloudStorageConfig struct {
 	WorkerCount   *int    `json:"worker_count,omitempty"`
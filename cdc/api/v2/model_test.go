@@ -72,11 +72,19 @@ var defaultAPIConfig = &ReplicaConfig{
 			Scheduler.RegionThreshold,
 		WriteKeyThreshold: config.GetDefaultReplicaConfig().
 			Scheduler.WriteKeyThreshold,
+		WriteRowThreshold: config.GetDefaultReplicaConfig().
+			Scheduler.WriteRowThreshold,
 	},
 	Integrity: &IntegrityConfig{
 		IntegrityCheckLevel:   config.GetDefaultReplicaConfig().Integrity.IntegrityCheckLevel,
 		CorruptionHandleLevel: config.GetDefaultReplicaConfig().Integrity.CorruptionHandleLevel,
 	},
+	ErrorRetryPolicy: &ErrorRetryPolicyConfig{
+		InitialInterval: &JSONDuration{10 * time.Second},
+		MaxInterval:     &JSONDuration{30 * time.Minute},
+		MaxElapsedTime:  &JSONDuration{90 * time.Minute},
+	},
+	ThroughputLimit: &ThroughputLimitConfig{},
 }
 
 func TestDefaultReplicaConfig(t *testing.T) {
@@ -95,6 +103,7 @@ func TestToAPIReplicaConfig(t *testing.T) {
 	cfg := config.GetDefaultReplicaConfig()
 	cfg.EnableOldValue = false
 	cfg.CheckGCSafePoint = false
+	cfg.EnableLargeTxnSpill = true
 	cfg.Sink = &config.SinkConfig{
 		DispatchRules: []*config.DispatchRule{
 			{
@@ -109,6 +118,10 @@ func TestToAPIReplicaConfig(t *testing.T) {
 			{
 				Matcher: []string{"a", "b", "c"},
 				Columns: []string{"a", "b"},
+				Masks: []*config.ColumnMask{
+					{Columns: []string{"a"}, Action: "hash", Salt: "salt"},
+					{Columns: []string{"b"}, Action: "truncate", Length: 4},
+				},
 			},
 		},
 		SchemaRegistry: util.AddressOf("bbb"),
@@ -119,6 +132,8 @@ func TestToAPIReplicaConfig(t *testing.T) {
 		MaxLogSize:        99,
 		FlushIntervalInMs: 10,
 		Storage:           "s3",
+		EncryptionKeyFile: "/etc/cdc/redo.key",
+		AllowPlaintext:    true,
 	}
 	cfg.Filter = &config.FilterConfig{
 		Rules: []string{"a", "b", "c"},
@@ -150,6 +165,22 @@ func TestToAPIReplicaConfig(t *testing.T) {
 	cfg.Mounter = &config.MounterConfig{WorkerNum: 11}
 	cfg.Scheduler = &config.ChangefeedSchedulerConfig{
 		EnableTableAcrossNodes: true, RegionThreshold: 10001, WriteKeyThreshold: 10001,
+		WriteRowThreshold: 10001,
+		RequiredLabels:    map[string]string{"zone": "z1"},
+		PreferredLabels:   map[string]string{"host": "h1"},
+	}
+	cfg.ErrorRetryPolicy = &config.ErrorRetryPolicyConfig{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Minute,
+		MaxElapsedTime:  0,
+		FatalErrors:     []string{"CDC:ErrKafkaNewProducer"},
+		RetryableErrors: []string{"CDC:ErrSinkURIInvalid"},
+	}
+	cfg.ThroughputLimit = &config.ThroughputLimitConfig{
+		RowsPerSecond: 1000, BytesPerSecond: 1 << 20,
+	}
+	cfg.Follower = &config.FollowerConfig{
+		LeaderChangefeedID: "leader", MaxLag: time.Minute,
 	}
 	cfg2 := ToAPIReplicaConfig(cfg).ToInternalReplicaConfig()
 	require.Equal(t, "", cfg2.Sink.DispatchRules[0].DispatcherRule)
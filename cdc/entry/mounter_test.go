@@ -1161,6 +1161,7 @@ func TestE2ERowLevelChecksum(t *testing.T) {
 	codecConfig.EnableRowChecksum = true
 	codecConfig.AvroDecimalHandlingMode = "string"
 	codecConfig.AvroBigintUnsignedHandlingMode = "string"
+	codecConfig.AvroSchemaRegistry = "http://127.0.0.1:8081"
 
 	avroEncoder, err := avro.SetupEncoderAndSchemaRegistry4Testing(ctx, codecConfig)
 	defer avro.TeardownEncoderAndSchemaRegistry4Testing()
@@ -1174,7 +1175,7 @@ func TestE2ERowLevelChecksum(t *testing.T) {
 	require.Len(t, msg, 1)
 
 	keySchemaM, valueSchemaM, err := avro.NewKeyAndValueSchemaManagers(
-		ctx, "http://127.0.0.1:8081", nil)
+		ctx, codecConfig, nil)
 	require.NoError(t, err)
 
 	// decoder enable checksum functionality.
@@ -28,6 +28,8 @@ type CaptureInfo struct {
 	ID            CaptureID `json:"id"`
 	AdvertiseAddr string    `json:"address"`
 	Version       string    `json:"version"`
+	// Labels are used to place the tables of changefeeds on some captures.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Marshal using json.Marshal.
@@ -47,6 +49,28 @@ func (c *CaptureInfo) Unmarshal(data []byte) error {
 		"unmarshal data: %v", data)
 }
 
+// CaptureLoad is the replication load of a capture.
+type CaptureLoad struct {
+	// TableCount is the number of table spans replicated by the capture.
+	TableCount int `json:"table_count"`
+	// RegionCount is the number of regions of the table spans.
+	RegionCount uint64 `json:"region_count"`
+	// SinkRowsPerSecond is the number of rows written to the table sinks per
+	// second.
+	SinkRowsPerSecond float64 `json:"sink_rows_per_second"`
+	// Load is the estimated cost of replicating the table spans, which is
+	// used by the scheduler to balance table spans among captures.
+	Load uint64 `json:"load"`
+}
+
+// Add adds the load of other to c.
+func (c *CaptureLoad) Add(other *CaptureLoad) {
+	c.TableCount += other.TableCount
+	c.RegionCount += other.RegionCount
+	c.SinkRowsPerSecond += other.SinkRowsPerSecond
+	c.Load += other.Load
+}
+
 // ListVersionsFromCaptureInfos returns the version list of the CaptureInfo list.
 func ListVersionsFromCaptureInfos(captureInfos []*CaptureInfo) []string {
 	var captureVersions []string
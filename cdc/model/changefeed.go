@@ -155,6 +155,13 @@ type ChangeFeedInfo struct {
 	CreatorVersion string `json:"creator-version"`
 	// Epoch is the epoch of a changefeed, changes on every restart.
 	Epoch uint64 `json:"epoch"`
+	// FilterUpdateTs is the ts from which the tables added by an online filter
+	// update start to replicate, 0 means the current checkpoint ts.
+	FilterUpdateTs uint64 `json:"filter-update-ts,omitempty"`
+	// PrevFilter is the filter applied before an online filter update, it is
+	// kept until the owner applies the update, so that a new owner still
+	// waits for FilterUpdateTs and the processors keep the tables it matches.
+	PrevFilter *config.FilterConfig `json:"prev-filter,omitempty"`
 }
 
 const changeFeedIDMaxLen = 128
@@ -291,6 +298,12 @@ func (info *ChangeFeedInfo) VerifyAndComplete() {
 	if info.Config.Integrity == nil {
 		info.Config.Integrity = defaultConfig.Integrity
 	}
+	if info.Config.ErrorRetryPolicy == nil {
+		info.Config.ErrorRetryPolicy = defaultConfig.ErrorRetryPolicy
+	}
+	if info.Config.ThroughputLimit == nil {
+		info.Config.ThroughputLimit = defaultConfig.ThroughputLimit
+	}
 
 	info.RmUnusedFields()
 }
@@ -311,10 +324,17 @@ func (info *ChangeFeedInfo) RmUnusedFields() {
 	if !sink.IsMQScheme(uri.Scheme) {
 		info.rmMQOnlyFields()
 	} else {
+		// remove the config of the MQ system which is not used
+		if sink.IsPulsarScheme(uri.Scheme) {
+			info.Config.Sink.KafkaConfig = nil
+		} else {
+			info.Config.Sink.PulsarConfig = nil
+		}
 		// remove schema registry for MQ downstream with
 		// protocol other than avro
 		if util.GetOrZero(info.Config.Sink.Protocol) != config.ProtocolAvro.String() {
 			info.Config.Sink.SchemaRegistry = nil
+			info.Config.Sink.SchemaRegistryConfig = nil
 		}
 	}
 
@@ -324,6 +344,10 @@ func (info *ChangeFeedInfo) RmUnusedFields() {
 
 	if !sink.IsMySQLCompatibleScheme(uri.Scheme) {
 		info.rmDBOnlyFields()
+		// the syncpoint is also supported by the MQ and storage downstream.
+		if !sink.IsMQScheme(uri.Scheme) && !sink.IsStorageScheme(uri.Scheme) {
+			info.rmSyncPointFields()
+		}
 	} else {
 		// remove fields only being used by MQ and Storage downstream
 		info.Config.Sink.Protocol = nil
@@ -334,12 +358,15 @@ func (info *ChangeFeedInfo) RmUnusedFields() {
 func (info *ChangeFeedInfo) rmMQOnlyFields() {
 	info.Config.Sink.DispatchRules = nil
 	info.Config.Sink.SchemaRegistry = nil
+	info.Config.Sink.SchemaRegistryConfig = nil
 	info.Config.Sink.EncoderConcurrency = nil
 	info.Config.Sink.EnableKafkaSinkV2 = nil
 	info.Config.Sink.OnlyOutputUpdatedColumns = nil
 	info.Config.Sink.DeleteOnlyOutputHandleKeyColumns = nil
 	info.Config.Sink.LargeMessageOnlyHandleKeyColumns = nil
+	info.Config.Sink.LargeMessageClaimCheckStorageURI = nil
 	info.Config.Sink.KafkaConfig = nil
+	info.Config.Sink.PulsarConfig = nil
 }
 
 func (info *ChangeFeedInfo) rmStorageOnlyFields() {
@@ -351,15 +378,18 @@ func (info *ChangeFeedInfo) rmStorageOnlyFields() {
 }
 
 func (info *ChangeFeedInfo) rmDBOnlyFields() {
-	info.Config.EnableSyncPoint = nil
 	info.Config.BDRMode = nil
-	info.Config.SyncPointInterval = nil
-	info.Config.SyncPointRetention = nil
 	info.Config.Consistent = nil
 	info.Config.Sink.SafeMode = nil
 	info.Config.Sink.MySQLConfig = nil
 }
 
+func (info *ChangeFeedInfo) rmSyncPointFields() {
+	info.Config.EnableSyncPoint = nil
+	info.Config.SyncPointInterval = nil
+	info.Config.SyncPointRetention = nil
+}
+
 // FixIncompatible fixes incompatible changefeed meta info.
 func (info *ChangeFeedInfo) FixIncompatible() {
 	creatorVersionGate := version.NewCreatorVersionGate(info.CreatorVersion)
@@ -63,6 +63,8 @@ func TestRmUnusedField(t *testing.T) {
 		strCf := &ChangeFeedInfo{
 			SinkURI: "s3://",
 			Config: &config.ReplicaConfig{
+				EnableSyncPoint:   util.AddressOf(true),
+				SyncPointInterval: util.AddressOf(time.Minute),
 				Sink: &config.SinkConfig{
 					SchemaRegistry: util.AddressOf(defaultRegistry),
 					Protocol:       util.AddressOf(defaultProtocol),
@@ -77,6 +79,9 @@ func TestRmUnusedField(t *testing.T) {
 		strCf.VerifyAndComplete()
 		require.True(t, strCf.Config.Sink.SchemaRegistry == nil)
 		require.NotNil(t, strCf.Config.Sink.CSVConfig)
+		// the syncpoint is supported by the storage downstream.
+		require.True(t, util.GetOrZero(strCf.Config.EnableSyncPoint))
+		require.Equal(t, time.Minute, util.GetOrZero(strCf.Config.SyncPointInterval))
 	}
 
 	// 3. kafka downstream using avro
@@ -126,6 +131,20 @@ func TestRmUnusedField(t *testing.T) {
 		)
 		require.Nil(t, kcCf.Config.Sink.CSVConfig)
 	}
+
+	// 5. blackhole downstream
+	{
+		bhCf := &ChangeFeedInfo{
+			SinkURI: "blackhole://",
+			Config: &config.ReplicaConfig{
+				EnableSyncPoint:   util.AddressOf(true),
+				SyncPointInterval: util.AddressOf(time.Minute),
+			},
+		}
+		bhCf.VerifyAndComplete()
+		require.Nil(t, bhCf.Config.EnableSyncPoint)
+		require.Nil(t, bhCf.Config.SyncPointInterval)
+	}
 }
 
 func TestFillV1(t *testing.T) {
@@ -14,6 +14,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -45,6 +46,8 @@ const (
 	MessageTypeDDL
 	// MessageTypeResolved is resolved type of message key
 	MessageTypeResolved
+	// MessageTypeSyncPoint is syncpoint type of message key
+	MessageTypeSyncPoint
 )
 
 // ColumnFlagType is for encapsulating the flag operations for different flags.
@@ -672,6 +675,18 @@ func (d *DDLEvent) FromJobWithArgs(
 	}
 }
 
+// SyncPointEvent is a consistent snapshot marker of the changefeed,
+// all the changes with commit ts not larger than the PrimaryTs have been
+// written to the downstream when it is received.
+//
+//msgp:ignore SyncPointEvent
+type SyncPointEvent struct {
+	// PrimaryTs is the ts of the upstream snapshot.
+	PrimaryTs uint64 `json:"primary-ts"`
+	// SecondaryTs is the ts when the syncpoint is written to the downstream.
+	SecondaryTs uint64 `json:"secondary-ts"`
+}
+
 // SingleTableTxn represents a transaction which includes many row events in a single table
 //
 //msgp:ignore SingleTableTxn
@@ -687,6 +702,10 @@ type SingleTableTxn struct {
 	StartTs  uint64
 	CommitTs uint64
 	Rows     []*RowChangedEvent
+	// StagedRows reads the rows of a large transaction, which are staged in
+	// the sort engine instead of being held in Rows. It is nil for the other
+	// transactions.
+	StagedRows StagedRows
 
 	// control fields of SingleTableTxn
 	// FinishWg is a barrier txn, after this txn is received, the worker must
@@ -694,6 +713,18 @@ type SingleTableTxn struct {
 	FinishWg *sync.WaitGroup
 }
 
+// StagedRows reads the rows of a staged transaction in batches, so that the
+// transaction can be written to the downstream without holding all its rows
+// in memory.
+type StagedRows interface {
+	// Len returns the number of rows of the transaction.
+	Len() int
+	// Read calls fn with the rows of the transaction in order, about
+	// batchSize rows each time. It can be called many times, e.g., to retry
+	// writing the transaction.
+	Read(ctx context.Context, batchSize int, fn func(rows []*RowChangedEvent) error) error
+}
+
 // GetCommitTs returns the commit timestamp of the transaction.
 func (t *SingleTableTxn) GetCommitTs() uint64 {
 	return t.CommitTs
@@ -26,6 +26,10 @@ const (
 	syncPointBarrier barrierType = iota
 	// finishBarrier denotes a barrier for changefeed finished.
 	finishBarrier
+	// filterUpdateBarrier denotes a barrier for applying an updated filter.
+	filterUpdateBarrier
+	// followerBarrier denotes a barrier for following another changefeed.
+	followerBarrier
 )
 
 // barriers stores some barrierType and barrierTs, and can calculate the min barrierTs
@@ -16,6 +16,7 @@ package owner
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -84,6 +85,16 @@ type changefeed struct {
 	schema    *schemaWrap4Owner
 	ddlSink   DDLSink
 	ddlPuller puller.DDLPuller
+	// filterConfig is the filter config that the schema and the ddlPuller
+	// are built with, it is used to detect online filter updates.
+	filterConfig *config.FilterConfig
+	// ddlPullerCancel cancels the context of the running ddlPuller.
+	ddlPullerCancel context.CancelFunc
+	// leaderState is the state of the changefeed followed by this changefeed,
+	// it is set by the owner before every tick.
+	leaderState *orchestrator.ChangefeedReactorState
+	// leaderMissing is true if the followed changefeed can not be found.
+	leaderMissing bool
 	// The changefeed will start a backend goroutine in the function `initialize`
 	// for DDLPuller and redo manager. `wg` is used to manage this backend goroutine.
 	wg sync.WaitGroup
@@ -339,6 +350,11 @@ func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*
 	default:
 	}
 
+	if err := c.updateFilter(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	c.updateFollowerBarrier()
+
 	// TODO: pass table checkpointTs when we support concurrent process ddl
 	allPhysicalTables, barrier, err := c.ddlManager.tick(ctx, preCheckpointTs, nil)
 	if err != nil {
@@ -503,20 +519,7 @@ LOOP2:
 		}
 	}
 
-	var ddlStartTs model.Ts
-	// This means there was a ddl job when the changefeed was paused.
-	// We don't know whether the ddl job is finished or not, so we need to
-	// start the ddl puller from the `checkpointTs-1` to execute the ddl job
-	// again.
-	// FIXME: TiCDC can't handle some ddl jobs correctly in this situation.
-	// For example, if the ddl job is `add index`, TiCDC will execute the ddl
-	// job again and cause the index to be added twice. We need to fix this
-	// problem in the future. See:https://github.com/pingcap/tiflow/issues/2543
-	if checkpointTs == minTableBarrierTs {
-		ddlStartTs = checkpointTs - 1
-	} else {
-		ddlStartTs = checkpointTs
-	}
+	ddlStartTs := getDDLStartTs(checkpointTs, minTableBarrierTs)
 
 	c.barriers = newBarriers()
 	if util.GetOrZero(c.state.Info.Config.EnableSyncPoint) {
@@ -524,14 +527,21 @@ LOOP2:
 	}
 	c.barriers.Update(finishBarrier, c.state.Info.GetTargetTs())
 
-	filter, err := filter.NewFilter(c.state.Info.Config, "")
+	// The previous filter is still applied if the online filter update
+	// is not reached yet.
+	filterCfg := c.state.Info.Config.Clone()
+	if c.state.Info.PrevFilter != nil && c.state.Info.FilterUpdateTs > checkpointTs {
+		filterCfg.Filter = c.state.Info.PrevFilter
+	}
+	c.filterConfig = filterCfg.Filter
+	filter, err := filter.NewFilter(filterCfg, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
 	c.schema, err = newSchemaWrap4Owner(
 		c.upstream.KVStorage,
 		ddlStartTs,
-		c.state.Info.Config,
+		filterCfg,
 		c.id,
 		filter)
 	if err != nil {
@@ -560,8 +570,10 @@ LOOP2:
 	})
 	c.ddlSink.run(cancelCtx)
 
-	c.ddlPuller, err = c.newDDLPuller(cancelCtx,
-		c.state.Info.Config,
+	pullerCtx, pullerCancel := cdcContext.WithCancel(cancelCtx)
+	c.ddlPullerCancel = pullerCancel
+	c.ddlPuller, err = c.newDDLPuller(pullerCtx,
+		filterCfg,
 		c.upstream, ddlStartTs,
 		c.id,
 		c.schema,
@@ -570,11 +582,7 @@ LOOP2:
 		return errors.Trace(err)
 	}
 
-	c.wg.Add(1)
-	go func() {
-		defer c.wg.Done()
-		ctx.Throw(c.ddlPuller.Run(cancelCtx))
-	}()
+	c.runDDLPuller(cancelCtx, pullerCtx, c.ddlPuller)
 
 	c.downstreamObserver, err = c.newDownstreamObserver(ctx, c.id, c.state.Info.SinkURI, c.state.Info.Config)
 	if err != nil {
@@ -655,6 +663,169 @@ LOOP2:
 	return nil
 }
 
+// getDDLStartTs returns the ts from which the ddl puller and the schema start.
+func getDDLStartTs(checkpointTs, minTableBarrierTs model.Ts) model.Ts {
+	// This means there was a ddl job when the changefeed was paused.
+	// We don't know whether the ddl job is finished or not, so we need to
+	// start the ddl puller from the `checkpointTs-1` to execute the ddl job
+	// again.
+	// FIXME: TiCDC can't handle some ddl jobs correctly in this situation.
+	// For example, if the ddl job is `add index`, TiCDC will execute the ddl
+	// job again and cause the index to be added twice. We need to fix this
+	// problem in the future. See:https://github.com/pingcap/tiflow/issues/2543
+	if checkpointTs == minTableBarrierTs {
+		return checkpointTs - 1
+	}
+	return checkpointTs
+}
+
+// runDDLPuller runs the ddlPuller in a backend goroutine until pullerCtx,
+// which is derived from ctx, is done.
+func (c *changefeed) runDDLPuller(
+	ctx cdcContext.Context, pullerCtx context.Context, ddlPuller puller.DDLPuller,
+) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		err := ddlPuller.Run(pullerCtx)
+		// The ddlPuller is canceled because it is replaced by a new one
+		// after the filter is updated, so the error can be ignored.
+		if errors.Cause(err) == context.Canceled && ctx.Err() == nil {
+			return
+		}
+		ctx.Throw(err)
+	}()
+}
+
+// updateFilter applies the filter rules updated online. The schema and the
+// ddlPuller are rebuilt from the checkpoint ts with the new filter, and then
+// the scheduler adds or removes the affected tables, the added tables start
+// to replicate from the checkpoint ts. If the filter update ts is specified,
+// the checkpoint ts is blocked by a barrier until it reaches the given ts.
+// The previous filter is persisted in the changefeed info until the update
+// is applied, so that a new owner keeps it until the filter update ts.
+func (c *changefeed) updateFilter(ctx cdcContext.Context) error {
+	if reflect.DeepEqual(c.filterConfig, c.state.Info.Config.Filter) {
+		c.clearPrevFilter()
+		return nil
+	}
+	checkpointTs := c.state.Status.CheckpointTs
+	if c.state.Info.FilterUpdateTs > checkpointTs {
+		c.barriers.Update(filterUpdateBarrier, c.state.Info.FilterUpdateTs)
+		return nil
+	}
+	// Wait for the executing DDL, the new ddlManager knows nothing about it.
+	if c.ddlManager.executingDDL != nil {
+		return nil
+	}
+	c.barriers.Remove(filterUpdateBarrier)
+
+	ddlStartTs := getDDLStartTs(checkpointTs, c.state.Status.MinTableBarrierTs)
+	f, err := filter.NewFilter(c.state.Info.Config, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema, err := newSchemaWrap4Owner(
+		c.upstream.KVStorage,
+		ddlStartTs,
+		c.state.Info.Config,
+		c.id,
+		f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	pullerCtx, pullerCancel := cdcContext.WithCancel(ctx)
+	ddlPuller, err := c.newDDLPuller(pullerCtx,
+		c.state.Info.Config,
+		c.upstream, ddlStartTs,
+		c.id,
+		schema,
+		f)
+	if err != nil {
+		pullerCancel()
+		return errors.Trace(err)
+	}
+	c.ddlPullerCancel()
+	c.ddlPuller.Close()
+	c.ddlPullerCancel = pullerCancel
+	c.schema = schema
+	c.ddlPuller = ddlPuller
+	c.runDDLPuller(ctx, pullerCtx, ddlPuller)
+
+	downstreamType, err := c.state.Info.DownstreamType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.ddlManager = newDDLManager(
+		c.id,
+		ddlStartTs,
+		checkpointTs,
+		c.ddlSink,
+		c.ddlPuller,
+		c.schema,
+		c.redoDDLMgr,
+		c.redoMetaMgr,
+		downstreamType,
+		util.GetOrZero(c.state.Info.Config.BDRMode),
+	)
+	c.filterConfig = c.state.Info.Config.Clone().Filter
+	c.clearPrevFilter()
+
+	log.Info("changefeed filter updated",
+		zap.String("namespace", c.id.Namespace),
+		zap.String("changefeed", c.id.ID),
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Strings("rules", c.filterConfig.Rules))
+	return nil
+}
+
+// clearPrevFilter removes the previous filter from the changefeed info after
+// the filter update is applied.
+func (c *changefeed) clearPrevFilter() {
+	if c.state.Info.PrevFilter == nil {
+		return
+	}
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil || info.PrevFilter == nil {
+			return info, false, nil
+		}
+		info.PrevFilter = nil
+		return info, true, nil
+	})
+}
+
+// updateFollowerBarrier caps the progress of the changefeed at the checkpoint
+// of the leader changefeed plus the max lag, if the changefeed follows
+// another changefeed.
+func (c *changefeed) updateFollowerBarrier() {
+	follower := c.state.Info.Config.Follower
+	if follower == nil {
+		c.barriers.Remove(followerBarrier)
+		return
+	}
+	leader := c.leaderState
+	if leader == nil || leader.Info == nil || leader.Status == nil {
+		if !c.leaderMissing {
+			log.Warn("the leader changefeed is not found, "+
+				"the follower changefeed stops advancing",
+				zap.String("namespace", c.id.Namespace),
+				zap.String("changefeed", c.id.ID),
+				zap.String("leader", follower.LeaderChangefeedID))
+			c.leaderMissing = true
+		}
+		// Keep the barrier until the leader changefeed shows up.
+		if _, ok := c.barriers.inner[followerBarrier]; !ok {
+			c.barriers.Update(followerBarrier, c.state.Status.CheckpointTs)
+		}
+		return
+	}
+	c.leaderMissing = false
+	leaderCheckpointTs := leader.Info.GetCheckpointTs(leader.Status)
+	barrierTs := leaderCheckpointTs + oracle.ComposeTS(follower.MaxLag.Milliseconds(), 0)
+	c.barriers.Update(followerBarrier, barrierTs)
+}
+
 func (c *changefeed) initMetrics() {
 	c.metricsChangefeedCheckpointTsGauge = changefeedCheckpointTsGauge.
 		WithLabelValues(c.id.Namespace, c.id.ID)
@@ -689,6 +860,10 @@ func (c *changefeed) releaseResources(ctx cdcContext.Context) {
 
 	c.cancel()
 	c.cancel = func() {}
+	if c.ddlPullerCancel != nil {
+		c.ddlPullerCancel()
+		c.ddlPullerCancel = nil
+	}
 
 	if c.ddlPuller != nil {
 		c.ddlPuller.Close()
@@ -894,6 +1069,10 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context, barrier *schedulepb.B
 			c.barriers.Update(syncPointBarrier, nextSyncPointTs)
 		case finishBarrier:
 			c.feedStateManager.MarkFinished()
+		case filterUpdateBarrier:
+			// The filter is applied in updateFilter in the next tick.
+		case followerBarrier:
+			// The barrier is advanced along with the leader changefeed.
 		default:
 			log.Panic("Unknown barrier type", zap.Int("barrierType", int(barrierTp)))
 		}
@@ -385,6 +385,180 @@ func TestExecDDL(t *testing.T) {
 	require.Contains(t, cf.scheduler.(*mockScheduler).currentTables, job.TableID)
 }
 
+func TestUpdateFilter(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job0 := helper.DDL2Job("create table test0.table0(id int primary key)")
+	job1 := helper.DDL2Job("create table test0.table1(id int primary key)")
+	startTs := job1.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	ctx.ChangefeedVars().Info.Config.Filter.Rules = []string{"test0.table0"}
+
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	tickThreeTime := func() {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	// pre check and initialize
+	tickThreeTime()
+	ddlPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	ddlPuller.resolvedTs = startTs + 1000
+	tickThreeTime()
+	checkpointTs := cf.state.Status.CheckpointTs
+	require.Equal(t, ddlPuller.resolvedTs, checkpointTs)
+	require.Equal(t, []model.TableID{job0.TableID}, cf.scheduler.(*mockScheduler).currentTables)
+
+	// The filter update is delayed until the checkpoint reaches the given ts.
+	filterUpdateTs := checkpointTs + 500
+	cf.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.PrevFilter = &config.FilterConfig{Rules: info.Config.Filter.Rules}
+		info.Config.Filter.Rules = []string{"test0.*"}
+		info.FilterUpdateTs = filterUpdateTs
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	ddlPuller.resolvedTs += 1000
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	require.Equal(t, filterUpdateTs, cf.state.Status.CheckpointTs)
+	require.Equal(t, ddlPuller, cf.ddlManager.ddlPuller)
+	require.Equal(t, []model.TableID{job0.TableID}, cf.scheduler.(*mockScheduler).currentTables)
+
+	// The schema and the ddl puller are rebuilt with the new filter once the
+	// checkpoint reaches the filter update ts.
+	tickThreeTime()
+	require.Equal(t, filterUpdateTs, cf.state.Status.CheckpointTs)
+	newDDLPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	require.NotEqual(t, ddlPuller, newDDLPuller)
+	require.Equal(t, []string{"test0.*"}, cf.filterConfig.Rules)
+	require.Nil(t, cf.state.Info.PrevFilter)
+	newDDLPuller.resolvedTs = filterUpdateTs + 1000
+	tickThreeTime()
+	require.Equal(t, newDDLPuller.resolvedTs, cf.state.Status.CheckpointTs)
+	require.ElementsMatch(t, []model.TableID{job0.TableID, job1.TableID},
+		cf.scheduler.(*mockScheduler).currentTables)
+}
+
+func TestFollowerChangefeed(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	job := helper.DDL2Job("create database test0")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	ctx.ChangefeedVars().Info.Config.Follower = &config.FollowerConfig{
+		LeaderChangefeedID: "leader",
+		MaxLag:             0,
+	}
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	tickThreeTime := func() {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	leader := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("leader"))
+	leader.Info = &model.ChangeFeedInfo{StartTs: startTs}
+	leader.Status = &model.ChangeFeedStatus{CheckpointTs: startTs + 500}
+	cf.leaderState = leader
+
+	// pre check and initialize
+	tickThreeTime()
+	mockDDLPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.resolvedTs = startTs + 1000
+	tickThreeTime()
+	require.Equal(t, startTs+500, cf.state.Status.CheckpointTs)
+
+	// The follower advances along with the leader.
+	leader.Status.CheckpointTs = startTs + 800
+	tickThreeTime()
+	require.Equal(t, startTs+800, cf.state.Status.CheckpointTs)
+
+	// The follower stops advancing if the leader is not found.
+	cf.leaderState = nil
+	tickThreeTime()
+	require.Equal(t, startTs+800, cf.state.Status.CheckpointTs)
+
+	// The follower is ahead of the leader by at most the max lag.
+	cf.leaderState = leader
+	cf.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.Config.Follower.MaxLag = time.Second
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	maxTs := startTs + 800 + oracle.ComposeTS(time.Second.Milliseconds(), 0)
+	mockDDLPuller.resolvedTs = maxTs + 1000
+	tickThreeTime()
+	require.Equal(t, maxTs, cf.state.Status.CheckpointTs)
+}
+
+func TestInitializeWithPrevFilter(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job0 := helper.DDL2Job("create table test0.table0(id int primary key)")
+	job1 := helper.DDL2Job("create table test0.table1(id int primary key)")
+	startTs := job1.BinlogInfo.FinishedTS + 1000
+	filterUpdateTs := startTs + 500
+
+	// The owner restarts before the filter update is applied.
+	ctx := cdcContext.NewContext4Test(context.Background(), true)
+	ctx.ChangefeedVars().Info.StartTs = startTs
+	ctx.ChangefeedVars().Info.Config.Filter.Rules = []string{"test0.*"}
+	ctx.ChangefeedVars().Info.PrevFilter = &config.FilterConfig{
+		Rules: []string{"test0.table0"},
+	}
+	ctx.ChangefeedVars().Info.FilterUpdateTs = filterUpdateTs
+
+	cf, captures, tester := createChangefeed4Test(ctx, t)
+	cf.upstream.KVStorage = helper.Storage()
+	defer cf.Close(ctx)
+	tickThreeTime := func() {
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+		cf.Tick(ctx, captures)
+		tester.MustApplyPatches()
+	}
+	// pre check and initialize
+	tickThreeTime()
+	require.Equal(t, []string{"test0.table0"}, cf.filterConfig.Rules)
+	ddlPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	ddlPuller.resolvedTs = startTs + 1000
+	cf.Tick(ctx, captures)
+	tester.MustApplyPatches()
+	require.Equal(t, filterUpdateTs, cf.state.Status.CheckpointTs)
+	require.Equal(t, []model.TableID{job0.TableID}, cf.scheduler.(*mockScheduler).currentTables)
+	require.NotNil(t, cf.state.Info.PrevFilter)
+
+	// The filter update is applied once the checkpoint reaches the filter
+	// update ts.
+	tickThreeTime()
+	require.Equal(t, []string{"test0.*"}, cf.filterConfig.Rules)
+	require.Nil(t, cf.state.Info.PrevFilter)
+	newDDLPuller := cf.ddlManager.ddlPuller.(*mockDDLPuller)
+	newDDLPuller.resolvedTs = filterUpdateTs + 1000
+	tickThreeTime()
+	require.ElementsMatch(t, []model.TableID{job0.TableID, job1.TableID},
+		cf.scheduler.(*mockScheduler).currentTables)
+}
+
 func TestEmitCheckpointTs(t *testing.T) {
 	helper := entry.NewSchemaTestHelper(t)
 	defer helper.Close()
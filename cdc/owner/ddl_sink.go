@@ -127,7 +127,7 @@ func ddlSinkInitializer(ctx context.Context, a *ddlSinkImpl) error {
 func (s *ddlSinkImpl) makeSyncPointStoreReady(ctx context.Context) error {
 	if util.GetOrZero(s.info.Config.EnableSyncPoint) && s.syncPointStore == nil {
 		syncPointStore, err := syncpointstore.NewSyncPointStore(
-			ctx, s.changefeedID, s.info.SinkURI, util.GetOrZero(s.info.Config.SyncPointRetention))
+			ctx, s.changefeedID, s.info.SinkURI, s.info.Config)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -344,10 +344,15 @@ func (s *ddlSinkImpl) emitSyncPoint(ctx context.Context, checkpointTs uint64) (e
 	}
 	s.lastSyncPoint = checkpointTs
 
+	s.mu.Lock()
+	tables := make([]*model.TableInfo, 0, len(s.mu.currentTables))
+	tables = append(tables, s.mu.currentTables...)
+	s.mu.Unlock()
+
 	for {
 		if err = s.makeSyncPointStoreReady(ctx); err == nil {
 			// TODO implement async sink syncPoint
-			err = s.syncPointStore.SinkSyncPoint(ctx, s.changefeedID, checkpointTs)
+			err = s.syncPointStore.SinkSyncPoint(ctx, s.changefeedID, checkpointTs, tables)
 		}
 		if err == nil {
 			return nil
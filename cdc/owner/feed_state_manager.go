@@ -15,12 +15,14 @@ package owner
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/upstream"
@@ -31,12 +33,10 @@ import (
 
 const (
 	// When errors occurred, and we need to do backoff, we start an exponential backoff
-	// with an interval from 10s to 30min (10s, 20s, 40s, 80s, 160s, 320s,
-	//	 640s, 1280s, 1800s, ...).
+	// with an interval from 10s to 30min by default (10s, 20s, 40s, 80s, 160s, 320s,
+	//	 640s, 1280s, 1800s, ...). The intervals can be changed by the error retry
+	// policy of the changefeed.
 	// To avoid thunderherd, a random factor is also added.
-	defaultBackoffInitInterval        = 10 * time.Second
-	defaultBackoffMaxInterval         = 30 * time.Minute
-	defaultBackoffMaxElapsedTime      = 90 * time.Minute
 	defaultBackoffRandomizationFactor = 0.1
 	defaultBackoffMultiplier          = 2.0
 
@@ -62,6 +62,8 @@ type feedStateManager struct {
 	lastErrorTime   time.Time                   // time of last error for a changefeed
 	backoffInterval time.Duration               // the interval for restarting a changefeed in 'error' state
 	errBackoff      *backoff.ExponentialBackOff // an exponential backoff for restarting a changefeed
+	// retryPolicy is the error retry policy applied to errBackoff.
+	retryPolicy *config.ErrorRetryPolicyConfig
 }
 
 // newFeedStateManager creates feedStateManager and initialize the exponential backoff
@@ -70,12 +72,14 @@ func newFeedStateManager(up *upstream.Upstream) *feedStateManager {
 	f.upstream = up
 
 	f.errBackoff = backoff.NewExponentialBackOff()
-	f.errBackoff.InitialInterval = defaultBackoffInitInterval
-	f.errBackoff.MaxInterval = defaultBackoffMaxInterval
+	f.errBackoff.InitialInterval = config.DefaultErrorRetryInitialInterval
+	f.errBackoff.MaxInterval = config.DefaultErrorRetryMaxInterval
 	f.errBackoff.Multiplier = defaultBackoffMultiplier
 	f.errBackoff.RandomizationFactor = defaultBackoffRandomizationFactor
-	// backoff will stop once the defaultBackoffMaxElapsedTime has elapsed.
-	f.errBackoff.MaxElapsedTime = defaultBackoffMaxElapsedTime
+	// backoff will stop once the DefaultErrorRetryMaxElapsedTime has elapsed.
+	f.errBackoff.MaxElapsedTime = config.DefaultErrorRetryMaxElapsedTime
+	// The default policy is already applied to the backoff.
+	f.retryPolicy = config.GetDefaultReplicaConfig().ErrorRetryPolicy
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
@@ -89,6 +93,43 @@ func (m *feedStateManager) resetErrBackoff() {
 	m.backoffInterval = m.errBackoff.NextBackOff()
 }
 
+// applyRetryPolicy updates the backoff if the error retry policy of the
+// changefeed is changed, the backoff is reset in this case.
+func (m *feedStateManager) applyRetryPolicy() {
+	if m.state.Info == nil || m.state.Info.Config == nil {
+		return
+	}
+	policy := m.state.Info.Config.ErrorRetryPolicy
+	if policy == nil || reflect.DeepEqual(policy, m.retryPolicy) {
+		return
+	}
+	log.Info("apply error retry policy of the changefeed",
+		zap.String("namespace", m.state.ID.Namespace),
+		zap.String("changefeed", m.state.ID.ID),
+		zap.Any("policy", policy))
+	m.retryPolicy = policy
+	m.errBackoff.InitialInterval = policy.InitialInterval
+	m.errBackoff.MaxInterval = policy.MaxInterval
+	// backoff never stops if MaxElapsedTime is 0.
+	m.errBackoff.MaxElapsedTime = policy.MaxElapsedTime
+	m.resetErrBackoff()
+}
+
+// isFatalError returns true if the error is configured as fatal
+// in the error retry policy.
+func (m *feedStateManager) isFatalError(err *model.RunningError) bool {
+	return m.retryPolicy != nil && m.retryPolicy.IsFatalError(err.Code, err.Message)
+}
+
+// isUnretryableError returns true if the error is unretryable and
+// it is not configured as retryable in the error retry policy.
+func (m *feedStateManager) isUnretryableError(err *model.RunningError) bool {
+	if m.retryPolicy != nil && m.retryPolicy.IsRetryableError(err.Code, err.Message) {
+		return false
+	}
+	return err.IsChangefeedUnRetryableError()
+}
+
 // isChangefeedStable check if there are states other than 'normal' in this sliding window.
 func (m *feedStateManager) isChangefeedStable() bool {
 	for _, val := range m.stateHistory {
@@ -112,6 +153,7 @@ func (m *feedStateManager) shiftStateWindow(state model.FeedState) {
 func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adminJobPending bool) {
 	m.state = state
 	m.shouldBeRunning = true
+	m.applyRetryPolicy()
 	defer func() {
 		if m.shouldBeRunning {
 			m.patchState(model.StateNormal)
@@ -134,7 +176,7 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 		m.shouldBeRunning = false
 		return
 	case model.StateError:
-		if m.state.Info.Error.IsChangefeedUnRetryableError() {
+		if m.isUnretryableError(m.state.Info.Error) {
 			m.shouldBeRunning = false
 			m.patchState(model.StateFailed)
 			return
@@ -460,7 +502,8 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 	// if there are a fastFail error in errs, we can just fastFail the changefeed
 	// and no need to patch other error to the changefeed info
 	for _, err := range errs {
-		if cerrors.IsChangefeedFastFailErrorCode(errors.RFCErrorCode(err.Code)) {
+		if cerrors.IsChangefeedFastFailErrorCode(errors.RFCErrorCode(err.Code)) ||
+			m.isFatalError(err) {
 			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 				if info == nil {
 					return nil, false, nil
@@ -488,7 +531,7 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 	// so we have to iterate all errs here to check wether it is a unretryable
 	// error in errs
 	for _, err := range errs {
-		if err.IsChangefeedUnRetryableError() {
+		if m.isUnretryableError(err) {
 			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 				if info == nil {
 					return nil, false, nil
@@ -61,6 +61,8 @@ func newFeedStateManager4Test(
 	f.errBackoff.MaxElapsedTime = maxElapsedTimeInMs * time.Millisecond
 	f.errBackoff.Multiplier = multiplier
 	f.errBackoff.RandomizationFactor = 0
+	// Keep the backoff above unless the test changes the retry policy.
+	f.retryPolicy = config.GetDefaultReplicaConfig().ErrorRetryPolicy
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
@@ -700,3 +702,72 @@ func TestUpdateChangefeedEpoch(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorRetryPolicy(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	// Set a long backoff time, which is overridden by the policy.
+	manager := newFeedStateManager4Test(time.Hour, time.Hour, 0, 1.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	replicaConfig := &config.ReplicaConfig{
+		ErrorRetryPolicy: &config.ErrorRetryPolicyConfig{
+			InitialInterval: 100 * time.Millisecond,
+			MaxInterval:     200 * time.Millisecond,
+			MaxElapsedTime:  0,
+			FatalErrors:     []string{string(cerror.ErrKafkaNewProducer.RFCCode())},
+			RetryableErrors: []string{string(cerror.ErrExpressionColumnNotFound.RFCCode())},
+		},
+	}
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: replicaConfig}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, 100*time.Millisecond, manager.errBackoff.InitialInterval)
+	require.Equal(t, 200*time.Millisecond, manager.errBackoff.MaxInterval)
+	require.Equal(t, time.Duration(0), manager.errBackoff.MaxElapsedTime)
+	require.Equal(t, 100*time.Millisecond, manager.backoffInterval)
+
+	// the unretryable error configured as retryable is retried.
+	state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			return &model.TaskPosition{Error: &model.RunningError{
+				Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+				Code:    string(cerror.ErrExpressionColumnNotFound.RFCCode()),
+				Message: cerror.ErrExpressionColumnNotFound.Error(),
+			}}, true, nil
+		})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateError, state.Info.State)
+	time.Sleep(100 * time.Millisecond)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+
+	// the error configured as fatal fails the changefeed immediately.
+	state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			return &model.TaskPosition{Error: &model.RunningError{
+				Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+				Code:    string(cerror.ErrKafkaNewProducer.RFCCode()),
+				Message: "fake error for test",
+			}}, true, nil
+		})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateFailed, state.Info.State)
+}
@@ -17,8 +17,11 @@ import (
 	"testing"
 
 	"github.com/pingcap/tiflow/pkg/leakutil"
+	"go.uber.org/goleak"
 )
 
 func TestMain(m *testing.M) {
-	leakutil.SetUpLeakTest(m)
+	// The pulsar client links keyring, which connects to the dbus session
+	// bus during initialization if the bus is available.
+	leakutil.SetUpLeakTest(m, goleak.IgnoreCurrent())
 }
@@ -80,6 +80,21 @@ func (mr *MockStatusProviderMockRecorder) GetAllTaskStatuses(ctx, changefeedID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTaskStatuses", reflect.TypeOf((*MockStatusProvider)(nil).GetAllTaskStatuses), ctx, changefeedID)
 }
 
+// GetCaptureLoads mocks base method.
+func (m *MockStatusProvider) GetCaptureLoads(ctx context.Context) (map[model.CaptureID]*model.CaptureLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaptureLoads", ctx)
+	ret0, _ := ret[0].(map[model.CaptureID]*model.CaptureLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaptureLoads indicates an expected call of GetCaptureLoads.
+func (mr *MockStatusProviderMockRecorder) GetCaptureLoads(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaptureLoads", reflect.TypeOf((*MockStatusProvider)(nil).GetCaptureLoads), ctx)
+}
+
 // GetCaptures mocks base method.
 func (m *MockStatusProvider) GetCaptures(ctx context.Context) ([]*model.CaptureInfo, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +198,7 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 		ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
 			ID: changefeedID,
 		})
+		cfReactor.leaderState = getLeaderState(state, changefeedState)
 		cfReactor.Tick(ctx, state.Captures)
 	}
 	o.changefeedTicked = true
@@ -321,6 +322,21 @@ func (o *ownerImpl) Bootstrap(state *orchestrator.GlobalReactorState) {
 	fixChangefeedInfos(state)
 }
 
+// getLeaderState returns the state of the changefeed followed by the given
+// changefeed, nil if it follows no changefeed or the leader does not exist.
+func getLeaderState(
+	state *orchestrator.GlobalReactorState, cfState *orchestrator.ChangefeedReactorState,
+) *orchestrator.ChangefeedReactorState {
+	if cfState.Info.Config == nil || cfState.Info.Config.Follower == nil {
+		return nil
+	}
+	leaderID := model.ChangeFeedID{
+		Namespace: cfState.ID.Namespace,
+		ID:        cfState.Info.Config.Follower.LeaderChangefeedID,
+	}
+	return state.Changefeeds[leaderID]
+}
+
 // fixChangefeedInfos attempts to fix incompatible or incorrect meta information in changefeed state.
 func fixChangefeedInfos(state *orchestrator.GlobalReactorState) {
 	for _, changefeedState := range state.Changefeeds {
@@ -579,11 +595,30 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 				ID:            captureInfo.ID,
 				AdvertiseAddr: captureInfo.AdvertiseAddr,
 				Version:       captureInfo.Version,
+				Labels:        captureInfo.Labels,
 			})
 		}
 		query.Data = ret
 	case QueryHealth:
 		query.Data = o.isHealthy()
+	case QueryCaptureLoads:
+		ret := make(map[model.CaptureID]*model.CaptureLoad, len(o.captures))
+		for captureID := range o.captures {
+			ret[captureID] = &model.CaptureLoad{}
+		}
+		for _, cfReactor := range o.changefeeds {
+			provider := cfReactor.GetInfoProvider()
+			if provider == nil {
+				// The scheduler has not been initialized yet.
+				continue
+			}
+			for captureID, load := range provider.GetCaptureLoads() {
+				if total, ok := ret[captureID]; ok {
+					total.Add(load)
+				}
+			}
+		}
+		query.Data = ret
 	}
 	return nil
 }
@@ -930,3 +930,46 @@ func TestIsHealthy(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, query.Data.(bool))
 }
+
+type loadScheduler struct {
+	scheduler.Scheduler
+	scheduler.InfoProvider
+	loads map[model.CaptureID]*model.CaptureLoad
+}
+
+func (l *loadScheduler) GetCaptureLoads() map[model.CaptureID]*model.CaptureLoad {
+	return l.loads
+}
+
+func TestHandleQueryCaptureLoads(t *testing.T) {
+	t.Parallel()
+
+	o := &ownerImpl{
+		changefeeds: make(map[model.ChangeFeedID]*changefeed),
+		captures: map[model.CaptureID]*model.CaptureInfo{
+			"a": {ID: "a"}, "b": {ID: "b"},
+		},
+	}
+	o.changefeeds[model.ChangeFeedID{ID: "1"}] = &changefeed{
+		scheduler: &loadScheduler{loads: map[model.CaptureID]*model.CaptureLoad{
+			"a": {TableCount: 1, RegionCount: 2, SinkRowsPerSecond: 10, Load: 4},
+			"b": {TableCount: 1, Load: 1},
+		}},
+	}
+	o.changefeeds[model.ChangeFeedID{ID: "2"}] = &changefeed{
+		scheduler: &loadScheduler{loads: map[model.CaptureID]*model.CaptureLoad{
+			"a": {TableCount: 1, RegionCount: 1, Load: 2},
+			// The capture is offline.
+			"c": {TableCount: 1, Load: 1},
+		}},
+	}
+	// The scheduler is not initialized.
+	o.changefeeds[model.ChangeFeedID{ID: "3"}] = &changefeed{}
+
+	query := &Query{Tp: QueryCaptureLoads}
+	require.NoError(t, o.handleQueries(query))
+	require.Equal(t, map[model.CaptureID]*model.CaptureLoad{
+		"a": {TableCount: 2, RegionCount: 3, SinkRowsPerSecond: 10, Load: 6},
+		"b": {TableCount: 1, Load: 1},
+	}, query.Data)
+}
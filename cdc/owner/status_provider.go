@@ -47,6 +47,10 @@ type StatusProvider interface {
 
 	// IsHealthy return true if the cluster is healthy
 	IsHealthy(ctx context.Context) (bool, error)
+
+	// GetCaptureLoads returns the replication loads of all captures, summed
+	// over all changefeeds.
+	GetCaptureLoads(ctx context.Context) (map[model.CaptureID]*model.CaptureLoad, error)
 }
 
 // QueryType is the type of different queries.
@@ -65,6 +69,8 @@ const (
 	QueryCaptures
 	// QueryHealth is the type of query cluster health info.
 	QueryHealth
+	// QueryCaptureLoads is the type of query the loads of captures.
+	QueryCaptureLoads
 )
 
 // Query wraps query command and return results.
@@ -179,6 +185,18 @@ func (p *ownerStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
 	return query.Data.(bool), nil
 }
 
+func (p *ownerStatusProvider) GetCaptureLoads(ctx context.Context) (
+	map[model.CaptureID]*model.CaptureLoad, error,
+) {
+	query := &Query{
+		Tp: QueryCaptureLoads,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.Data.(map[model.CaptureID]*model.CaptureLoad), nil
+}
+
 func (p *ownerStatusProvider) sendQueryToOwner(ctx context.Context, query *Query) error {
 	doneCh := make(chan error, 1)
 	p.owner.Query(query, doneCh)
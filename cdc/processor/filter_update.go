@@ -0,0 +1,251 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/entry"
+	"github.com/pingcap/tiflow/cdc/entry/schema"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cdcContext "github.com/pingcap/tiflow/pkg/context"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// updateFilter rebuilds the schema storage and the DDL puller from the
+// checkpoint ts when the filter of the changefeed is updated online, so that
+// the tables added by the new filter can be mounted.
+// The tables matched by the previous filter are kept until the owner applies
+// the update and removes them from this processor, then the previous filter
+// is dropped.
+func (p *processor) updateFilter(ctx cdcContext.Context) error {
+	// filterConfig is nil if the processor is not initialized by lazyInitImpl.
+	if p.filterConfig == nil {
+		return nil
+	}
+	info := p.changefeed.Info
+	prevFilterConfig := p.prevFilterConfig
+	switch {
+	case !reflect.DeepEqual(p.filterConfig, info.Config.Filter):
+		// Only the filter applied by the owner is kept, the tables of the
+		// earlier filters are not replicated anymore.
+		prevFilterConfig = info.PrevFilter
+		if prevFilterConfig == nil {
+			prevFilterConfig = p.filterConfig
+		}
+	case prevFilterConfig != nil && info.PrevFilter == nil && !p.hasIgnoredTables():
+		prevFilterConfig = nil
+	default:
+		return nil
+	}
+
+	dmlFilter, schemaFilter, err := p.newFilters(prevFilterConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	prcCtx := cdcContext.NewContext(context.Background(), ctx.GlobalVars())
+	prcCtx = cdcContext.WithChangefeedVars(prcCtx, ctx.ChangefeedVars())
+	p.ddlHandler.stop()
+	if err := p.initDDLHandler(prcCtx, schemaFilter); err != nil {
+		return errors.Trace(err)
+	}
+	p.ddlHandler.spawn(prcCtx)
+	p.schemaStorage.set(p.ddlHandler.r.schemaStorage)
+	p.filter.set(dmlFilter)
+
+	var prevRules []string
+	if prevFilterConfig != nil {
+		prevRules = prevFilterConfig.Rules
+	}
+	log.Info("processor filter updated",
+		zap.String("capture", p.captureInfo.ID),
+		zap.String("namespace", p.changefeedID.Namespace),
+		zap.String("changefeed", p.changefeedID.ID),
+		zap.Strings("rules", p.filterConfig.Rules),
+		zap.Strings("prevRules", prevRules))
+	return nil
+}
+
+// newFilters builds the filter for DML events and the filter for the schema
+// storage from the current filter config, the tables matched by the previous
+// filter config are also kept if it is not nil.
+func (p *processor) newFilters(
+	prevFilterConfig *config.FilterConfig,
+) (dmlFilter filter.Filter, schemaFilter filter.Filter, err error) {
+	tz, err := util.GetTimezone(config.GetGlobalServerConfig().TZ)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg := p.changefeed.Info.Config
+	dmlFilter, err = filter.NewFilter(cfg, util.GetTimeZoneName(tz))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	schemaFilter, err = filter.NewFilter(cfg, "")
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	p.filterConfig = cfg.Clone().Filter
+	p.tableFilter = schemaFilter
+	p.prevFilterConfig = prevFilterConfig
+	if prevFilterConfig == nil {
+		return dmlFilter, schemaFilter, nil
+	}
+
+	prevCfg := cfg.Clone()
+	prevCfg.Filter = prevFilterConfig
+	prevDMLFilter, err := filter.NewFilter(prevCfg, util.GetTimeZoneName(tz))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	prevSchemaFilter, err := filter.NewFilter(prevCfg, "")
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return filter.NewUnionFilter(prevDMLFilter, dmlFilter),
+		filter.NewUnionFilter(prevSchemaFilter, schemaFilter), nil
+}
+
+// hasIgnoredTables returns true if any table of the processor is ignored by
+// the current filter, which means the owner has not removed it yet.
+func (p *processor) hasIgnoredTables() bool {
+	snap := p.schemaStorage.GetLastSnapshot()
+	for _, span := range p.sinkManager.r.GetAllCurrentTableSpans() {
+		tableInfo, ok := snap.PhysicalTableByID(span.TableID)
+		if !ok {
+			continue
+		}
+		if p.tableFilter.ShouldIgnoreTable(
+			tableInfo.TableName.Schema, tableInfo.TableName.Table) {
+			return true
+		}
+	}
+	return false
+}
+
+// switchableSchemaStorage forwards the calls to the current schema storage,
+// which is replaced when the filter of the changefeed is updated.
+type switchableSchemaStorage struct {
+	mu      sync.RWMutex
+	storage entry.SchemaStorage
+}
+
+func newSwitchableSchemaStorage(storage entry.SchemaStorage) *switchableSchemaStorage {
+	return &switchableSchemaStorage{storage: storage}
+}
+
+func (s *switchableSchemaStorage) get() entry.SchemaStorage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.storage
+}
+
+func (s *switchableSchemaStorage) set(storage entry.SchemaStorage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage = storage
+}
+
+// GetSnapshot implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) GetSnapshot(ctx context.Context, ts uint64) (*schema.Snapshot, error) {
+	return s.get().GetSnapshot(ctx, ts)
+}
+
+// GetLastSnapshot implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) GetLastSnapshot() *schema.Snapshot {
+	return s.get().GetLastSnapshot()
+}
+
+// HandleDDLJob implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) HandleDDLJob(job *timodel.Job) error {
+	return s.get().HandleDDLJob(job)
+}
+
+// AdvanceResolvedTs implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) AdvanceResolvedTs(ts uint64) {
+	s.get().AdvanceResolvedTs(ts)
+}
+
+// ResolvedTs implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) ResolvedTs() uint64 {
+	return s.get().ResolvedTs()
+}
+
+// DoGC implements entry.SchemaStorage.
+func (s *switchableSchemaStorage) DoGC(ts uint64) uint64 {
+	return s.get().DoGC(ts)
+}
+
+// switchableFilter forwards the calls to the current filter, which is
+// replaced when the filter of the changefeed is updated.
+type switchableFilter struct {
+	mu     sync.RWMutex
+	filter filter.Filter
+}
+
+func newSwitchableFilter(f filter.Filter) *switchableFilter {
+	return &switchableFilter{filter: f}
+}
+
+func (f *switchableFilter) get() filter.Filter {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.filter
+}
+
+func (f *switchableFilter) set(inner filter.Filter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filter = inner
+}
+
+// ShouldIgnoreDMLEvent implements filter.Filter.
+func (f *switchableFilter) ShouldIgnoreDMLEvent(
+	dml *model.RowChangedEvent, rawRow model.RowChangedDatums, tableInfo *model.TableInfo,
+) (bool, error) {
+	return f.get().ShouldIgnoreDMLEvent(dml, rawRow, tableInfo)
+}
+
+// ShouldIgnoreDDLEvent implements filter.Filter.
+func (f *switchableFilter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
+	return f.get().ShouldIgnoreDDLEvent(ddl)
+}
+
+// ShouldDiscardDDL implements filter.Filter.
+func (f *switchableFilter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table string) bool {
+	return f.get().ShouldDiscardDDL(ddlType, schema, table)
+}
+
+// ShouldIgnoreTable implements filter.Filter.
+func (f *switchableFilter) ShouldIgnoreTable(schema, table string) bool {
+	return f.get().ShouldIgnoreTable(schema, table)
+}
+
+// ShouldIgnoreSchema implements filter.Filter.
+func (f *switchableFilter) ShouldIgnoreSchema(schema string) bool {
+	return f.get().ShouldIgnoreSchema(schema)
+}
+
+// Verify implements filter.Filter.
+func (f *switchableFilter) Verify(tableInfos []*model.TableInfo) error {
+	return f.get().Verify(tableInfos)
+}
@@ -63,7 +63,14 @@ type processor struct {
 	upstream     *upstream.Upstream
 	lastSchemaTs model.Ts
 
-	filter filter.Filter
+	// filter and schemaStorage are replaced when the filter of the changefeed
+	// is updated online, filterConfig and prevFilterConfig are the configs
+	// they are built with. tableFilter is built with filterConfig only.
+	filter           *switchableFilter
+	schemaStorage    *switchableSchemaStorage
+	filterConfig     *config.FilterConfig
+	prevFilterConfig *config.FilterConfig
+	tableFilter      filter.Filter
 
 	// To manager DDL events and schema storage.
 	ddlHandler component[*ddlHandler]
@@ -357,6 +364,7 @@ func (p *processor) getStatsFromSourceManagerAndSinkManager(
 		RegionCount: pullerStats.RegionCount,
 		CurrentTs:   oracle.ComposeTS(oracle.GetPhysical(now), 0),
 		BarrierTs:   sinkStats.BarrierTs,
+		SinkRows:    sinkStats.SinkRows,
 		StageCheckpoints: map[string]tablepb.Checkpoint{
 			"puller-ingress": {
 				CheckpointTs: pullerStats.CheckpointTsIngress,
@@ -583,6 +591,12 @@ func (p *processor) tick(ctx cdcContext.Context) error {
 	if err := p.lazyInit(ctx); err != nil {
 		return errors.Trace(err)
 	}
+	// The throughput limit and the filter can be updated without restarting
+	// the changefeed.
+	p.sinkManager.r.UpdateThroughputLimit(p.changefeed.Info.Config.ThroughputLimit)
+	if err := p.updateFilter(ctx); err != nil {
+		return errors.Trace(err)
+	}
 
 	barrier, err := p.agent.Tick(ctx)
 	if err != nil {
@@ -645,19 +659,23 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	p.filter, err = filter.NewFilter(p.changefeed.Info.Config, util.GetTimeZoneName(tz))
+	// The tables matched by the previous filter are kept until the owner
+	// applies the online filter update.
+	f, schemaFilter, err := p.newFilters(p.changefeed.Info.PrevFilter)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	p.filter = newSwitchableFilter(f)
 
-	if err = p.initDDLHandler(prcCtx); err != nil {
+	if err = p.initDDLHandler(prcCtx, schemaFilter); err != nil {
 		return err
 	}
 	p.ddlHandler.name = "ddlHandler"
 	p.ddlHandler.changefeedID = p.changefeedID
 	p.ddlHandler.spawn(prcCtx)
+	p.schemaStorage = newSwitchableSchemaStorage(p.ddlHandler.r.schemaStorage)
 
-	p.mg.r = entry.NewMounterGroup(p.ddlHandler.r.schemaStorage,
+	p.mg.r = entry.NewMounterGroup(p.schemaStorage,
 		p.changefeed.Info.Config.Mounter.WorkerNum,
 		p.filter, tz, p.changefeedID, p.changefeed.Info.Config.Integrity)
 	p.mg.name = "MounterGroup"
@@ -696,7 +714,7 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 
 	p.sinkManager.r = sinkmanager.New(
 		p.changefeedID, p.changefeed.Info, p.upstream,
-		p.ddlHandler.r.schemaStorage, p.redo.r, p.sourceManager.r)
+		p.schemaStorage, p.redo.r, p.sourceManager.r)
 	p.sinkManager.name = "SinkManager"
 	p.sinkManager.changefeedID = p.changefeedID
 	p.sinkManager.spawn(prcCtx)
@@ -762,7 +780,7 @@ func (p *processor) handleErrorCh() (err error) {
 	return cerror.ErrReactorFinished
 }
 
-func (p *processor) initDDLHandler(ctx context.Context) error {
+func (p *processor) initDDLHandler(ctx context.Context, f filter.Filter) error {
 	checkpointTs := p.changefeed.Info.GetCheckpointTs(p.changefeed.Status)
 	minTableBarrierTs := p.changefeed.Status.MinTableBarrierTs
 	forceReplicate := p.changefeed.Info.Config.ForceReplicate
@@ -780,10 +798,6 @@ func (p *processor) initDDLHandler(ctx context.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	f, err := filter.NewFilter(p.changefeed.Info.Config, "")
-	if err != nil {
-		return errors.Trace(err)
-	}
 	schemaStorage, err := entry.NewSchemaStorage(meta, ddlStartTs,
 		forceReplicate, p.changefeedID, util.RoleProcessor, f)
 	if err != nil {
@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
 	tablesinkmetrics "github.com/pingcap/tiflow/cdc/sink/metrics/tablesink"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/pingcap/tiflow/pkg/upstream"
@@ -56,6 +57,8 @@ type TableStats struct {
 	CheckpointTs model.Ts
 	ResolvedTs   model.Ts
 	BarrierTs    model.Ts
+	// SinkRows is the number of rows written to the table sink.
+	SinkRows uint64
 }
 
 // SinkManager is the implementation of SinkManager.
@@ -96,6 +99,8 @@ type SinkManager struct {
 	sinkWorkerAvailable chan struct{}
 	// sinkMemQuota is used to control the total memory usage of the table sink.
 	sinkMemQuota *memquota.MemQuota
+	// sinkLimiter is used to control the write speed of the table sink.
+	sinkLimiter *throughputLimiter
 
 	// redoWorkers used to pull data from source manager.
 	redoWorkers []*redoWorker
@@ -104,6 +109,8 @@ type SinkManager struct {
 	redoWorkerAvailable chan struct{}
 	// redoMemQuota is used to control the total memory usage of the redo.
 	redoMemQuota *memquota.MemQuota
+	// redoLimiter is used to control the write speed of the redo.
+	redoLimiter *throughputLimiter
 
 	// To control lifetime of all sub-goroutines.
 	managerCtx    context.Context
@@ -141,6 +148,8 @@ func New(
 		sinkWorkers:         make([]*sinkWorker, 0, sinkWorkerNum),
 		sinkTaskChan:        make(chan *sinkTask),
 		sinkWorkerAvailable: make(chan struct{}, 1),
+		sinkLimiter: newThroughputLimiter(changefeedID, "sink",
+			changefeedInfo.Config.ThroughputLimit),
 
 		metricsTableSinkTotalRows: tablesinkmetrics.TotalRowsCountCounter.
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
@@ -152,6 +161,8 @@ func New(
 		m.redoWorkers = make([]*redoWorker, 0, redoWorkerNum)
 		m.redoTaskChan = make(chan *redoTask)
 		m.redoWorkerAvailable = make(chan struct{}, 1)
+		m.redoLimiter = newThroughputLimiter(changefeedID, "redo",
+			changefeedInfo.Config.ThroughputLimit)
 
 		// Use 3/4 memory quota as redo quota, and 1/2 again for redo cache.
 		m.sinkMemQuota = memquota.NewMemQuota(changefeedID, changefeedInfo.Config.MemoryQuota/4*1, "sink")
@@ -183,6 +194,7 @@ func (m *SinkManager) Run(ctx context.Context, warnings ...chan<- error) (err er
 
 	splitTxn := util.GetOrZero(m.changefeedInfo.Config.Sink.TxnAtomicity).ShouldSplitTxn()
 	enableOldValue := m.changefeedInfo.Config.EnableOldValue
+	spillLargeTxn := m.changefeedInfo.Config.EnableLargeTxnSpill
 
 	gcErrors := make(chan error, 16)
 	sinkFactoryErrors := make(chan error, 16)
@@ -193,7 +205,7 @@ func (m *SinkManager) Run(ctx context.Context, warnings ...chan<- error) (err er
 	if m.sinkEg == nil {
 		var sinkCtx context.Context
 		m.sinkEg, sinkCtx = errgroup.WithContext(m.managerCtx)
-		m.startSinkWorkers(sinkCtx, m.sinkEg, splitTxn, enableOldValue)
+		m.startSinkWorkers(sinkCtx, m.sinkEg, splitTxn, spillLargeTxn, enableOldValue)
 		m.sinkEg.Go(func() error { return m.generateSinkTasks(sinkCtx) })
 		m.wg.Add(1)
 		go func() {
@@ -321,11 +333,13 @@ func (m *SinkManager) clearSinkFactory() {
 	}
 }
 
-func (m *SinkManager) startSinkWorkers(ctx context.Context, eg *errgroup.Group, splitTxn bool, enableOldValue bool) {
+func (m *SinkManager) startSinkWorkers(
+	ctx context.Context, eg *errgroup.Group, splitTxn bool, spillLargeTxn bool, enableOldValue bool,
+) {
 	for i := 0; i < sinkWorkerNum; i++ {
 		w := newSinkWorker(m.changefeedID, m.sourceManager,
 			m.sinkMemQuota, m.redoMemQuota,
-			m.eventCache, splitTxn, enableOldValue)
+			m.eventCache, m.sinkLimiter, splitTxn, spillLargeTxn, enableOldValue)
 		m.sinkWorkers = append(m.sinkWorkers, w)
 		eg.Go(func() error { return w.handleTasks(ctx, m.sinkTaskChan) })
 	}
@@ -334,7 +348,7 @@ func (m *SinkManager) startSinkWorkers(ctx context.Context, eg *errgroup.Group,
 func (m *SinkManager) startRedoWorkers(ctx context.Context, eg *errgroup.Group, enableOldValue bool) {
 	for i := 0; i < redoWorkerNum; i++ {
 		w := newRedoWorker(m.changefeedID, m.sourceManager, m.redoMemQuota,
-			m.redoDMLMgr, m.eventCache, enableOldValue)
+			m.redoDMLMgr, m.eventCache, m.redoLimiter, enableOldValue)
 		m.redoWorkers = append(m.redoWorkers, w)
 		eg.Go(func() error { return w.handleTasks(ctx, m.redoTaskChan) })
 	}
@@ -714,6 +728,15 @@ func (m *SinkManager) UpdateReceivedSorterResolvedTs(span tablepb.Span, ts model
 	tableSink.(*tableSinkWrapper).updateReceivedSorterResolvedTs(ts)
 }
 
+// UpdateThroughputLimit updates the throughput limit of the table sink and
+// the redo, it takes effect without restarting the workers.
+func (m *SinkManager) UpdateThroughputLimit(cfg *config.ThroughputLimitConfig) {
+	m.sinkLimiter.update(cfg)
+	if m.redoLimiter != nil {
+		m.redoLimiter.update(cfg)
+	}
+}
+
 // UpdateBarrierTs update all tableSink's barrierTs in the SinkManager
 func (m *SinkManager) UpdateBarrierTs(
 	globalBarrierTs model.Ts,
@@ -947,6 +970,7 @@ func (m *SinkManager) GetTableStats(span tablepb.Span) TableStats {
 		CheckpointTs: checkpointTs.ResolvedMark(),
 		ResolvedTs:   resolvedTs,
 		BarrierTs:    tableSink.barrierTs.Load(),
+		SinkRows:     tableSink.sinkRows.Load(),
 	}
 }
 
@@ -982,6 +1006,10 @@ func (m *SinkManager) Close() {
 	if m.eventCache != nil {
 		m.eventCache.clear()
 	}
+	m.sinkLimiter.close()
+	if m.redoLimiter != nil {
+		m.redoLimiter.close()
+	}
 
 	log.Info("Closed sink manager",
 		zap.String("namespace", m.changefeedID.Namespace),
@@ -57,6 +57,39 @@ var (
 		Name:      "output_event_count",
 		Help:      "The number of events output by the sorter",
 	}, []string{"namespace", "changefeed", "type"})
+
+	// ThroughputLimit indicates the throughput limit of a changefeed, 0 means no limit.
+	ThroughputLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkmanager",
+			Name:      "throughput_limit",
+			Help:      "rows or bytes allowed to be written per second by the changefeed",
+		},
+		// component includes sink and redo, type includes rows and bytes.
+		[]string{"namespace", "changefeed", "component", "type"})
+
+	// ThrottledDuration indicates how long the workers of a changefeed are
+	// blocked by the throughput limit.
+	ThrottledDuration = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkmanager",
+			Name:      "throttled_duration_seconds",
+			Help:      "total duration the workers are blocked by the throughput limit",
+		},
+		// component includes sink and redo.
+		[]string{"namespace", "changefeed", "component"})
+
+	// stagedTxnCount is the number of transactions staged in the sort engine
+	// because they exceed the memory quota.
+	stagedTxnCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sinkmanager",
+			Name:      "staged_txn_count",
+			Help:      "The number of transactions staged in the sort engine because they exceed the memory quota",
+		}, []string{"namespace", "changefeed"})
 )
 
 // InitMetrics registers all metrics in this file.
@@ -64,4 +97,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(RedoEventCache)
 	registry.MustRegister(RedoEventCacheAccess)
 	registry.MustRegister(outputEventCount)
+	registry.MustRegister(ThroughputLimit)
+	registry.MustRegister(ThrottledDuration)
+	registry.MustRegister(stagedTxnCount)
 }
@@ -33,6 +33,7 @@ type redoWorker struct {
 	memQuota       *memquota.MemQuota
 	redoDMLManager redo.DMLManager
 	eventCache     *redoEventCache
+	limiter        *throughputLimiter
 	enableOldValue bool
 }
 
@@ -42,6 +43,7 @@ func newRedoWorker(
 	quota *memquota.MemQuota,
 	redoDMLMgr redo.DMLManager,
 	eventCache *redoEventCache,
+	limiter *throughputLimiter,
 	enableOldValue bool,
 ) *redoWorker {
 	return &redoWorker{
@@ -50,6 +52,7 @@ func newRedoWorker(
 		memQuota:       quota,
 		redoDMLManager: redoDMLMgr,
 		eventCache:     eventCache,
+		limiter:        limiter,
 		enableOldValue: enableOldValue,
 	}
 }
@@ -152,6 +155,9 @@ func (w *redoWorker) handleTask(ctx context.Context, task *redoTask) (finalErr e
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.limiter.wait(ctx, len(x), size); err != nil {
+				return errors.Trace(err)
+			}
 			advancer.appendEvents(x, size)
 		}
 
@@ -0,0 +1,134 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/memquota"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/engine"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// txnStager stages the transactions exceeding the memory quota in the sort
+// engine. The events of a staged transaction are kept in the sort engine
+// until the table sink checkpoint passes it, so they are read again when
+// the transaction is written to the downstream.
+type txnStager struct {
+	changefeedID   model.ChangeFeedID
+	sourceManager  *sourcemanager.SourceManager
+	sinkMemQuota   *memquota.MemQuota
+	enableOldValue bool
+
+	metricStagedTxnCount prometheus.Counter
+}
+
+func newTxnStager(
+	changefeedID model.ChangeFeedID,
+	sourceManager *sourcemanager.SourceManager,
+	sinkMemQuota *memquota.MemQuota,
+	enableOldValue bool,
+) *txnStager {
+	return &txnStager{
+		changefeedID:   changefeedID,
+		sourceManager:  sourceManager,
+		sinkMemQuota:   sinkMemQuota,
+		enableOldValue: enableOldValue,
+
+		metricStagedTxnCount: stagedTxnCount.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+	}
+}
+
+// stagingTxn is the transaction being staged by a table sink task.
+type stagingTxn struct {
+	// firstRow is the first row of the transaction.
+	firstRow *model.RowChangedEvent
+	// pos is the position of all events of the transaction.
+	pos      engine.Position
+	rowCount int
+}
+
+// stagedRows returns the rows of the staged transaction.
+func (s *txnStager) stagedRows(task *sinkTask, txn *stagingTxn) *stagedTxnRows {
+	return &stagedTxnRows{
+		stager:      s,
+		span:        task.span,
+		pos:         txn.pos,
+		rowCount:    txn.rowCount,
+		replicateTs: task.tableSink.replicateTs,
+	}
+}
+
+// stagedTxnRows reads the rows of a staged transaction from the sort engine.
+type stagedTxnRows struct {
+	stager      *txnStager
+	span        tablepb.Span
+	pos         engine.Position
+	rowCount    int
+	replicateTs model.Ts
+}
+
+// Len implements model.StagedRows.
+func (r *stagedTxnRows) Len() int {
+	return r.rowCount
+}
+
+// Read implements model.StagedRows.
+func (r *stagedTxnRows) Read(
+	ctx context.Context, batchSize int, fn func(rows []*model.RowChangedEvent) error,
+) (err error) {
+	// All events of the transaction are at the same position.
+	iter := r.stager.sourceManager.FetchByTable(r.span, r.pos, r.pos, r.stager.sinkMemQuota)
+	defer func() {
+		if closeErr := iter.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+	}()
+
+	rows := make([]*model.RowChangedEvent, 0, batchSize)
+	for {
+		e, _, err := iter.Next(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if e == nil {
+			break
+		}
+		// NOTICE: The event can be filtered by the event filter.
+		if e.Row == nil {
+			continue
+		}
+		// Convert the rows in the same way as the table sink worker.
+		e.Row.ReplicatingTs = r.replicateTs
+		x, _, err := convertRowChangedEvents(r.stager.changefeedID, r.span, r.stager.enableOldValue, e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rows = append(rows, x...)
+		if len(rows) >= batchSize {
+			if err := fn(rows); err != nil {
+				return err
+			}
+			rows = make([]*model.RowChangedEvent, 0, batchSize)
+		}
+	}
+	if len(rows) > 0 {
+		return fn(rows)
+	}
+	return nil
+}
@@ -27,6 +27,9 @@ type tableSinkAdvancer struct {
 	task *sinkTask
 	// splitTxn indicates whether to split the transaction into multiple batches.
 	splitTxn bool
+	// stager is used to stage the transactions exceeding the memory quota.
+	// It is nil if the large transaction spill is disabled.
+	stager *txnStager
 	// sinkMemQuota is used to acquire memory quota for the table sink.
 	sinkMemQuota *memquota.MemQuota
 	// NOTICE: First time to run the task, we have initialized memory quota for the table.
@@ -51,17 +54,26 @@ type tableSinkAdvancer struct {
 	pendingTxnSize uint64
 	// Used to record the current transaction commit ts.
 	currTxnCommitTs uint64
+
+	// Used to record the buffered events and their size of the unfinished
+	// upstream transaction. Only used when stager is not nil.
+	txnEvents int
+	txnSize   uint64
+	// The upstream transaction being staged, its events are not buffered.
+	stagingTxn *stagingTxn
 }
 
 func newTableSinkAdvancer(
 	task *sinkTask,
 	splitTxn bool,
+	stager *txnStager,
 	sinkMemQuota *memquota.MemQuota,
 	availableMem uint64,
 ) *tableSinkAdvancer {
 	return &tableSinkAdvancer{
 		task:         task,
 		splitTxn:     splitTxn,
+		stager:       stager,
 		sinkMemQuota: sinkMemQuota,
 		availableMem: availableMem,
 		events:       make([]*model.RowChangedEvent, 0, bufferSize),
@@ -74,14 +86,20 @@ func newTableSinkAdvancer(
 // If it is the last time, and we still have some events in the buffer,
 // we need to record the memory usage and append the events to the table sink.
 func (a *tableSinkAdvancer) advance(isLastTime bool) (err error) {
-	// Append the events to the table sink first.
-	if len(a.events) > 0 {
-		if err = a.task.tableSink.appendRowChangedEvents(a.events...); err != nil {
+	// The unfinished transaction will be fetched again by the next task,
+	// so drop it if it is the last time.
+	if isLastTime && a.stager != nil {
+		a.dropUnfinishedTxn()
+	}
+	// Append the events to the table sink first. The events of the unfinished
+	// transaction are kept, because it may be staged later.
+	if n := len(a.events) - a.txnEvents; n > 0 {
+		if err = a.task.tableSink.appendRowChangedEvents(a.events[:n]...); err != nil {
 			return
 		}
-		a.events = a.events[:0]
+		a.events = append(a.events[:0], a.events[n:]...)
 		if cap(a.events) > bufferSize {
-			a.events = make([]*model.RowChangedEvent, 0, bufferSize)
+			a.events = append(make([]*model.RowChangedEvent, 0, bufferSize), a.events...)
 		}
 	}
 	log.Debug("check should advance or not",
@@ -98,19 +116,20 @@ func (a *tableSinkAdvancer) advance(isLastTime bool) (err error) {
 		// All transactions before currTxnCommitTs are resolved.
 		if a.lastPos.IsCommitFence() {
 			err = advanceTableSink(a.task, a.currTxnCommitTs,
-				a.committedTxnSize+a.pendingTxnSize, a.sinkMemQuota)
+				a.committedTxnSize+a.pendingTxnSize-a.txnSize, a.sinkMemQuota)
 		} else {
 			// This means all events of the current transaction have been fetched, but we can't
 			// ensure whether there are more transaction with the same CommitTs or not.
 			// So we need to advance the table sink with a batchID. It will make sure that
 			// we do not cross the CommitTs boundary.
 			err = advanceTableSinkWithBatchID(a.task, a.currTxnCommitTs,
-				a.committedTxnSize+a.pendingTxnSize, batchID.Load(), a.sinkMemQuota)
+				a.committedTxnSize+a.pendingTxnSize-a.txnSize, batchID.Load(), a.sinkMemQuota)
 			batchID.Add(1)
 		}
 
 		a.committedTxnSize = 0
-		a.pendingTxnSize = 0
+		// The unfinished transaction is not appended, so its size is kept.
+		a.pendingTxnSize = a.txnSize
 	} else if a.splitTxn && a.currTxnCommitTs > 0 {
 		// We just got a new commit ts. Because we split the transaction,
 		// we can advance the table sink with the current commit ts.
@@ -156,6 +175,15 @@ func (a *tableSinkAdvancer) tryAdvanceAndAcquireMem(
 	allFetched bool,
 	txnFinished bool,
 ) error {
+	// The staged transaction is finished, append it to the table sink.
+	if txnFinished {
+		if err := a.finishStagedTxn(); err != nil {
+			return errors.Trace(err)
+		}
+		a.txnEvents = 0
+		a.txnSize = 0
+	}
+
 	// If used memory size exceeds the required limit, do a force acquire to
 	// make sure the memory quota is not exceeded or leak.
 	// For example, if the memory quota is 100MB, and current usedMem is 90MB,
@@ -190,6 +218,23 @@ func (a *tableSinkAdvancer) tryAdvanceAndAcquireMem(
 	}
 
 	if a.usedMem >= a.availableMem {
+		// The transaction is not finished and the memory quota is exhausted,
+		// stage it instead of force acquiring memory if possible.
+		if !txnFinished && !a.splitTxn && a.stager != nil {
+			if a.sinkMemQuota.TryAcquire(requestMemSize) {
+				a.availableMem += requestMemSize
+				log.Debug("MemoryQuotaTracing: try acquire memory for table sink task",
+					zap.String("namespace", a.task.tableSink.changefeed.Namespace),
+					zap.String("changefeed", a.task.tableSink.changefeed.ID),
+					zap.Stringer("span", &a.task.span),
+					zap.Uint64("memory", requestMemSize))
+				return nil
+			}
+			if a.stageTxn() {
+				return nil
+			}
+		}
+
 		// We just finished a transaction, and the memory usage is still high.
 		// We need try to acquire memory for the next transaction. It is possible
 		// we can't acquire memory, but we finish the current transaction. So
@@ -262,12 +307,79 @@ func (a *tableSinkAdvancer) finish(upperBound engine.Position) error {
 
 // appendEvents appends events to the buffer and record the memory usage.
 func (a *tableSinkAdvancer) appendEvents(events []*model.RowChangedEvent, size uint64) {
+	// The events of a staging transaction are read from the sort engine
+	// again when the transaction is written, so only count them.
+	if a.stagingTxn != nil {
+		a.stagingTxn.rowCount += len(events)
+		return
+	}
 	a.events = append(a.events, events...)
 	// Record the memory usage.
 	a.usedMem += size
 	// Record the pending transaction size. It means how many events we do
 	// not flush to the table sink.
 	a.pendingTxnSize += size
+	if a.stager != nil {
+		a.txnEvents += len(events)
+		a.txnSize += size
+	}
+}
+
+// stageTxn stages the unfinished transaction and releases the memory of
+// its buffered events. It returns false if there is nothing to stage.
+func (a *tableSinkAdvancer) stageTxn() bool {
+	if a.stagingTxn != nil || a.txnEvents == 0 {
+		return false
+	}
+	firstRow := a.events[len(a.events)-a.txnEvents]
+	a.stagingTxn = &stagingTxn{
+		firstRow: firstRow,
+		pos:      engine.Position{StartTs: firstRow.StartTs, CommitTs: firstRow.CommitTs},
+		rowCount: a.txnEvents,
+	}
+	log.Info("Stage the transaction exceeding the memory quota",
+		zap.String("namespace", a.task.tableSink.changefeed.Namespace),
+		zap.String("changefeed", a.task.tableSink.changefeed.ID),
+		zap.Stringer("span", &a.task.span),
+		zap.Any("pos", a.stagingTxn.pos),
+		zap.Int("bufferedRows", a.txnEvents),
+		zap.Uint64("bufferedSize", a.txnSize))
+	a.stager.metricStagedTxnCount.Inc()
+	a.dropBufferedTxn()
+	return true
+}
+
+// finishStagedTxn appends the staged transaction to the table sink after
+// all the buffered events.
+func (a *tableSinkAdvancer) finishStagedTxn() error {
+	if a.stagingTxn == nil {
+		return nil
+	}
+	if len(a.events) > 0 {
+		if err := a.task.tableSink.appendRowChangedEvents(a.events...); err != nil {
+			return err
+		}
+		a.events = a.events[:0]
+	}
+	txn := a.stagingTxn
+	a.stagingTxn = nil
+	return a.task.tableSink.appendStagedTxn(txn.firstRow, a.stager.stagedRows(a.task, txn))
+}
+
+// dropUnfinishedTxn drops the buffered or staging unfinished transaction.
+func (a *tableSinkAdvancer) dropUnfinishedTxn() {
+	a.stagingTxn = nil
+	a.dropBufferedTxn()
+}
+
+// dropBufferedTxn drops the buffered events of the unfinished transaction
+// and releases their memory.
+func (a *tableSinkAdvancer) dropBufferedTxn() {
+	a.events = a.events[:len(a.events)-a.txnEvents]
+	a.usedMem -= a.txnSize
+	a.pendingTxnSize -= a.txnSize
+	a.txnEvents = 0
+	a.txnSize = 0
 }
 
 // hasEnoughMem returns whether the table sink task has enough memory to continue.
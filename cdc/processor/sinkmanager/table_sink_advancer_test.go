@@ -127,7 +127,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceTableSinkWithBatchID() {
 	task, _ := suite.genSinkTask()
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 
 	err := advanceTableSinkWithBatchID(task, 2, 256, 1, memoryQuota)
@@ -143,7 +143,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceTableSink() {
 	task, _ := suite.genSinkTask()
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 
 	err := advanceTableSink(task, 2, 256, memoryQuota)
@@ -157,7 +157,7 @@ func (suite *tableSinkAdvancerSuite) TestNewTableSinkAdvancer() {
 	task, _ := suite.genSinkTask()
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 	require.Equal(suite.T(), uint64(512), advancer.availableMem)
 }
@@ -166,7 +166,7 @@ func (suite *tableSinkAdvancerSuite) TestHasEnoughMem() {
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
 	task, _ := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 	require.True(suite.T(), advancer.hasEnoughMem())
 	for i := 0; i < 6; i++ {
@@ -181,7 +181,7 @@ func (suite *tableSinkAdvancerSuite) TestCleanup() {
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
 	task, _ := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 	require.Equal(suite.T(), uint64(512), advancer.availableMem)
 	require.Equal(suite.T(), uint64(0), advancer.usedMem)
@@ -195,7 +195,7 @@ func (suite *tableSinkAdvancerSuite) TestAppendEvents() {
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
 	task, _ := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 	require.True(suite.T(), advancer.hasEnoughMem())
 	for i := 0; i < 2; i++ {
@@ -211,7 +211,7 @@ func (suite *tableSinkAdvancerSuite) TestTryMoveMoveToNextTxn() {
 	memoryQuota := suite.genMemQuota(512)
 	defer memoryQuota.Close()
 	task, _ := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 512)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 512)
 	require.NotNil(suite.T(), advancer)
 
 	// Initial state.
@@ -258,7 +258,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceTheSameCommitTsEventsWithCommitF
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 1
@@ -301,7 +301,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceTheSameCommitTsEventsWithoutComm
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 1
@@ -349,7 +349,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceDifferentCommitTsEventsWithSplit
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -400,7 +400,7 @@ func (suite *tableSinkAdvancerSuite) TestAdvanceDifferentCommitTsEventsWithoutSp
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
 	// Do not split txn.
-	advancer := newTableSinkAdvancer(task, false, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, false, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -455,7 +455,7 @@ func (suite *tableSinkAdvancerSuite) TestLastTimeAdvanceDifferentCommitTsEventsW
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
 	// Do not split txn.
-	advancer := newTableSinkAdvancer(task, false, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, false, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -510,7 +510,7 @@ func (suite *tableSinkAdvancerSuite) TestTryAdvanceWhenExceedAvailableMem() {
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -564,7 +564,7 @@ func (suite *tableSinkAdvancerSuite) TestTryAdvanceWhenReachTheMaxUpdateIntSizeA
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -613,7 +613,7 @@ func (suite *tableSinkAdvancerSuite) TestFinish() {
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -665,7 +665,7 @@ func (suite *tableSinkAdvancerSuite) TestTryAdvanceAndForceAcquireWithoutSplitTx
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, false, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, false, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -714,7 +714,7 @@ func (suite *tableSinkAdvancerSuite) TestTryAdvanceAndBlockAcquireWithSplitTxn()
 	memoryQuota := suite.genMemQuota(768)
 	defer memoryQuota.Close()
 	task, sink := suite.genSinkTask()
-	advancer := newTableSinkAdvancer(task, true, memoryQuota, 768)
+	advancer := newTableSinkAdvancer(task, true, nil, memoryQuota, 768)
 	require.NotNil(suite.T(), advancer)
 
 	// 1. append 1 event with commit ts 2
@@ -63,8 +63,12 @@ type sinkWorker struct {
 	sinkMemQuota  *memquota.MemQuota
 	redoMemQuota  *memquota.MemQuota
 	eventCache    *redoEventCache
+	limiter       *throughputLimiter
 	// splitTxn indicates whether to split the transaction into multiple batches.
 	splitTxn bool
+	// stager stages the transactions exceeding the memory quota if splitTxn
+	// is false. It is nil if the large transaction spill is disabled.
+	stager *txnStager
 	// enableOldValue indicates whether to enable the old value feature.
 	// If it is enabled, we need to deal with the compatibility of the data format.
 	enableOldValue bool
@@ -82,16 +86,24 @@ func newSinkWorker(
 	sinkQuota *memquota.MemQuota,
 	redoQuota *memquota.MemQuota,
 	eventCache *redoEventCache,
+	limiter *throughputLimiter,
 	splitTxn bool,
+	spillLargeTxn bool,
 	enableOldValue bool,
 ) *sinkWorker {
+	var stager *txnStager
+	if spillLargeTxn && !splitTxn {
+		stager = newTxnStager(changefeedID, sourceManager, sinkQuota, enableOldValue)
+	}
 	return &sinkWorker{
 		changefeedID:   changefeedID,
 		sourceManager:  sourceManager,
 		sinkMemQuota:   sinkQuota,
 		redoMemQuota:   redoQuota,
 		eventCache:     eventCache,
+		limiter:        limiter,
 		splitTxn:       splitTxn,
+		stager:         stager,
 		enableOldValue: enableOldValue,
 
 		metricRedoEventCacheHit:  RedoEventCacheAccess.WithLabelValues(changefeedID.Namespace, changefeedID.ID, "hit"),
@@ -120,7 +132,7 @@ func (w *sinkWorker) handleTasks(ctx context.Context, taskChan <-chan *sinkTask)
 func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr error) {
 	// We need to use a new batch ID for each task.
 	batchID.Add(1)
-	advancer := newTableSinkAdvancer(task, w.splitTxn, w.sinkMemQuota, requestMemSize)
+	advancer := newTableSinkAdvancer(task, w.splitTxn, w.stager, w.sinkMemQuota, requestMemSize)
 	// The task is finished and some required memory isn't used.
 	defer advancer.cleanup()
 
@@ -130,7 +142,7 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 		task.lowerBound,
 		task.getUpperBound(task.tableSink.getUpperBoundTs()))
 	if w.eventCache != nil {
-		drained, err := w.fetchFromCache(task, &lowerBound, &upperBound)
+		drained, err := w.fetchFromCache(ctx, task, &lowerBound, &upperBound)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -239,6 +251,9 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 			if err != nil {
 				return err
 			}
+			if err := w.limiter.wait(ctx, len(x), size); err != nil {
+				return errors.Trace(err)
+			}
 
 			advancer.appendEvents(x, size)
 			allEventSize += size
@@ -256,6 +271,7 @@ func (w *sinkWorker) handleTask(ctx context.Context, task *sinkTask) (finalErr e
 }
 
 func (w *sinkWorker) fetchFromCache(
+	ctx context.Context,
 	task *sinkTask, // task is read-only here.
 	lowerBound *engine.Position,
 	upperBound *engine.Position,
@@ -273,6 +289,9 @@ func (w *sinkWorker) fetchFromCache(
 		if len(popRes.events) > 0 {
 			w.metricOutputEventCountKV.Add(float64(popRes.pushCount))
 			w.metricRedoEventCacheHit.Add(float64(popRes.size))
+			if err = w.limiter.wait(ctx, len(popRes.events), popRes.size); err != nil {
+				return
+			}
 			if err = task.tableSink.appendRowChangedEvents(popRes.events...); err != nil {
 				return
 			}
@@ -137,7 +137,8 @@ func (suite *tableSinkWorkerSuite) createWorker(
 	quota.ForceAcquire(testEventSize)
 	quota.AddTable(suite.testSpan)
 
-	return newSinkWorker(suite.testChangefeedID, sm, quota, nil, nil, splitTxn, false), sortEngine
+	return newSinkWorker(suite.testChangefeedID, sm, quota, nil, nil,
+		newThroughputLimiter(suite.testChangefeedID, "sink", nil), splitTxn, false, false), sortEngine
 }
 
 func (suite *tableSinkWorkerSuite) addEventsToSortEngine(
@@ -429,6 +430,80 @@ func (suite *tableSinkWorkerSuite) TestHandleTaskWithoutSplitTxnAndAbortWhenNoMe
 		"All events from the first txn should be sent to sink")
 }
 
+// Test Scenario:
+// worker will stage the txn exceeding the memory quota instead of force consuming it.
+func (suite *tableSinkWorkerSuite) TestHandleTaskWithoutSplitTxnAndStageWhenNoMem() {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := []*model.PolymorphicEvent{
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(1, 2, suite.testSpan),
+		genPolymorphicEvent(3, 4, suite.testSpan),
+		genPolymorphicResolvedEvent(5),
+	}
+
+	// Only for three events.
+	eventSize := uint64(testEventSize * 3)
+	// Disable split txn and enable large txn spill.
+	w, e := suite.createWorker(ctx, eventSize, false)
+	defer w.sinkMemQuota.Close()
+	w.stager = newTxnStager(suite.testChangefeedID, w.sourceManager, w.sinkMemQuota, false)
+	suite.addEventsToSortEngine(events, e)
+
+	taskChan := make(chan *sinkTask)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := w.handleTasks(ctx, taskChan)
+		require.Equal(suite.T(), context.Canceled, err)
+	}()
+
+	wrapper, sink := createTxnTableSinkWrapper(suite.testChangefeedID, suite.testSpan)
+	callback := func(lastWritePos engine.Position) {
+		require.Equal(suite.T(), engine.Position{
+			StartTs:  3,
+			CommitTs: 4,
+		}, lastWritePos)
+		cancel()
+	}
+	taskChan <- &sinkTask{
+		span:          suite.testSpan,
+		lowerBound:    genLowerBound(),
+		getUpperBound: genUpperBoundGetter(4),
+		tableSink:     wrapper,
+		callback:      callback,
+		isCanceled:    func() bool { return false },
+	}
+	wg.Wait()
+	txns := sink.GetEvents()
+	require.Len(suite.T(), txns, 2)
+	require.Nil(suite.T(), txns[1].Event.StagedRows)
+	require.Len(suite.T(), txns[1].Event.Rows, 1)
+
+	// The first txn is staged and its rows are read from the sort engine.
+	staged := txns[0].Event
+	require.Empty(suite.T(), staged.Rows)
+	require.Equal(suite.T(), uint64(1), staged.StartTs)
+	require.Equal(suite.T(), uint64(2), staged.CommitTs)
+	require.Equal(suite.T(), 5, staged.StagedRows.Len())
+	var batches [][]*model.RowChangedEvent
+	err := staged.StagedRows.Read(context.Background(), 2, func(rows []*model.RowChangedEvent) error {
+		batches = append(batches, rows)
+		return nil
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), batches, 3)
+	require.Len(suite.T(), batches[2], 1)
+	for _, rows := range batches {
+		for _, row := range rows {
+			require.Equal(suite.T(), uint64(2), row.CommitTs)
+		}
+	}
+}
+
 // Test Scenario:
 // worker will advance the table sink only when it reaches the max update interval size.
 func (suite *tableSinkWorkerSuite) TestTaskWithoutSplitTxnOnlyAdvanceWhenReachMaxUpdateIntSize() {
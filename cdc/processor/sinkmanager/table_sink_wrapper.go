@@ -64,6 +64,8 @@ type tableSinkWrapper struct {
 	// receivedSorterResolvedTs is the resolved ts received from the sorter.
 	// We use this to advance the redo log.
 	receivedSorterResolvedTs atomic.Uint64
+	// sinkRows is the number of rows appended to the table sink.
+	sinkRows atomic.Uint64
 
 	// replicateTs is the ts that the table sink has started to replicate.
 	replicateTs    model.Ts
@@ -162,6 +164,7 @@ func (t *tableSinkWrapper) appendRowChangedEvents(events ...*model.RowChangedEve
 	// If it's nil it means it's closed.
 	if t.tableSink != nil {
 		t.tableSink.AppendRowChangedEvents(events...)
+		t.sinkRows.Add(uint64(len(events)))
 	} else {
 		// If it's nil it means it's closed.
 		return tablesink.NewSinkInternalError(errors.New("table sink cleared"))
@@ -169,6 +172,20 @@ func (t *tableSinkWrapper) appendRowChangedEvents(events ...*model.RowChangedEve
 	return nil
 }
 
+func (t *tableSinkWrapper) appendStagedTxn(row *model.RowChangedEvent, rows model.StagedRows) error {
+	t.tableSinkMu.RLock()
+	defer t.tableSinkMu.RUnlock()
+	// If it's nil it means it's closed.
+	if t.tableSink == nil {
+		return tablesink.NewSinkInternalError(errors.New("table sink cleared"))
+	}
+	if err := t.tableSink.AppendStagedTxn(row, rows); err != nil {
+		return errors.Trace(err)
+	}
+	t.sinkRows.Add(uint64(rows.Len()))
+	return nil
+}
+
 func (t *tableSinkWrapper) updateReceivedSorterResolvedTs(ts model.Ts) {
 	for {
 		old := t.receivedSorterResolvedTs.Load()
@@ -96,6 +96,50 @@ func createTableSinkWrapper(
 	return wrapper, sink
 }
 
+type mockTxnSink struct {
+	mu     sync.Mutex
+	events []*dmlsink.CallbackableEvent[*model.SingleTableTxn]
+}
+
+func (m *mockTxnSink) WriteEvents(events ...*dmlsink.CallbackableEvent[*model.SingleTableTxn]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *mockTxnSink) GetEvents() []*dmlsink.CallbackableEvent[*model.SingleTableTxn] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.events
+}
+
+func (m *mockTxnSink) Close() {}
+
+func (m *mockTxnSink) Dead() <-chan struct{} {
+	return make(chan struct{})
+}
+
+func createTxnTableSinkWrapper(
+	changefeedID model.ChangeFeedID, span tablepb.Span,
+) (*tableSinkWrapper, *mockTxnSink) {
+	sink := &mockTxnSink{}
+	innerTableSink := tablesink.New[*model.SingleTableTxn](
+		changefeedID, span, model.Ts(0),
+		sink, &dmlsink.TxnEventAppender{}, prometheus.NewCounter(prometheus.CounterOpts{}))
+	wrapper := newTableSinkWrapper(
+		changefeedID,
+		span,
+		func() tablesink.TableSink { return innerTableSink },
+		tablepb.TableStatePreparing,
+		0,
+		100,
+		func(_ context.Context) (model.Ts, error) { return math.MaxUint64, nil },
+	)
+	wrapper.tableSink = wrapper.tableSinkCreater()
+	return wrapper, sink
+}
+
 func TestTableSinkWrapperClose(t *testing.T) {
 	t.Parallel()
 
@@ -116,6 +160,17 @@ func TestUpdateReceivedSorterResolvedTs(t *testing.T) {
 	require.Equal(t, tablepb.TableStatePrepared, wrapper.getState())
 }
 
+func TestTableSinkWrapperSinkRows(t *testing.T) {
+	t.Parallel()
+
+	wrapper, _ := createTableSinkWrapper(
+		model.DefaultChangeFeedID("1"), spanz.TableIDToComparableSpan(1))
+	require.NoError(t, wrapper.appendRowChangedEvents(
+		&model.RowChangedEvent{CommitTs: 1}, &model.RowChangedEvent{CommitTs: 2}))
+	require.NoError(t, wrapper.appendRowChangedEvents(&model.RowChangedEvent{CommitTs: 3}))
+	require.Equal(t, uint64(3), wrapper.sinkRows.Load())
+}
+
 func TestConvertNilRowChangedEvents(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,167 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// throughputLimiter limits the rows and bytes written by the workers of a
+// changefeed per second. It is shared by all workers of a component, and its
+// limits can be updated when the workers are running.
+type throughputLimiter struct {
+	changefeedID model.ChangeFeedID
+	// component is sink or redo.
+	component string
+
+	mu          sync.Mutex
+	cfg         config.ThroughputLimitConfig
+	rowLimiter  *rate.Limiter
+	byteLimiter *rate.Limiter
+
+	metricThrottledDuration prometheus.Counter
+	metricRowsLimit         prometheus.Gauge
+	metricBytesLimit        prometheus.Gauge
+}
+
+func newThroughputLimiter(
+	changefeedID model.ChangeFeedID,
+	component string,
+	cfg *config.ThroughputLimitConfig,
+) *throughputLimiter {
+	l := &throughputLimiter{
+		changefeedID: changefeedID,
+		component:    component,
+		rowLimiter:   rate.NewLimiter(rate.Inf, 0),
+		byteLimiter:  rate.NewLimiter(rate.Inf, 0),
+
+		metricThrottledDuration: ThrottledDuration.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, component),
+		metricRowsLimit: ThroughputLimit.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, component, "rows"),
+		metricBytesLimit: ThroughputLimit.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, component, "bytes"),
+	}
+	l.setLimitsLocked(limitsOf(cfg))
+	return l
+}
+
+func limitsOf(cfg *config.ThroughputLimitConfig) config.ThroughputLimitConfig {
+	if cfg == nil {
+		return config.ThroughputLimitConfig{}
+	}
+	return *cfg
+}
+
+// update updates the limits if they are changed.
+func (l *throughputLimiter) update(cfg *config.ThroughputLimitConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	newCfg := limitsOf(cfg)
+	if newCfg == l.cfg {
+		return
+	}
+	log.Info("throughput limit is updated",
+		zap.String("namespace", l.changefeedID.Namespace),
+		zap.String("changefeed", l.changefeedID.ID),
+		zap.String("component", l.component),
+		zap.Any("oldLimit", l.cfg),
+		zap.Any("newLimit", newCfg))
+	l.setLimitsLocked(newCfg)
+}
+
+func (l *throughputLimiter) setLimitsLocked(cfg config.ThroughputLimitConfig) {
+	l.cfg = cfg
+	setLimit(l.rowLimiter, cfg.RowsPerSecond)
+	setLimit(l.byteLimiter, cfg.BytesPerSecond)
+	l.metricRowsLimit.Set(float64(cfg.RowsPerSecond))
+	l.metricBytesLimit.Set(float64(cfg.BytesPerSecond))
+}
+
+// setLimit sets the limit per second, 0 means no limit. The burst is the
+// limit of one second, larger batches are waited for in chunks of the burst.
+func setLimit(limiter *rate.Limiter, perSecond uint64) {
+	if perSecond == 0 {
+		limiter.SetLimit(rate.Inf)
+		return
+	}
+	if perSecond > math.MaxInt32 {
+		perSecond = math.MaxInt32
+	}
+	limiter.SetBurst(int(perSecond))
+	limiter.SetLimit(rate.Limit(perSecond))
+}
+
+// wait blocks until the rows and bytes are allowed to be written.
+func (l *throughputLimiter) wait(ctx context.Context, rows int, bytes uint64) error {
+	start := time.Now()
+	if err := waitN(ctx, l.rowLimiter, uint64(rows)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := waitN(ctx, l.byteLimiter, bytes); err != nil {
+		return errors.Trace(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Millisecond {
+		l.metricThrottledDuration.Add(elapsed.Seconds())
+	}
+	return nil
+}
+
+// waitN blocks until n tokens are consumed. WaitN fails if n exceeds the
+// burst, so a large batch consumes the tokens in chunks of the burst.
+func waitN(ctx context.Context, limiter *rate.Limiter, n uint64) error {
+	for n > 0 {
+		if limiter.Limit() == rate.Inf {
+			return nil
+		}
+		burst := uint64(limiter.Burst())
+		if burst == 0 {
+			// setLimit never sets a zero burst for a finite limit.
+			return nil
+		}
+		chunk := n
+		if chunk > burst {
+			chunk = burst
+		}
+		if err := limiter.WaitN(ctx, int(chunk)); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if chunk > uint64(limiter.Burst()) || limiter.Limit() == rate.Inf {
+				// The limit is updated concurrently, retry with the new limit.
+				continue
+			}
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+func (l *throughputLimiter) close() {
+	ThrottledDuration.DeleteLabelValues(l.changefeedID.Namespace, l.changefeedID.ID, l.component)
+	ThroughputLimit.DeleteLabelValues(l.changefeedID.Namespace, l.changefeedID.ID, l.component, "rows")
+	ThroughputLimit.DeleteLabelValues(l.changefeedID.Namespace, l.changefeedID.ID, l.component, "bytes")
+}
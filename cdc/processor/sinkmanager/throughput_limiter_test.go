@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestThroughputLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	changefeedID := model.DefaultChangeFeedID("throughput-limiter")
+	l := newThroughputLimiter(changefeedID, "sink", nil)
+	defer l.close()
+	require.Equal(t, rate.Inf, l.rowLimiter.Limit())
+	require.Equal(t, rate.Inf, l.byteLimiter.Limit())
+	require.NoError(t, l.wait(ctx, 1000, 1<<30))
+
+	l.update(&config.ThroughputLimitConfig{RowsPerSecond: 20, BytesPerSecond: 1024})
+	require.Equal(t, rate.Limit(20), l.rowLimiter.Limit())
+	require.Equal(t, 20, l.rowLimiter.Burst())
+	require.Equal(t, rate.Limit(1024), l.byteLimiter.Limit())
+
+	// The burst of one second is consumed, and the next 10 rows
+	// have to wait for about 500ms.
+	require.NoError(t, l.wait(ctx, 20, 0))
+	start := time.Now()
+	require.NoError(t, l.wait(ctx, 10, 0))
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// A batch larger than the burst waits for all of its tokens, at least
+	// one more second is needed after the burst is consumed.
+	start = time.Now()
+	require.NoError(t, l.wait(ctx, 0, 2048))
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	// The wait is interrupted by the context.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, l.wait(cancelCtx, 20, 0), context.Canceled)
+
+	// Remove the limits.
+	l.update(&config.ThroughputLimitConfig{})
+	start = time.Now()
+	require.NoError(t, l.wait(ctx, 1000, 1<<30))
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+}
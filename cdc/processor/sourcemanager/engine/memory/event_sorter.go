@@ -146,8 +146,17 @@ func (s *EventIter) Next() (event *model.PolymorphicEvent, txnFinished engine.Po
 	if len(s.resolved) == 0 {
 		return
 	}
-	event = s.resolved[s.position]
+	stored := s.resolved[s.position]
 	s.position += 1
+	// Return a new event like other engines, so that the event can be
+	// fetched and mounted again, e.g., by a staged transaction.
+	event = &model.PolymorphicEvent{
+		StartTs:  stored.StartTs,
+		CRTs:     stored.CRTs,
+		Resolved: stored.Resolved,
+		RawKV:    stored.RawKV,
+		Row:      stored.Row,
+	}
 
 	var next *model.PolymorphicEvent
 	if s.position < len(s.resolved) {
@@ -103,14 +103,16 @@ func (s *Span) String() string {
 }
 
 // Less compares two Spans, defines the order between spans.
+// Spans with the same start key are ordered by end key, so that a span and
+// the spans it is split into or merged from can be kept at the same time.
 func (s *Span) Less(b *Span) bool {
 	if s.TableID < b.TableID {
 		return true
 	}
-	if bytes.Compare(s.StartKey, b.StartKey) < 0 {
-		return true
+	if c := bytes.Compare(s.StartKey, b.StartKey); c != 0 {
+		return c < 0
 	}
-	return false
+	return bytes.Compare(s.EndKey, b.EndKey) < 0
 }
 
 // Eq compares two Spans, defines the equality between spans.
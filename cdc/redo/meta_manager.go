@@ -61,6 +61,8 @@ type metaManager struct {
 	extStorage    storage.ExternalStorage
 	uuidGenerator uuid.Generator
 	preMetaFile   string
+	// cipher is used to encrypt the meta files, it's nil if encryption is disabled.
+	cipher *redo.Cipher
 
 	lastFlushTime          time.Time
 	flushIntervalInMs      int64
@@ -132,6 +134,9 @@ func NewMetaManager(ctx context.Context, changefeedID model.ChangeFeedID,
 	if redo.IsBlackholeStorage(uri.Scheme) {
 		return m, nil
 	}
+	if m.cipher, err = redo.NewCipherFromFile(cfg.EncryptionKeyFile, cfg.AllowPlaintext); err != nil {
+		return nil, err
+	}
 
 	// "nfs" and "local" scheme are converted to "file" scheme
 	redo.FixLocalScheme(uri)
@@ -219,6 +224,9 @@ func (m *metaManager) initMeta(ctx context.Context, startTs model.Ts) error {
 			return err
 		}
 		if len(data) != 0 {
+			if data, err = m.cipher.DecryptFile(path, data); err != nil {
+				return err
+			}
 			var meta common.LogMeta
 			_, err = meta.UnmarshalMsg(data)
 			if err != nil {
@@ -381,6 +389,9 @@ func (m *metaManager) flush(ctx context.Context, meta common.LogMeta) error {
 		return errors.WrapError(errors.ErrMarshalFailed, err)
 	}
 	metaFile := getMetafileName(m.captureID, m.changeFeedID, m.uuidGenerator)
+	if data, err = m.cipher.EncryptFile(metaFile, data); err != nil {
+		return err
+	}
 	if err := m.extStorage.WriteFile(ctx, metaFile, data); err != nil {
 		return errors.WrapError(errors.ErrExternalStorageAPI, err)
 	}
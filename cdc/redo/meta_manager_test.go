@@ -16,6 +16,8 @@ package redo
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -94,6 +96,52 @@ func TestInitAndWriteMeta(t *testing.T) {
 	testWriteMeta(t, m)
 }
 
+func TestInitAndWriteEncryptedMeta(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	captureID := "test-capture"
+	nConfig := config.GetGlobalServerConfig().Clone()
+	config.StoreGlobalServerConfig(nConfig)
+	changefeedID := model.DefaultChangeFeedID("test-changefeed")
+
+	extStorage, uri, err := util.GetTestExtStorage(ctx, t.TempDir())
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "redo.key")
+	err = os.WriteFile(keyFile, []byte("00112233445566778899aabbccddeeff"), 0o600)
+	require.NoError(t, err)
+	cipher, err := redo.NewCipherFromFile(keyFile, false)
+	require.NoError(t, err)
+
+	meta := common.LogMeta{CheckpointTs: 9, ResolvedTs: 11}
+	data, err := meta.MarshalMsg(nil)
+	require.NoError(t, err)
+	metaName := getMetafileName(captureID, changefeedID, uuid.NewGenerator())
+	data, err = cipher.EncryptFile(metaName, data)
+	require.NoError(t, err)
+	require.NoError(t, extStorage.WriteFile(ctx, metaName, data))
+
+	startTs := uint64(10)
+	cfg := &config.ConsistentConfig{
+		Level:             string(redo.ConsistentLevelEventual),
+		MaxLogSize:        redo.DefaultMaxLogSize,
+		Storage:           uri.String(),
+		FlushIntervalInMs: redo.MinFlushIntervalInMs,
+	}
+	// the encrypted meta can't be read without the key.
+	_, err = NewMetaManagerWithInit(ctx, changefeedID, cfg, startTs)
+	require.ErrorContains(t, err, "no key is specified")
+
+	cfg.EncryptionKeyFile = keyFile
+	m, err := NewMetaManagerWithInit(ctx, changefeedID, cfg, startTs)
+	require.NoError(t, err)
+	require.Equal(t, startTs, m.metaCheckpointTs.getFlushed())
+	require.Equal(t, uint64(11), m.metaResolvedTs.getFlushed())
+
+	testWriteMeta(t, m)
+}
+
 func TestPreCleanupAndWriteMeta(t *testing.T) {
 	t.Parallel()
 
@@ -166,6 +214,9 @@ func testWriteMeta(t *testing.T, m *metaManager) {
 			cnt++
 			data, err := m.extStorage.ReadFile(ctx, path)
 			require.NoError(t, err)
+			require.Equal(t, m.cipher != nil, redo.IsEncrypted(data))
+			data, err = m.cipher.DecryptFile(path, data)
+			require.NoError(t, err)
 			meta := &common.LogMeta{}
 			_, err = meta.UnmarshalMsg(data)
 			require.NoError(t, err)
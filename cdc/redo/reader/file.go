@@ -67,16 +67,29 @@ type readerConfig struct {
 	uri                url.URL
 	useExternalStorage bool
 	workerNums         int
+	encryptionKeyFile  string
+	allowPlaintext     bool
 }
 
 type reader struct {
 	cfg      *readerConfig
 	mu       sync.Mutex
-	br       io.Reader
+	br       *bufio.Reader
 	fileName string
 	closer   io.Closer
 	// lastValidOff file offset following the last valid decoded record
 	lastValidOff int64
+
+	// cipher is used to decrypt the encrypted log files, it's nil if no
+	// encryption key is specified.
+	cipher *redo.Cipher
+	// headerRead indicates whether the header of encrypted files is checked.
+	headerRead bool
+	encrypted  bool
+	// header is the header of the encrypted file, and frameIndex is the
+	// index of the next frame in the file.
+	header     []byte
+	frameIndex uint64
 }
 
 func newReaders(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
@@ -91,7 +104,11 @@ func newReaders(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
 	}
 	start := time.Now()
 
-	sortedFiles, err := downLoadAndSortFiles(ctx, cfg)
+	cipher, err := redo.NewCipherFromFile(cfg.encryptionKeyFile, cfg.allowPlaintext)
+	if err != nil {
+		return nil, err
+	}
+	sortedFiles, err := downLoadAndSortFiles(ctx, cfg, cipher)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +121,7 @@ func newReaders(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
 				br:       bufio.NewReader(sortedFiles[i]),
 				fileName: sortedFiles[i].(*os.File).Name(),
 				closer:   sortedFiles[i],
+				cipher:   cipher,
 			})
 	}
 
@@ -113,7 +131,9 @@ func newReaders(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
 	return readers, nil
 }
 
-func downLoadAndSortFiles(ctx context.Context, cfg *readerConfig) ([]io.ReadCloser, error) {
+func downLoadAndSortFiles(
+	ctx context.Context, cfg *readerConfig, cipher *redo.Cipher,
+) ([]io.ReadCloser, error) {
 	dir := cfg.dir
 	// create temp dir in local storage
 	err := os.MkdirAll(dir, redo.DefaultDirMode)
@@ -148,7 +168,7 @@ func downLoadAndSortFiles(ctx context.Context, cfg *readerConfig) ([]io.ReadClos
 		sortedFileNames = append(sortedFileNames, getSortedFileName(fileName))
 		eg.Go(func() error {
 			defer func() { <-limit }()
-			return sortAndWriteFile(ctx, extStorage, fileName, cfg)
+			return sortAndWriteFile(ctx, extStorage, fileName, cfg, cipher)
 		})
 	}
 	if err := eg.Wait(); err != nil {
@@ -203,9 +223,10 @@ func selectDownLoadFile(
 	return files, nil
 }
 
-func readAllFromBuffer(buf []byte) (logHeap, error) {
+func readAllFromBuffer(buf []byte, cipher *redo.Cipher) (logHeap, error) {
 	r := &reader{
-		br: bytes.NewReader(buf),
+		br:     bufio.NewReader(bytes.NewReader(buf)),
+		cipher: cipher,
 	}
 	defer r.Close()
 
@@ -229,13 +250,15 @@ func readAllFromBuffer(buf []byte) (logHeap, error) {
 func sortAndWriteFile(
 	egCtx context.Context,
 	extStorage storage.ExternalStorage,
-	fileName string, cfg *readerConfig,
+	fileName string, cfg *readerConfig, cipher *redo.Cipher,
 ) error {
 	sortedName := getSortedFileName(fileName)
 	writerCfg := &writer.LogWriterConfig{
 		Dir:               cfg.dir,
 		MaxLogSizeInBytes: math.MaxInt32,
 	}
+	// the sorted files are encrypted with the same key as the log files.
+	writerCfg.EncryptionKeyFile = cfg.encryptionKeyFile
 	w, err := file.NewFileWriter(egCtx, writerCfg, writer.WithLogFileName(func() string {
 		return sortedName
 	}))
@@ -253,7 +276,7 @@ func sortAndWriteFile(
 	}
 
 	// sort data
-	h, err := readAllFromBuffer(fileContent)
+	h, err := readAllFromBuffer(fileContent, cipher)
 	if err != nil {
 		return err
 	}
@@ -309,6 +332,12 @@ func (r *reader) Read() (*model.RedoLog, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if !r.headerRead {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
 	lenField, err := readInt64(r.br)
 	if err != nil {
 		if err == io.EOF {
@@ -330,13 +359,13 @@ func (r *reader) Read() (*model.RedoLog, error) {
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp, err)
 	}
 
-	redoLog, _, err := codec.UnmarshalRedoLog(data[:recBytes])
+	redoLog, err := r.decode(data[:recBytes])
 	if err != nil {
 		if r.isTornEntry(data) {
 			// just return io.EOF, since if torn write it is the last redoLog entry
 			return nil, io.EOF
 		}
-		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+		return nil, err
 	}
 
 	// point last valid offset to the end of redoLog
@@ -344,6 +373,57 @@ func (r *reader) Read() (*model.RedoLog, error) {
 	return redoLog, nil
 }
 
+// readHeader checks whether the file is encrypted, and skips the header of
+// the encrypted file. Files without the header are read as plaintext if it
+// is allowed by the cipher.
+func (r *reader) readHeader() error {
+	r.headerRead = true
+	header, err := r.br.Peek(redo.EncryptionHeaderSize)
+	if err != nil && err != io.EOF {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	if len(header) == 0 {
+		// the file is empty.
+		return nil
+	}
+	if !redo.IsEncrypted(header) {
+		if err := r.cipher.CheckPlaintext(); err != nil {
+			log.Warn("read plaintext redo log failed",
+				zap.String("fileName", r.fileName), zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	if err := r.cipher.CheckHeader(header); err != nil {
+		log.Warn("check header of encrypted redo log failed",
+			zap.String("fileName", r.fileName), zap.Error(err))
+		return err
+	}
+	r.header = append([]byte(nil), header...)
+	if _, err := r.br.Discard(redo.EncryptionHeaderSize); err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	r.lastValidOff += int64(redo.EncryptionHeaderSize)
+	r.encrypted = true
+	return nil
+}
+
+// decode decrypts the record if the file is encrypted and unmarshals it.
+func (r *reader) decode(rec []byte) (*model.RedoLog, error) {
+	if r.encrypted {
+		var err error
+		if rec, err = r.cipher.OpenFrame(r.header, r.frameIndex, rec); err != nil {
+			return nil, err
+		}
+		r.frameIndex++
+	}
+	redoLog, _, err := codec.UnmarshalRedoLog(rec)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	return redoLog, nil
+}
+
 func readInt64(r io.Reader) (int64, error) {
 	var n int64
 	err := binary.Read(r, binary.LittleEndian, &n)
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pingcap/log"
@@ -77,3 +79,74 @@ func TestFileReaderRead(t *testing.T) {
 		require.NoError(t, r.Close())
 	}
 }
+
+func TestFileReaderReadEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keyFile := filepath.Join(t.TempDir(), "redo.key")
+	key := "00112233445566778899aabbccddeeff"
+	require.NoError(t, os.WriteFile(keyFile, []byte(key), 0o600))
+
+	uri, err := url.Parse(fmt.Sprintf("file://%s", dir))
+	require.NoError(t, err)
+	cfg := &readerConfig{
+		dir:                t.TempDir(),
+		startTs:            10,
+		endTs:              12,
+		fileType:           redo.RedoRowLogFileType,
+		uri:                *uri,
+		useExternalStorage: true,
+	}
+	genEncryptedLogFile(ctx, t, dir, redo.RedoRowLogFileType, cfg.startTs, cfg.endTs+2, keyFile)
+	// plaintext log files written before encryption is enabled.
+	genLogFile(ctx, t, dir, redo.RedoRowLogFileType, cfg.endTs-1, 20)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	encrypted := 0
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, err)
+		if redo.IsEncrypted(data) {
+			encrypted++
+		}
+	}
+	require.Equal(t, 1, encrypted)
+
+	// the encrypted log files can't be read without the key.
+	_, err = newReaders(ctx, cfg)
+	require.ErrorContains(t, err, "no key is specified")
+
+	// the plaintext log files are rejected unless they are allowed.
+	cfg.encryptionKeyFile = keyFile
+	_, err = newReaders(ctx, cfg)
+	require.ErrorContains(t, err, "the file is not encrypted")
+
+	cfg.allowPlaintext = true
+	readers, err := newReaders(ctx, cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(readers))
+	for _, r := range readers {
+		log, err := r.Read()
+		require.NoError(t, err)
+		require.EqualValues(t, 11, log.RedoRow.Row.CommitTs)
+		log, err = r.Read()
+		require.NoError(t, err)
+		require.EqualValues(t, 12, log.RedoRow.Row.CommitTs)
+		log, err = r.Read()
+		require.Nil(t, log)
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, r.Close())
+	}
+
+	// the sorted files are encrypted as well.
+	sortedFiles, err := os.ReadDir(cfg.dir)
+	require.NoError(t, err)
+	for _, f := range sortedFiles {
+		data, err := os.ReadFile(filepath.Join(cfg.dir, f.Name()))
+		require.NoError(t, err)
+		require.True(t, redo.IsEncrypted(data))
+	}
+}
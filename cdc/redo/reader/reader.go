@@ -81,14 +81,22 @@ type LogReaderConfig struct {
 	// will load the file to memory first then write the sorted file to disk
 	// the memory used is WorkerNums * defaultMaxLogSize (64 * megabyte) total
 	WorkerNums int
+
+	// EncryptionKeyFile is the file of the key used to decrypt the encrypted
+	// redo logs and meta, it's the same as the one of the changefeed.
+	EncryptionKeyFile string
+	// AllowPlaintext indicates whether the redo logs and meta that are not
+	// encrypted can be read when the EncryptionKeyFile is specified.
+	AllowPlaintext bool
 }
 
 // LogReader implement RedoLogReader interface
 type LogReader struct {
-	cfg   *LogReaderConfig
-	meta  *common.LogMeta
-	rowCh chan *model.RowChangedEvent
-	ddlCh chan *model.DDLEvent
+	cfg    *LogReaderConfig
+	cipher *redo.Cipher
+	meta   *common.LogMeta
+	rowCh  chan *model.RowChangedEvent
+	ddlCh  chan *model.DDLEvent
 }
 
 // newLogReader creates a LogReader instance.
@@ -104,10 +112,15 @@ func newLogReader(ctx context.Context, cfg *LogReaderConfig) (*LogReader, error)
 		cfg.WorkerNums = defaultWorkerNum
 	}
 
+	cipher, err := redo.NewCipherFromFile(cfg.EncryptionKeyFile, cfg.AllowPlaintext)
+	if err != nil {
+		return nil, err
+	}
 	logReader := &LogReader{
-		cfg:   cfg,
-		rowCh: make(chan *model.RowChangedEvent, defaultReaderChanSize),
-		ddlCh: make(chan *model.DDLEvent, defaultReaderChanSize),
+		cfg:    cfg,
+		cipher: cipher,
+		rowCh:  make(chan *model.RowChangedEvent, defaultReaderChanSize),
+		ddlCh:  make(chan *model.DDLEvent, defaultReaderChanSize),
 	}
 	// remove logs in local dir first, if have logs left belongs to previous changefeed with the same name may have error when apply logs
 	if err := os.RemoveAll(cfg.Dir); err != nil {
@@ -151,6 +164,8 @@ func (l *LogReader) runRowReader(egCtx context.Context) error {
 		uri:                l.cfg.URI,
 		useExternalStorage: l.cfg.UseExternalStorage,
 		workerNums:         l.cfg.WorkerNums,
+		encryptionKeyFile:  l.cfg.EncryptionKeyFile,
+		allowPlaintext:     l.cfg.AllowPlaintext,
 	}
 	return l.runReader(egCtx, rowCfg)
 }
@@ -165,6 +180,8 @@ func (l *LogReader) runDDLReader(egCtx context.Context) error {
 		uri:                l.cfg.URI,
 		useExternalStorage: l.cfg.UseExternalStorage,
 		workerNums:         l.cfg.WorkerNums,
+		encryptionKeyFile:  l.cfg.EncryptionKeyFile,
+		allowPlaintext:     l.cfg.AllowPlaintext,
 	}
 	return l.runReader(egCtx, ddlCfg)
 }
@@ -295,6 +312,9 @@ func (l *LogReader) initMeta(ctx context.Context) error {
 			return err
 		}
 		if len(data) != 0 {
+			if data, err = l.cipher.DecryptFile(path, data); err != nil {
+				return err
+			}
 			var meta common.LogMeta
 			_, err = meta.UnmarshalMsg(data)
 			if err != nil {
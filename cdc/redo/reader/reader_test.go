@@ -37,11 +37,21 @@ func genLogFile(
 	ctx context.Context, t *testing.T,
 	dir string, logType string,
 	minCommitTs, maxCommitTs uint64,
+) {
+	genEncryptedLogFile(ctx, t, dir, logType, minCommitTs, maxCommitTs, "")
+}
+
+func genEncryptedLogFile(
+	ctx context.Context, t *testing.T,
+	dir string, logType string,
+	minCommitTs, maxCommitTs uint64,
+	keyFile string,
 ) {
 	cfg := &writer.LogWriterConfig{
 		MaxLogSizeInBytes: 100000,
 		Dir:               dir,
 	}
+	cfg.EncryptionKeyFile = keyFile
 	fileName := fmt.Sprintf(redo.RedoLogFileFormatV2, "capture", "default",
 		"changefeed", logType, maxCommitTs, uuid.NewString(), redo.LogEXT)
 	w, err := file.NewFileWriter(ctx, cfg, writer.WithLogFileName(func() string {
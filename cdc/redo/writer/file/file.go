@@ -68,6 +68,12 @@ type Writer struct {
 	sync.RWMutex
 	uuidGenerator uuid.Generator
 	allocator     *fsutil.FileAllocator
+	// cipher is used to encrypt the redo logs, it's nil if encryption is disabled.
+	cipher *redo.Cipher
+	// header is the header of the current encrypted file, and frameIndex is
+	// the index of the next frame in the file.
+	header     []byte
+	frameIndex uint64
 
 	metricFsyncDuration    prometheus.Observer
 	metricFlushAllDuration prometheus.Observer
@@ -92,6 +98,11 @@ func NewFileWriter(
 		}
 	}
 
+	cipher, err := redo.NewCipherFromFile(cfg.EncryptionKeyFile, false)
+	if err != nil {
+		return nil, err
+	}
+
 	op := &writer.LogWriterOptions{}
 	for _, opt := range opts {
 		opt(op)
@@ -102,6 +113,7 @@ func NewFileWriter(
 		op:        op,
 		uint64buf: make([]byte, 8),
 		storage:   extStorage,
+		cipher:    cipher,
 
 		metricFsyncDuration: common.RedoFsyncDurationHistogram.
 			WithLabelValues(cfg.ChangeFeedID.Namespace, cfg.ChangeFeedID.ID),
@@ -120,7 +132,7 @@ func NewFileWriter(
 		return nil, errors.WrapError(errors.ErrRedoFileOp, errors.New("invalid redo dir path"))
 	}
 
-	err := os.MkdirAll(cfg.Dir, redo.DefaultDirMode)
+	err = os.MkdirAll(cfg.Dir, redo.DefaultDirMode)
 	if err != nil {
 		return nil, errors.WrapError(errors.ErrRedoFileOp,
 			errors.Annotatef(err, "can't make dir: %s for redo writing", cfg.Dir))
@@ -144,6 +156,9 @@ func (w *Writer) Write(rawData []byte) (int, error) {
 	defer w.Unlock()
 
 	writeLen := int64(len(rawData))
+	if w.cipher != nil {
+		writeLen = int64(w.cipher.SealedSize(len(rawData)))
+	}
 	if writeLen > w.cfg.MaxLogSizeInBytes {
 		return 0, errors.ErrFileSizeExceed.GenWithStackByArgs(writeLen, w.cfg.MaxLogSizeInBytes)
 	}
@@ -163,6 +178,28 @@ func (w *Writer) Write(rawData []byte) (int, error) {
 	if w.maxCommitTS.Load() < w.eventCommitTS.Load() {
 		w.maxCommitTS.Store(w.eventCommitTS.Load())
 	}
+	// the header is written before the first frame of each encrypted file.
+	if w.cipher != nil && w.size == 0 {
+		header, err := w.cipher.NewHeader()
+		if err != nil {
+			return 0, err
+		}
+		n, err := w.bw.Write(header)
+		w.metricWriteBytes.Add(float64(n))
+		if err != nil {
+			return 0, err
+		}
+		w.size += int64(n)
+		w.header = header
+		w.frameIndex = 0
+	}
+	if w.cipher != nil {
+		var err error
+		if rawData, err = w.cipher.SealFrame(w.header, w.frameIndex, rawData); err != nil {
+			return 0, err
+		}
+		w.frameIndex++
+	}
 	// ref: https://github.com/etcd-io/etcd/pull/5250
 	lenField, padBytes := writer.EncodeFrameSize(len(rawData))
 	if err := w.writeUint64(lenField, w.uint64buf); err != nil {
@@ -66,7 +66,10 @@ func (e *polymorphicRedoEvent) reset() {
 }
 
 // encoding format: lenField(8 bytes) + rawData + padding bytes(force 8 bytes alignment)
-func (e *polymorphicRedoEvent) encode() (err error) {
+// If encrypted is true, only the rawData is encoded, it is sealed and framed
+// by the file worker, since the sealed frame is bound to the file and its
+// index in the file.
+func (e *polymorphicRedoEvent) encode(encrypted bool) (err error) {
 	redoLog := e.event.ToRedoLog()
 	e.commitTs = redoLog.GetCommitTs()
 
@@ -74,6 +77,13 @@ func (e *polymorphicRedoEvent) encode() (err error) {
 	if err != nil {
 		return err
 	}
+	if encrypted {
+		e.data = dataPool.Get().(*bytes.Buffer)
+		e.data.Reset()
+		_, err = e.data.Write(rawData)
+		e.event = nil
+		return err
+	}
 	uint64buf := make([]byte, 8)
 	lenField, padBytes := writer.EncodeFrameSize(len(rawData))
 	binary.LittleEndian.PutUint64(uint64buf, lenField)
@@ -101,11 +111,12 @@ type encodingWorkerGroup struct {
 	inputChs   []chan *polymorphicRedoEvent
 	workerNum  int
 	nextWorker atomic.Uint64
+	encrypted  bool
 
 	closed chan struct{}
 }
 
-func newEncodingWorkerGroup(workerNum int) *encodingWorkerGroup {
+func newEncodingWorkerGroup(workerNum int, encrypted bool) *encodingWorkerGroup {
 	if workerNum <= 0 {
 		workerNum = defaultEncodingWorkerNum
 	}
@@ -117,6 +128,7 @@ func newEncodingWorkerGroup(workerNum int) *encodingWorkerGroup {
 		inputChs:  inputChs,
 		outputCh:  make(chan *polymorphicRedoEvent, defaultEncodingOutputChanSize),
 		workerNum: workerNum,
+		encrypted: encrypted,
 		closed:    make(chan struct{}),
 	}
 }
@@ -153,7 +165,7 @@ func (e *encodingWorkerGroup) runWorker(egCtx context.Context, idx int) error {
 			return errors.Trace(egCtx.Err())
 		case event := <-e.inputChs[idx]:
 			if event.event != nil {
-				if err := event.encode(); err != nil {
+				if err := event.encode(e.encrypted); err != nil {
 					return errors.Trace(err)
 				}
 				if err := e.output(egCtx, event); err != nil {
@@ -15,6 +15,7 @@ package memory
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"time"
@@ -41,16 +42,23 @@ type fileCache struct {
 
 	filename string
 	flushed  chan struct{}
+
+	// header is the header of the file if encryption is enabled, and
+	// frameIndex is the index of the next frame in the file.
+	header     []byte
+	frameIndex uint64
 }
 
-func newFileCache(event *polymorphicRedoEvent, buf []byte) *fileCache {
+func newFileCache(event *polymorphicRedoEvent, buf []byte, header []byte) *fileCache {
 	buf = buf[:0]
+	buf = append(buf, header...)
 	buf = append(buf, event.data.Bytes()...)
 	return &fileCache{
 		data:        buf,
 		maxCommitTs: event.commitTs,
 		minCommitTs: event.commitTs,
 		flushed:     make(chan struct{}),
+		header:      header,
 	}
 }
 
@@ -88,6 +96,8 @@ type fileWorkerGroup struct {
 
 	extStorage    storage.ExternalStorage
 	uuidGenerator uuid.Generator
+	// cipher is used to encrypt the redo logs, it's nil if encryption is disabled.
+	cipher *redo.Cipher
 
 	pool    sync.Pool
 	files   []*fileCache
@@ -99,7 +109,7 @@ type fileWorkerGroup struct {
 
 func newFileWorkerGroup(
 	cfg *writer.LogWriterConfig, workerNum int,
-	extStorage storage.ExternalStorage,
+	extStorage storage.ExternalStorage, cipher *redo.Cipher,
 	opts ...writer.Option,
 ) *fileWorkerGroup {
 	if workerNum <= 0 {
@@ -117,6 +127,7 @@ func newFileWorkerGroup(
 		workerNum:     workerNum,
 		extStorage:    extStorage,
 		uuidGenerator: uuid.NewGenerator(),
+		cipher:        cipher,
 		pool: sync.Pool{
 			New: func() interface{} {
 				// Use pointer here to prevent static checkers from reporting errors.
@@ -213,16 +224,62 @@ func (f *fileWorkerGroup) bgWriteLogs(
 }
 
 // newFileCache write event to a new file cache.
-func (f *fileWorkerGroup) newFileCache(event *polymorphicRedoEvent) {
+func (f *fileWorkerGroup) newFileCache(event *polymorphicRedoEvent) error {
+	var header []byte
+	if f.cipher != nil {
+		var err error
+		if header, err = f.cipher.NewHeader(); err != nil {
+			return err
+		}
+		if err = f.sealEvent(header, 0, event); err != nil {
+			return err
+		}
+	}
 	bufPtr := f.pool.Get().(*[]byte)
-	file := newFileCache(event, *bufPtr)
+	file := newFileCache(event, *bufPtr, header)
+	if f.cipher != nil {
+		file.frameIndex = 1
+	}
 	f.files = append(f.files, file)
+	return nil
+}
+
+// sealEvent encrypts the rawData of the event as the index-th frame of the
+// file with the given header, and replaces the data of the event with the
+// frame of the encrypted data.
+func (f *fileWorkerGroup) sealEvent(
+	header []byte, index uint64, event *polymorphicRedoEvent,
+) error {
+	sealed, err := f.cipher.SealFrame(header, index, event.data.Bytes())
+	if err != nil {
+		return err
+	}
+	lenField, padBytes := writer.EncodeFrameSize(len(sealed))
+	event.data.Reset()
+	var uint64buf [8]byte
+	binary.LittleEndian.PutUint64(uint64buf[:], lenField)
+	event.data.Write(uint64buf[:])
+	event.data.Write(sealed)
+	if padBytes != 0 {
+		event.data.Write(make([]byte, padBytes))
+	}
+	return nil
+}
+
+// frameSize returns the size of the frame of the event in the file.
+func (f *fileWorkerGroup) frameSize(event *polymorphicRedoEvent) int64 {
+	if f.cipher == nil {
+		return int64(event.data.Len())
+	}
+	sealedSize := f.cipher.SealedSize(event.data.Len())
+	_, padBytes := writer.EncodeFrameSize(sealedSize)
+	return int64(8 + sealedSize + padBytes)
 }
 
 func (f *fileWorkerGroup) writeToCache(
 	egCtx context.Context, event *polymorphicRedoEvent,
 ) error {
-	writeLen := int64(event.data.Len())
+	writeLen := f.frameSize(event)
 	if writeLen > f.cfg.MaxLogSizeInBytes {
 		// TODO: maybe we need to deal with the oversized event.
 		return errors.ErrFileSizeExceed.GenWithStackByArgs(writeLen, f.cfg.MaxLogSizeInBytes)
@@ -230,8 +287,7 @@ func (f *fileWorkerGroup) writeToCache(
 	defer f.metricWriteBytes.Add(float64(writeLen))
 
 	if len(f.files) == 0 {
-		f.newFileCache(event)
-		return nil
+		return f.newFileCache(event)
 	}
 
 	file := f.files[len(f.files)-1]
@@ -243,10 +299,15 @@ func (f *fileWorkerGroup) writeToCache(
 		case f.flushCh <- file:
 		}
 
-		f.newFileCache(event)
-		return nil
+		return f.newFileCache(event)
 	}
 
+	if f.cipher != nil {
+		if err := f.sealEvent(file.header, file.frameIndex, event); err != nil {
+			return err
+		}
+		file.frameIndex++
+	}
 	file.appendData(event)
 	return nil
 }
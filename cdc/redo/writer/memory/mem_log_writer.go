@@ -62,6 +62,10 @@ func NewLogWriter(
 	if err != nil {
 		return nil, err
 	}
+	cipher, err := redo.NewCipherFromFile(cfg.EncryptionKeyFile, false)
+	if err != nil {
+		return nil, err
+	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 	lwCtx, lwCancel := context.WithCancel(ctx)
@@ -71,11 +75,11 @@ func NewLogWriter(
 		cancel: lwCancel,
 	}
 
-	lw.encodeWorkers = newEncodingWorkerGroup(defaultEncodingWorkerNum)
+	lw.encodeWorkers = newEncodingWorkerGroup(defaultEncodingWorkerNum, cipher != nil)
 	eg.Go(func() error {
 		return lw.encodeWorkers.Run(lwCtx)
 	})
-	lw.fileWorkers = newFileWorkerGroup(cfg, defaultFlushWorkerNum, extStorage, opts...)
+	lw.fileWorkers = newFileWorkerGroup(cfg, defaultFlushWorkerNum, extStorage, cipher, opts...)
 	eg.Go(func() error {
 		return lw.fileWorkers.Run(lwCtx, lw.encodeWorkers.outputCh)
 	})
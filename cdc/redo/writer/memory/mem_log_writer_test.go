@@ -15,12 +15,16 @@ package memory
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/model/codec"
 	"github.com/pingcap/tiflow/cdc/redo/writer"
 	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -96,3 +100,65 @@ func testWriteEvents(t *testing.T, events []writer.RedoEvent) {
 	err = lw.FlushLog(ctx)
 	require.ErrorContains(t, err, "redo log writer stopped")
 }
+
+func TestWriteEncryptedEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keyFile := filepath.Join(t.TempDir(), "redo.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("00112233445566778899aabbccddeeff"), 0o600))
+	cipher, err := redo.NewCipherFromFile(keyFile, false)
+	require.NoError(t, err)
+
+	extStorage, uri, err := util.GetTestExtStorage(ctx, t.TempDir())
+	require.NoError(t, err)
+	lwcfg := &writer.LogWriterConfig{
+		LogType:            redo.RedoDDLLogFileType,
+		CaptureID:          "test-capture",
+		ChangeFeedID:       model.DefaultChangeFeedID("test-changefeed"),
+		URI:                *uri,
+		UseExternalStorage: true,
+		MaxLogSizeInBytes:  10 * redo.Megabyte,
+	}
+	lwcfg.EncryptionKeyFile = keyFile
+	filename := t.Name()
+	lw, err := NewLogWriter(ctx, lwcfg, writer.WithLogFileName(func() string {
+		return filename
+	}))
+	require.NoError(t, err)
+
+	ddls := []writer.RedoEvent{
+		&model.DDLEvent{CommitTs: 1, Query: "create table t1(id int)"},
+		&model.DDLEvent{CommitTs: 10, Query: "create table t2(id int)"},
+	}
+	require.NoError(t, lw.WriteEvents(ctx, ddls...))
+	require.NoError(t, lw.FlushLog(ctx))
+
+	data, err := extStorage.ReadFile(ctx, filename)
+	require.NoError(t, err)
+	require.NoError(t, cipher.CheckHeader(data))
+	require.NotContains(t, string(data), "create table")
+
+	// each frame is encrypted separately and bound to its index in the file.
+	header := data[:redo.EncryptionHeaderSize]
+	data = data[redo.EncryptionHeaderSize:]
+	var commitTs []model.Ts
+	for index := uint64(0); len(data) > 0; index++ {
+		lenField := binary.LittleEndian.Uint64(data)
+		recBytes := int(lenField & ^(uint64(0xff) << 56))
+		padBytes := int((lenField >> 56) & 0x7)
+		_, err := cipher.OpenFrame(header, index+1, data[8:8+recBytes])
+		require.ErrorContains(t, err, "authentication failed")
+		rec, err := cipher.OpenFrame(header, index, data[8:8+recBytes])
+		require.NoError(t, err)
+		redoLog, _, err := codec.UnmarshalRedoLog(rec)
+		require.NoError(t, err)
+		commitTs = append(commitTs, redoLog.GetCommitTs())
+		data = data[8+recBytes+padBytes:]
+	}
+	require.ElementsMatch(t, []model.Ts{1, 10}, commitTs)
+
+	require.ErrorIs(t, lw.Close(), context.Canceled)
+}
@@ -26,4 +26,8 @@ type InfoProvider interface {
 
 	// GetTaskStatuses returns the task statuses.
 	GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus, error)
+
+	// GetCaptureLoads returns the loads of the table spans replicated by
+	// each capture.
+	GetCaptureLoads() map[model.CaptureID]*model.CaptureLoad
 }
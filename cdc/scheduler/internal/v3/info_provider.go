@@ -15,7 +15,9 @@ package v3
 
 import (
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
 )
 
 var _ internal.InfoProvider = (*coordinator)(nil)
@@ -48,3 +50,28 @@ func (c *coordinator) GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus,
 	}
 	return tasks, nil
 }
+
+// GetCaptureLoads returns the loads of the table spans replicated by each
+// capture.
+func (c *coordinator) GetCaptureLoads() map[model.CaptureID]*model.CaptureLoad {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loads := make(map[model.CaptureID]*model.CaptureLoad, len(c.captureM.Captures))
+	for captureID := range c.captureM.Captures {
+		loads[captureID] = &model.CaptureLoad{}
+	}
+	c.replicationM.ReplicationSets().Ascend(
+		func(_ tablepb.Span, rep *replication.ReplicationSet) bool {
+			load, ok := loads[rep.Primary]
+			if !ok {
+				return true
+			}
+			load.TableCount++
+			load.RegionCount += rep.Stats.RegionCount
+			load.SinkRowsPerSecond += rep.SinkRowsRate()
+			load.Load += rep.Load()
+			return true
+		})
+	return loads
+}
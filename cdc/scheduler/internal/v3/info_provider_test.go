@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/internal"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/keyspan"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -90,3 +91,31 @@ func TestInfoProviderIsInitialized(t *testing.T) {
 	coord.captureM.SetInitializedForTests(true)
 	require.True(t, ip.IsInitialized())
 }
+
+func TestInfoProviderCaptureLoads(t *testing.T) {
+	t.Parallel()
+
+	coord := newCoordinatorForTest("a", model.ChangeFeedID{}, 1, &config.SchedulerConfig{
+		HeartbeatTick:      math.MaxInt,
+		MaxTaskConcurrency: 1,
+		ChangefeedSettings: config.GetDefaultReplicaConfig().Scheduler,
+	}, redo.NewDisabledMetaManager())
+	coord.captureM.Captures = map[model.CaptureID]*member.CaptureStatus{
+		"a": {}, "b": {},
+	}
+	for tableID, regionCount := range map[model.TableID]uint64{1: 2, 2: 3} {
+		span := tablepb.Span{TableID: tableID}
+		coord.replicationM.ReplicationSets().ReplaceOrInsert(span, &replication.ReplicationSet{
+			Span:    span,
+			State:   replication.ReplicationSetStateReplicating,
+			Primary: "a",
+			Stats:   tablepb.Stats{RegionCount: regionCount},
+		})
+	}
+
+	var ip internal.InfoProvider = coord
+	require.Equal(t, map[model.CaptureID]*model.CaptureLoad{
+		"a": {TableCount: 2, RegionCount: 5, Load: 7},
+		"b": {},
+	}, ip.GetCaptureLoads())
+}
@@ -78,9 +78,12 @@ func (m *mockCache) LocateRegionByID(
 func NewReconcilerForTests(
 	cache RegionCache, config *config.ChangefeedSchedulerConfig,
 ) *Reconciler {
+	regionSplitter := newRegionCountSplitter(model.ChangeFeedID{}, cache)
 	return &Reconciler{
-		tableSpans: make(map[int64]splittedSpans),
-		config:     config,
-		splitter:   []splitter{newRegionCountSplitter(model.ChangeFeedID{}, cache)},
+		tableSpans:      make(map[int64]splittedSpans),
+		config:          config,
+		splitter:        []splitter{regionSplitter},
+		regionSplitter:  regionSplitter,
+		resplitInterval: defaultResplitInterval,
 	}
 }
@@ -15,6 +15,8 @@ package keyspan
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -30,7 +32,17 @@ import (
 	"go.uber.org/zap"
 )
 
-const spanRegionLimit = 50000
+const (
+	spanRegionLimit = 50000
+
+	// writeRowMergeRatio is the ratio of WriteRowThreshold that the rows
+	// written per second of a split table must drop below to merge it back.
+	writeRowMergeRatio = 0.5
+	// defaultResplitInterval is the minimum interval between splitting or
+	// merging a table by write rate, it leaves enough time to collect the
+	// write rate of new spans and prevents tables from flapping.
+	defaultResplitInterval = 5 * time.Minute
+)
 
 type splitter interface {
 	split(
@@ -42,6 +54,11 @@ type splitter interface {
 type splittedSpans struct {
 	byAddTable bool
 	spans      []tablepb.Span
+	// replacing is set when the table is split or merged by write rate, it
+	// is cleared after spans replace the old spans in replications.
+	replacing bool
+	// lastResplitTime is the last time spans of the table are changed.
+	lastResplitTime time.Time
 }
 
 // Reconciler reconciles span and table mapping, make sure spans are in
@@ -54,6 +71,9 @@ type Reconciler struct {
 	config       *config.ChangefeedSchedulerConfig
 
 	splitter []splitter
+	// regionSplitter splits tables by write rate.
+	regionSplitter  *regionCountSplitter
+	resplitInterval time.Duration
 }
 
 // NewReconciler returns a Reconciler.
@@ -66,6 +86,7 @@ func NewReconciler(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	regionSplitter := newRegionCountSplitter(changefeedID, up.RegionCache)
 	return &Reconciler{
 		tableSpans:   make(map[int64]splittedSpans),
 		changefeedID: changefeedID,
@@ -73,8 +94,10 @@ func NewReconciler(
 		splitter: []splitter{
 			// write splitter has the highest priority.
 			newWriteSplitter(changefeedID, pdapi),
-			newRegionCountSplitter(changefeedID, up.RegionCache),
+			regionSplitter,
 		},
+		regionSplitter:  regionSplitter,
+		resplitInterval: defaultResplitInterval,
 	}, nil
 }
 
@@ -86,6 +109,7 @@ func NewReconciler(
 // 4. Add table by DDL.
 // 5. Drop table by DDL.
 // 6. Some captures fail, does NOT affect spans.
+// 7. Split or merge tables by write rate.
 func (m *Reconciler) Reconcile(
 	ctx context.Context,
 	currentTables *replication.TableRanges,
@@ -96,6 +120,7 @@ func (m *Reconciler) Reconcile(
 	tablesLenEqual := currentTables.Len() == len(m.tableSpans)
 	allTablesFound := true
 	updateCache := false
+	now := time.Now()
 	currentTables.Iter(func(tableID model.TableID, tableStart, tableEnd tablepb.Span) bool {
 		if _, ok := m.tableSpans[tableID]; !ok {
 			// Find a new table.
@@ -128,8 +153,9 @@ func (m *Reconciler) Reconcile(
 				}
 			}
 			m.tableSpans[tableID] = splittedSpans{
-				byAddTable: true,
-				spans:      spans,
+				byAddTable:      true,
+				spans:           spans,
+				lastResplitTime: now,
 			}
 			updateCache = true
 		} else if len(holes) != 0 {
@@ -160,17 +186,49 @@ func (m *Reconciler) Reconcile(
 				// TODO: maybe we should split holes too.
 			}
 			m.tableSpans[tableID] = splittedSpans{
-				byAddTable: false,
-				spans:      spans,
+				byAddTable:      false,
+				spans:           spans,
+				lastResplitTime: now,
 			}
 			updateCache = true
 		} else {
 			// Found and no hole, maybe:
 			// 2. owner switch and no capture fails.
-			ss := m.tableSpans[tableID]
+			// 7. the table is being split or merged by write rate.
+			ss, ok := m.tableSpans[tableID]
+			if !ok {
+				ss.lastResplitTime = now
+			}
 			ss.byAddTable = false
-			ss.spans = ss.spans[:0]
-			ss.spans = append(ss.spans, coveredSpans...)
+			if ss.replacing && spansEqual(ss.spans, coveredSpans) {
+				// New spans have replaced the old spans.
+				ss.replacing = false
+			}
+			if ss.replacing && now.Sub(ss.lastResplitTime) > m.resplitInterval {
+				// New spans fail to replace the old spans in time, give up.
+				log.Warn("schedulerv3: resplit table timeout, keep old spans",
+					zap.String("namespace", m.changefeedID.Namespace),
+					zap.String("changefeed", m.changefeedID.ID),
+					zap.Int64("tableID", tableID))
+				ss.replacing = false
+			}
+			if !ss.replacing && !spansEqual(ss.spans, coveredSpans) {
+				ss.spans = append(ss.spans[:0:0], coveredSpans...)
+				updateCache = true
+			}
+			// 7. Split or merge the table by write rate.
+			if !ss.replacing && m.config.WriteRowThreshold > 0 &&
+				compat.CheckSpanReplicationEnabled() {
+				spans := m.resplitByWriteRow(
+					ctx, tableID, &ss, replications, len(aliveCaptures), now)
+				if len(spans) != 0 {
+					// The basic scheduler adds new spans, and removes old
+					// spans after new spans are prepared.
+					ss.spans = spans
+					ss.replacing = true
+					updateCache = true
+				}
+			}
 			m.tableSpans[tableID] = ss
 		}
 		return true
@@ -208,3 +266,83 @@ func (m *Reconciler) Reconcile(
 	}
 	return m.spanCache
 }
+
+// resplitByWriteRow returns the spans that replace the spans of the table if
+// the table should be split or merged by its rows written per second.
+// It returns nil if spans of the table do not need to change.
+func (m *Reconciler) resplitByWriteRow(
+	ctx context.Context, tableID model.TableID, ss *splittedSpans,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	totalCaptures int, now time.Time,
+) []tablepb.Span {
+	if now.Sub(ss.lastResplitTime) < m.resplitInterval {
+		return nil
+	}
+	rate, regionCount := 0.0, uint64(0)
+	for _, span := range ss.spans {
+		rep, ok := replications.Get(span)
+		if !ok || rep.State != replication.ReplicationSetStateReplicating ||
+			rep.IsReplaced() {
+			// Only resplit tables that are not being scheduled.
+			return nil
+		}
+		rate += rep.SinkRowsRate()
+		regionCount += rep.Stats.RegionCount
+	}
+
+	threshold := float64(m.config.WriteRowThreshold)
+	tableSpan := spanz.TableIDToComparableSpan(tableID)
+	var spans []tablepb.Span
+	if rate > threshold {
+		pages := int(math.Ceil(rate / threshold))
+		if pages > totalCaptures {
+			pages = totalCaptures
+		}
+		if pages <= len(ss.spans) {
+			return nil
+		}
+		spans = m.regionSplitter.splitIntoPages(ctx, tableSpan, pages)
+		if len(spans) <= len(ss.spans) {
+			return nil
+		}
+		log.Info("schedulerv3: split span by write rate",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.Int64("tableID", tableID),
+			zap.Int("oldSpans", len(ss.spans)),
+			zap.Int("spans", len(spans)),
+			zap.Float64("rowsPerSecond", rate),
+			zap.Int("writeRowThreshold", m.config.WriteRowThreshold))
+	} else if rate < threshold*writeRowMergeRatio && len(ss.spans) > 1 {
+		if regionCount > uint64(m.config.RegionThreshold) ||
+			regionCount >= spanRegionLimit {
+			// The table is split by region count, keep it split.
+			return nil
+		}
+		spans = []tablepb.Span{tableSpan}
+		log.Info("schedulerv3: merge spans by write rate",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.Int64("tableID", tableID),
+			zap.Int("oldSpans", len(ss.spans)),
+			zap.Float64("rowsPerSecond", rate),
+			zap.Uint64("regionCount", regionCount),
+			zap.Int("writeRowThreshold", m.config.WriteRowThreshold))
+	} else {
+		return nil
+	}
+	ss.lastResplitTime = now
+	return spans
+}
+
+func spansEqual(lhs, rhs []tablepb.Span) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		if !lhs[i].Eq(&rhs[i]) {
+			return false
+		}
+	}
+	return true
+}
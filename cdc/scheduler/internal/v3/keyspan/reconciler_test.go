@@ -16,6 +16,7 @@ package keyspan
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -162,6 +163,137 @@ func TestReconcile(t *testing.T) {
 	require.Equal(t, 1, len(reconciler.tableSpans))
 }
 
+func newResplitTestReplicationSet(
+	span tablepb.Span, sinkRowsRate float64, regionCount uint64,
+) *replication.ReplicationSet {
+	rep := &replication.ReplicationSet{
+		Span:  span,
+		State: replication.ReplicationSetStateReplicating,
+		Stats: tablepb.Stats{RegionCount: regionCount},
+	}
+	rep.SetSinkRowsRateForTests(sinkRowsRate)
+	return rep
+}
+
+func TestReconcileResplitByWriteRow(t *testing.T) {
+	t.Parallel()
+
+	allSpan, cache := prepareSpanCache(t, [][3]uint8{
+		{1, 0, 1}, // table ID, start key suffix, end key suffix.
+		{1, 1, 2},
+		{1, 2, 3},
+		{1, 3, 4},
+	})
+	cfg := &config.SchedulerConfig{
+		ChangefeedSettings: &config.ChangefeedSchedulerConfig{
+			EnableTableAcrossNodes: true,
+			RegionThreshold:        100,
+			WriteRowThreshold:      100,
+		},
+	}
+	compat := compat.New(cfg, map[string]*model.CaptureInfo{})
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"1": nil,
+		"2": nil,
+		"3": nil,
+		"4": nil,
+	}
+	ctx := context.Background()
+	tableSpan := spanz.TableIDToComparableSpan(1)
+	currentTables := &replication.TableRanges{}
+	currentTables.UpdateTables([]model.TableID{1})
+
+	// The table is not split by region count.
+	reps := spanz.NewBtreeMap[*replication.ReplicationSet]()
+	reconciler := NewReconcilerForTests(cache, cfg.ChangefeedSettings)
+	spans := reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+
+	// The write rate is under the threshold.
+	reps.ReplaceOrInsert(tableSpan, newResplitTestReplicationSet(tableSpan, 50, 4))
+	reconciler.resplitInterval = 0
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+
+	// Do not split the table within the resplit interval.
+	reps.GetV(tableSpan).SetSinkRowsRateForTests(250)
+	reconciler.resplitInterval = time.Hour
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+
+	// Do not split the table being scheduled.
+	reconciler.resplitInterval = 0
+	reps.GetV(tableSpan).State = replication.ReplicationSetStateCommit
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+
+	// Split the table into 3 spans, the table span is kept until split spans
+	// replace it.
+	reps.GetV(tableSpan).State = replication.ReplicationSetStateReplicating
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	splitSpans := []tablepb.Span{
+		{TableID: 1, StartKey: allSpan[0].StartKey, EndKey: allSpan[1].EndKey},
+		allSpan[2],
+		allSpan[3],
+	}
+	require.Equal(t, splitSpans, spans)
+	require.True(t, reconciler.tableSpans[1].replacing)
+	reconciler.resplitInterval = time.Hour
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, splitSpans, spans)
+	require.True(t, reconciler.tableSpans[1].replacing)
+
+	// Split spans replace the table span.
+	reps.Delete(tableSpan)
+	for _, span := range splitSpans {
+		reps.ReplaceOrInsert(span, newResplitTestReplicationSet(span, 10, 50))
+	}
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, splitSpans, spans)
+	require.False(t, reconciler.tableSpans[1].replacing)
+
+	// Do not merge spans split by region count.
+	reconciler.resplitInterval = 0
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, splitSpans, spans)
+
+	// Do not merge spans if the write rate is not low enough.
+	for _, span := range splitSpans {
+		reps.ReplaceOrInsert(span, newResplitTestReplicationSet(span, 20, 1))
+	}
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, splitSpans, spans)
+
+	// Keep split spans if the table span fails to replace them in time.
+	for _, span := range splitSpans {
+		reps.GetV(span).SetSinkRowsRateForTests(10)
+	}
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+	require.True(t, reconciler.tableSpans[1].replacing)
+	for _, span := range splitSpans {
+		reps.GetV(span).SetSinkRowsRateForTests(20)
+	}
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, splitSpans, spans)
+	require.False(t, reconciler.tableSpans[1].replacing)
+
+	// Merge spans back after the write rate drops.
+	for _, span := range splitSpans {
+		reps.GetV(span).SetSinkRowsRateForTests(10)
+	}
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+	reconciler.resplitInterval = time.Hour
+	for _, span := range splitSpans {
+		reps.Delete(span)
+	}
+	reps.ReplaceOrInsert(tableSpan, newResplitTestReplicationSet(tableSpan, 10, 4))
+	spans = reconciler.Reconcile(ctx, currentTables, reps, captures, compat)
+	require.Equal(t, []tablepb.Span{tableSpan}, spans)
+	require.False(t, reconciler.tableSpans[1].replacing)
+}
+
 func TestCompatDisable(t *testing.T) {
 	t.Parallel()
 
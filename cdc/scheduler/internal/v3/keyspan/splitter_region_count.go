@@ -76,7 +76,50 @@ func (m *regionCountSplitter) split(
 		pages = totalRegions / spanRegionLimit
 	}
 
-	stepper := newEvenlySplitStepper(pages, totalRegions)
+	spans := m.splitRegions(bo, span, regions, pages)
+	if len(spans) == 0 {
+		return []tablepb.Span{span}
+	}
+	log.Info("schedulerv3: split span by region count",
+		zap.String("namespace", m.changefeedID.Namespace),
+		zap.String("changefeed", m.changefeedID.ID),
+		zap.String("span", span.String()),
+		zap.Int("spans", len(spans)),
+		zap.Int("totalCaptures", totalCaptures),
+		zap.Int("regionCount", len(regions)),
+		zap.Int("regionThreshold", config.RegionThreshold),
+		zap.Int("spanRegionLimit", spanRegionLimit))
+	return spans
+}
+
+// splitIntoPages evenly splits the span into at most pages spans by region
+// count regardless of the region threshold. It returns nil if the span can
+// not be split.
+func (m *regionCountSplitter) splitIntoPages(
+	ctx context.Context, span tablepb.Span, pages int,
+) []tablepb.Span {
+	bo := tikv.NewBackoffer(ctx, 500)
+	regions, err := m.regionCache.ListRegionIDsInKeyRange(bo, span.StartKey, span.EndKey)
+	if err != nil {
+		log.Warn("schedulerv3: list regions failed, skip split span",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.String("span", span.String()),
+			zap.Error(err))
+		return nil
+	}
+	if len(regions) <= 1 || pages <= 1 {
+		return nil
+	}
+	return m.splitRegions(bo, span, regions, pages)
+}
+
+// splitRegions evenly splits the span into pages spans by the given regions.
+// It returns nil if the regions can not be located.
+func (m *regionCountSplitter) splitRegions(
+	bo *tikv.Backoffer, span tablepb.Span, regions []uint64, pages int,
+) []tablepb.Span {
+	stepper := newEvenlySplitStepper(pages, len(regions))
 	spans := make([]tablepb.Span, 0, stepper.SpanCount())
 	start, end := 0, stepper.Step()
 	for {
@@ -87,7 +130,7 @@ func (m *regionCountSplitter) split(
 				zap.String("changefeed", m.changefeedID.ID),
 				zap.String("span", span.String()),
 				zap.Error(err))
-			return []tablepb.Span{span}
+			return nil
 		}
 		endRegion, err := m.regionCache.LocateRegionByID(bo, regions[end-1])
 		if err != nil {
@@ -96,7 +139,7 @@ func (m *regionCountSplitter) split(
 				zap.String("changefeed", m.changefeedID.ID),
 				zap.String("span", span.String()),
 				zap.Error(err))
-			return []tablepb.Span{span}
+			return nil
 		}
 		if len(spans) > 0 &&
 			bytes.Compare(spans[len(spans)-1].EndKey, startRegion.StartKey) > 0 {
@@ -106,7 +149,7 @@ func (m *regionCountSplitter) split(
 				zap.String("span", span.String()),
 				zap.Stringer("lastSpan", &spans[len(spans)-1]),
 				zap.Stringer("region", startRegion))
-			return []tablepb.Span{span}
+			return nil
 		}
 		spans = append(spans, tablepb.Span{
 			TableID:  span.TableID,
@@ -128,15 +171,6 @@ func (m *regionCountSplitter) split(
 	// Make sure spans does not exceed [startKey, endKey).
 	spans[0].StartKey = span.StartKey
 	spans[len(spans)-1].EndKey = span.EndKey
-	log.Info("schedulerv3: split span by region count",
-		zap.String("namespace", m.changefeedID.Namespace),
-		zap.String("changefeed", m.changefeedID.ID),
-		zap.String("span", span.String()),
-		zap.Int("spans", len(spans)),
-		zap.Int("totalCaptures", totalCaptures),
-		zap.Int("regionCount", len(regions)),
-		zap.Int("regionThreshold", config.RegionThreshold),
-		zap.Int("spanRegionLimit", spanRegionLimit))
 	return spans
 }
 
@@ -64,6 +64,8 @@ type CaptureStatus struct {
 	ID       model.CaptureID
 	Addr     string
 	IsOwner  bool
+	// Labels are the labels of the capture, which are used to place tables.
+	Labels map[string]string
 }
 
 func newCaptureStatus(
@@ -233,9 +235,11 @@ func (c *CaptureManager) HandleAliveCaptureUpdate(
 			// A new capture.
 			c.Captures[id] = newCaptureStatus(
 				c.OwnerRev, id, info.AdvertiseAddr, c.ownerID == id)
+			c.Captures[id].Labels = info.Labels
 			log.Info("schedulerv3: find a new capture",
 				zap.String("captureAddr", info.AdvertiseAddr),
-				zap.String("capture", id))
+				zap.String("capture", id),
+				zap.Any("labels", info.Labels))
 			msgs = append(msgs, &schedulepb.Message{
 				To:        id,
 				MsgType:   schedulepb.MsgHeartbeat,
@@ -62,7 +62,7 @@ func TestCaptureManagerHandleAliveCaptureUpdate(t *testing.T) {
 	rev := schedulepb.OwnerRevision{}
 	cm := NewCaptureManager("1", model.ChangeFeedID{}, rev, config.NewDefaultSchedulerConfig())
 	ms := map[model.CaptureID]*model.CaptureInfo{
-		"1": {}, "2": {}, "3": {},
+		"1": {}, "2": {Labels: map[string]string{"zone": "z1"}}, "3": {},
 	}
 
 	// Initial handle alive captures.
@@ -78,6 +78,7 @@ func TestCaptureManagerHandleAliveCaptureUpdate(t *testing.T) {
 	require.True(t, cm.Captures["1"].IsOwner)
 	require.Contains(t, cm.Captures, "2")
 	require.False(t, cm.Captures["2"].IsOwner)
+	require.Equal(t, map[string]string{"zone": "z1"}, cm.Captures["2"].Labels)
 	require.Contains(t, cm.Captures, "3")
 
 	// Remove one capture before init.
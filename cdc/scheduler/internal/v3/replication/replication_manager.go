@@ -70,12 +70,27 @@ type RemoveTable struct {
 	CaptureID model.CaptureID
 }
 
+// ResplitTable is a schedule task for replacing spans of a table with new
+// spans covering the same range, when the table is split or merged.
+//
+// The new spans are prepared while the spans they replace keep replicating.
+// After all new spans are prepared, the replaced spans are removed, and the
+// new spans start replicating from the checkpoints of the replaced spans.
+type ResplitTable struct {
+	TableID model.TableID
+	// Spans are the new spans, each replaces the current spans it overlaps.
+	Spans []tablepb.Span
+	// CaptureIDs are the captures to replicate Spans, one for each span.
+	CaptureIDs []model.CaptureID
+}
+
 // ScheduleTask is a schedule task that wraps add/move/remove table tasks.
 type ScheduleTask struct { //nolint:revive
 	MoveTable    *MoveTable
 	AddTable     *AddTable
 	RemoveTable  *RemoveTable
 	BurstBalance *BurstBalance
+	ResplitTable *ResplitTable
 
 	Accept Callback
 }
@@ -90,16 +105,32 @@ func (s *ScheduleTask) Name() string {
 		return "removeTable"
 	} else if s.BurstBalance != nil {
 		return "burstBalance"
+	} else if s.ResplitTable != nil {
+		return "resplitTable"
 	}
 	return "unknown"
 }
 
+// resplitTable is a running ResplitTable task.
+type resplitTable struct {
+	// oldSpans are the spans being replaced, they are still in Manager.spans.
+	oldSpans []*ReplicationSet
+	// newSpans are the spans replacing oldSpans, they are added to
+	// Manager.spans after all oldSpans are removed.
+	newSpans []*ReplicationSet
+	// removing is set after all newSpans are prepared and oldSpans are being
+	// removed.
+	removing bool
+}
+
 // Manager manages replications and running scheduling tasks.
 type Manager struct { //nolint:revive
 	spans *spanz.BtreeMap[*ReplicationSet]
 
 	runningTasks       *spanz.BtreeMap[*ScheduleTask]
 	maxTaskConcurrency int
+	// resplitTables are the running ResplitTable tasks.
+	resplitTables map[model.TableID]*resplitTable
 
 	changefeedID           model.ChangeFeedID
 	slowestTableID         tablepb.Span
@@ -107,6 +138,7 @@ type Manager struct { //nolint:revive
 	acceptRemoveTableTask  int
 	acceptMoveTableTask    int
 	acceptBurstBalanceTask int
+	acceptResplitTableTask int
 
 	slowTableHeap         SetHeap
 	lastLogSlowTablesTime time.Time
@@ -125,6 +157,7 @@ func NewReplicationManager(
 		spans:              spanz.NewBtreeMapWithDegree[*ReplicationSet](degreeReadHeavy),
 		runningTasks:       spanz.NewBtreeMap[*ScheduleTask](),
 		maxTaskConcurrency: maxTaskConcurrency,
+		resplitTables:      make(map[model.TableID]*resplitTable),
 		changefeedID:       changefeedID,
 	}
 }
@@ -135,6 +168,7 @@ func (r *Manager) HandleCaptureChanges(
 	removed map[model.CaptureID][]tablepb.TableStatus,
 	checkpointTs model.Ts,
 ) ([]*schedulepb.Message, error) {
+	sentMsgs := make([]*schedulepb.Message, 0)
 	if init != nil {
 		if r.spans.Len() != 0 {
 			log.Panic("schedulerv3: init again",
@@ -153,6 +187,9 @@ func (r *Manager) HandleCaptureChanges(
 				spanStatusMap.GetV(table.Span)[captureID] = &table
 			}
 		}
+		// Spans of a table overlap if the owner changed while the table is
+		// being split or merged, remove the overlapped ones.
+		sentMsgs = append(sentMsgs, removeOverlappedSpans(spanStatusMap)...)
 		var err error
 		spanStatusMap.Ascend(func(span tablepb.Span, status map[string]*tablepb.TableStatus) bool {
 			table, err1 := NewReplicationSet(span, checkpointTs, status, r.changefeedID)
@@ -167,7 +204,6 @@ func (r *Manager) HandleCaptureChanges(
 			return nil, errors.Trace(err)
 		}
 	}
-	sentMsgs := make([]*schedulepb.Message, 0)
 	if removed != nil {
 		var err error
 		r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
@@ -188,10 +224,90 @@ func (r *Manager) HandleCaptureChanges(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		for _, rt := range r.resplitTables {
+			for _, table := range rt.newSpans {
+				for captureID := range removed {
+					msgs, _, err := table.handleCaptureShutdown(captureID)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					sentMsgs = append(sentMsgs, msgs...)
+				}
+			}
+		}
 	}
 	return sentMsgs, nil
 }
 
+// removeOverlappedSpans removes the spans overlapping other spans of the same
+// table from spanStatusMap, and returns messages to remove them from captures.
+// The spans being replicated are kept first, then the spans being prepared.
+func removeOverlappedSpans(
+	spanStatusMap *spanz.BtreeMap[map[model.CaptureID]*tablepb.TableStatus],
+) []*schedulepb.Message {
+	priority := func(status map[model.CaptureID]*tablepb.TableStatus) int {
+		p := 2
+		for _, table := range status {
+			switch table.State {
+			case tablepb.TableStateReplicating, tablepb.TableStateStopping:
+				return 0
+			case tablepb.TableStatePreparing, tablepb.TableStatePrepared:
+				p = 1
+			}
+		}
+		return p
+	}
+	tableSpans := make(map[model.TableID][][]tablepb.Span)
+	spanStatusMap.Ascend(func(span tablepb.Span, status map[model.CaptureID]*tablepb.TableStatus) bool {
+		if tableSpans[span.TableID] == nil {
+			tableSpans[span.TableID] = make([][]tablepb.Span, 3)
+		}
+		p := priority(status)
+		tableSpans[span.TableID][p] = append(tableSpans[span.TableID][p], span)
+		return true
+	})
+
+	msgs := make([]*schedulepb.Message, 0)
+	for _, spansByPriority := range tableSpans {
+		kept := make([]tablepb.Span, 0)
+		for _, spans := range spansByPriority {
+			for _, span := range spans {
+				overlapped := false
+				for i := range kept {
+					if spanz.Overlap(span, kept[i]) {
+						overlapped = true
+						break
+					}
+				}
+				if !overlapped {
+					kept = append(kept, span)
+					continue
+				}
+				status, _ := spanStatusMap.Delete(span)
+				log.Info("schedulerv3: remove overlapped span",
+					zap.String("span", span.String()),
+					zap.Any("status", status))
+				for captureID, table := range status {
+					if table.State == tablepb.TableStateAbsent ||
+						table.State == tablepb.TableStateStopped {
+						continue
+					}
+					msgs = append(msgs, &schedulepb.Message{
+						To:      captureID,
+						MsgType: schedulepb.MsgDispatchTableRequest,
+						DispatchTableRequest: &schedulepb.DispatchTableRequest{
+							Request: &schedulepb.DispatchTableRequest_RemoveTable{
+								RemoveTable: &schedulepb.RemoveTableRequest{Span: span},
+							},
+						},
+					})
+				}
+			}
+		}
+	}
+	return msgs
+}
+
 // HandleMessage handles messages sent by other captures.
 func (r *Manager) HandleMessage(
 	msgs []*schedulepb.Message,
@@ -227,7 +343,7 @@ func (r *Manager) handleMessageHeartbeatResponse(
 ) ([]*schedulepb.Message, error) {
 	sentMsgs := make([]*schedulepb.Message, 0)
 	for _, status := range msg.Tables {
-		table, ok := r.spans.Get(status.Span)
+		table, ok := r.getReplicationSet(status.Span)
 		if !ok {
 			log.Info("schedulerv3: ignore table status no table found",
 				zap.String("namespace", r.changefeedID.Namespace),
@@ -239,7 +355,7 @@ func (r *Manager) handleMessageHeartbeatResponse(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if table.hasRemoved() {
+		if table.hasRemoved() && !table.replaced {
 			log.Info("schedulerv3: table has removed",
 				zap.String("namespace", r.changefeedID.Namespace),
 				zap.String("changefeed", r.changefeedID.ID),
@@ -268,7 +384,7 @@ func (r *Manager) handleMessageDispatchTableResponse(
 		return nil, nil
 	}
 
-	table, ok := r.spans.Get(status.Span)
+	table, ok := r.getReplicationSet(status.Span)
 	if !ok {
 		log.Info("schedulerv3: ignore table status no table found",
 			zap.String("namespace", r.changefeedID.Namespace),
@@ -280,7 +396,7 @@ func (r *Manager) handleMessageDispatchTableResponse(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if table.hasRemoved() {
+	if table.hasRemoved() && !table.replaced {
 		log.Info("schedulerv3: table has removed",
 			zap.String("namespace", r.changefeedID.Namespace),
 			zap.String("changefeed", r.changefeedID.ID),
@@ -290,17 +406,40 @@ func (r *Manager) handleMessageDispatchTableResponse(
 	return msgs, nil
 }
 
+// getReplicationSet returns the replication set of the span, including the
+// new spans of running ResplitTable tasks.
+func (r *Manager) getReplicationSet(span tablepb.Span) (*ReplicationSet, bool) {
+	if table, ok := r.spans.Get(span); ok {
+		return table, true
+	}
+	if rt, ok := r.resplitTables[span.TableID]; ok {
+		for _, table := range rt.newSpans {
+			if table.Span.Eq(&span) {
+				return table, true
+			}
+		}
+	}
+	return nil, false
+}
+
 // HandleTasks handles schedule tasks.
 func (r *Manager) HandleTasks(
 	tasks []*ScheduleTask,
 ) ([]*schedulepb.Message, error) {
+	sentMsgs, err := r.pollResplitTables()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Check if a running task is finished.
 	toBeDeleted := []tablepb.Span{}
 	r.runningTasks.Ascend(func(span tablepb.Span, task *ScheduleTask) bool {
 		if table, ok := r.spans.Get(span); ok {
 			// If table is back to Replicating or Removed,
 			// the running task is finished.
-			if table.State == ReplicationSetStateReplicating || table.hasRemoved() {
+			// The spans being replaced are kept until they are removed.
+			if (table.State == ReplicationSetStateReplicating || table.hasRemoved()) &&
+				!table.replaced {
 				toBeDeleted = append(toBeDeleted, span)
 			}
 		} else {
@@ -313,7 +452,6 @@ func (r *Manager) HandleTasks(
 		r.runningTasks.Delete(span)
 	}
 
-	sentMsgs := make([]*schedulepb.Message, 0)
 	for _, task := range tasks {
 		// Burst balance does not affect by maxTaskConcurrency.
 		if task.BurstBalance != nil {
@@ -327,6 +465,19 @@ func (r *Manager) HandleTasks(
 			}
 			continue
 		}
+		// Resplit table is generated by the basic scheduler too, it does not
+		// affect by maxTaskConcurrency.
+		if task.ResplitTable != nil {
+			msgs, err := r.handleResplitTableTask(task.ResplitTable)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			sentMsgs = append(sentMsgs, msgs...)
+			if task.Accept != nil {
+				task.Accept()
+			}
+			continue
+		}
 
 		// Check if accepting one more task exceeds maxTaskConcurrency.
 		if r.runningTasks.Len() == r.maxTaskConcurrency {
@@ -425,6 +576,254 @@ func (r *Manager) handleMoveTableTask(
 	return table.handleMoveTable(task.DestCapture)
 }
 
+func (r *Manager) handleResplitTableTask(
+	task *ResplitTable,
+) ([]*schedulepb.Message, error) {
+	if _, ok := r.resplitTables[task.TableID]; ok {
+		log.Debug("schedulerv3: ignore resplit table task, already exists",
+			zap.String("namespace", r.changefeedID.Namespace),
+			zap.String("changefeed", r.changefeedID.ID),
+			zap.Int64("tableID", task.TableID))
+		return nil, nil
+	}
+	rt := &resplitTable{}
+	newSpans := make([]tablepb.Span, 0, len(task.Spans))
+	for _, span := range task.Spans {
+		if !r.spans.Has(span) {
+			newSpans = append(newSpans, span)
+		}
+	}
+	tableStart, tableEnd := spanz.TableIDToComparableRange(task.TableID)
+	r.spans.AscendRange(tableStart, tableEnd, func(span tablepb.Span, table *ReplicationSet) bool {
+		for i := range newSpans {
+			if spanz.Overlap(span, newSpans[i]) {
+				rt.oldSpans = append(rt.oldSpans, table)
+				break
+			}
+		}
+		return true
+	})
+	oldSpans := make([]tablepb.Span, 0, len(rt.oldSpans))
+	for _, table := range rt.oldSpans {
+		oldSpans = append(oldSpans, table.Span)
+	}
+	if len(newSpans) == 0 || !spansCoverSameRange(oldSpans, newSpans) {
+		log.Warn("schedulerv3: ignore resplit table task, "+
+			"new spans do not cover the same range as old spans",
+			zap.String("namespace", r.changefeedID.Namespace),
+			zap.String("changefeed", r.changefeedID.ID),
+			zap.Int64("tableID", task.TableID),
+			zap.Any("oldSpans", oldSpans),
+			zap.Any("newSpans", newSpans))
+		return nil, nil
+	}
+	for _, table := range rt.oldSpans {
+		_, running := r.runningTasks.Get(table.Span)
+		if table.State != ReplicationSetStateReplicating || running {
+			log.Info("schedulerv3: ignore resplit table task, span is being scheduled",
+				zap.String("namespace", r.changefeedID.Namespace),
+				zap.String("changefeed", r.changefeedID.ID),
+				zap.Any("replicationSet", table))
+			return nil, nil
+		}
+	}
+
+	r.acceptResplitTableTask++
+	sentMsgs := make([]*schedulepb.Message, 0, len(newSpans))
+	for i, span := range task.Spans {
+		if r.spans.Has(span) {
+			continue
+		}
+		checkpoint := rt.checkpoint(span)
+		table, err := NewReplicationSet(span, checkpoint.CheckpointTs, nil, r.changefeedID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		table.Checkpoint = checkpoint
+		table.replacing = true
+		msgs, err := table.handleAddTable(task.CaptureIDs[i])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sentMsgs = append(sentMsgs, msgs...)
+		rt.newSpans = append(rt.newSpans, table)
+	}
+	for _, table := range rt.oldSpans {
+		table.replaced = true
+		// Prevent other tasks from scheduling the old spans.
+		r.runningTasks.ReplaceOrInsert(table.Span, &ScheduleTask{})
+	}
+	r.resplitTables[task.TableID] = rt
+	log.Info("schedulerv3: resplit table",
+		zap.String("namespace", r.changefeedID.Namespace),
+		zap.String("changefeed", r.changefeedID.ID),
+		zap.Int64("tableID", task.TableID),
+		zap.Any("oldSpans", oldSpans),
+		zap.Any("newSpans", newSpans))
+	return sentMsgs, nil
+}
+
+// pollResplitTables advances the running ResplitTable tasks.
+func (r *Manager) pollResplitTables() ([]*schedulepb.Message, error) {
+	sentMsgs := make([]*schedulepb.Message, 0)
+	for tableID, rt := range r.resplitTables {
+		if !rt.removing {
+			prepared := true
+			for _, table := range rt.newSpans {
+				if table.State == ReplicationSetStateAbsent {
+					// A new span fails to be prepared, the old spans are
+					// still replicating, cancel the task.
+					sentMsgs = append(sentMsgs, r.cancelResplitTable(tableID, rt)...)
+					prepared = false
+					break
+				}
+				if table.State != ReplicationSetStateCommit {
+					prepared = false
+				}
+			}
+			if !prepared {
+				continue
+			}
+			log.Info("schedulerv3: new spans are prepared, remove old spans",
+				zap.String("namespace", r.changefeedID.Namespace),
+				zap.String("changefeed", r.changefeedID.ID),
+				zap.Int64("tableID", tableID))
+			rt.removing = true
+			for _, table := range rt.oldSpans {
+				if table.State != ReplicationSetStateReplicating {
+					// The capture of the old span is shutdown.
+					continue
+				}
+				msgs, err := table.handleRemoveTable()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				sentMsgs = append(sentMsgs, msgs...)
+			}
+		}
+
+		removed := true
+		for _, table := range rt.oldSpans {
+			// The old span is removed, or its capture is shutdown.
+			if !table.hasRemoved() &&
+				!(table.State == ReplicationSetStateAbsent && len(table.Captures) == 0) {
+				removed = false
+				break
+			}
+		}
+		if !removed {
+			continue
+		}
+		for _, table := range rt.oldSpans {
+			r.spans.Delete(table.Span)
+			r.runningTasks.Delete(table.Span)
+		}
+		for _, table := range rt.newSpans {
+			table.Checkpoint = rt.checkpoint(table.Span)
+			table.replacing = false
+			r.spans.ReplaceOrInsert(table.Span, table)
+			secondary, ok := table.getRole(RoleSecondary)
+			if !ok {
+				// The new span is Absent, the basic scheduler adds it again.
+				continue
+			}
+			r.runningTasks.ReplaceOrInsert(table.Span, &ScheduleTask{})
+			// Promote the prepared secondary.
+			msgs, err := table.poll(&tablepb.TableStatus{
+				Span:  table.Span,
+				State: tablepb.TableStatePrepared,
+			}, secondary)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			sentMsgs = append(sentMsgs, msgs...)
+		}
+		delete(r.resplitTables, tableID)
+		log.Info("schedulerv3: old spans are replaced by new spans",
+			zap.String("namespace", r.changefeedID.Namespace),
+			zap.String("changefeed", r.changefeedID.ID),
+			zap.Int64("tableID", tableID))
+	}
+	return sentMsgs, nil
+}
+
+// cancelResplitTable cancels a ResplitTable task before the old spans are
+// removed, and returns messages to remove the new spans from captures.
+func (r *Manager) cancelResplitTable(
+	tableID model.TableID, rt *resplitTable,
+) []*schedulepb.Message {
+	log.Info("schedulerv3: cancel resplit table, new span fails to be prepared",
+		zap.String("namespace", r.changefeedID.Namespace),
+		zap.String("changefeed", r.changefeedID.ID),
+		zap.Int64("tableID", tableID))
+	sentMsgs := make([]*schedulepb.Message, 0)
+	for _, table := range rt.newSpans {
+		for captureID := range table.Captures {
+			sentMsgs = append(sentMsgs, &schedulepb.Message{
+				To:      captureID,
+				MsgType: schedulepb.MsgDispatchTableRequest,
+				DispatchTableRequest: &schedulepb.DispatchTableRequest{
+					Request: &schedulepb.DispatchTableRequest_RemoveTable{
+						RemoveTable: &schedulepb.RemoveTableRequest{Span: table.Span},
+					},
+				},
+			})
+		}
+	}
+	for _, table := range rt.oldSpans {
+		table.replaced = false
+		r.runningTasks.Delete(table.Span)
+	}
+	delete(r.resplitTables, tableID)
+	return sentMsgs
+}
+
+// checkpoint returns the min checkpoint of the old spans overlapping the span.
+func (rt *resplitTable) checkpoint(span tablepb.Span) tablepb.Checkpoint {
+	checkpoint := tablepb.Checkpoint{CheckpointTs: math.MaxUint64, ResolvedTs: math.MaxUint64}
+	for _, table := range rt.oldSpans {
+		if !spanz.Overlap(span, table.Span) {
+			continue
+		}
+		if table.Checkpoint.CheckpointTs < checkpoint.CheckpointTs {
+			checkpoint.CheckpointTs = table.Checkpoint.CheckpointTs
+		}
+		if table.Checkpoint.ResolvedTs < checkpoint.ResolvedTs {
+			checkpoint.ResolvedTs = table.Checkpoint.ResolvedTs
+		}
+	}
+	return checkpoint
+}
+
+// spansCoverSameRange returns true if the two groups of non-overlapping spans
+// cover the same key range.
+func spansCoverSameRange(lhs, rhs []tablepb.Span) bool {
+	merge := func(spans []tablepb.Span) []tablepb.Span {
+		spans = append([]tablepb.Span{}, spans...)
+		spanz.Sort(spans)
+		merged := make([]tablepb.Span, 0, len(spans))
+		for _, span := range spans {
+			last := len(merged) - 1
+			if last >= 0 && bytes.Equal(merged[last].EndKey, span.StartKey) {
+				merged[last].EndKey = span.EndKey
+				continue
+			}
+			merged = append(merged, span)
+		}
+		return merged
+	}
+	l, r := merge(lhs), merge(rhs)
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if !bytes.Equal(l[i].StartKey, r[i].StartKey) || !bytes.Equal(l[i].EndKey, r[i].EndKey) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Manager) handleBurstBalanceTasks(
 	task *BurstBalance,
 ) ([]*schedulepb.Message, error) {
@@ -736,6 +1135,8 @@ func (r *Manager) CollectMetrics() {
 	r.acceptMoveTableTask = 0
 	metricAcceptScheduleTask.WithLabelValues("burstBalance").Add(float64(r.acceptBurstBalanceTask))
 	r.acceptBurstBalanceTask = 0
+	metricAcceptScheduleTask.WithLabelValues("resplitTable").Add(float64(r.acceptResplitTableTask))
+	r.acceptResplitTableTask = 0
 	runningScheduleTaskGauge.
 		WithLabelValues(cf.Namespace, cf.ID).Set(float64(r.runningTasks.Len()))
 	var stateCounters [6]int
@@ -779,6 +1180,7 @@ func (r *Manager) CleanMetrics() {
 	metricAcceptScheduleTask.DeleteLabelValues("removeTable")
 	metricAcceptScheduleTask.DeleteLabelValues("moveTable")
 	metricAcceptScheduleTask.DeleteLabelValues("burstBalance")
+	metricAcceptScheduleTask.DeleteLabelValues("resplitTable")
 	var stateCounters [6]int
 	for s := range stateCounters {
 		tableStateGauge.
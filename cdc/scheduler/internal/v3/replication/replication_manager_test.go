@@ -537,6 +537,220 @@ func TestReplicationManagerBurstBalanceMoveTables(t *testing.T) {
 	require.True(t, r.runningTasks.Has(spanz.TableIDToComparableSpan(2)))
 }
 
+func newResplitTestSpans() (tablepb.Span, tablepb.Span, tablepb.Span) {
+	old := spanz.TableIDToComparableSpan(1)
+	mid := append(append([]byte{}, old.StartKey...), 1)
+	return old,
+		tablepb.Span{TableID: 1, StartKey: old.StartKey, EndKey: mid},
+		tablepb.Span{TableID: 1, StartKey: mid, EndKey: old.EndKey}
+}
+
+func TestReplicationManagerResplitTable(t *testing.T) {
+	t.Parallel()
+
+	r := NewReplicationManager(10, model.ChangeFeedID{})
+	old, span1, span2 := newResplitTestSpans()
+	tbl, err := NewReplicationSet(old, 0, map[string]*tablepb.TableStatus{
+		"1": {
+			Span:       old,
+			State:      tablepb.TableStateReplicating,
+			Checkpoint: tablepb.Checkpoint{CheckpointTs: 10, ResolvedTs: 12},
+		},
+	}, model.ChangeFeedID{})
+	require.Nil(t, err)
+	r.spans.ReplaceOrInsert(old, tbl)
+
+	// Add new spans as secondary, the old span keeps replicating.
+	task := &ScheduleTask{ResplitTable: &ResplitTable{
+		TableID:    1,
+		Spans:      []tablepb.Span{span1, span2},
+		CaptureIDs: []model.CaptureID{"1", "2"},
+	}}
+	msgs, err := r.HandleTasks([]*ScheduleTask{task})
+	require.Nil(t, err)
+	require.Len(t, msgs, 2)
+	for i, span := range []tablepb.Span{span1, span2} {
+		require.EqualValues(t, &schedulepb.Message{
+			To:      []string{"1", "2"}[i],
+			MsgType: schedulepb.MsgDispatchTableRequest,
+			DispatchTableRequest: &schedulepb.DispatchTableRequest{
+				Request: &schedulepb.DispatchTableRequest_AddTable{
+					AddTable: &schedulepb.AddTableRequest{
+						Span:        span,
+						IsSecondary: true,
+						Checkpoint:  tablepb.Checkpoint{CheckpointTs: 10, ResolvedTs: 12},
+					},
+				},
+			},
+		}, msgs[i])
+	}
+	require.Equal(t, 1, r.spans.Len())
+	require.True(t, tbl.IsReplaced())
+	require.True(t, r.runningTasks.Has(old))
+
+	// Ignore if resplit the table again.
+	msgs, err = r.HandleTasks([]*ScheduleTask{task})
+	require.Nil(t, err)
+	require.Len(t, msgs, 0)
+
+	// New spans wait in Commit until the old span is removed.
+	for i, span := range []tablepb.Span{span1, span2} {
+		msgs, err = r.HandleMessage([]*schedulepb.Message{{
+			From:    []string{"1", "2"}[i],
+			MsgType: schedulepb.MsgDispatchTableResponse,
+			DispatchTableResponse: &schedulepb.DispatchTableResponse{
+				Response: &schedulepb.DispatchTableResponse_AddTable{
+					AddTable: &schedulepb.AddTableResponse{
+						Status: &tablepb.TableStatus{
+							Span:  span,
+							State: tablepb.TableStatePrepared,
+						},
+					},
+				},
+			},
+		}})
+		require.Nil(t, err)
+		require.Len(t, msgs, 0)
+	}
+	require.Equal(t, ReplicationSetStateReplicating, tbl.State)
+
+	// Remove the old span after new spans are prepared.
+	msgs, err = r.HandleTasks(nil)
+	require.Nil(t, err)
+	require.Len(t, msgs, 1)
+	require.EqualValues(t, &schedulepb.Message{
+		To:      "1",
+		MsgType: schedulepb.MsgDispatchTableRequest,
+		DispatchTableRequest: &schedulepb.DispatchTableRequest{
+			Request: &schedulepb.DispatchTableRequest_RemoveTable{
+				RemoveTable: &schedulepb.RemoveTableRequest{Span: old},
+			},
+		},
+	}, msgs[0])
+	msgs, err = r.HandleMessage([]*schedulepb.Message{{
+		From:    "1",
+		MsgType: schedulepb.MsgHeartbeatResponse,
+		HeartbeatResponse: &schedulepb.HeartbeatResponse{
+			Tables: []tablepb.TableStatus{{
+				Span:       old,
+				State:      tablepb.TableStateStopped,
+				Checkpoint: tablepb.Checkpoint{CheckpointTs: 15, ResolvedTs: 16},
+			}},
+		},
+	}})
+	require.Nil(t, err)
+	require.Len(t, msgs, 0)
+
+	// New spans replace the old span, and start from its final checkpoint.
+	msgs, err = r.HandleTasks(nil)
+	require.Nil(t, err)
+	require.Len(t, msgs, 2)
+	for i, span := range []tablepb.Span{span1, span2} {
+		require.EqualValues(t, &schedulepb.Message{
+			To:      []string{"1", "2"}[i],
+			MsgType: schedulepb.MsgDispatchTableRequest,
+			DispatchTableRequest: &schedulepb.DispatchTableRequest{
+				Request: &schedulepb.DispatchTableRequest_AddTable{
+					AddTable: &schedulepb.AddTableRequest{
+						Span:        span,
+						IsSecondary: false,
+						Checkpoint:  tablepb.Checkpoint{CheckpointTs: 15, ResolvedTs: 16},
+					},
+				},
+			},
+		}, msgs[i])
+	}
+	require.Equal(t, 2, r.spans.Len())
+	require.False(t, r.spans.Has(old))
+	require.Equal(t, ReplicationSetStateCommit, r.spans.GetV(span1).State)
+	require.Equal(t, ReplicationSetStateCommit, r.spans.GetV(span2).State)
+	require.Len(t, r.resplitTables, 0)
+}
+
+func TestReplicationManagerResplitTableCancel(t *testing.T) {
+	t.Parallel()
+
+	r := NewReplicationManager(10, model.ChangeFeedID{})
+	old, span1, span2 := newResplitTestSpans()
+	tbl, err := NewReplicationSet(old, 0, map[string]*tablepb.TableStatus{
+		"1": {Span: old, State: tablepb.TableStateReplicating},
+	}, model.ChangeFeedID{})
+	require.Nil(t, err)
+	r.spans.ReplaceOrInsert(old, tbl)
+
+	// Ignore if new spans do not cover the old span.
+	msgs, err := r.HandleTasks([]*ScheduleTask{{ResplitTable: &ResplitTable{
+		TableID:    1,
+		Spans:      []tablepb.Span{span1},
+		CaptureIDs: []model.CaptureID{"1"},
+	}}})
+	require.Nil(t, err)
+	require.Len(t, msgs, 0)
+	require.False(t, tbl.IsReplaced())
+
+	msgs, err = r.HandleTasks([]*ScheduleTask{{ResplitTable: &ResplitTable{
+		TableID:    1,
+		Spans:      []tablepb.Span{span1, span2},
+		CaptureIDs: []model.CaptureID{"1", "2"},
+	}}})
+	require.Nil(t, err)
+	require.Len(t, msgs, 2)
+
+	// Cancel if a new span fails to be prepared, the old span is kept.
+	removed := map[string][]tablepb.TableStatus{
+		"2": {{Span: span2, State: tablepb.TableStatePreparing}},
+	}
+	msgs, err = r.HandleCaptureChanges(nil, removed, 0)
+	require.Nil(t, err)
+	require.Len(t, msgs, 0)
+	msgs, err = r.HandleTasks(nil)
+	require.Nil(t, err)
+	require.Len(t, msgs, 1)
+	require.EqualValues(t, &schedulepb.Message{
+		To:      "1",
+		MsgType: schedulepb.MsgDispatchTableRequest,
+		DispatchTableRequest: &schedulepb.DispatchTableRequest{
+			Request: &schedulepb.DispatchTableRequest_RemoveTable{
+				RemoveTable: &schedulepb.RemoveTableRequest{Span: span1},
+			},
+		},
+	}, msgs[0])
+	require.Equal(t, 1, r.spans.Len())
+	require.Equal(t, ReplicationSetStateReplicating, tbl.State)
+	require.False(t, tbl.IsReplaced())
+	require.False(t, r.runningTasks.Has(old))
+	require.Len(t, r.resplitTables, 0)
+}
+
+func TestReplicationManagerRemoveOverlappedSpans(t *testing.T) {
+	t.Parallel()
+
+	// The owner switches while a table is being resplit.
+	r := NewReplicationManager(10, model.ChangeFeedID{})
+	old, span1, span2 := newResplitTestSpans()
+	init := map[model.CaptureID][]tablepb.TableStatus{
+		"1": {{Span: old, State: tablepb.TableStateReplicating}},
+		"2": {
+			{Span: span1, State: tablepb.TableStatePrepared},
+			{Span: span2, State: tablepb.TableStateStopped},
+		},
+	}
+	msgs, err := r.HandleCaptureChanges(init, nil, 0)
+	require.Nil(t, err)
+	require.Len(t, msgs, 1)
+	require.EqualValues(t, &schedulepb.Message{
+		To:      "2",
+		MsgType: schedulepb.MsgDispatchTableRequest,
+		DispatchTableRequest: &schedulepb.DispatchTableRequest{
+			Request: &schedulepb.DispatchTableRequest_RemoveTable{
+				RemoveTable: &schedulepb.RemoveTableRequest{Span: span1},
+			},
+		},
+	}, msgs[0])
+	require.Equal(t, 1, r.spans.Len())
+	require.Equal(t, ReplicationSetStateReplicating, r.spans.GetV(old).State)
+}
+
 func TestReplicationManagerMaxTaskConcurrency(t *testing.T) {
 	t.Parallel()
 
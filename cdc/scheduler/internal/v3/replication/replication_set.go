@@ -22,9 +22,14 @@ import (
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
+// sinkRowsPerLoadUnit is the number of rows written per second that costs
+// as much as a region.
+const sinkRowsPerLoadUnit = 10
+
 // ReplicationSetState is the state of ReplicationSet in owner.
 //
 //	 AddTable
@@ -40,6 +45,10 @@ import (
 // When a capture shutdown unexpectedly, we may need to transit the state to
 // Absent or Replicating immediately.
 //
+// When a table is split or merged, the new spans are added while the old
+// spans are still replicating, and they wait in Commit until the old spans
+// are removed, see ResplitTable.
+//
 //nolint:revive
 type ReplicationSetState int
 
@@ -135,7 +144,19 @@ type ReplicationSet struct { //nolint:revive
 	//     CaptureRolePrimary.
 	Captures   map[model.CaptureID]Role
 	Checkpoint tablepb.Checkpoint
-	Stats      tablepb.Stats
+	// Stats is the last stats collected from the primary.
+	Stats tablepb.Stats
+
+	// sinkRowsRate is the number of rows written to the table sink per
+	// second, it is calculated from the last two collected stats.
+	sinkRowsRate float64
+
+	// replacing is set if the span is added to replace other spans of the
+	// table. The span waits in Commit after it is prepared, until the spans
+	// it replaces are removed.
+	replacing bool
+	// replaced is set if the span is being replaced by other spans.
+	replaced bool
 }
 
 // NewReplicationSet returns a new replication set.
@@ -559,6 +580,11 @@ func (r *ReplicationSet) pollOnCommit(
 					zap.String("captureID", captureID))
 				return nil, false, nil
 			}
+			if r.replacing {
+				// The spans replaced by the span are still replicating,
+				// promote the secondary after they are removed.
+				return nil, false, nil
+			}
 			// No primary, promote secondary to primary.
 			err := r.promoteSecondary(captureID)
 			if err != nil {
@@ -774,6 +800,12 @@ func (r *ReplicationSet) pollOnRemoving(
 	case tablepb.TableStateAbsent, tablepb.TableStateStopped:
 		errField := zap.Skip()
 		if r.Primary == captureID {
+			if r.replaced {
+				// The spans replacing it start from its checkpoint.
+				if err := r.updateCheckpointAndStats(input.Checkpoint, input.Stats); err != nil {
+					return nil, false, errors.Trace(err)
+				}
+			}
 			r.clearPrimary()
 		} else if r.isInRole(captureID, RoleSecondary) {
 			err := r.clearCapture(captureID, RoleSecondary)
@@ -903,6 +935,11 @@ func (r *ReplicationSet) hasRemoved() bool {
 	return r.State == ReplicationSetStateRemoving && len(r.Captures) == 0
 }
 
+// IsReplaced returns true if the span is being replaced by other spans.
+func (r *ReplicationSet) IsReplaced() bool {
+	return r.replaced
+}
+
 // handleCaptureShutdown handle capture shutdown event.
 // Besides returning messages and errors, it also returns a bool to indicate
 // whether r is affected by the capture shutdown.
@@ -950,10 +987,52 @@ func (r *ReplicationSet) updateCheckpointAndStats(
 		return errors.ErrInvalidCheckpointTs.GenWithStackByArgs(r.Checkpoint.CheckpointTs,
 			r.Checkpoint.ResolvedTs)
 	}
-	r.Stats = stats
+	r.updateStats(stats)
 	return nil
 }
 
+// updateStats updates the stats of the span. Stats are only collected
+// periodically, so a status without stats keeps the last collected ones.
+func (r *ReplicationSet) updateStats(stats tablepb.Stats) {
+	if stats.CurrentTs == 0 {
+		return
+	}
+	last := r.Stats
+	r.Stats = stats
+	if last.CurrentTs == 0 || stats.SinkRows < last.SinkRows {
+		// The counter starts from zero after the span is moved to another
+		// capture, keep the last rate until the next stats are collected.
+		return
+	}
+	elapsed := oracle.GetTimeFromTS(stats.CurrentTs).Sub(oracle.GetTimeFromTS(last.CurrentTs))
+	if elapsed <= 0 {
+		return
+	}
+	r.sinkRowsRate = float64(stats.SinkRows-last.SinkRows) / elapsed.Seconds()
+}
+
+// SinkRowsRate returns the number of rows written to the table sink per second.
+func (r *ReplicationSet) SinkRowsRate() float64 {
+	return r.sinkRowsRate
+}
+
+// SetSinkRowsRateForTests is only used in tests.
+func (r *ReplicationSet) SetSinkRowsRateForTests(rate float64) {
+	r.sinkRowsRate = rate
+}
+
+// Load returns the estimated cost of replicating the span, which is used to
+// balance spans among captures. Every span costs at least 1, and each region
+// or every sinkRowsPerLoadUnit rows written per second costs 1 more.
+//
+// The written keys of regions used by the write splitter are not included,
+// they are only available by scanning the regions of the span in PD, which
+// is too expensive to do for every span on each balance. The rows written to
+// the sink reflect the same write traffic as seen by the capture.
+func (r *ReplicationSet) Load() uint64 {
+	return 1 + r.Stats.RegionCount + uint64(r.sinkRowsRate/sinkRowsPerLoadUnit)
+}
+
 // SetHeap is a max-heap, it implements heap.Interface.
 type SetHeap []*ReplicationSet
 
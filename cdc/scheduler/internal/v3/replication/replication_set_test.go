@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/schedulepb"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 // See https://stackoverflow.com/a/30230552/3920448 for details.
@@ -1455,3 +1456,43 @@ func TestReplicationSetHeap_MinK(t *testing.T) {
 	require.Equal(t, expectedTables, tables)
 	require.Equal(t, 0, h.Len())
 }
+
+func TestReplicationSetUpdateStats(t *testing.T) {
+	t.Parallel()
+
+	r := &ReplicationSet{}
+	require.Equal(t, uint64(1), r.Load())
+
+	now := time.Now()
+	currentTs := func(d time.Duration) uint64 {
+		return oracle.GoTimeToTS(now.Add(d))
+	}
+	checkpoint := tablepb.Checkpoint{CheckpointTs: 1, ResolvedTs: 1}
+	require.Nil(t, r.updateCheckpointAndStats(checkpoint, tablepb.Stats{
+		RegionCount: 2, CurrentTs: currentTs(0), SinkRows: 100,
+	}))
+	require.Equal(t, float64(0), r.SinkRowsRate())
+	require.Equal(t, uint64(3), r.Load())
+
+	require.Nil(t, r.updateCheckpointAndStats(checkpoint, tablepb.Stats{
+		RegionCount: 2, CurrentTs: currentTs(10 * time.Second), SinkRows: 1100,
+	}))
+	require.Equal(t, float64(100), r.SinkRowsRate())
+	require.Equal(t, uint64(13), r.Load())
+
+	// A status without stats does not reset the stats.
+	require.Nil(t, r.updateCheckpointAndStats(checkpoint, tablepb.Stats{}))
+	require.Equal(t, uint64(2), r.Stats.RegionCount)
+	require.Equal(t, uint64(13), r.Load())
+
+	// The counter is reset after the span is moved.
+	require.Nil(t, r.updateCheckpointAndStats(checkpoint, tablepb.Stats{
+		RegionCount: 2, CurrentTs: currentTs(20 * time.Second), SinkRows: 10,
+	}))
+	require.Equal(t, float64(100), r.SinkRowsRate())
+	require.Nil(t, r.updateCheckpointAndStats(checkpoint, tablepb.Stats{
+		RegionCount: 2, CurrentTs: currentTs(30 * time.Second), SinkRows: 510,
+	}))
+	require.Equal(t, float64(50), r.SinkRowsRate())
+	require.Equal(t, uint64(8), r.Load())
+}
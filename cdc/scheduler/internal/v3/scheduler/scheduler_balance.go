@@ -15,6 +15,7 @@ package scheduler
 
 import (
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/pingcap/log"
@@ -23,8 +24,14 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
 	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
 )
 
+// balanceLoadTolerance is how far the load of a capture can exceed the
+// average load before spans are moved out of it. It avoids moving spans back
+// and forth when the loads of captures fluctuate around the average.
+const balanceLoadTolerance = 0.2
+
 var _ scheduler = &balanceScheduler{}
 
 // The scheduler for balancing tables among all captures.
@@ -41,13 +48,17 @@ type balanceScheduler struct {
 	forceBalance bool
 
 	maxTaskConcurrency int
+	placement          placement
 }
 
-func newBalanceScheduler(interval time.Duration, concurrency int) *balanceScheduler {
+func newBalanceScheduler(
+	interval time.Duration, concurrency int, placement placement,
+) *balanceScheduler {
 	return &balanceScheduler{
 		random:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		checkBalanceInterval: interval,
 		maxTaskConcurrency:   concurrency,
+		placement:            placement,
 	}
 }
 
@@ -77,8 +88,14 @@ func (b *balanceScheduler) Schedule(
 		}
 	}
 
+	// Tables are balanced among the captures satisfying the placement, and
+	// tables on other captures are moved to them.
+	candidates := b.placement.candidates(captures)
+	if len(candidates) == 0 {
+		return nil
+	}
 	tasks := buildBalanceMoveTables(
-		b.random, captures, replications, b.maxTaskConcurrency)
+		b.random, candidates, replications, b.maxTaskConcurrency)
 	b.forceBalance = len(tasks) != 0
 	return tasks
 }
@@ -89,8 +106,14 @@ func buildBalanceMoveTables(
 	replications *spanz.BtreeMap[*replication.ReplicationSet],
 	maxTaskConcurrency int,
 ) []*replication.ScheduleTask {
-	moves := newBalanceMoveTables(
-		random, captures, replications, maxTaskConcurrency, model.ChangeFeedID{})
+	var moves []replication.MoveTable
+	if hasCollectedStats(replications) {
+		moves = newLoadBalanceMoveTables(captures, replications, maxTaskConcurrency)
+	} else {
+		// Balance by the number of spans before any stats are collected.
+		moves = newBalanceMoveTables(
+			random, captures, replications, maxTaskConcurrency, model.ChangeFeedID{})
+	}
 	tasks := make([]*replication.ScheduleTask, 0, len(moves))
 	for i := 0; i < len(moves); i++ {
 		// No need for accept callback here.
@@ -98,3 +121,135 @@ func buildBalanceMoveTables(
 	}
 	return tasks
 }
+
+func hasCollectedStats(replications *spanz.BtreeMap[*replication.ReplicationSet]) bool {
+	collected := false
+	replications.Ascend(func(_ tablepb.Span, rep *replication.ReplicationSet) bool {
+		collected = rep.Stats.CurrentTs != 0
+		return !collected
+	})
+	return collected
+}
+
+// newLoadBalanceMoveTables moves spans from the most loaded captures to the
+// least loaded captures until the load of every capture is within
+// balanceLoadTolerance of the average load. Spans replicated by other
+// captures are moved first.
+func newLoadBalanceMoveTables(
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+	maxTaskLimit int,
+) []replication.MoveTable {
+	if len(captures) == 0 {
+		return nil
+	}
+	loads := make(map[model.CaptureID]uint64, len(captures))
+	spansPerCapture := make(map[model.CaptureID][]*replication.ReplicationSet, len(captures))
+	for captureID := range captures {
+		loads[captureID] = 0
+	}
+	var misplaced []*replication.ReplicationSet
+	replicating := true
+	replications.Ascend(func(_ tablepb.Span, rep *replication.ReplicationSet) bool {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			replicating = false
+			return false
+		}
+		if _, ok := loads[rep.Primary]; ok {
+			loads[rep.Primary] += rep.Load()
+			spansPerCapture[rep.Primary] = append(spansPerCapture[rep.Primary], rep)
+		} else {
+			misplaced = append(misplaced, rep)
+		}
+		return true
+	})
+	if !replicating {
+		// The loads are not stable when spans are being moved.
+		return nil
+	}
+
+	captureIDs := make([]model.CaptureID, 0, len(captures))
+	totalLoad := uint64(0)
+	for captureID, load := range loads {
+		captureIDs = append(captureIDs, captureID)
+		totalLoad += load
+	}
+	// Sort the captures so that the result is deterministic.
+	sort.Strings(captureIDs)
+	minLoadCapture := func() model.CaptureID {
+		dst := captureIDs[0]
+		for _, captureID := range captureIDs {
+			if loads[captureID] < loads[dst] {
+				dst = captureID
+			}
+		}
+		return dst
+	}
+
+	var moveTables []replication.MoveTable
+	for _, rep := range misplaced {
+		if len(moveTables) >= maxTaskLimit {
+			return moveTables
+		}
+		dst := minLoadCapture()
+		loads[dst] += rep.Load()
+		totalLoad += rep.Load()
+		moveTables = append(moveTables, replication.MoveTable{
+			Span:        rep.Span,
+			DestCapture: dst,
+		})
+	}
+
+	upperLimit := float64(totalLoad) / float64(len(captures)) * (1 + balanceLoadTolerance)
+	for len(moveTables) < maxTaskLimit {
+		src, dst := captureIDs[0], minLoadCapture()
+		for _, captureID := range captureIDs {
+			if loads[captureID] > loads[src] {
+				src = captureID
+			}
+		}
+		if float64(loads[src]) <= upperLimit {
+			break
+		}
+		// Move the span that makes the loads of the two captures closest,
+		// and never make the destination more loaded than the source, so
+		// the span will not be moved back.
+		diff := loads[src] - loads[dst]
+		victim := -1
+		for i, rep := range spansPerCapture[src] {
+			load := rep.Load()
+			if loads[dst]+load >= loads[src] {
+				continue
+			}
+			if victim == -1 || absDiff(2*load, diff) < absDiff(2*spansPerCapture[src][victim].Load(), diff) {
+				victim = i
+			}
+		}
+		if victim == -1 {
+			break
+		}
+		rep := spansPerCapture[src][victim]
+		spansPerCapture[src] = append(spansPerCapture[src][:victim], spansPerCapture[src][victim+1:]...)
+		loads[src] -= rep.Load()
+		loads[dst] += rep.Load()
+		moveTables = append(moveTables, replication.MoveTable{
+			Span:        rep.Span,
+			DestCapture: dst,
+		})
+		log.Info("schedulerv3: move span to balance load",
+			zap.String("namespace", rep.Changefeed.Namespace),
+			zap.String("changefeed", rep.Changefeed.ID),
+			zap.String("span", rep.Span.String()),
+			zap.Uint64("load", rep.Load()),
+			zap.String("from", src),
+			zap.String("to", dst))
+	}
+	return moveTables
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
 	"github.com/pingcap/tiflow/pkg/spanz"
@@ -27,7 +28,7 @@ import (
 func TestSchedulerBalanceCaptureOnline(t *testing.T) {
 	t.Parallel()
 
-	sched := newBalanceScheduler(time.Duration(0), 3)
+	sched := newBalanceScheduler(time.Duration(0), 3, placement{})
 	sched.random = nil
 
 	// New capture "b" online
@@ -75,7 +76,7 @@ func TestSchedulerBalanceCaptureOnline(t *testing.T) {
 func TestSchedulerBalanceTaskLimit(t *testing.T) {
 	t.Parallel()
 
-	sched := newBalanceScheduler(time.Duration(0), 2)
+	sched := newBalanceScheduler(time.Duration(0), 2, placement{})
 	sched.random = nil
 
 	// New capture "b" online
@@ -90,7 +91,125 @@ func TestSchedulerBalanceTaskLimit(t *testing.T) {
 	tasks := sched.Schedule(0, currentTables, captures, replications)
 	require.Len(t, tasks, 2)
 
-	sched = newBalanceScheduler(time.Duration(0), 1)
+	sched = newBalanceScheduler(time.Duration(0), 1, placement{})
 	tasks = sched.Schedule(0, currentTables, captures, replications)
 	require.Len(t, tasks, 1)
 }
+
+func newLoadTestReplicationSets(
+	primaries map[model.TableID]model.CaptureID, regionCounts map[model.TableID]uint64,
+) *spanz.BtreeMap[*replication.ReplicationSet] {
+	replications := spanz.NewBtreeMap[*replication.ReplicationSet]()
+	for tableID, primary := range primaries {
+		span := tablepb.Span{TableID: tableID}
+		replications.ReplaceOrInsert(span, &replication.ReplicationSet{
+			Span:    span,
+			State:   replication.ReplicationSetStateReplicating,
+			Primary: primary,
+			Stats:   tablepb.Stats{RegionCount: regionCounts[tableID], CurrentTs: 1},
+		})
+	}
+	return replications
+}
+
+func TestSchedulerBalanceByLoad(t *testing.T) {
+	t.Parallel()
+
+	sched := newBalanceScheduler(time.Duration(0), 3, placement{})
+	sched.random = nil
+
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	// The tables are balanced by count, but "a" has all the hot tables.
+	replications := newLoadTestReplicationSets(
+		map[model.TableID]model.CaptureID{1: "a", 2: "a", 3: "b", 4: "b"},
+		map[model.TableID]uint64{1: 99, 2: 49})
+	tasks := sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, model.TableID(2), tasks[0].MoveTable.Span.TableID)
+	require.Equal(t, "b", tasks[0].MoveTable.DestCapture)
+
+	// The loads are within the tolerance.
+	replications = newLoadTestReplicationSets(
+		map[model.TableID]model.CaptureID{1: "a", 2: "b", 3: "b"},
+		map[model.TableID]uint64{1: 99, 2: 49, 3: 39})
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Moving the only hot table does not make the loads more balanced.
+	replications = newLoadTestReplicationSets(
+		map[model.TableID]model.CaptureID{1: "a", 2: "b"},
+		map[model.TableID]uint64{1: 99})
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Skip balance if some tables are being moved.
+	replications = newLoadTestReplicationSets(
+		map[model.TableID]model.CaptureID{1: "a", 2: "a", 3: "b"},
+		map[model.TableID]uint64{1: 99, 2: 49})
+	rep, _ := replications.Get(tablepb.Span{TableID: 3})
+	rep.State = replication.ReplicationSetStatePrepare
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestSchedulerBalanceByLoadNewCapture(t *testing.T) {
+	t.Parallel()
+
+	sched := newBalanceScheduler(time.Duration(0), 10, placement{})
+	sched.random = nil
+
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}, "c": {}}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4, 5, 6})
+	primaries := make(map[model.TableID]model.CaptureID)
+	regionCounts := make(map[model.TableID]uint64)
+	for _, span := range currentTables {
+		primaries[span.TableID] = "a"
+		regionCounts[span.TableID] = 9
+	}
+	replications := newLoadTestReplicationSets(primaries, regionCounts)
+	tasks := sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 4)
+	dests := make(map[model.CaptureID]int)
+	for _, task := range tasks {
+		dests[task.MoveTable.DestCapture]++
+	}
+	require.Equal(t, map[model.CaptureID]int{"b": 2, "c": 2}, dests)
+}
+
+func TestSchedulerBalancePlacement(t *testing.T) {
+	t.Parallel()
+
+	sched := newBalanceScheduler(time.Duration(0), 10,
+		placement{required: map[string]string{"zone": "z1"}})
+	sched.random = nil
+
+	// Balance by table count before stats are collected.
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "c"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "d"},
+	})
+	tasks := sched.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		require.Contains(t, []model.TableID{3, 4}, task.MoveTable.Span.TableID)
+		require.Contains(t, []model.CaptureID{"a", "b"}, task.MoveTable.DestCapture)
+	}
+
+	// Balance by load.
+	replications = newLoadTestReplicationSets(
+		map[model.TableID]model.CaptureID{1: "a", 2: "b", 3: "c"},
+		map[model.TableID]uint64{1: 9})
+	tasks = sched.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, model.TableID(3), tasks[0].MoveTable.Span.TableID)
+	require.Equal(t, "b", tasks[0].MoveTable.DestCapture)
+
+	// No capture has the required labels.
+	sched.placement = placement{required: map[string]string{"zone": "z3"}}
+	tasks = sched.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 0)
+}
@@ -32,15 +32,20 @@ var _ scheduler = &basicScheduler{}
 // 1. Initial table dispatch.
 // 2. DDL CREATE/DROP/TRUNCATE TABLE
 // 3. Capture offline.
+// 4. Table spans are split or merged.
 type basicScheduler struct {
 	batchSize    int
 	changefeedID model.ChangeFeedID
+	placement    placement
 }
 
-func newBasicScheduler(batchSize int, changefeed model.ChangeFeedID) *basicScheduler {
+func newBasicScheduler(
+	batchSize int, changefeed model.ChangeFeedID, placement placement,
+) *basicScheduler {
 	return &basicScheduler{
 		batchSize:    batchSize,
 		changefeedID: changefeed,
+		placement:    placement,
 	}
 }
 
@@ -64,10 +69,14 @@ func (b *basicScheduler) Schedule(
 		}
 		rep, ok := replications.Get(span)
 		if !ok {
-			newSpans = append(newSpans, span)
 			// The table ID is not in the replication means the two sets are
 			// not identical.
 			tablesAllFind = false
+			if overlapReplications(span, replications) {
+				// The span replaces replicated spans, see resplit tables below.
+				continue
+			}
+			newSpans = append(newSpans, span)
 			continue
 		}
 		if rep.State == replication.ReplicationSetStateAbsent {
@@ -77,28 +86,8 @@ func (b *basicScheduler) Schedule(
 
 	// Build add table tasks.
 	if len(newSpans) > 0 {
-		captureIDs := make([]model.CaptureID, 0, len(captures))
-		for captureID, status := range captures {
-			if status.State == member.CaptureStateStopping {
-				log.Warn("schedulerv3: capture is stopping, "+
-					"skip the capture when add new table",
-					zap.String("namespace", b.changefeedID.Namespace),
-					zap.String("changefeed", b.changefeedID.ID),
-					zap.Any("captureStatus", status))
-				continue
-			}
-			captureIDs = append(captureIDs, captureID)
-		}
-
+		captureIDs := b.captureIDsForAdd(captures)
 		if len(captureIDs) == 0 {
-			// this should never happen, if no capture can be found
-			// the changefeed cannot make progress
-			// for a cluster with n captures, n should be at least 2
-			// only n - 1 captures can be in the `stopping` at the same time.
-			log.Warn("schedulerv3: cannot found capture when add new table",
-				zap.String("namespace", b.changefeedID.Namespace),
-				zap.String("changefeed", b.changefeedID.ID),
-				zap.Any("allCaptureStatus", captures))
 			return tasks
 		}
 		log.Info("schedulerv3: burst add table",
@@ -110,7 +99,7 @@ func (b *basicScheduler) Schedule(
 			tasks, newBurstAddTables(checkpointTs, newSpans, captureIDs))
 	}
 
-	// Build remove table tasks.
+	// Build resplit and remove table tasks.
 	// For most of the time, remove tables are unlikely to happen.
 	//
 	// Fast path for check whether two sets are identical:
@@ -119,17 +108,42 @@ func (b *basicScheduler) Schedule(
 	if !tablesLenEqual || !tablesAllFind {
 		// The two sets are not identical. We need to find removed tables.
 		intersectionTable := spanz.NewBtreeMap[struct{}]()
+		// Spans that replace the overlapped replicated spans, grouped by table.
+		resplitSpans := make(map[model.TableID][]tablepb.Span)
+		resplitTableIDs := make([]model.TableID, 0)
 		for _, span := range currentSpans {
 			_, ok := replications.Get(span)
 			if !ok {
+				if overlapReplications(span, replications) {
+					if _, ok := resplitSpans[span.TableID]; !ok {
+						resplitTableIDs = append(resplitTableIDs, span.TableID)
+					}
+					resplitSpans[span.TableID] = append(resplitSpans[span.TableID], span)
+				}
 				continue
 			}
 			intersectionTable.ReplaceOrInsert(span, struct{}{})
 		}
+		if len(resplitTableIDs) > 0 {
+			captureIDs := b.captureIDsForAdd(captures)
+			if len(captureIDs) == 0 {
+				return tasks
+			}
+			for _, tableID := range resplitTableIDs {
+				task := b.newResplitTable(
+					tableID, resplitSpans[tableID], captureIDs, replications)
+				if task != nil {
+					tasks = append(tasks, task)
+				}
+			}
+		}
+
 		rmSpans := make([]tablepb.Span, 0)
 		replications.Ascend(func(span tablepb.Span, value *replication.ReplicationSet) bool {
 			ok := intersectionTable.Has(span)
-			if !ok {
+			if !ok && !overlapSpans(span, resplitSpans[span.TableID]) {
+				// Spans overlapped by the resplit spans are removed after
+				// the resplit spans are prepared.
 				rmSpans = append(rmSpans, span)
 			}
 			return true
@@ -146,6 +160,101 @@ func (b *basicScheduler) Schedule(
 	return tasks
 }
 
+// captureIDsForAdd returns captures that new tables can be added to.
+func (b *basicScheduler) captureIDsForAdd(
+	captures map[model.CaptureID]*member.CaptureStatus,
+) []model.CaptureID {
+	// Stopping captures are skipped when add new table.
+	captureIDs := make([]model.CaptureID, 0, len(captures))
+	for captureID := range b.placement.candidates(captures) {
+		captureIDs = append(captureIDs, captureID)
+	}
+
+	if len(captureIDs) == 0 {
+		// this should never happen, if no capture can be found
+		// the changefeed cannot make progress
+		// for a cluster with n captures, n should be at least 2
+		// only n - 1 captures can be in the `stopping` at the same time.
+		// Or there is no capture having the required labels.
+		log.Warn("schedulerv3: cannot found capture when add new table",
+			zap.String("namespace", b.changefeedID.Namespace),
+			zap.String("changefeed", b.changefeedID.ID),
+			zap.Any("requiredLabels", b.placement.required),
+			zap.Any("preferredLabels", b.placement.preferred),
+			zap.Any("allCaptureStatus", captures))
+	}
+	return captureIDs
+}
+
+// newResplitTable replaces the replicated spans of a table with the new
+// spans. It returns nil if any replicated span is being scheduled.
+func (b *basicScheduler) newResplitTable(
+	tableID model.TableID, newSpans []tablepb.Span, captureIDs []model.CaptureID,
+	replications *spanz.BtreeMap[*replication.ReplicationSet],
+) *replication.ScheduleTask {
+	ready := true
+	start, end := spanz.TableIDToComparableRange(tableID)
+	replications.AscendRange(start, end,
+		func(span tablepb.Span, rep *replication.ReplicationSet) bool {
+			if !overlapSpans(span, newSpans) {
+				return true
+			}
+			if rep.State != replication.ReplicationSetStateReplicating ||
+				rep.IsReplaced() {
+				ready = false
+				return false
+			}
+			return true
+		})
+	if !ready {
+		return nil
+	}
+
+	idx := 0
+	ids := make([]model.CaptureID, 0, len(newSpans))
+	for range newSpans {
+		ids = append(ids, captureIDs[idx])
+		idx++
+		if idx >= len(captureIDs) {
+			idx = 0
+		}
+	}
+	log.Info("schedulerv3: resplit table",
+		zap.String("namespace", b.changefeedID.Namespace),
+		zap.String("changefeed", b.changefeedID.ID),
+		zap.Int64("tableID", tableID),
+		zap.Int("spanCount", len(newSpans)))
+	return &replication.ScheduleTask{ResplitTable: &replication.ResplitTable{
+		TableID:    tableID,
+		Spans:      newSpans,
+		CaptureIDs: ids,
+	}}
+}
+
+// overlapReplications returns true if the span overlaps any replicated span.
+func overlapReplications(
+	span tablepb.Span, replications *spanz.BtreeMap[*replication.ReplicationSet],
+) bool {
+	overlap := false
+	start, end := spanz.TableIDToComparableRange(span.TableID)
+	replications.AscendRange(start, end,
+		func(s tablepb.Span, _ *replication.ReplicationSet) bool {
+			overlap = spanz.Overlap(span, s)
+			return !overlap
+		})
+	return overlap
+}
+
+// overlapSpans returns true if the span overlaps any of the spans.
+func overlapSpans(span tablepb.Span, spans []tablepb.Span) bool {
+	for i := range spans {
+		if spanz.Overlap(span, spans[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // newBurstAddTables add each new table to captures in a round-robin way.
 func newBurstAddTables(
 	checkpointTs model.Ts, newSpans []tablepb.Span, captureIDs []model.CaptureID,
@@ -42,7 +42,7 @@ func TestSchedulerBasic(t *testing.T) {
 	// Initial table dispatch.
 	// AddTable only
 	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
-	b := newBasicScheduler(2, model.ChangeFeedID{})
+	b := newBasicScheduler(2, model.ChangeFeedID{}, placement{})
 
 	// one capture stopping, another one is initialized
 	captures["a"].State = member.CaptureStateStopping
@@ -171,6 +171,47 @@ func TestSchedulerBasic(t *testing.T) {
 	require.Equal(t, tasks[0].BurstBalance.RemoveTables[0].Span.TableID, model.TableID(5))
 }
 
+func TestSchedulerBasicResplitTable(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}}
+	tableSpan := spanz.TableIDToComparableSpan(1)
+	mid := append(append([]byte{}, tableSpan.StartKey...), 1)
+	splitSpans := []tablepb.Span{
+		{TableID: 1, StartKey: tableSpan.StartKey, EndKey: mid},
+		{TableID: 1, StartKey: mid, EndKey: tableSpan.EndKey},
+	}
+	currentSpans := append([]tablepb.Span{}, splitSpans...)
+	currentSpans = append(currentSpans, spanz.TableIDToComparableSpan(2))
+	rep := &replication.ReplicationSet{
+		Span: tableSpan, State: replication.ReplicationSetStateCommit,
+		Captures: map[string]replication.Role{"a": replication.RoleSecondary},
+	}
+	replications := spanz.NewBtreeMap[*replication.ReplicationSet]()
+	replications.ReplaceOrInsert(tableSpan, rep)
+	b := newBasicScheduler(10, model.ChangeFeedID{}, placement{})
+
+	// Do not replace the table span being scheduled, and do not remove it.
+	tasks := b.Schedule(0, currentSpans, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, []replication.AddTable{{
+		Span: spanz.TableIDToComparableSpan(2), CaptureID: "a",
+	}}, tasks[0].BurstBalance.AddTables)
+
+	// Replace the table span with split spans.
+	rep.State = replication.ReplicationSetStateReplicating
+	rep.Primary = "a"
+	rep.Captures["a"] = replication.RolePrimary
+	tasks = b.Schedule(0, currentSpans, captures, replications)
+	require.Len(t, tasks, 2)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 1)
+	require.Equal(t, &replication.ResplitTable{
+		TableID:    1,
+		Spans:      splitSpans,
+		CaptureIDs: []model.CaptureID{"a", "a"},
+	}, tasks[1].ResplitTable)
+}
+
 func TestSchedulerPriority(t *testing.T) {
 	t.Parallel()
 
@@ -224,7 +265,7 @@ func BenchmarkSchedulerBasicAddTables(b *testing.B) {
 		}
 		replications = mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
 		name = fmt.Sprintf("AddTable %d", total)
-		sched = newBasicScheduler(50, model.ChangeFeedID{})
+		sched = newBasicScheduler(50, model.ChangeFeedID{}, placement{})
 		return name, currentTables, captures, replications, sched
 	})
 }
@@ -251,7 +292,7 @@ func BenchmarkSchedulerBasicRemoveTables(b *testing.B) {
 				})
 		}
 		name = fmt.Sprintf("RemoveTable %d", total)
-		sched = newBasicScheduler(50, model.ChangeFeedID{})
+		sched = newBasicScheduler(50, model.ChangeFeedID{}, placement{})
 		return name, currentTables, captures, replications, sched
 	})
 }
@@ -281,7 +322,42 @@ func BenchmarkSchedulerBasicAddRemoveTables(b *testing.B) {
 				})
 		}
 		name = fmt.Sprintf("AddRemoveTable %d", total)
-		sched = newBasicScheduler(50, model.ChangeFeedID{})
+		sched = newBasicScheduler(50, model.ChangeFeedID{}, placement{})
 		return name, currentTables, captures, replications, sched
 	})
 }
+
+func TestSchedulerBasicPlacement(t *testing.T) {
+	t.Parallel()
+
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{})
+	b := newBasicScheduler(10, model.ChangeFeedID{},
+		placement{required: map[string]string{"zone": "z2"}})
+	tasks := b.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 3)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		require.Equal(t, "c", add.CaptureID)
+	}
+
+	// No capture has the required labels.
+	b.placement = placement{required: map[string]string{"zone": "z3"}}
+	tasks = b.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 0)
+
+	// Tables are added to the captures having the required labels if the
+	// only preferred capture is stopping.
+	captures := newLabeledCaptures()
+	captures["c"].State = member.CaptureStateStopping
+	b.placement = placement{
+		required:  map[string]string{"host": "h1"},
+		preferred: map[string]string{"zone": "z2"},
+	}
+	tasks = b.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 3)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		require.Equal(t, "a", add.CaptureID)
+	}
+}
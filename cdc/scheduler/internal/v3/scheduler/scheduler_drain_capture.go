@@ -37,15 +37,17 @@ type drainCaptureScheduler struct {
 
 	changefeedID       model.ChangeFeedID
 	maxTaskConcurrency int
+	placement          placement
 }
 
 func newDrainCaptureScheduler(
-	concurrency int, changefeed model.ChangeFeedID,
+	concurrency int, changefeed model.ChangeFeedID, placement placement,
 ) *drainCaptureScheduler {
 	return &drainCaptureScheduler{
 		target:             captureIDNotDraining,
 		maxTaskConcurrency: concurrency,
 		changefeedID:       changefeed,
+		placement:          placement,
 	}
 }
 
@@ -105,11 +107,10 @@ func (d *drainCaptureScheduler) Schedule(
 	}
 
 	// Currently, the workload is the number of tables in a capture.
+	// Tables are only moved to the captures satisfying the placement.
 	captureWorkload := make(map[model.CaptureID]int)
-	for id := range captures {
-		if id != d.target {
-			captureWorkload[id] = 0
-		}
+	for id := range d.placement.candidates(captures, d.target) {
+		captureWorkload[id] = 0
 	}
 
 	// this may happen when inject the target, there is at least 2 alive captures
@@ -148,7 +149,7 @@ func (d *drainCaptureScheduler) Schedule(
 		}
 
 		// only calculate workload of other captures not the drain target.
-		if rep.Primary != d.target {
+		if _, ok := captureWorkload[rep.Primary]; ok {
 			captureWorkload[rep.Primary]++
 		}
 		return true
@@ -27,7 +27,7 @@ import (
 func TestDrainCapture(t *testing.T) {
 	t.Parallel()
 
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{})
 	require.Equal(t, "drain-capture-scheduler", scheduler.Name())
 
 	var checkpointTs model.Ts
@@ -122,7 +122,7 @@ func TestDrainCapture(t *testing.T) {
 	require.Equal(t, "a", scheduler.target)
 	require.Len(t, tasks, 3)
 
-	scheduler = newDrainCaptureScheduler(1, model.ChangeFeedID{})
+	scheduler = newDrainCaptureScheduler(1, model.ChangeFeedID{}, placement{})
 	require.True(t, scheduler.setTarget("a"))
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Equal(t, "a", scheduler.target)
@@ -136,7 +136,7 @@ func TestDrainStoppingCapture(t *testing.T) {
 	captures := make(map[model.CaptureID]*member.CaptureStatus)
 	currentTables := make([]tablepb.Span, 0)
 	replications := mapToSpanMap(make(map[model.TableID]*replication.ReplicationSet))
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{})
 
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Empty(t, tasks)
@@ -167,7 +167,7 @@ func TestDrainSkipOwner(t *testing.T) {
 		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		2: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
 	})
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{})
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 0)
 	require.EqualValues(t, captureIDNotDraining, scheduler.getTarget())
@@ -186,7 +186,7 @@ func TestDrainImbalanceCluster(t *testing.T) {
 		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 	})
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{})
 	scheduler.setTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 2)
@@ -209,7 +209,7 @@ func TestDrainEvenlyDistributedTables(t *testing.T) {
 		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		6: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
 	})
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{})
 	scheduler.setTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 3)
@@ -220,3 +220,56 @@ func TestDrainEvenlyDistributedTables(t *testing.T) {
 	require.Equal(t, 1, taskMap["b"])
 	require.Equal(t, 2, taskMap["c"])
 }
+
+func TestDrainCapturePlacement(t *testing.T) {
+	t.Parallel()
+
+	captures := newLabeledCaptures()
+	captures["a"].State = member.CaptureStateStopping
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "d"},
+	})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{},
+		placement{required: map[string]string{"zone": "z1"}})
+	tasks := scheduler.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		require.Equal(t, "b", task.MoveTable.DestCapture)
+	}
+
+	// Ignore the drain target if no other capture has the required labels.
+	delete(captures, "b")
+	scheduler = newDrainCaptureScheduler(10, model.ChangeFeedID{},
+		placement{required: map[string]string{"zone": "z1"}})
+	tasks = scheduler.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	require.Equal(t, captureIDNotDraining, scheduler.getTarget())
+}
+
+func TestDrainCaptureOnlyPreferredCapture(t *testing.T) {
+	t.Parallel()
+
+	captures := newLabeledCaptures()
+	captures["a"].State = member.CaptureStateStopping
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+	})
+	// Draining the only preferred capture moves tables to the captures
+	// having the required labels.
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, placement{
+		required:  map[string]string{"zone": "z1"},
+		preferred: map[string]string{"host": "h1"},
+	})
+	tasks := scheduler.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		require.Equal(t, "b", task.MoveTable.DestCapture)
+	}
+	require.Equal(t, "a", scheduler.getTarget())
+}
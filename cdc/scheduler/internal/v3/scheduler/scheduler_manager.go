@@ -50,14 +50,15 @@ func NewSchedulerManager(
 		}]int),
 	}
 
+	placement := newPlacement(cfg.ChangefeedSettings)
 	sm.schedulers[schedulerPriorityBasic] = newBasicScheduler(
-		cfg.AddTableBatchSize, changefeedID)
+		cfg.AddTableBatchSize, changefeedID, placement)
 	sm.schedulers[schedulerPriorityDrainCapture] = newDrainCaptureScheduler(
-		cfg.MaxTaskConcurrency, changefeedID)
+		cfg.MaxTaskConcurrency, changefeedID, placement)
 	sm.schedulers[schedulerPriorityBalance] = newBalanceScheduler(
-		time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency)
-	sm.schedulers[schedulerPriorityMoveTable] = newMoveTableScheduler(changefeedID)
-	sm.schedulers[schedulerPriorityRebalance] = newRebalanceScheduler(changefeedID)
+		time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency, placement)
+	sm.schedulers[schedulerPriorityMoveTable] = newMoveTableScheduler(changefeedID, placement)
+	sm.schedulers[schedulerPriorityRebalance] = newRebalanceScheduler(changefeedID, placement)
 
 	return sm
 }
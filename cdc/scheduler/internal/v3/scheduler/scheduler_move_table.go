@@ -32,12 +32,16 @@ type moveTableScheduler struct {
 	tasks *spanz.BtreeMap[*replication.ScheduleTask]
 
 	changefeedID model.ChangeFeedID
+	placement    placement
 }
 
-func newMoveTableScheduler(changefeed model.ChangeFeedID) *moveTableScheduler {
+func newMoveTableScheduler(
+	changefeed model.ChangeFeedID, placement placement,
+) *moveTableScheduler {
 	return &moveTableScheduler{
 		tasks:        spanz.NewBtreeMap[*replication.ScheduleTask](),
 		changefeedID: changefeed,
+		placement:    placement,
 	}
 }
 
@@ -88,6 +92,7 @@ func (m *moveTableScheduler) Schedule(
 		return result
 	}
 
+	candidates := m.placement.candidates(captures)
 	allSpans := spanz.NewSet()
 	for _, span := range currentSpans {
 		allSpans.Add(span)
@@ -129,6 +134,18 @@ func (m *moveTableScheduler) Schedule(
 			return true
 		}
 
+		if _, ok := candidates[task.MoveTable.DestCapture]; !ok {
+			log.Warn("schedulerv3: move table ignored, "+
+				"target capture does not satisfy the placement labels",
+				zap.String("namespace", m.changefeedID.Namespace),
+				zap.String("changefeed", m.changefeedID.ID),
+				zap.String("span", span.String()),
+				zap.String("captureID", task.MoveTable.DestCapture),
+				zap.Any("labels", status.Labels))
+			toBeDeleted = append(toBeDeleted, span)
+			return true
+		}
+
 		rep, ok := replications.Get(span)
 		if !ok {
 			log.Warn("schedulerv3: move table ignored, table not found in the replication set",
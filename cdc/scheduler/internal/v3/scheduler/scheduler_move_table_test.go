@@ -39,7 +39,7 @@ func TestSchedulerMoveTable(t *testing.T) {
 		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 	})
 
-	scheduler := newMoveTableScheduler(model.ChangeFeedID{})
+	scheduler := newMoveTableScheduler(model.ChangeFeedID{}, placement{})
 	require.Equal(t, "move-table-scheduler", scheduler.Name())
 
 	tasks := scheduler.Schedule(
@@ -87,3 +87,29 @@ func TestSchedulerMoveTable(t *testing.T) {
 	require.Len(t, tasks, 0)
 	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 1}))
 }
+
+func TestSchedulerMoveTablePlacement(t *testing.T) {
+	t.Parallel()
+
+	captures := newLabeledCaptures()
+	for _, capture := range captures {
+		capture.State = member.CaptureStateInitialized
+	}
+	currentTables := spanz.ArrayToSpan([]model.TableID{1})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+	})
+	scheduler := newMoveTableScheduler(model.ChangeFeedID{},
+		placement{required: map[string]string{"zone": "z1"}})
+
+	// The target capture does not have the required labels.
+	scheduler.addTask(tablepb.Span{TableID: 1}, "c")
+	tasks := scheduler.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	require.False(t, scheduler.tasks.Has(tablepb.Span{TableID: 1}))
+
+	scheduler.addTask(tablepb.Span{TableID: 1}, "b")
+	tasks = scheduler.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, "b", tasks[0].MoveTable.DestCapture)
+}
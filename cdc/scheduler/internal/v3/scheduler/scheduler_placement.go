@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// placement constrains the captures that replicate the tables of a changefeed
+// by the labels of captures. The zero value places tables on any capture.
+type placement struct {
+	// required are the labels a capture must have to replicate tables.
+	required map[string]string
+	// preferred are the labels of the captures that replicate tables if
+	// there are any such captures satisfying the required labels.
+	preferred map[string]string
+}
+
+func newPlacement(cfg *config.ChangefeedSchedulerConfig) placement {
+	if cfg == nil {
+		return placement{}
+	}
+	return placement{
+		required:  cfg.RequiredLabels,
+		preferred: cfg.PreferredLabels,
+	}
+}
+
+// candidates returns the captures that should replicate tables, which are
+// the captures having the required labels and, if there are any, the
+// preferred labels. Stopping captures and the excluded captures never
+// replicate tables, so they are filtered out before choosing the preferred
+// captures.
+func (p placement) candidates(
+	captures map[model.CaptureID]*member.CaptureStatus, excluded ...model.CaptureID,
+) map[model.CaptureID]*member.CaptureStatus {
+	allowed := make(map[model.CaptureID]*member.CaptureStatus, len(captures))
+	preferred := make(map[model.CaptureID]*member.CaptureStatus, len(captures))
+	for id, capture := range captures {
+		if capture.State == member.CaptureStateStopping || isExcluded(id, excluded) {
+			continue
+		}
+		if !matchLabels(capture.Labels, p.required) {
+			continue
+		}
+		allowed[id] = capture
+		if matchLabels(capture.Labels, p.preferred) {
+			preferred[id] = capture
+		}
+	}
+	if len(preferred) != 0 {
+		return preferred
+	}
+	return allowed
+}
+
+func isExcluded(id model.CaptureID, excluded []model.CaptureID) bool {
+	for _, e := range excluded {
+		if id == e {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabels returns true if labels contain all the expected labels.
+func matchLabels(labels, expected map[string]string) bool {
+	for key, value := range expected {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newLabeledCaptures() map[model.CaptureID]*member.CaptureStatus {
+	return map[model.CaptureID]*member.CaptureStatus{
+		"a": {Labels: map[string]string{"zone": "z1", "host": "h1"}},
+		"b": {Labels: map[string]string{"zone": "z1", "host": "h2"}},
+		"c": {Labels: map[string]string{"zone": "z2", "host": "h1"}},
+		"d": {},
+	}
+}
+
+func candidateIDs(captures map[model.CaptureID]*member.CaptureStatus) []model.CaptureID {
+	ids := make([]model.CaptureID, 0, len(captures))
+	for id := range captures {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestPlacementCandidates(t *testing.T) {
+	t.Parallel()
+
+	captures := newLabeledCaptures()
+	require.Len(t, newPlacement(nil).candidates(captures), 4)
+	require.Len(t, newPlacement(&config.ChangefeedSchedulerConfig{}).candidates(captures), 4)
+
+	p := newPlacement(&config.ChangefeedSchedulerConfig{
+		RequiredLabels: map[string]string{"zone": "z1"},
+	})
+	require.ElementsMatch(t, []model.CaptureID{"a", "b"}, candidateIDs(p.candidates(captures)))
+
+	p.preferred = map[string]string{"host": "h1"}
+	require.ElementsMatch(t, []model.CaptureID{"a"}, candidateIDs(p.candidates(captures)))
+
+	// Fall back to the captures having the required labels.
+	p.preferred = map[string]string{"host": "h3"}
+	require.ElementsMatch(t, []model.CaptureID{"a", "b"}, candidateIDs(p.candidates(captures)))
+
+	// Preferred labels without required labels.
+	p = placement{preferred: map[string]string{"host": "h1"}}
+	require.ElementsMatch(t, []model.CaptureID{"a", "c"}, candidateIDs(p.candidates(captures)))
+
+	p = placement{required: map[string]string{"zone": "z3"}}
+	require.Empty(t, p.candidates(captures))
+
+	// Fall back to the required labels if the only preferred capture is
+	// stopping or excluded.
+	p = placement{
+		required:  map[string]string{"zone": "z1"},
+		preferred: map[string]string{"host": "h1"},
+	}
+	require.ElementsMatch(t, []model.CaptureID{"b"}, candidateIDs(p.candidates(captures, "a")))
+	captures["a"].State = member.CaptureStateStopping
+	require.ElementsMatch(t, []model.CaptureID{"b"}, candidateIDs(p.candidates(captures)))
+	require.Empty(t, p.candidates(captures, "b"))
+	require.Len(t, placement{}.candidates(captures), 3)
+}
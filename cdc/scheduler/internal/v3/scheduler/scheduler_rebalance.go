@@ -36,13 +36,17 @@ type rebalanceScheduler struct {
 	random    *rand.Rand
 
 	changefeedID model.ChangeFeedID
+	placement    placement
 }
 
-func newRebalanceScheduler(changefeed model.ChangeFeedID) *rebalanceScheduler {
+func newRebalanceScheduler(
+	changefeed model.ChangeFeedID, placement placement,
+) *rebalanceScheduler {
 	return &rebalanceScheduler{
 		rebalance:    0,
 		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
 		changefeedID: changefeed,
+		placement:    placement,
 	}
 }
 
@@ -90,8 +94,12 @@ func (r *rebalanceScheduler) Schedule(
 		}
 	}
 
+	candidates := r.placement.candidates(captures)
+	if len(candidates) == 0 {
+		return nil
+	}
 	unlimited := math.MaxInt
-	tasks := newBalanceMoveTables(r.random, captures, replications, unlimited, r.changefeedID)
+	tasks := newBalanceMoveTables(r.random, candidates, replications, unlimited, r.changefeedID)
 	if len(tasks) == 0 {
 		return nil
 	}
@@ -119,9 +127,16 @@ func newBalanceMoveTables(
 		tablesPerCapture[captureID] = spanz.NewSet()
 	}
 
+	// Tables replicated by other captures, e.g. captures not satisfying the
+	// placement, are always moved.
+	victims := make([]tablepb.Span, 0)
 	replications.Ascend(func(span tablepb.Span, rep *replication.ReplicationSet) bool {
 		if rep.State == replication.ReplicationSetStateReplicating {
-			tablesPerCapture[rep.Primary].Add(span)
+			if ts, ok := tablesPerCapture[rep.Primary]; ok {
+				ts.Add(span)
+			} else {
+				victims = append(victims, span)
+			}
 		}
 		return true
 	})
@@ -129,7 +144,6 @@ func newBalanceMoveTables(
 	// findVictim return tables which need to be moved
 	upperLimitPerCapture := int(math.Ceil(float64(replications.Len()) / float64(len(captures))))
 
-	victims := make([]tablepb.Span, 0)
 	for _, ts := range tablesPerCapture {
 		spans := ts.Keys()
 		if random != nil {
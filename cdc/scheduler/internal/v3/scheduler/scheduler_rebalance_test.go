@@ -54,7 +54,7 @@ func TestSchedulerRebalance(t *testing.T) {
 		4: {State: replication.ReplicationSetStateAbsent},
 	})
 
-	scheduler := newRebalanceScheduler(model.ChangeFeedID{})
+	scheduler := newRebalanceScheduler(model.ChangeFeedID{}, placement{})
 	require.Equal(t, "rebalance-scheduler", scheduler.Name())
 	// rebalance is not triggered
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
@@ -118,3 +118,27 @@ func TestSchedulerRebalance(t *testing.T) {
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 0)
 }
+
+func TestSchedulerRebalancePlacement(t *testing.T) {
+	t.Parallel()
+
+	currentTables := spanz.ArrayToSpan([]model.TableID{1, 2, 3, 4})
+	replications := mapToSpanMap(map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "c"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "d"},
+	})
+	scheduler := newRebalanceScheduler(model.ChangeFeedID{},
+		placement{required: map[string]string{"zone": "z1"}})
+	scheduler.random = nil
+	atomic.StoreInt32(&scheduler.rebalance, 1)
+	tasks := scheduler.Schedule(0, currentTables, newLabeledCaptures(), replications)
+	require.Len(t, tasks, 1)
+	dests := make(map[model.CaptureID]int)
+	for _, move := range tasks[0].BurstBalance.MoveTables {
+		dests[move.DestCapture]++
+	}
+	// Tables on c and d are moved, and tables are balanced between a and b.
+	require.Equal(t, map[model.CaptureID]int{"b": 2}, dests)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlsink
+
+import (
+	"context"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
+)
+
+// Assert Sink implementation
+var _ Sink = (*ColumnSelectorSink)(nil)
+
+// ColumnSelectorSink is a wrapper of Sink, it removes the columns which are
+// not selected from the table infos before writing them, so the table
+// schemas sent to the downstream are consistent with the rows.
+type ColumnSelectorSink struct {
+	Sink
+	selector *columnselector.Selector
+}
+
+// NewColumnSelectorSink creates a ColumnSelectorSink.
+func NewColumnSelectorSink(sink Sink, selector *columnselector.Selector) *ColumnSelectorSink {
+	return &ColumnSelectorSink{
+		Sink:     sink,
+		selector: selector,
+	}
+}
+
+// WriteDDLEvent selects the columns of the table infos of the DDL event
+// and writes it to the underlying sink.
+func (s *ColumnSelectorSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	return s.Sink.WriteDDLEvent(ctx, s.selector.SelectDDLEvent(ddl))
+}
+
+// WriteCheckpointTs selects the columns of the tables and writes the
+// checkpoint ts to the underlying sink.
+func (s *ColumnSelectorSink) WriteCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	return s.Sink.WriteCheckpointTs(ctx, ts, s.selector.SelectTableInfos(tables))
+}
@@ -25,11 +25,15 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mysql"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/webhook"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	kafkav2 "github.com/pingcap/tiflow/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"github.com/pingcap/tiflow/pkg/sink/router"
 	"github.com/pingcap/tiflow/pkg/util"
 )
 
@@ -44,6 +48,16 @@ func New(
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
+	r, err := router.NewRouter(cfg.CaseSensitive, cfg.Sink.RouteRules)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := columnselector.New(cfg.CaseSensitive, cfg.Sink.ColumnSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	var s ddlsink.Sink
 	scheme := strings.ToLower(sinkURI.Scheme)
 	switch scheme {
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
@@ -51,16 +65,34 @@ func New(
 		if util.GetOrZero(cfg.Sink.EnableKafkaSinkV2) {
 			factoryCreator = kafkav2.NewFactory
 		}
-		return mq.NewKafkaDDLSink(ctx, changefeedID, sinkURI, cfg,
+		s, err = mq.NewKafkaDDLSink(ctx, changefeedID, sinkURI, cfg,
 			factoryCreator, ddlproducer.NewKafkaDDLProducer)
+	case sink.PulsarScheme, sink.PulsarSSLScheme:
+		s, err = mq.NewPulsarDDLSink(ctx, changefeedID, sinkURI, cfg,
+			pulsar.NewFactory, ddlproducer.NewPulsarDDLProducer)
 	case sink.BlackHoleScheme:
-		return blackhole.NewDDLSink(), nil
+		s = blackhole.NewDDLSink()
 	case sink.MySQLSSLScheme, sink.MySQLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
-		return mysql.NewDDLSink(ctx, changefeedID, sinkURI, cfg)
+		s, err = mysql.NewDDLSink(ctx, changefeedID, sinkURI, cfg)
 	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
-		return cloudstorage.NewDDLSink(ctx, changefeedID, sinkURI)
+		s, err = cloudstorage.NewDDLSink(ctx, changefeedID, sinkURI)
+	case sink.HTTPScheme, sink.HTTPSScheme:
+		s, err = webhook.NewDDLSink(ctx, changefeedID, sinkURI, cfg)
 	default:
 		return nil,
 			cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", scheme)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Enabled() {
+		s = ddlsink.NewRouteSink(s, r)
+	}
+	// The column selectors match the upstream tables, so the columns
+	// are selected before the tables are routed.
+	if selector.Enabled() {
+		s = ddlsink.NewColumnSelectorSink(s, selector)
+	}
+	return s, nil
 }
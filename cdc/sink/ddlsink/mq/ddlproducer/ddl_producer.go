@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
 )
 
 // DDLProducer is the interface for DDL message producer.
@@ -38,3 +39,7 @@ type DDLProducer interface {
 // Factory is a function to create a producer.
 type Factory func(ctx context.Context, changefeedID model.ChangeFeedID,
 	factory kafka.Factory) (DDLProducer, error)
+
+// PulsarFactory is a function to create a pulsar producer.
+type PulsarFactory func(ctx context.Context, changefeedID model.ChangeFeedID,
+	factory pulsar.Factory) (DDLProducer, error)
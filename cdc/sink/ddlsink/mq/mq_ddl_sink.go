@@ -145,8 +145,37 @@ func (k *DDLSink) WriteCheckpointTs(ctx context.Context,
 	if msg == nil {
 		return nil
 	}
+	return k.broadcastToActiveTopics(ctx, msg, tables)
+}
+
+// WriteSyncPoint sends the syncpoint event to the MQ system.
+// Like the checkpoint, it is broadcast to all partitions of the active topics,
+// so the consumer of each partition can tell when all tables are consistent.
+func (k *DDLSink) WriteSyncPoint(ctx context.Context,
+	event *model.SyncPointEvent, tables []*model.TableInfo,
+) error {
+	encoder, ok := k.encoderBuilder.Build().(codec.SyncPointEventEncoder)
+	if !ok {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"syncpoint is not supported by the protocol %s", k.protocol.String())
+	}
+	msg, err := encoder.EncodeSyncPointEvent(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("Emit syncpoint",
+		zap.Uint64("primaryTs", event.PrimaryTs),
+		zap.Uint64("secondaryTs", event.SecondaryTs),
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID))
+	return k.broadcastToActiveTopics(ctx, msg, tables)
+}
+
+func (k *DDLSink) broadcastToActiveTopics(ctx context.Context,
+	msg *common.Message, tables []*model.TableInfo,
+) error {
 	// NOTICE: When there are no tables to replicate,
-	// we need to send checkpoint ts to the default topic.
+	// we need to send the message to the default topic.
 	// This will be compatible with the old behavior.
 	if len(tables) == 0 {
 		topic := k.eventRouter.GetDefaultTopic()
@@ -154,8 +183,9 @@ func (k *DDLSink) WriteCheckpointTs(ctx context.Context,
 		if err != nil {
 			return errors.Trace(err)
 		}
-		log.Debug("Emit checkpointTs to default topic",
-			zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
+		log.Debug("Emit message to default topic",
+			zap.String("topic", topic), zap.Uint64("ts", msg.Ts),
+			zap.Any("type", msg.Type))
 		err = k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
 		return errors.Trace(err)
 	}
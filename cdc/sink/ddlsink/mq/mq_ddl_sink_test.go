@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -303,3 +304,51 @@ func TestWriteCheckpointTsWhenCanalJsonTiDBExtensionIsDisable(t *testing.T) {
 	require.Len(t, s.producer.(*ddlproducer.MockDDLProducer).GetAllEvents(),
 		0, "No topic and partition should be broadcast")
 }
+
+func TestWriteSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip" +
+		"&protocol=%s"
+
+	sinkURI, err := url.Parse(fmt.Sprintf(uriTemplate, leader.Addr(), topic, "open-protocol"))
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.EnableSyncPoint = util.AddressOf(true)
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewKafkaDDLSink(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI, replicaConfig,
+		kafka.NewMockFactory,
+		ddlproducer.NewMockDDLProducer)
+	require.NoError(t, err)
+
+	event := &model.SyncPointEvent{PrimaryTs: 417318403368288260, SecondaryTs: 417318403368288261}
+	err = s.WriteSyncPoint(ctx, event, nil)
+	require.NoError(t, err)
+	events := s.producer.(*ddlproducer.MockDDLProducer).GetAllEvents()
+	require.Len(t, events, 3, "All partitions should be broadcast")
+	for _, e := range events {
+		require.Equal(t, model.MessageTypeSyncPoint, e.Type)
+	}
+
+	// the syncpoint is not supported by the maxwell protocol.
+	sinkURI, err = url.Parse(fmt.Sprintf(uriTemplate, leader.Addr(), topic, "maxwell"))
+	require.NoError(t, err)
+	replicaConfig = config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	s, err = NewKafkaDDLSink(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI, replicaConfig,
+		kafka.NewMockFactory,
+		ddlproducer.NewMockDDLProducer)
+	require.NoError(t, err)
+	err = s.WriteSyncPoint(ctx, event, nil)
+	require.ErrorContains(t, err, "ErrSinkInvalidConfig")
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	mm "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulsarWriteDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: "cdc", Table: "person",
+			},
+		},
+		Query: "create table person(id int, name varchar(32), primary key(id))",
+		Type:  mm.ActionCreateTable,
+	}
+
+	for _, tc := range []struct {
+		protocol   string
+		partitions []int32
+	}{
+		// open protocol broadcasts DDL to all partitions.
+		{protocol: "open-protocol", partitions: []int32{0, 1, 2}},
+		// canal-json only sends DDL to partition 0.
+		{protocol: "canal-json", partitions: []int32{0}},
+	} {
+		sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/persistent://public/default/test?protocol=" +
+			tc.protocol)
+		require.Nil(t, err)
+		replicaConfig := config.GetDefaultReplicaConfig()
+		require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+		var factory *pulsar.MockFactory
+		factoryCreator := func(o *pulsar.Options, id model.ChangeFeedID) (pulsar.Factory, error) {
+			f, err := pulsar.NewMockFactory(o, id)
+			factory = f.(*pulsar.MockFactory)
+			return f, err
+		}
+		s, err := NewPulsarDDLSink(ctx, changefeedID, sinkURI, replicaConfig,
+			factoryCreator, ddlproducer.NewPulsarDDLProducer)
+		require.Nil(t, err)
+
+		err = s.WriteDDLEvent(ctx, ddl)
+		require.Nil(t, err)
+		require.Len(t, factory.GetAllMessages(), len(tc.partitions))
+		for _, partition := range tc.partitions {
+			require.Len(t, factory.GetMessages(pulsar.MockPartitionTopic(
+				"persistent://public/default/test", partition)), 1)
+		}
+
+		s.Close()
+		_, err = factory.Producer("persistent://public/default/test")
+		require.Error(t, err, "the client should be closed with the sink")
+	}
+}
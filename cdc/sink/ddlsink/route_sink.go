@@ -0,0 +1,56 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddlsink
+
+import (
+	"context"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/router"
+)
+
+// Assert Sink implementation
+var _ Sink = (*RouteSink)(nil)
+
+// RouteSink is a wrapper of Sink, it routes the DDL events and
+// the tables to the downstream schemas and tables before writing them.
+type RouteSink struct {
+	Sink
+	router *router.Router
+}
+
+// NewRouteSink creates a RouteSink.
+func NewRouteSink(sink Sink, router *router.Router) *RouteSink {
+	return &RouteSink{
+		Sink:   sink,
+		router: router,
+	}
+}
+
+// WriteDDLEvent routes the DDL event and writes it to the underlying sink.
+func (s *RouteSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	routed, err := s.router.RouteDDLEvent(ddl)
+	if err != nil {
+		return err
+	}
+	return s.Sink.WriteDDLEvent(ctx, routed)
+}
+
+// WriteCheckpointTs routes the tables and writes the checkpoint ts
+// to the underlying sink.
+func (s *RouteSink) WriteCheckpointTs(
+	ctx context.Context, ts uint64, tables []*model.TableInfo,
+) error {
+	return s.Sink.WriteCheckpointTs(ctx, ts, s.router.RouteTableInfos(tables))
+}
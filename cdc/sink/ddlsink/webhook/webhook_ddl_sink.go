@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/builder"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Assert Sink implementation
+var _ ddlsink.Sink = (*DDLSink)(nil)
+
+// DDLSink is a sink that posts DDL events to the webhook endpoint.
+type DDLSink struct {
+	// id indicates which changefeed this sink belongs to.
+	id model.ChangeFeedID
+	// protocol indicates the protocol used by this sink.
+	protocol        config.Protocol
+	encoderBuilder  codec.RowEventEncoderBuilder
+	client          *webhook.Client
+	maxRequestBytes int
+	// statistics is used to record DDL metrics.
+	statistics *metrics.Statistics
+}
+
+// NewDDLSink creates a ddl sink for webhook.
+func NewDDLSink(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (*DDLSink, error) {
+	cfg := webhook.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, err
+	}
+
+	protocol, err := util.GetProtocol(putil.GetOrZero(replicaConfig.Sink.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoderConfig, err := util.GetEncoderConfig(sinkURI, protocol, replicaConfig, cfg.MaxRequestBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encoderBuilder, err := builder.NewRowEventEncoderBuilder(ctx, changefeedID, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+
+	return &DDLSink{
+		id:              changefeedID,
+		protocol:        protocol,
+		encoderBuilder:  encoderBuilder,
+		client:          webhook.NewClient(cfg),
+		maxRequestBytes: cfg.MaxRequestBytes,
+		statistics:      metrics.NewStatistics(ctx, changefeedID, sink.TxnSink),
+	}, nil
+}
+
+// WriteDDLEvent encodes the DDL event and posts it to the webhook endpoint.
+func (d *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	encoder := d.encoderBuilder.Build()
+	msg, err := encoder.EncodeDDLEvent(ddl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if msg == nil {
+		log.Info("Skip ddl event", zap.Uint64("commitTs", ddl.CommitTs),
+			zap.String("query", ddl.Query),
+			zap.String("protocol", d.protocol.String()),
+			zap.String("namespace", d.id.Namespace),
+			zap.String("changefeed", d.id.ID))
+		return nil
+	}
+
+	keyPrefix := fmt.Sprintf("%s/%s/ddl/%d", d.id.Namespace, d.id.ID, ddl.CommitTs)
+	requests := webhook.BuildRequests(keyPrefix, d.protocol,
+		[]*common.Message{msg}, d.maxRequestBytes)
+	return d.statistics.RecordDDLExecution(func() error {
+		for _, req := range requests {
+			if err := d.client.Post(ctx, req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	})
+}
+
+// WriteCheckpointTs does nothing, the checkpoint is not sent to the
+// webhook endpoint to avoid posting requests periodically.
+func (d *DDLSink) WriteCheckpointTs(_ context.Context,
+	_ uint64, _ []*model.TableInfo,
+) error {
+	return nil
+}
+
+// Close closes the sink.
+func (d *DDLSink) Close() {
+	if d.statistics != nil {
+		d.statistics.Close()
+	}
+}
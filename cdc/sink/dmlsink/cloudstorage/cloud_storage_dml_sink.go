@@ -155,7 +155,7 @@ func NewDMLSink(ctx context.Context,
 	clock := clock.New()
 	for i := 0; i < cfg.WorkerCount; i++ {
 		inputCh := chann.NewAutoDrainChann[eventFragment]()
-		s.workers[i] = newDMLWorker(i, s.changefeedID, storage, cfg, ext,
+		s.workers[i] = newDMLWorker(i, s.changefeedID, storage, cfg, protocol, ext,
 			inputCh, clock, s.statistics)
 		workerChannels[i] = inputCh
 	}
@@ -27,8 +27,10 @@ import (
 	mcloudstorage "github.com/pingcap/tiflow/cdc/sink/metrics/cloudstorage"
 	"github.com/pingcap/tiflow/engine/pkg/clock"
 	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/parquet"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -41,6 +43,7 @@ type dmlWorker struct {
 	changeFeedID model.ChangeFeedID
 	storage      storage.ExternalStorage
 	config       *cloudstorage.Config
+	protocol     config.Protocol
 	// flushNotifyCh is used to notify that several tables can be flushed.
 	flushNotifyCh     chan dmlTask
 	inputCh           *chann.DrainableChann[eventFragment]
@@ -101,6 +104,7 @@ func newDMLWorker(
 	changefeedID model.ChangeFeedID,
 	storage storage.ExternalStorage,
 	config *cloudstorage.Config,
+	protocol config.Protocol,
 	extension string,
 	inputCh *chann.DrainableChann[eventFragment],
 	clock clock.Clock,
@@ -111,6 +115,7 @@ func newDMLWorker(
 		changeFeedID:      changefeedID,
 		storage:           storage,
 		config:            config,
+		protocol:          protocol,
 		inputCh:           inputCh,
 		flushNotifyCh:     make(chan dmlTask, 64),
 		statistics:        statistics,
@@ -237,8 +242,21 @@ func (d *dmlWorker) writeDataFile(ctx context.Context, path string, task *single
 		callbacks = append(callbacks, msg.Callback)
 	}
 
+	data := buf.Bytes()
+	if d.protocol == config.ProtocolParquet {
+		// The messages of parquet protocol are intermediate rows, which
+		// need to be converted into a parquet file as a whole.
+		var def cloudstorage.TableDefinition
+		def.FromTableInfo(task.tableInfo, task.tableInfo.Version)
+		parquetData, err := parquet.EncodeFile(&def, data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data = parquetData
+	}
+
 	if err := d.statistics.RecordBatchExecution(func() (int, error) {
-		err := d.storage.WriteFile(ctx, path, buf.Bytes())
+		err := d.storage.WriteFile(ctx, path, data)
 		if err != nil {
 			return 0, err
 		}
@@ -289,7 +307,8 @@ func (d *dmlWorker) dispatchFlushTasks(ctx context.Context,
 			}
 			flushTask.handleSingleTableEvent(frag)
 			// if the file size exceeds the upper limit, emit the flush task containing the table
-			// as soon as possible.
+			// as soon as possible. For the parquet protocol the size is of the intermediate
+			// rows, the parquet file written is usually smaller.
 			table := frag.versionedTable
 			if flushTask.tasks[table].size >= uint64(d.config.FileSize) {
 				task := flushTask.generateTaskByTable(table)
@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"path"
 	"sync"
 	"testing"
@@ -33,11 +34,15 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/parquet"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
-func testDMLWorker(ctx context.Context, t *testing.T, dir string) *dmlWorker {
+func testDMLWorker(
+	ctx context.Context, t *testing.T, dir string,
+	protocol config.Protocol, extension string,
+) *dmlWorker {
 	uri := fmt.Sprintf("file:///%s?flush-interval=2s", dir)
 	storage, err := util.GetExternalStorageFromURI(ctx, uri)
 	require.Nil(t, err)
@@ -53,7 +58,7 @@ func testDMLWorker(ctx context.Context, t *testing.T, dir string) *dmlWorker {
 	statistics := metrics.NewStatistics(ctx, model.DefaultChangeFeedID("dml-worker-test"),
 		sink.TxnSink)
 	d := newDMLWorker(1, model.DefaultChangeFeedID("dml-worker-test"), storage,
-		cfg, ".json", chann.NewAutoDrainChann[eventFragment](), clock.New(), statistics)
+		cfg, protocol, extension, chann.NewAutoDrainChann[eventFragment](), clock.New(), statistics)
 	return d
 }
 
@@ -62,7 +67,7 @@ func TestDMLWorkerRun(t *testing.T) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	parentDir := t.TempDir()
-	d := testDMLWorker(ctx, t, parentDir)
+	d := testDMLWorker(ctx, t, parentDir, config.ProtocolCanalJSON, ".json")
 	fragCh := d.inputCh
 	table1Dir := path.Join(parentDir, "test/table1/99")
 	// assume table1 and table2 are dispatched to the same DML worker
@@ -137,3 +142,46 @@ func TestDMLWorkerRun(t *testing.T) {
 	wg.Wait()
 	fragCh.CloseAndDrain()
 }
+
+func TestDMLWorkerWriteParquetFile(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	parentDir := t.TempDir()
+	d := testDMLWorker(ctx, t, parentDir, config.ProtocolParquet, ".parquet")
+	defer d.inputCh.CloseAndDrain()
+	tableInfo := &model.TableInfo{
+		TableName: model.TableName{Schema: "test", Table: "table1", TableID: 100},
+		Version:   99,
+		TableInfo: &timodel.TableInfo{
+			Columns: []*timodel.ColumnInfo{
+				{ID: 1, Name: timodel.NewCIStr("c1"), FieldType: *types.NewFieldType(mysql.TypeLong)},
+			},
+		},
+	}
+
+	callbackCnt := 0
+	task := &singleTableTask{tableInfo: tableInfo}
+	for i := 0; i < 3; i++ {
+		task.msgs = append(task.msgs, &common.Message{
+			Value:    []byte(fmt.Sprintf(`{"op":"I","commitTs":%d,"values":{"c1":%d}}`+"\n", i+1, i)),
+			Callback: func() { callbackCnt++ },
+		})
+	}
+	filePath := "test/table1/99/CDC000001.parquet"
+	require.NoError(t, d.writeDataFile(ctx, filePath, task))
+	require.Equal(t, 3, callbackCnt)
+
+	content, err := os.ReadFile(path.Join(parentDir, filePath))
+	require.NoError(t, err)
+	pr, err := parquet.NewColumnReaderForTests(content)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(3), pr.GetNumRows())
+	values, _, _, err := pr.ReadColumnByIndex(2, 3)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(0), int64(1), int64(2)}, values)
+	require.Equal(t, parquet.OpColumnName, pr.SchemaHandler.Infos[1].ExName)
+	require.Equal(t, "c1", pr.SchemaHandler.Infos[3].ExName)
+}
@@ -14,8 +14,12 @@
 package dmlsink
 
 import (
+	"context"
+
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
+	"github.com/pingcap/tiflow/pkg/sink/router"
 	"go.uber.org/zap"
 )
 
@@ -25,22 +29,45 @@ type Appender[E TableEvent] interface {
 	Append(buffer []E, rows ...*model.RowChangedEvent) []E
 }
 
+// StagedTxnAppender is the interface for appending transactions whose rows
+// are staged to buffer.
+type StagedTxnAppender[E TableEvent] interface {
+	// AppendStagedTxn appends the staged transaction to buffer, row is the
+	// first row of the transaction.
+	AppendStagedTxn(buffer []E, row *model.RowChangedEvent, rows model.StagedRows) []E
+}
+
 // Assert Appender[E TableEvent] implementation
 var _ Appender[*model.RowChangedEvent] = (*RowChangeEventAppender)(nil)
 
 // RowChangeEventAppender is the builder for RowChangedEvent.
-type RowChangeEventAppender struct{}
+type RowChangeEventAppender struct {
+	// Router routes the rows to the downstream tables, it can be nil.
+	Router *router.Router
+	// ColumnSelector selects and masks the columns of the rows, it can be nil.
+	ColumnSelector *columnselector.Selector
+}
 
 // Append appends the given rows to the given buffer.
 func (r *RowChangeEventAppender) Append(
 	buffer []*model.RowChangedEvent,
 	rows ...*model.RowChangedEvent,
 ) []*model.RowChangedEvent {
-	return append(buffer, rows...)
+	if !r.Router.Enabled() && !r.ColumnSelector.Enabled() {
+		return append(buffer, rows...)
+	}
+	for _, row := range rows {
+		row = r.ColumnSelector.SelectRowChangedEvent(row)
+		buffer = append(buffer, r.Router.RouteRowChangedEvent(row))
+	}
+	return buffer
 }
 
 // Assert Appender[E TableEvent] implementation
-var _ Appender[*model.SingleTableTxn] = (*TxnEventAppender)(nil)
+var (
+	_ Appender[*model.SingleTableTxn]          = (*TxnEventAppender)(nil)
+	_ StagedTxnAppender[*model.SingleTableTxn] = (*TxnEventAppender)(nil)
+)
 
 // TxnEventAppender is the appender for SingleTableTxn.
 type TxnEventAppender struct {
@@ -51,6 +78,10 @@ type TxnEventAppender struct {
 	// Most of our protocols are ignoring the startTs of the row, so we
 	// can not use the startTs to identify a transaction.
 	IgnoreStartTs bool
+	// Router routes the rows to the downstream tables, it can be nil.
+	Router *router.Router
+	// ColumnSelector selects and masks the columns of the rows, it can be nil.
+	ColumnSelector *columnselector.Selector
 }
 
 // Append appends the given rows to the given txn buffer.
@@ -70,6 +101,9 @@ func (t *TxnEventAppender) Append(
 	rows ...*model.RowChangedEvent,
 ) []*model.SingleTableTxn {
 	for _, row := range rows {
+		// The column selectors match the upstream tables, so the
+		// columns are selected before the tables are routed.
+		row = t.Router.RouteRowChangedEvent(t.ColumnSelector.SelectRowChangedEvent(row))
 		// This means no txn is in the buffer.
 		if len(buffer) == 0 {
 			txn := t.createSingleTableTxn(row)
@@ -94,7 +128,8 @@ func (t *TxnEventAppender) Append(
 
 		// Split on big transactions or a new one. For 2 transactions,
 		// their commitTs can be same but startTs will be never same.
-		normalBoundary := row.SplitTxn || lastTxn.StartTs != row.StartTs
+		normalBoundary := row.SplitTxn || lastTxn.StartTs != row.StartTs ||
+			lastTxn.StagedRows != nil
 		// NOTICE: This is a special case for compatibility with old version.
 		// In our lots of protocols, we are ignoring the startTs of the row,
 		// so we can not use the startTs to identify a transaction.
@@ -109,6 +144,20 @@ func (t *TxnEventAppender) Append(
 	return buffer
 }
 
+// AppendStagedTxn appends a transaction whose rows are staged to the given
+// txn buffer, row is the first row of the transaction. The staged rows are
+// routed and selected when they are read.
+func (t *TxnEventAppender) AppendStagedTxn(
+	buffer []*model.SingleTableTxn,
+	row *model.RowChangedEvent,
+	rows model.StagedRows,
+) []*model.SingleTableTxn {
+	row = t.Router.RouteRowChangedEvent(t.ColumnSelector.SelectRowChangedEvent(row))
+	txn := t.createSingleTableTxn(row)
+	txn.StagedRows = &appendedStagedRows{StagedRows: rows, appender: t}
+	return append(buffer, txn)
+}
+
 func (t *TxnEventAppender) createSingleTableTxn(
 	row *model.RowChangedEvent,
 ) *model.SingleTableTxn {
@@ -128,3 +177,23 @@ func (t *TxnEventAppender) createSingleTableTxn(
 	}
 	return txn
 }
+
+// appendedStagedRows routes and selects the staged rows in the same way as
+// the appended rows.
+type appendedStagedRows struct {
+	model.StagedRows
+	appender *TxnEventAppender
+}
+
+// Read implements model.StagedRows.
+func (r *appendedStagedRows) Read(
+	ctx context.Context, batchSize int, fn func(rows []*model.RowChangedEvent) error,
+) error {
+	return r.StagedRows.Read(ctx, batchSize, func(rows []*model.RowChangedEvent) error {
+		for i, row := range rows {
+			row = r.appender.ColumnSelector.SelectRowChangedEvent(row)
+			rows[i] = r.appender.Router.RouteRowChangedEvent(row)
+		}
+		return fn(rows)
+	})
+}
@@ -16,7 +16,11 @@ package dmlsink
 import (
 	"testing"
 
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
+	"github.com/pingcap/tiflow/pkg/sink/router"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,6 +64,88 @@ func TestRowChangeEventAppender(t *testing.T) {
 	require.Equal(t, uint64(2), buffer[2].GetCommitTs())
 }
 
+func TestEventAppenderWithRouter(t *testing.T) {
+	t.Parallel()
+
+	r, err := router.NewRouter(true, []*config.RouteRule{
+		{Matcher: []string{"test.*"}, TargetSchema: "test_bak", TargetTable: "t"},
+	})
+	require.NoError(t, err)
+
+	tableName := &model.TableName{Schema: "test", Table: "t1", TableID: 1}
+	rows := []*model.RowChangedEvent{
+		{Table: tableName, StartTs: 1, CommitTs: 2},
+		{Table: tableName, StartTs: 1, CommitTs: 2},
+	}
+	expected := &model.TableName{Schema: "test_bak", Table: "t", TableID: 1}
+
+	rowAppender := &RowChangeEventAppender{Router: r}
+	rowBuffer := rowAppender.Append(nil, rows...)
+	require.Len(t, rowBuffer, 2)
+	for _, row := range rowBuffer {
+		require.Equal(t, expected, row.Table)
+	}
+
+	txnAppender := &TxnEventAppender{Router: r}
+	txnBuffer := txnAppender.Append(nil, rows...)
+	require.Len(t, txnBuffer, 1)
+	require.Equal(t, expected, txnBuffer[0].Table)
+	require.Len(t, txnBuffer[0].Rows, 2)
+	for _, row := range txnBuffer[0].Rows {
+		require.Equal(t, expected, row.Table)
+	}
+	// The original rows are not modified.
+	require.Equal(t, "test", rows[0].Table.Schema)
+	require.Equal(t, "t1", rows[1].Table.Table)
+}
+
+func TestEventAppenderWithColumnSelector(t *testing.T) {
+	t.Parallel()
+
+	s, err := columnselector.New(true, []*config.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"id"}},
+	})
+	require.NoError(t, err)
+	r, err := router.NewRouter(true, []*config.RouteRule{
+		{Matcher: []string{"test.*"}, TargetSchema: "test_bak"},
+	})
+	require.NoError(t, err)
+
+	tableInfo := model.WrapTableInfo(1, "test", 1, &timodel.TableInfo{
+		ID:   1,
+		Name: timodel.NewCIStr("t1"),
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: timodel.NewCIStr("id"), Offset: 0, State: timodel.StatePublic},
+			{ID: 2, Name: timodel.NewCIStr("name"), Offset: 1, State: timodel.StatePublic},
+		},
+	})
+	rows := []*model.RowChangedEvent{
+		{
+			Table:     &tableInfo.TableName,
+			TableInfo: tableInfo,
+			StartTs:   1,
+			CommitTs:  2,
+			Columns:   []*model.Column{{Name: "id", Value: 1}, {Name: "name", Value: "a"}},
+		},
+	}
+
+	rowAppender := &RowChangeEventAppender{ColumnSelector: s, Router: r}
+	rowBuffer := rowAppender.Append(nil, rows...)
+	require.Len(t, rowBuffer, 1)
+	require.Equal(t, []*model.Column{{Name: "id", Value: 1}}, rowBuffer[0].Columns)
+	require.Equal(t, "test_bak", rowBuffer[0].Table.Schema)
+
+	txnAppender := &TxnEventAppender{ColumnSelector: s, Router: r}
+	txnBuffer := txnAppender.Append(nil, rows...)
+	require.Len(t, txnBuffer, 1)
+	require.Equal(t, "test_bak", txnBuffer[0].Table.Schema)
+	require.Len(t, txnBuffer[0].TableInfo.Columns, 1)
+	require.Equal(t, []*model.Column{{Name: "id", Value: 1}}, txnBuffer[0].Rows[0].Columns)
+	// The original rows are not modified.
+	require.Len(t, rows[0].Columns, 2)
+	require.Len(t, tableInfo.Columns, 2)
+}
+
 func TestTxnEventAppenderWithoutIgnoreStartTs(t *testing.T) {
 	t.Parallel()
 
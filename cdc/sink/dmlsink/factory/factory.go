@@ -27,12 +27,16 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/txn"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/webhook"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	v2 "github.com/pingcap/tiflow/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"github.com/pingcap/tiflow/pkg/sink/router"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -45,6 +49,10 @@ import (
 type SinkFactory struct {
 	rowSink dmlsink.EventSink[*model.RowChangedEvent]
 	txnSink dmlsink.EventSink[*model.SingleTableTxn]
+	// router routes the rows to the downstream tables.
+	router *router.Router
+	// selector selects and masks the columns of the rows.
+	selector *columnselector.Selector
 }
 
 // New creates a new SinkFactory by schema.
@@ -60,7 +68,17 @@ func New(
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
 
-	s := &SinkFactory{}
+	r, err := router.NewRouter(cfg.CaseSensitive, cfg.Sink.RouteRules)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := columnselector.New(cfg.CaseSensitive, cfg.Sink.ColumnSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SinkFactory{router: r, selector: selector}
 	schema := strings.ToLower(sinkURI.Scheme)
 	switch schema {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
@@ -81,12 +99,25 @@ func New(
 			return nil, err
 		}
 		s.rowSink = mqs
+	case sink.PulsarScheme, sink.PulsarSSLScheme:
+		mqs, err := mq.NewPulsarDMLSink(ctx, changefeedID, sinkURI, cfg, errCh,
+			pulsar.NewFactory, dmlproducer.NewPulsarDMLProducer)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
 	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
 		storageSink, err := cloudstorage.NewDMLSink(ctx, changefeedID, sinkURI, cfg, errCh)
 		if err != nil {
 			return nil, err
 		}
 		s.txnSink = storageSink
+	case sink.HTTPScheme, sink.HTTPSScheme:
+		webhookSink, err := webhook.NewDMLSink(ctx, changefeedID, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.txnSink = webhookSink
 	case sink.BlackHoleScheme:
 		bs := blackhole.NewDMLSink()
 		s.rowSink = bs
@@ -106,11 +137,17 @@ func (s *SinkFactory) CreateTableSink(
 ) tablesink.TableSink {
 	if s.txnSink != nil {
 		return tablesink.New(changefeedID, span, startTs, s.txnSink,
-			&dmlsink.TxnEventAppender{TableSinkStartTs: startTs}, totalRowsCounter)
+			&dmlsink.TxnEventAppender{
+				TableSinkStartTs: startTs,
+				Router:           s.router,
+				ColumnSelector:   s.selector,
+			},
+			totalRowsCounter)
 	}
 
 	return tablesink.New(changefeedID, span, startTs, s.rowSink,
-		&dmlsink.RowChangeEventAppender{}, totalRowsCounter)
+		&dmlsink.RowChangeEventAppender{Router: s.router, ColumnSelector: s.selector},
+		totalRowsCounter)
 }
 
 // CreateTableSinkForConsumer creates a TableSink by schema for consumer.
@@ -126,12 +163,18 @@ func (s *SinkFactory) CreateTableSinkForConsumer(
 		return tablesink.New(changefeedID, span, startTs, s.txnSink,
 			// IgnoreStartTs is true because the consumer can
 			// **not** get the start ts of the row changed event.
-			&dmlsink.TxnEventAppender{TableSinkStartTs: startTs, IgnoreStartTs: true},
+			&dmlsink.TxnEventAppender{
+				TableSinkStartTs: startTs,
+				IgnoreStartTs:    true,
+				Router:           s.router,
+				ColumnSelector:   s.selector,
+			},
 			totalRowsCounter)
 	}
 
 	return tablesink.New(changefeedID, span, startTs, s.rowSink,
-		&dmlsink.RowChangeEventAppender{}, totalRowsCounter)
+		&dmlsink.RowChangeEventAppender{Router: s.router, ColumnSelector: s.selector},
+		totalRowsCounter)
 }
 
 // Close closes the sink.
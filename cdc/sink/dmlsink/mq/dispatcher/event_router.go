@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
 	filter "github.com/pingcap/tidb/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/partition"
@@ -45,6 +46,7 @@ const (
 	partitionDispatchRuleTS
 	partitionDispatchRuleTable
 	partitionDispatchRuleIndexValue
+	partitionDispatchRuleColumns
 )
 
 func (r *partitionDispatchRule) fromString(rule string) {
@@ -60,9 +62,11 @@ func (r *partitionDispatchRule) fromString(rule string) {
 		log.Warn("rowid is deprecated, please use index-value instead.")
 	case "index-value":
 		*r = partitionDispatchRuleIndexValue
+	case "columns":
+		*r = partitionDispatchRuleColumns
 	default:
 		*r = partitionDispatchRuleDefault
-		log.Warn("the partition dispatch rule is not default/ts/table/index-value," +
+		log.Warn("the partition dispatch rule is not default/ts/table/index-value/columns," +
 			" use the default rule instead.")
 	}
 }
@@ -202,6 +206,27 @@ func (s *EventRouter) GetActiveTopics(activeTables []model.TableName) []string {
 	return topics
 }
 
+// VerifyTables checks that the columns used by the columns partition
+// dispatchers exist in the tables.
+func (s *EventRouter) VerifyTables(infos []*model.TableInfo) error {
+	for _, table := range infos {
+		_, partitionDispatcher := s.matchDispatcher(table.TableName.Schema, table.TableName.Table)
+		columnsDispatcher, ok := partitionDispatcher.(*partition.ColumnsDispatcher)
+		if !ok {
+			continue
+		}
+		for _, name := range columnsDispatcher.Columns {
+			if timodel.FindColumnInfo(table.Columns, name) == nil {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"column %s does not exist in table %s, "+
+						"which is required by the columns partition dispatcher",
+					name, table.TableName.String())
+			}
+		}
+	}
+	return nil
+}
+
 // GetDefaultTopic returns the default topic name.
 func (s *EventRouter) GetDefaultTopic() string {
 	return s.defaultTopic
@@ -239,6 +264,8 @@ func getPartitionDispatcher(
 				"switching on the old value, so please use caution!")
 		}
 		d = partition.NewIndexValueDispatcher()
+	case partitionDispatchRuleColumns:
+		d = partition.NewColumnsDispatcher(ruleConfig.Columns)
 	case partitionDispatchRuleTS:
 		d = partition.NewTsDispatcher()
 	case partitionDispatchRuleTable:
@@ -487,3 +487,48 @@ func TestGetTopicForDDL(t *testing.T) {
 		require.Equal(t, test.expectedTopic, d.GetTopicForDDL(test.ddl))
 	}
 }
+
+func TestColumnsPartitionDispatcher(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewEventRouter(&config.ReplicaConfig{
+		Sink: &config.SinkConfig{
+			DispatchRules: []*config.DispatchRule{
+				{
+					Matcher:       []string{"test.*"},
+					PartitionRule: "columns",
+					Columns:       []string{"tenant_id"},
+				},
+			},
+		},
+	}, "")
+	require.Nil(t, err)
+	_, partitionDispatcher := d.matchDispatcher("test", "t1")
+	require.IsType(t, &partition.ColumnsDispatcher{}, partitionDispatcher)
+	_, partitionDispatcher = d.matchDispatcher("other", "t1")
+	require.IsType(t, &partition.DefaultDispatcher{}, partitionDispatcher)
+
+	newTableInfo := func(schema, table string, columns ...string) *model.TableInfo {
+		info := &model.TableInfo{
+			TableName: model.TableName{Schema: schema, Table: table},
+			TableInfo: &timodel.TableInfo{Name: timodel.NewCIStr(table)},
+		}
+		for _, col := range columns {
+			info.Columns = append(info.Columns, &timodel.ColumnInfo{
+				Name: timodel.NewCIStr(col),
+			})
+		}
+		return info
+	}
+
+	require.Nil(t, d.VerifyTables([]*model.TableInfo{
+		newTableInfo("test", "t1", "id", "Tenant_ID"),
+		// tables not matched by the columns rule are not checked.
+		newTableInfo("other", "t1", "id"),
+	}))
+	err = d.VerifyTables([]*model.TableInfo{
+		newTableInfo("test", "t1", "id", "tenant_id"),
+		newTableInfo("test", "t2", "id"),
+	})
+	require.ErrorContains(t, err, "column tenant_id does not exist in table test.t2")
+}
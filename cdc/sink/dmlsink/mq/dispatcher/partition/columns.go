@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/hash"
+	"go.uber.org/zap"
+)
+
+// ColumnsDispatcher is a partition dispatcher which dispatches events
+// based on the values of the given columns.
+// Only the values are hashed, so rows which have the same values of these
+// columns are dispatched to the same partition, even if they belong to
+// different tables.
+type ColumnsDispatcher struct {
+	hasher *hash.PositionInertia
+	lock   sync.Mutex
+	// warnedTables are the tables which miss some of the columns, the
+	// warning is logged only once for each table.
+	warnedTables map[model.TableID]struct{}
+
+	Columns []string
+}
+
+// NewColumnsDispatcher creates a ColumnsDispatcher.
+func NewColumnsDispatcher(columns []string) *ColumnsDispatcher {
+	return &ColumnsDispatcher{
+		hasher:       hash.NewPositionInertia(),
+		warnedTables: make(map[model.TableID]struct{}),
+		Columns:      columns,
+	}
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched.
+func (r *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hasher.Reset()
+
+	dispatchCols := row.Columns
+	if len(row.Columns) == 0 {
+		dispatchCols = row.PreColumns
+	}
+
+	for _, name := range r.Columns {
+		col := findColumn(dispatchCols, name)
+		if col == nil {
+			// The column may be dropped after the changefeed is created,
+			// fallback to dispatch the row by the table name.
+			if _, ok := r.warnedTables[row.Table.TableID]; !ok {
+				r.warnedTables[row.Table.TableID] = struct{}{}
+				log.Warn("column not found in the row, dispatch rows of the table by the table name",
+					zap.String("column", name),
+					zap.String("schema", row.Table.Schema),
+					zap.String("table", row.Table.Table))
+			}
+			r.hasher.Reset()
+			r.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
+			return int32(r.hasher.Sum32() % uint32(partitionNum))
+		}
+		r.hasher.Write([]byte(model.ColumnValueString(col.Value)))
+	}
+	return int32(r.hasher.Sum32() % uint32(partitionNum))
+}
+
+// findColumn returns the column with the given name, the name of
+// column is case-insensitive in TiDB.
+func findColumn(columns []*model.Column, name string) *model.Column {
+	for _, col := range columns {
+		if col != nil && strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnsDispatcher(t *testing.T) {
+	t.Parallel()
+
+	newRow := func(schema, table string, id, tenant int) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table: &model.TableName{Schema: schema, Table: table},
+			Columns: []*model.Column{
+				{Name: "id", Value: id, Flag: model.HandleKeyFlag},
+				{Name: "tenant_id", Value: tenant},
+			},
+		}
+	}
+
+	d := NewColumnsDispatcher([]string{"TENANT_ID"})
+	p := d.DispatchRowChangedEvent(newRow("test", "t1", 1, 100), 16)
+	// Rows of the same tenant are dispatched to the same partition,
+	// even if the primary keys or the tables are different.
+	require.Equal(t, p, d.DispatchRowChangedEvent(newRow("test", "t1", 2, 100), 16))
+	require.Equal(t, p, d.DispatchRowChangedEvent(newRow("test", "t2", 3, 100), 16))
+
+	partitions := make(map[int32]struct{})
+	for tenant := 0; tenant < 100; tenant++ {
+		partitions[d.DispatchRowChangedEvent(newRow("test", "t1", 1, tenant), 16)] = struct{}{}
+	}
+	require.Greater(t, len(partitions), 1)
+
+	// The pre columns are used for the delete event.
+	deleteRow := newRow("test", "t1", 1, 100)
+	deleteRow.PreColumns, deleteRow.Columns = deleteRow.Columns, nil
+	require.Equal(t, p, d.DispatchRowChangedEvent(deleteRow, 16))
+
+	// Fallback to dispatch by the table name if the column does not exist.
+	d = NewColumnsDispatcher([]string{"not_exist"})
+	tableDispatcher := NewTableDispatcher()
+	row := newRow("test", "t1", 1, 100)
+	require.Equal(t, tableDispatcher.DispatchRowChangedEvent(row, 16),
+		d.DispatchRowChangedEvent(row, 16))
+	require.Equal(t, tableDispatcher.DispatchRowChangedEvent(row, 16),
+		d.DispatchRowChangedEvent(row, 16))
+	require.Len(t, d.warnedTables, 1)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
 )
 
 // DMLProducer is the interface for message producer.
@@ -41,3 +42,8 @@ type DMLProducer interface {
 type Factory func(ctx context.Context, changefeedID model.ChangeFeedID,
 	factory kafka.Factory,
 	adminClient kafka.ClusterAdminClient, errCh chan error) (DMLProducer, error)
+
+// PulsarFactory is a function to create a pulsar producer.
+// errCh has the same semantics as the one of Factory.
+type PulsarFactory func(ctx context.Context, changefeedID model.ChangeFeedID,
+	factory pulsar.Factory, errCh chan error) (DMLProducer, error)
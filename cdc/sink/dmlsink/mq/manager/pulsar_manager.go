@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"go.uber.org/zap"
+)
+
+// pulsarTopicManager is a manager for pulsar topics.
+// Pulsar creates topics on demand if `allowAutoTopicCreation` is enabled
+// on the broker, so the manager only queries and caches the partitions.
+type pulsarTopicManager struct {
+	changefeedID model.ChangeFeedID
+
+	factory pulsar.Factory
+
+	topics sync.Map
+}
+
+// NewPulsarTopicManager creates a new topic manager.
+func NewPulsarTopicManager(
+	changefeedID model.ChangeFeedID,
+	factory pulsar.Factory,
+) *pulsarTopicManager {
+	return &pulsarTopicManager{
+		changefeedID: changefeedID,
+		factory:      factory,
+	}
+}
+
+// GetPartitionNum returns the number of partitions of the topic.
+func (m *pulsarTopicManager) GetPartitionNum(
+	ctx context.Context,
+	topic string,
+) (int32, error) {
+	if partitions, ok := m.topics.Load(topic); ok {
+		return partitions.(int32), nil
+	}
+
+	partitionNum, err := m.CreateTopicAndWaitUntilVisible(ctx, topic)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return partitionNum, nil
+}
+
+// CreateTopicAndWaitUntilVisible queries the partitions of the topic,
+// which also makes the broker create the topic if it does not exist.
+func (m *pulsarTopicManager) CreateTopicAndWaitUntilVisible(
+	_ context.Context, topicName string,
+) (int32, error) {
+	partitions, err := m.factory.TopicPartitions(topicName)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	partitionNum := int32(len(partitions))
+	m.topics.Store(topicName, partitionNum)
+	log.Info("pulsar topic is visible",
+		zap.String("namespace", m.changefeedID.Namespace),
+		zap.String("changefeed", m.changefeedID.ID),
+		zap.String("topic", topicName),
+		zap.Int32("partitionNumber", partitionNum))
+	return partitionNum, nil
+}
+
+// Close does nothing, the factory is closed by the producer.
+func (m *pulsarTopicManager) Close() {}
@@ -0,0 +1,105 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulsarWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test-topic?" +
+		"protocol=canal-json&max-message-bytes=1048576")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	var factory *pulsar.MockFactory
+	factoryCreator := func(o *pulsar.Options, id model.ChangeFeedID) (pulsar.Factory, error) {
+		f, err := pulsar.NewMockFactory(o, id)
+		factory = f.(*pulsar.MockFactory)
+		return f, err
+	}
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	s, err := NewPulsarDMLSink(ctx, changefeedID, sinkURI, replicaConfig, errCh,
+		factoryCreator, dmlproducer.NewPulsarDMLProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	tableStatus := state.TableSinkSinking
+	row := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+	}
+
+	count := 0
+	events := make([]*dmlsink.RowChangeCallbackableEvent, 0, 100)
+	for i := 0; i < 100; i++ {
+		events = append(events, &dmlsink.RowChangeCallbackableEvent{
+			Event:     row,
+			Callback:  func() { count++ },
+			SinkState: &tableStatus,
+		})
+	}
+
+	err = s.WriteEvents(events...)
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return len(factory.GetAllMessages()) == 100
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, errCh, 0)
+	// The default dispatcher routes all rows of a table without
+	// primary key by the table name.
+	partition := s.alive.eventRouter.GetPartitionForRowChange(row,
+		pulsar.DefaultMockPartitionNum)
+	require.Len(t, factory.GetMessages(
+		pulsar.MockPartitionTopic("test-topic", partition)), 100)
+	s.Close()
+}
+
+func TestNewPulsarDMLSinkFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test-topic?protocol=avro")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	s, err := NewPulsarDMLSink(ctx, changefeedID, sinkURI, replicaConfig,
+		make(chan error, 1), pulsar.NewMockFactory, dmlproducer.NewPulsarDMLProducer)
+	require.ErrorContains(t, err, "Avro protocol requires parameter \"schema-registry\"")
+	require.Nil(t, s)
+}
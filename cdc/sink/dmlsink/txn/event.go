@@ -14,21 +14,30 @@
 package txn
 
 import (
+	"context"
 	"encoding/binary"
 	"hash/fnv"
 	"sort"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
 	"go.uber.org/zap"
 )
 
+// stagedTxnReadBatchSize is the batch size to read the rows of a staged
+// transaction when generating its conflict keys.
+const stagedTxnReadBatchSize = 1024
+
 type txnEvent struct {
 	*dmlsink.TxnCallbackableEvent
 	start            time.Time
 	conflictResolved time.Time
+	// stagedKeys are the conflict keys of a staged transaction, which are
+	// generated by reading its rows in advance.
+	stagedKeys []uint64
 }
 
 func newTxnEvent(event *dmlsink.TxnCallbackableEvent) *txnEvent {
@@ -41,7 +50,10 @@ func (e *txnEvent) OnConflictResolved() {
 
 // ConflictKeys implements causality.txnEvent interface.
 func (e *txnEvent) ConflictKeys(numSlots uint64) []uint64 {
-	keys := genTxnKeys(e.TxnCallbackableEvent.Event)
+	keys := e.stagedKeys
+	if e.TxnCallbackableEvent.Event.StagedRows == nil {
+		keys = genTxnKeys(e.TxnCallbackableEvent.Event)
+	}
 	sort.Slice(keys, func(i, j int) bool { return keys[i]%numSlots < keys[j]%numSlots })
 	return keys
 }
@@ -52,8 +64,28 @@ func genTxnKeys(txn *model.SingleTableTxn) []uint64 {
 		return nil
 	}
 	hashRes := make(map[uint64]struct{}, len(txn.Rows))
+	hashRowKeys(hashRes, txn.Rows)
+	return hashResToKeys(hashRes)
+}
+
+// genStagedTxnKeys returns hash keys for a staged `txn` by reading its rows.
+// Only the keys are kept in memory, which are much smaller than the rows.
+func genStagedTxnKeys(ctx context.Context, txn *model.SingleTableTxn) ([]uint64, error) {
+	hashRes := make(map[uint64]struct{}, txn.StagedRows.Len())
+	err := txn.StagedRows.Read(ctx, stagedTxnReadBatchSize,
+		func(rows []*model.RowChangedEvent) error {
+			hashRowKeys(hashRes, rows)
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hashResToKeys(hashRes), nil
+}
+
+func hashRowKeys(hashRes map[uint64]struct{}, rows []*model.RowChangedEvent) {
 	hasher := fnv.New32a()
-	for _, row := range txn.Rows {
+	for _, row := range rows {
 		for _, key := range genRowKeys(row) {
 			if n, err := hasher.Write(key); n != len(key) || err != nil {
 				log.Panic("transaction key hash fail")
@@ -62,6 +94,9 @@ func genTxnKeys(txn *model.SingleTableTxn) []uint64 {
 			hasher.Reset()
 		}
 	}
+}
+
+func hashResToKeys(hashRes map[uint64]struct{}) []uint64 {
 	keys := make([]uint64, 0, len(hashRes))
 	for key := range hashRes {
 		keys = append(keys, key)
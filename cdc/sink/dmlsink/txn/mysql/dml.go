@@ -21,6 +21,39 @@ import (
 	"github.com/pingcap/tiflow/pkg/quotes"
 )
 
+// PrepareRowDMLs builds the parametric statements to apply a row change event,
+// it's used by the consumers which execute the statements in their own
+// transactions. If translateToInsert is false, an update is translated to
+// DELETE + REPLACE and an insert is translated to REPLACE, like the safe mode.
+func PrepareRowDMLs(
+	row *model.RowChangedEvent, translateToInsert bool, forceReplicate bool,
+) (sqls []string, values [][]interface{}) {
+	quoteTable := row.Table.QuoteString()
+	if translateToInsert && len(row.PreColumns) != 0 && len(row.Columns) != 0 {
+		query, args := prepareUpdate(quoteTable, row.PreColumns, row.Columns, forceReplicate)
+		if query != "" {
+			sqls = append(sqls, query)
+			values = append(values, args)
+		}
+		return sqls, values
+	}
+	if len(row.PreColumns) != 0 {
+		query, args := prepareDelete(quoteTable, row.PreColumns, forceReplicate)
+		if query != "" {
+			sqls = append(sqls, query)
+			values = append(values, args)
+		}
+	}
+	if len(row.Columns) != 0 {
+		query, args := prepareReplace(quoteTable, row.Columns, true /* appendPlaceHolder */, translateToInsert)
+		if query != "" {
+			sqls = append(sqls, query)
+			values = append(values, args)
+		}
+	}
+	return sqls, values
+}
+
 // prepareUpdate builds a parametrics UPDATE statement as following
 // sql: `UPDATE `test`.`t` SET {} = ?, {} = ? WHERE {} = ?, {} = {} LIMIT 1`
 // `WHERE` conditions come from `preCols` and SET clause targets come from `cols`.
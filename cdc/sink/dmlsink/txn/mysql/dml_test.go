@@ -719,3 +719,42 @@ func TestMapReplace(t *testing.T) {
 		}
 	}
 }
+
+func TestPrepareRowDMLs(t *testing.T) {
+	t.Parallel()
+	preCols := []*model.Column{
+		{Name: "a", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag, Value: 1},
+		{Name: "b", Type: mysql.TypeVarchar, Value: "x"},
+	}
+	cols := []*model.Column{
+		{Name: "a", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag, Value: 1},
+		{Name: "b", Type: mysql.TypeVarchar, Value: "y"},
+	}
+	table := &model.TableName{Schema: "test", Table: "t1"}
+
+	// insert
+	row := &model.RowChangedEvent{Table: table, Columns: cols}
+	sqls, values := PrepareRowDMLs(row, true, false)
+	require.Equal(t, []string{"INSERT INTO `test`.`t1` (`a`,`b`) VALUES (?,?)"}, sqls)
+	require.Equal(t, [][]interface{}{{1, "y"}}, values)
+	sqls, _ = PrepareRowDMLs(row, false, false)
+	require.Equal(t, []string{"REPLACE INTO `test`.`t1` (`a`,`b`) VALUES (?,?)"}, sqls)
+
+	// update
+	row = &model.RowChangedEvent{Table: table, PreColumns: preCols, Columns: cols}
+	sqls, values = PrepareRowDMLs(row, true, false)
+	require.Equal(t, []string{"UPDATE `test`.`t1` SET `a`=?,`b`=? WHERE `a`=? LIMIT 1"}, sqls)
+	require.Equal(t, [][]interface{}{{1, "y", 1}}, values)
+	sqls, values = PrepareRowDMLs(row, false, false)
+	require.Equal(t, []string{
+		"DELETE FROM `test`.`t1` WHERE `a` = ? LIMIT 1",
+		"REPLACE INTO `test`.`t1` (`a`,`b`) VALUES (?,?)",
+	}, sqls)
+	require.Equal(t, [][]interface{}{{1}, {1, "y"}}, values)
+
+	// delete
+	row = &model.RowChangedEvent{Table: table, PreColumns: preCols}
+	sqls, values = PrepareRowDMLs(row, true, false)
+	require.Equal(t, []string{"DELETE FROM `test`.`t1` WHERE `a` = ? LIMIT 1"}, sqls)
+	require.Equal(t, [][]interface{}{{1}}, values)
+}
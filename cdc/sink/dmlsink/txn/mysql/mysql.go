@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
 	"github.com/pingcap/tiflow/cdc/sink/metrics"
 	"github.com/pingcap/tiflow/cdc/sink/metrics/txn"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -67,6 +68,9 @@ type mysqlBackend struct {
 
 	events []*dmlsink.TxnCallbackableEvent
 	rows   int
+	// stagedTxn is a staged transaction, which is written alone after the
+	// buffered events.
+	stagedTxn *dmlsink.TxnCallbackableEvent
 
 	statistics                      *metrics.Statistics
 	metricTxnSinkDMLBatchCommit     prometheus.Observer
@@ -203,13 +207,27 @@ func NewMySQLBackends(
 // OnTxnEvent implements interface backend.
 // It adds the event to the buffer, and return true if it needs flush immediately.
 func (s *mysqlBackend) OnTxnEvent(event *dmlsink.TxnCallbackableEvent) (needFlush bool) {
+	if event.Event.StagedRows != nil {
+		s.stagedTxn = event
+		return true
+	}
 	s.events = append(s.events, event)
 	s.rows += len(event.Event.Rows)
 	return event.Event.ToWaitFlush() || s.rows >= s.cfg.MaxTxnRow
 }
 
 // Flush implements interface backend.
-func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
+func (s *mysqlBackend) Flush(ctx context.Context) error {
+	if err := s.flushEvents(ctx); err != nil {
+		return err
+	}
+	if s.stagedTxn != nil {
+		return s.flushStagedTxn(ctx)
+	}
+	return nil
+}
+
+func (s *mysqlBackend) flushEvents(ctx context.Context) (err error) {
 	if s.rows == 0 {
 		return
 	}
@@ -255,6 +273,31 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	return
 }
 
+// flushStagedTxn writes the staged transaction to the downstream in one
+// transaction, its rows are read and executed in batches.
+func (s *mysqlBackend) flushStagedTxn(ctx context.Context) error {
+	event := s.stagedTxn
+	if err := s.execStagedTxnWithMaxRetries(ctx, event.Event); err != nil {
+		if event.GetTableSinkState() != state.TableSinkSinking {
+			// The table is stopping, so its rows can be cleaned from the sort
+			// engine, and it's safe to drop the transaction directly.
+			log.Warn("drop the staged transaction of a stopping table",
+				zap.String("changefeed", s.changefeed),
+				zap.Uint64("startTs", event.Event.StartTs),
+				zap.Uint64("commitTs", event.Event.CommitTs),
+				zap.Error(err))
+		} else {
+			if errors.Cause(err) != context.Canceled {
+				log.Error("execute staged transaction failed", zap.Error(err))
+			}
+			return errors.Trace(err)
+		}
+	}
+	event.Callback()
+	s.stagedTxn = nil
+	return nil
+}
+
 // Close implements interface backend.
 func (s *mysqlBackend) Close() (err error) {
 	if s.stmtCache != nil {
@@ -524,11 +567,28 @@ func hasHandleKey(cols []*model.Column) bool {
 
 // prepareDMLs converts model.RowChangedEvent list to query string list and args list
 func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
+	return s.prepareEventDMLs(s.events, s.rows)
+}
+
+// prepareStagedDMLs converts a batch of rows of the staged transaction.
+func (s *mysqlBackend) prepareStagedDMLs(
+	txn *model.SingleTableTxn, rows []*model.RowChangedEvent,
+) *preparedDMLs {
+	batch := *txn
+	batch.Rows = rows
+	batch.StagedRows = nil
+	events := []*dmlsink.TxnCallbackableEvent{{Event: &batch}}
+	return s.prepareEventDMLs(events, len(rows))
+}
+
+func (s *mysqlBackend) prepareEventDMLs(
+	events []*dmlsink.TxnCallbackableEvent, numRows int,
+) *preparedDMLs {
 	// TODO: use a sync.Pool to reduce allocations.
-	startTs := make([]uint64, 0, s.rows)
-	sqls := make([]string, 0, s.rows)
-	values := make([][]interface{}, 0, s.rows)
-	callbacks := make([]dmlsink.CallbackFunc, 0, len(s.events))
+	startTs := make([]uint64, 0, numRows)
+	sqls := make([]string, 0, numRows)
+	values := make([][]interface{}, 0, numRows)
+	callbacks := make([]dmlsink.CallbackFunc, 0, len(events))
 
 	// translateToInsert control the update and insert behavior
 	// we only translate into insert when old value is enabled and safe mode is disabled
@@ -536,7 +596,7 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 
 	rowCount := 0
 	approximateSize := int64(0)
-	for _, event := range s.events {
+	for _, event := range events {
 		if len(event.Event.Rows) == 0 {
 			continue
 		}
@@ -819,6 +879,94 @@ func (s *mysqlBackend) execDMLWithMaxRetries(pctx context.Context, dmls *prepare
 		retry.WithIsRetryableErr(isRetryableDMLError))
 }
 
+// execStagedTxnWithMaxRetries executes the staged transaction in one
+// transaction, and retries it from the first row if it fails.
+func (s *mysqlBackend) execStagedTxnWithMaxRetries(
+	pctx context.Context, txn *model.SingleTableTxn,
+) error {
+	start := time.Now()
+	startTs := []model.Ts{txn.StartTs}
+	rowCount := txn.StagedRows.Len()
+	observed := false
+	return retry.Do(pctx, func() error {
+		writeTimeout, _ := time.ParseDuration(s.cfg.WriteTimeout)
+		writeTimeout += networkDriftDuration
+
+		err := s.statistics.RecordBatchExecution(func() (int, error) {
+			tx, err := s.db.BeginTx(pctx, nil)
+			if err != nil {
+				return 0, logDMLTxnErr(
+					cerror.WrapError(cerror.ErrMySQLTxnError, err),
+					start, s.changefeed, "BEGIN", rowCount, startTs)
+			}
+
+			executed := 0
+			err = txn.StagedRows.Read(pctx, s.cfg.MaxTxnRow,
+				func(rows []*model.RowChangedEvent) error {
+					if !observed {
+						s.statistics.ObserveRows(rows...)
+					}
+					dmls := s.prepareStagedDMLs(txn, rows)
+					executed += len(rows)
+					// The executors roll back the transaction if they fail.
+					if s.cfg.MultiStmtEnable && dmls.approximateSize*2 <= s.maxAllowedPacket {
+						return s.multiStmtExecute(pctx, dmls, tx, writeTimeout)
+					}
+					return s.sequenceExecute(pctx, dmls, tx, writeTimeout)
+				})
+			observed = true
+			if err == nil && executed != rowCount {
+				err = cerror.ErrMySQLTxnError.GenWithStack(
+					"staged transaction has %d rows, but %d rows are read", rowCount, executed)
+			}
+			if err != nil {
+				if rbErr := tx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+					if errors.Cause(rbErr) != context.Canceled {
+						log.Warn("failed to rollback txn", zap.Error(rbErr))
+					}
+				}
+				return 0, errors.Trace(err)
+			}
+
+			if err = s.setWriteSource(pctx, tx); err != nil {
+				err := logDMLTxnErr(
+					cerror.WrapError(cerror.ErrMySQLTxnError, err),
+					start, s.changefeed,
+					fmt.Sprintf("SET SESSION %s = %d", "tidb_cdc_write_source",
+						s.cfg.SourceID),
+					rowCount, startTs)
+				if rbErr := tx.Rollback(); rbErr != nil {
+					if errors.Cause(rbErr) != context.Canceled {
+						log.Warn("failed to rollback txn", zap.Error(rbErr))
+					}
+				}
+				return 0, err
+			}
+
+			if err = tx.Commit(); err != nil {
+				return 0, logDMLTxnErr(
+					cerror.WrapError(cerror.ErrMySQLTxnError, err),
+					start, s.changefeed, "COMMIT", rowCount, startTs)
+			}
+			return rowCount, nil
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		log.Info("Exec staged transaction succeeded",
+			zap.Int("workerID", s.workerID),
+			zap.String("changefeed", s.changefeed),
+			zap.Uint64("startTs", txn.StartTs),
+			zap.Uint64("commitTs", txn.CommitTs),
+			zap.Int("numOfRows", rowCount),
+			zap.Duration("duration", time.Since(start)))
+		return nil
+	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(s.dmlMaxRetry),
+		retry.WithIsRetryableErr(isRetryableDMLError))
+}
+
 func logDMLTxnErr(
 	err error, start time.Time, changefeed string,
 	query string, count int, startTs []model.Ts,
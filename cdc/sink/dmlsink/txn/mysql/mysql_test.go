@@ -373,6 +373,117 @@ func TestNewMySQLBackendExecDML(t *testing.T) {
 	require.Nil(t, sink.Close())
 }
 
+type mockStagedRows struct {
+	rows []*model.RowChangedEvent
+}
+
+func (m *mockStagedRows) Len() int {
+	return len(m.rows)
+}
+
+func (m *mockStagedRows) Read(
+	_ context.Context, batchSize int, fn func(rows []*model.RowChangedEvent) error,
+) error {
+	for i := 0; i < len(m.rows); i += batchSize {
+		end := i + batchSize
+		if end > len(m.rows) {
+			end = len(m.rows)
+		}
+		if err := fn(m.rows[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNewMySQLBackendExecStagedTxn(t *testing.T) {
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() { dbIndex++ }()
+
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+
+		// normal db
+		db, mock := newTestMockDB(t)
+		// The buffered events are flushed before the staged transaction.
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `s1`.`t1` (`a`,`b`) VALUES (?,?)").
+			WithArgs(1, "test").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		// The staged transaction is executed in batches in one transaction.
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `s1`.`t1` (`a`,`b`) VALUES (?,?),(?,?)").
+			WithArgs(2, "test", 3, "test").
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectExec("INSERT INTO `s1`.`t1` (`a`,`b`) VALUES (?,?)").
+			WithArgs(4, "test").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectClose()
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changefeed := "test-changefeed"
+	sinkURI, err := url.Parse(
+		"mysql://127.0.0.1:4000/?time-zone=UTC&worker-count=1&cache-prep-stmts=false&max-txn-row=2")
+	require.Nil(t, err)
+	sink, err := newMySQLBackend(ctx, model.DefaultChangeFeedID(changefeed), sinkURI,
+		config.GetDefaultReplicaConfig(), mockGetDBConn)
+	require.Nil(t, err)
+
+	genRow := func(startTs, commitTs uint64, a int) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:  startTs,
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "s1", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{
+					Name:  "a",
+					Type:  mysql.TypeLong,
+					Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+					Value: a,
+				},
+				{
+					Name:  "b",
+					Type:  mysql.TypeVarchar,
+					Flag:  0,
+					Value: "test",
+				},
+			},
+		}
+	}
+
+	var flushed []uint64
+	needFlush := sink.OnTxnEvent(&dmlsink.TxnCallbackableEvent{
+		Event:    &model.SingleTableTxn{StartTs: 1, CommitTs: 2, Rows: []*model.RowChangedEvent{genRow(1, 2, 1)}},
+		Callback: func() { flushed = append(flushed, 2) },
+	})
+	require.False(t, needFlush)
+	stagedRows := &mockStagedRows{rows: []*model.RowChangedEvent{
+		genRow(3, 4, 2), genRow(3, 4, 3), genRow(3, 4, 4),
+	}}
+	needFlush = sink.OnTxnEvent(&dmlsink.TxnCallbackableEvent{
+		Event:    &model.SingleTableTxn{StartTs: 3, CommitTs: 4, StagedRows: stagedRows},
+		Callback: func() { flushed = append(flushed, 4) },
+	})
+	require.True(t, needFlush, "staged transaction should be flushed immediately")
+
+	err = sink.Flush(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, []uint64{2, 4}, flushed)
+	require.Nil(t, sink.stagedTxn)
+
+	require.Nil(t, sink.Close())
+}
+
 func TestExecDMLRollbackErrDatabaseNotExists(t *testing.T) {
 	rows := []*model.RowChangedEvent{
 		{
@@ -48,7 +48,9 @@ type dmlSink struct {
 	}
 
 	workers []*worker
-	cancel  func()
+	// ctx is used to read the rows of staged transactions.
+	ctx    context.Context
+	cancel func()
 
 	wg   sync.WaitGroup
 	dead chan struct{}
@@ -99,6 +101,7 @@ func newSink(ctx context.Context,
 	ctx, cancel := context.WithCancel(ctx)
 	sink := &dmlSink{
 		workers: make([]*worker, 0, len(backends)),
+		ctx:     ctx,
 		cancel:  cancel,
 		dead:    make(chan struct{}),
 	}
@@ -149,7 +152,15 @@ func (s *dmlSink) WriteEvents(txnEvents ...*dmlsink.TxnCallbackableEvent) error
 			txn.Callback()
 			continue
 		}
-		s.alive.conflictDetector.Add(newTxnEvent(txn))
+		event := newTxnEvent(txn)
+		if txn.Event.StagedRows != nil {
+			keys, err := genStagedTxnKeys(s.ctx, txn.Event)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			event.stagedKeys = keys
+		}
+		s.alive.conflictDetector.Add(event)
 	}
 	return nil
 }
@@ -161,7 +161,11 @@ func (w *worker) onEvent(txn txnWithNotifier) bool {
 
 	w.metricConflictDetectDuration.Observe(txn.conflictResolved.Sub(txn.start).Seconds())
 	w.metricQueueDuration.Observe(time.Since(txn.start).Seconds())
-	w.metricTxnWorkerHandledRows.Add(float64(len(txn.Event.Rows)))
+	rows := len(txn.Event.Rows)
+	if txn.Event.StagedRows != nil {
+		rows = txn.Event.StagedRows.Len()
+	}
+	w.metricTxnWorkerHandledRows.Add(float64(rows))
 	w.wantMoreCallbacks = append(w.wantMoreCallbacks, txn.wantMore)
 	return w.backend.OnTxnEvent(txn.txnEvent.TxnCallbackableEvent)
 }
@@ -0,0 +1,218 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	idempotencyKey string
+	table          string
+	body           string
+}
+
+func newTestServer(status int) (*httptest.Server, func() []receivedRequest) {
+	var (
+		mu       sync.Mutex
+		requests []receivedRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, receivedRequest{
+			idempotencyKey: r.Header.Get(webhook.IdempotencyKeyHeader),
+			table:          r.Header.Get(webhook.TableHeader),
+			body:           string(body),
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	return server, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedRequest(nil), requests...)
+	}
+}
+
+func newTestTxn(
+	tableID int64, table string, commitTs uint64, values []int,
+	cnt *uint64, tableStatus *state.TableSinkState,
+) *dmlsink.TxnCallbackableEvent {
+	tableName := &model.TableName{Schema: "test", Table: table, TableID: tableID}
+	txn := &dmlsink.TxnCallbackableEvent{
+		Event: &model.SingleTableTxn{
+			StartTs:  commitTs - 1,
+			CommitTs: commitTs,
+			Table:    tableName,
+		},
+		Callback: func() {
+			atomic.AddUint64(cnt, 1)
+		},
+		SinkState: tableStatus,
+	}
+	for _, v := range values {
+		txn.Event.Rows = append(txn.Event.Rows, &model.RowChangedEvent{
+			StartTs:  commitTs - 1,
+			CommitTs: commitTs,
+			Table:    tableName,
+			Columns: []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Value: v, Flag: model.HandleKeyFlag},
+			},
+		})
+	}
+	return txn
+}
+
+func TestWebhookWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	server, getRequests := newTestServer(http.StatusOK)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sinkURI, err := url.Parse(server.URL + "?worker-count=2")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = util.AddressOf(config.ProtocolCanalJSON.String())
+	s, err := NewDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI, replicaConfig,
+		make(chan error, 1))
+	require.NoError(t, err)
+	defer s.Close()
+
+	var cnt uint64
+	sinking := state.TableSinkSinking
+	stopping := state.TableSinkStopping
+	txns := []*dmlsink.TxnCallbackableEvent{
+		newTestTxn(1, "t1", 100, []int{1, 2}, &cnt, &sinking),
+		newTestTxn(2, "t2", 101, []int{3}, &cnt, &sinking),
+		newTestTxn(1, "t1", 102, []int{4}, &cnt, &sinking),
+		// the events of a stopping table are dropped.
+		newTestTxn(3, "t3", 103, []int{5}, &cnt, &stopping),
+	}
+	require.NoError(t, s.WriteEvents(txns...))
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint64(&cnt) == uint64(len(txns))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var t1Requests []receivedRequest
+	for _, req := range getRequests() {
+		if req.table == "t1" {
+			t1Requests = append(t1Requests, req)
+		}
+	}
+	require.Len(t, getRequests(), 3)
+	// the txns of a table are posted in order.
+	require.Len(t, t1Requests, 2)
+	require.Regexp(t, "^default/test/1/99/100/[0-9a-f]{16}/0$", t1Requests[0].idempotencyKey)
+	require.Equal(t, 2, strings.Count(t1Requests[0].body, "\n"))
+	require.Contains(t, t1Requests[0].body, `"id":"1"`)
+	require.Regexp(t, "^default/test/1/101/102/[0-9a-f]{16}/0$", t1Requests[1].idempotencyKey)
+	require.Contains(t, t1Requests[1].body, `"id":"4"`)
+}
+
+func TestWebhookIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+
+	server, getRequests := newTestServer(http.StatusOK)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sinkURI, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = util.AddressOf(config.ProtocolCanalJSON.String())
+	s, err := NewDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI, replicaConfig,
+		make(chan error, 1))
+	require.NoError(t, err)
+	defer s.Close()
+
+	var cnt uint64
+	sinking := state.TableSinkSinking
+	// Two txns with the same commit ts.
+	txn1 := newTestTxn(1, "t1", 100, []int{1}, &cnt, &sinking)
+	txn2 := newTestTxn(1, "t1", 100, []int{2}, &cnt, &sinking)
+	txn2.Event.StartTs = 98
+	// Two parts of a split txn.
+	part1 := newTestTxn(1, "t1", 101, []int{3}, &cnt, &sinking)
+	part2 := newTestTxn(1, "t1", 101, []int{4}, &cnt, &sinking)
+	txns := []*dmlsink.TxnCallbackableEvent{txn1, txn2, part1, part2}
+	require.NoError(t, s.WriteEvents(txns...))
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint64(&cnt) == uint64(len(txns))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	keys := make(map[string]struct{})
+	for _, req := range getRequests() {
+		keys[req.idempotencyKey] = struct{}{}
+	}
+	require.Len(t, getRequests(), 4)
+	require.Len(t, keys, 4)
+
+	// The txn resent after a restart gets the same key.
+	resent := newTestTxn(1, "t1", 101, []int{3}, &cnt, &sinking)
+	require.Equal(t, rowDigest(part1.Event.Rows[0]), rowDigest(resent.Event.Rows[0]))
+}
+
+func TestWebhookWriteEventsFailed(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(http.StatusBadRequest)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sinkURI, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = util.AddressOf(config.ProtocolCanalJSON.String())
+	errCh := make(chan error, 1)
+	s, err := NewDMLSink(ctx, model.DefaultChangeFeedID("test"), sinkURI, replicaConfig, errCh)
+	require.NoError(t, err)
+	defer s.Close()
+
+	var cnt uint64
+	sinking := state.TableSinkSinking
+	require.NoError(t, s.WriteEvents(newTestTxn(1, "t1", 100, []int{1}, &cnt, &sinking)))
+	select {
+	case err := <-errCh:
+		require.True(t, cerror.ErrWebhookRequestFailed.Equal(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the error is not reported")
+	}
+	<-s.Dead()
+	require.Error(t, s.WriteEvents(newTestTxn(1, "t1", 101, []int{2}, &cnt, &sinking)))
+	require.Equal(t, uint64(0), atomic.LoadUint64(&cnt))
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"go.uber.org/zap"
+)
+
+// worker encodes the txns and posts them to the webhook endpoint.
+// The txns of a table are always handled by the same worker one by one,
+// so they are delivered in the commit order.
+type worker struct {
+	id           int
+	changefeedID model.ChangeFeedID
+	protocol     config.Protocol
+	encoder      codec.RowEventEncoder
+	client       *webhook.Client
+	// maxRequestBytes is the max size of a request body.
+	maxRequestBytes int
+	inputCh         *chann.DrainableChann[*dmlsink.TxnCallbackableEvent]
+	statistics      *metrics.Statistics
+}
+
+func newWorker(
+	id int,
+	changefeedID model.ChangeFeedID,
+	protocol config.Protocol,
+	encoder codec.RowEventEncoder,
+	client *webhook.Client,
+	maxRequestBytes int,
+	statistics *metrics.Statistics,
+) *worker {
+	return &worker{
+		id:              id,
+		changefeedID:    changefeedID,
+		protocol:        protocol,
+		encoder:         encoder,
+		client:          client,
+		maxRequestBytes: maxRequestBytes,
+		inputCh:         chann.NewAutoDrainChann[*dmlsink.TxnCallbackableEvent](),
+		statistics:      statistics,
+	}
+}
+
+func (w *worker) run(ctx context.Context) error {
+	log.Debug("webhook worker started", zap.Int("workerID", w.id),
+		zap.String("namespace", w.changefeedID.Namespace),
+		zap.String("changefeed", w.changefeedID.ID))
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case txn, ok := <-w.inputCh.Out():
+			if !ok {
+				return nil
+			}
+			if err := w.sendTxn(ctx, txn); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// sendTxn posts all rows of the txn, the callback of the txn is
+// called after all requests of the txn are posted successfully.
+func (w *worker) sendTxn(ctx context.Context, txn *dmlsink.TxnCallbackableEvent) error {
+	if txn.GetTableSinkState() != state.TableSinkSinking {
+		// The table where the event comes from is in stopping, so it's safe
+		// to drop the event directly.
+		txn.Callback()
+		return nil
+	}
+
+	for _, row := range txn.Event.Rows {
+		if err := w.encoder.AppendRowChangedEvent(ctx, "", row, nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	msgs := w.encoder.Build()
+	// Txns of a table may have the same commit ts, and a large txn may be
+	// split into several parts, so the start ts and the first row are
+	// part of the idempotency key too.
+	var digest uint64
+	if len(txn.Event.Rows) > 0 {
+		digest = rowDigest(txn.Event.Rows[0])
+	}
+	keyPrefix := fmt.Sprintf("%s/%s/%d/%d/%d/%016x", w.changefeedID.Namespace,
+		w.changefeedID.ID, txn.Event.Table.TableID, txn.Event.StartTs,
+		txn.Event.CommitTs, digest)
+	requests := webhook.BuildRequests(keyPrefix, w.protocol, msgs, w.maxRequestBytes)
+
+	err := w.statistics.RecordBatchExecution(func() (int, error) {
+		for _, req := range requests {
+			if err := w.client.Post(ctx, req); err != nil {
+				return 0, errors.Trace(err)
+			}
+		}
+		return len(txn.Event.Rows), nil
+	})
+	if err != nil {
+		return err
+	}
+	txn.Callback()
+	return nil
+}
+
+// rowDigest returns the digest of the handle key of the row, or of all
+// columns if the row has no handle key.
+func rowDigest(row *model.RowChangedEvent) uint64 {
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	hasHandleKey := false
+	for _, col := range cols {
+		if col != nil && col.Flag.IsHandleKey() {
+			hasHandleKey = true
+			break
+		}
+	}
+	h := fnv.New64a()
+	for _, col := range cols {
+		if col == nil || (hasHandleKey && !col.Flag.IsHandleKey()) {
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%v\x00", col.Name, col.Value)
+	}
+	return h.Sum64()
+}
+
+func (w *worker) close() {
+	w.inputCh.CloseAndDrain()
+}
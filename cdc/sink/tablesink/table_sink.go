@@ -24,6 +24,11 @@ type TableSink interface {
 	// Usually, it is used to cache the row changed events into table sink.
 	// This is a not thread-safe method. Please do not call it concurrently.
 	AppendRowChangedEvents(rows ...*model.RowChangedEvent)
+	// AppendStagedTxn appends a transaction whose rows are staged to the
+	// table sink, row is the first row of the transaction. It returns an
+	// error if the table sink does not support staged transactions.
+	// This is a not thread-safe method. Please do not call it concurrently.
+	AppendStagedTxn(row *model.RowChangedEvent, rows model.StagedRows) error
 	// UpdateResolvedTs writes the buffered row changed events to the eventTableSink.
 	// Note: This is an asynchronous and not thread-safe method.
 	// Please do not call it concurrently.
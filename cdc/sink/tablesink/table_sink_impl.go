@@ -16,6 +16,7 @@ package tablesink
 import (
 	"sort"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
@@ -79,6 +80,19 @@ func (e *EventTableSink[E, P]) AppendRowChangedEvents(rows ...*model.RowChangedE
 	e.metricsTableSinkTotalRows.Add(float64(len(rows)))
 }
 
+// AppendStagedTxn appends a transaction whose rows are staged to the table sink.
+func (e *EventTableSink[E, P]) AppendStagedTxn(
+	row *model.RowChangedEvent, rows model.StagedRows,
+) error {
+	appender, ok := any(e.eventAppender).(dmlsink.StagedTxnAppender[E])
+	if !ok {
+		return errors.New("staged transactions are not supported by the sink")
+	}
+	e.eventBuffer = appender.AppendStagedTxn(e.eventBuffer, row, rows)
+	e.metricsTableSinkTotalRows.Add(float64(rows.Len()))
+	return nil
+}
+
 // UpdateResolvedTs advances the resolved ts of the table sink.
 func (e *EventTableSink[E, P]) UpdateResolvedTs(resolvedTs model.ResolvedTs) error {
 	// If resolvedTs is not greater than maxResolvedTs,
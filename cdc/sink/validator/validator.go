@@ -19,13 +19,41 @@ import (
 
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/columnselector"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/util"
 )
 
+// ValidateDispatchRules checks the dispatch rules of MQ sinks against the
+// tables to be replicated, e.g. the columns used by the columns partition
+// dispatcher must exist in the tables.
+func ValidateDispatchRules(
+	sinkURI *url.URL, cfg *config.ReplicaConfig, tableInfos []*model.TableInfo,
+) error {
+	if !sink.IsMQScheme(sinkURI.Scheme) {
+		return nil
+	}
+	router, err := dispatcher.NewEventRouter(cfg, "")
+	if err != nil {
+		return err
+	}
+	return router.VerifyTables(tableInfos)
+}
+
+// ValidateColumnSelectors checks the column selectors against the tables to
+// be replicated, e.g. only the string columns can be masked.
+func ValidateColumnSelectors(cfg *config.ReplicaConfig, tableInfos []*model.TableInfo) error {
+	selector, err := columnselector.New(cfg.CaseSensitive, cfg.Sink.ColumnSelectors)
+	if err != nil {
+		return err
+	}
+	return selector.VerifyTables(tableInfos)
+}
+
 // Validate sink if given valid parameters.
 // TODO: For now, we create a real sink instance and validate it.
 // Maybe we should support the dry-run mode to validate sink.
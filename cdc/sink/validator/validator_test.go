@@ -15,8 +15,10 @@ package validator
 
 import (
 	"context"
+	"net/url"
 	"testing"
 
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -127,3 +129,29 @@ func TestValidateSink(t *testing.T) {
 		"sink uri scheme is not supported with syncpoint enabled",
 	)
 }
+
+func TestValidateDispatchRules(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"test.*"}, PartitionRule: "columns", Columns: []string{"tenant_id"}},
+	}
+	tableInfos := []*model.TableInfo{{
+		TableName: model.TableName{Schema: "test", Table: "t1"},
+		TableInfo: &timodel.TableInfo{
+			Name:    timodel.NewCIStr("t1"),
+			Columns: []*timodel.ColumnInfo{{Name: timodel.NewCIStr("id")}},
+		},
+	}}
+
+	// Dispatch rules are only checked for MQ sinks.
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	require.NoError(t, ValidateDispatchRules(sinkURI, cfg, tableInfos))
+
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/topic")
+	require.NoError(t, err)
+	require.ErrorContains(t, ValidateDispatchRules(sinkURI, cfg, tableInfos),
+		"column tenant_id does not exist in table test.t1")
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpointstore
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	kafkav2 "github.com/pingcap/tiflow/pkg/sink/kafka/v2"
+	"github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"github.com/pingcap/tiflow/pkg/sink/router"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// mqSyncPointStore sends the syncpoint as a special message through the MQ codecs,
+// the message is broadcast to all partitions of the active topics.
+// The expired syncpoints are cleaned up by the retention of the MQ system.
+type mqSyncPointStore struct {
+	sink   *mq.DDLSink
+	router *router.Router
+}
+
+// newMQSyncPointStore creates a syncpoint store which sends the syncpoint to the MQ system.
+func newMQSyncPointStore(
+	ctx context.Context,
+	id model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (SyncPointStore, error) {
+	// the syncpoint is sent to the same topics as the checkpoint,
+	// so the tables must be routed in the same way as the DDL sink.
+	r, err := router.NewRouter(replicaConfig.CaseSensitive, replicaConfig.Sink.RouteRules)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var s *mq.DDLSink
+	switch strings.ToLower(sinkURI.Scheme) {
+	case sink.PulsarScheme, sink.PulsarSSLScheme:
+		s, err = mq.NewPulsarDDLSink(ctx, id, sinkURI, replicaConfig,
+			pulsar.NewFactory, ddlproducer.NewPulsarDDLProducer)
+	default:
+		factoryCreator := kafka.NewSaramaFactory
+		if util.GetOrZero(replicaConfig.Sink.EnableKafkaSinkV2) {
+			factoryCreator = kafkav2.NewFactory
+		}
+		s, err = mq.NewKafkaDDLSink(ctx, id, sinkURI, replicaConfig,
+			factoryCreator, ddlproducer.NewKafkaDDLProducer)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	log.Info("Start mq syncpoint sink",
+		zap.String("namespace", id.Namespace),
+		zap.String("changefeed", id.ID))
+	return &mqSyncPointStore{sink: s, router: r}, nil
+}
+
+// CreateSyncTable implements the SyncPointStore interface,
+// the topics are created by the DDL sink, so nothing to do here.
+func (s *mqSyncPointStore) CreateSyncTable(_ context.Context) error {
+	return nil
+}
+
+// SinkSyncPoint implements the SyncPointStore interface.
+func (s *mqSyncPointStore) SinkSyncPoint(ctx context.Context,
+	_ model.ChangeFeedID,
+	checkpointTs uint64,
+	tables []*model.TableInfo,
+) error {
+	if s.router.Enabled() {
+		tables = s.router.RouteTableInfos(tables)
+	}
+	event := &model.SyncPointEvent{
+		PrimaryTs: checkpointTs,
+		// there is no TSO in the MQ system, so the time when
+		// the syncpoint is sent is used as the secondary ts.
+		SecondaryTs: oracle.GoTimeToTS(time.Now()),
+	}
+	return errors.Trace(s.sink.WriteSyncPoint(ctx, event, tables))
+}
+
+// Close implements the SyncPointStore interface.
+func (s *mqSyncPointStore) Close() error {
+	s.sink.Close()
+	return nil
+}
@@ -136,6 +136,7 @@ func (s *mysqlSyncPointStore) CreateSyncTable(ctx context.Context) error {
 func (s *mysqlSyncPointStore) SinkSyncPoint(ctx context.Context,
 	id model.ChangeFeedID,
 	checkpointTs uint64,
+	_ []*model.TableInfo,
 ) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -0,0 +1,148 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpointstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// syncPointMarker is the content of the syncpoint marker file.
+type syncPointMarker struct {
+	ClusterID  string `json:"cluster-id"`
+	Namespace  string `json:"namespace"`
+	Changefeed string `json:"changefeed"`
+	model.SyncPointEvent
+}
+
+// storageSyncPointStore writes the syncpoint as a marker file into the
+// `syncpoint` directory of the storage sink layout.
+type storageSyncPointStore struct {
+	storage                storage.ExternalStorage
+	clusterID              string
+	syncPointRetention     time.Duration
+	lastCleanSyncPointTime time.Time
+}
+
+// newStorageSyncPointStore creates a syncpoint store which writes the syncpoint to the external storage.
+func newStorageSyncPointStore(
+	ctx context.Context,
+	id model.ChangeFeedID,
+	sinkURI *url.URL,
+	syncPointRetention time.Duration,
+) (SyncPointStore, error) {
+	s, err := util.GetExternalStorageFromURI(ctx, sinkURI.String())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Start storage syncpoint sink",
+		zap.String("namespace", id.Namespace),
+		zap.String("changefeed", id.ID))
+	return &storageSyncPointStore{
+		storage:                s,
+		clusterID:              config.GetGlobalServerConfig().ClusterID,
+		syncPointRetention:     syncPointRetention,
+		lastCleanSyncPointTime: time.Now(),
+	}, nil
+}
+
+// CreateSyncTable implements the SyncPointStore interface,
+// the directory is created along with the first marker file.
+func (s *storageSyncPointStore) CreateSyncTable(_ context.Context) error {
+	return nil
+}
+
+// SinkSyncPoint implements the SyncPointStore interface.
+func (s *storageSyncPointStore) SinkSyncPoint(ctx context.Context,
+	id model.ChangeFeedID,
+	checkpointTs uint64,
+	_ []*model.TableInfo,
+) error {
+	marker := &syncPointMarker{
+		ClusterID:  s.clusterID,
+		Namespace:  id.Namespace,
+		Changefeed: id.ID,
+		SyncPointEvent: model.SyncPointEvent{
+			PrimaryTs: checkpointTs,
+			// there is no TSO in the external storage, so the time when
+			// the marker is written is used as the secondary ts.
+			SecondaryTs: oracle.GoTimeToTS(time.Now()),
+		},
+	}
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	path := cloudstorage.GenerateSyncPointFilePath(checkpointTs)
+	if err := s.storage.WriteFile(ctx, path, data); err != nil {
+		return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+
+	// clean stale syncpoint marker files
+	if time.Since(s.lastCleanSyncPointTime) >= s.syncPointRetention {
+		if err := s.cleanSyncPoints(ctx); err != nil {
+			// It is ok to ignore the error, since it will not affect the correctness of the system,
+			// and no any business logic depends on this behavior, so we just log the error.
+			log.Error("failed to clean syncpoint files", zap.Error(err))
+		} else {
+			s.lastCleanSyncPointTime = time.Now()
+		}
+	}
+	return nil
+}
+
+// cleanSyncPoints removes the marker files whose primary ts is older than the retention.
+func (s *storageSyncPointStore) cleanSyncPoints(ctx context.Context) error {
+	expired := time.Now().Add(-s.syncPointRetention)
+	var toRemove []string
+	opt := &storage.WalkOption{SubDir: cloudstorage.SyncPointDir}
+	err := s.storage.WalkDir(ctx, opt, func(path string, _ int64) error {
+		primaryTs, err := cloudstorage.ParseSyncPointFilePath(path)
+		if err != nil {
+			log.Warn("ignore the unknown file in syncpoint directory", zap.String("path", path))
+			return nil
+		}
+		if oracle.GetTimeFromTS(primaryTs).Before(expired) {
+			toRemove = append(toRemove, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	for _, path := range toRemove {
+		if err := s.storage.DeleteFile(ctx, path); err != nil {
+			return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+		}
+	}
+	return nil
+}
+
+// Close implements the SyncPointStore interface.
+func (s *storageSyncPointStore) Close() error {
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpointstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestStorageSyncPointStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.SyncPointRetention = util.AddressOf(time.Hour)
+	id := model.DefaultChangeFeedID("test")
+	store, err := NewSyncPointStore(ctx, id, fmt.Sprintf("file://%s", dir), replicaConfig)
+	require.NoError(t, err)
+	defer store.Close()
+	require.NoError(t, store.CreateSyncTable(ctx))
+
+	expiredTs := oracle.GoTimeToTS(time.Now().Add(-2 * time.Hour))
+	require.NoError(t, store.SinkSyncPoint(ctx, id, expiredTs, nil))
+
+	ext, _, err := util.GetTestExtStorage(ctx, dir)
+	require.NoError(t, err)
+	data, err := ext.ReadFile(ctx, cloudstorage.GenerateSyncPointFilePath(expiredTs))
+	require.NoError(t, err)
+	event := &model.SyncPointEvent{}
+	require.NoError(t, json.Unmarshal(data, event))
+	require.Equal(t, expiredTs, event.PrimaryTs)
+	require.Greater(t, event.SecondaryTs, expiredTs)
+
+	// the expired marker file is removed after the retention.
+	store.(*storageSyncPointStore).lastCleanSyncPointTime = time.Now().Add(-2 * time.Hour)
+	currentTs := oracle.GoTimeToTS(time.Now())
+	require.NoError(t, store.SinkSyncPoint(ctx, id, currentTs, nil))
+	exists, err := ext.FileExists(ctx, cloudstorage.GenerateSyncPointFilePath(expiredTs))
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = ext.FileExists(ctx, cloudstorage.GenerateSyncPointFilePath(currentTs))
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestNewSyncPointStoreInvalidScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSyncPointStore(context.Background(), model.DefaultChangeFeedID("test"),
+		"blackhole://", config.GetDefaultReplicaConfig())
+	require.ErrorContains(t, err, "the sink scheme (blackhole) is not supported")
+}
@@ -17,10 +17,12 @@ import (
 	"context"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/util"
 )
 
 // SyncPointStore is an abstraction for anything that a changefeed may emit into.
@@ -28,8 +30,14 @@ type SyncPointStore interface {
 	// CreateSyncTable create a table to record the syncpoints
 	CreateSyncTable(ctx context.Context) error
 
-	// SinkSyncPoint record the syncpoint(a map with ts) in downstream db
-	SinkSyncPoint(ctx context.Context, id model.ChangeFeedID, checkpointTs uint64) error
+	// SinkSyncPoint record the syncpoint(a map with ts) in downstream,
+	// the tables are used to decide where the syncpoint should be sent.
+	SinkSyncPoint(
+		ctx context.Context,
+		id model.ChangeFeedID,
+		checkpointTs uint64,
+		tables []*model.TableInfo,
+	) error
 
 	// Close closes the SyncPointSink
 	Close() error
@@ -40,16 +48,22 @@ func NewSyncPointStore(
 	ctx context.Context,
 	changefeedID model.ChangeFeedID,
 	sinkURIStr string,
-	syncPointRetention time.Duration,
+	replicaConfig *config.ReplicaConfig,
 ) (SyncPointStore, error) {
 	// parse sinkURI as a URI
 	sinkURI, err := url.Parse(sinkURIStr)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
-	switch strings.ToLower(sinkURI.Scheme) {
-	case "mysql", "tidb", "mysql+ssl", "tidb+ssl":
+	syncPointRetention := util.GetOrZero(replicaConfig.SyncPointRetention)
+	scheme := strings.ToLower(sinkURI.Scheme)
+	switch {
+	case sink.IsMySQLCompatibleScheme(scheme):
 		return newMySQLSyncPointStore(ctx, changefeedID, sinkURI, syncPointRetention)
+	case sink.IsMQScheme(scheme):
+		return newMQSyncPointStore(ctx, changefeedID, sinkURI, replicaConfig)
+	case sink.IsStorageScheme(scheme):
+		return newStorageSyncPointStore(ctx, changefeedID, sinkURI, syncPointRetention)
 	default:
 		return nil, cerror.ErrSinkURIInvalid.
 			GenWithStack("the sink scheme (%s) is not supported", sinkURI.Scheme)
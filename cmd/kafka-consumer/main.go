@@ -49,7 +49,9 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink/codec/avro"
 	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/debezium"
 	"github.com/pingcap/tiflow/pkg/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/sink/codec/protobuf"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
@@ -83,7 +85,14 @@ var (
 	ca, cert, key string
 
 	// avro schema registry uri should be set if the encoding protocol is avro
-	schemaRegistryURI string
+	schemaRegistryURI  string
+	schemaRegistryType string
+	// the AWS Glue schema registry is located by the region and the registry name
+	glueRegion       string
+	glueRegistryName string
+
+	// the external storage of the large messages sent by the claim check
+	claimCheckStorageURI string
 )
 
 func init() {
@@ -96,6 +105,12 @@ func init() {
 	flag.StringVar(&upstreamURIStr, "upstream-uri", "", "Kafka uri")
 	flag.StringVar(&downstreamURIStr, "downstream-uri", "", "downstream sink uri")
 	flag.StringVar(&schemaRegistryURI, "schema-registry-uri", "", "schema registry uri")
+	flag.StringVar(&schemaRegistryType, "schema-registry-type",
+		common.SchemaRegistryTypeConfluent, "schema registry type, confluent, apicurio or glue")
+	flag.StringVar(&glueRegion, "glue-region", "", "AWS region of the Glue schema registry")
+	flag.StringVar(&glueRegistryName, "glue-registry-name", "", "name of the Glue schema registry")
+	flag.StringVar(&claimCheckStorageURI, "large-message-claim-check-storage-uri", "",
+		"external storage uri of the large messages sent by the claim check")
 	flag.StringVar(&configFile, "config", "", "config file for changefeed")
 	flag.StringVar(&logPath, "log-file", "cdc_kafka_consumer.log", "log file path")
 	flag.StringVar(&logLevel, "log-level", "info", "log file path")
@@ -194,8 +209,9 @@ func init() {
 			log.Panic("invalid enable-tidb-extension of upstream-uri")
 		}
 		if enableTiDBExtension {
-			if protocol != config.ProtocolCanalJSON && protocol != config.ProtocolAvro {
-				log.Panic("enable-tidb-extension only work with canal-json / avro")
+			if protocol != config.ProtocolCanalJSON && protocol != config.ProtocolAvro &&
+				protocol != config.ProtocolDebezium {
+				log.Panic("enable-tidb-extension only work with canal-json / avro / debezium")
 			}
 		}
 	}
@@ -407,6 +423,8 @@ type Consumer struct {
 	enableTiDBExtension bool
 	enableRowChecksum   bool
 
+	claimCheckStorageURI string
+
 	eventRouter *dispatcher.EventRouter
 
 	// avro only
@@ -434,10 +452,16 @@ func NewConsumer(ctx context.Context) (*Consumer, error) {
 	c.protocol = protocol
 	c.enableTiDBExtension = enableTiDBExtension
 	c.enableRowChecksum = enableRowChecksum
+	c.claimCheckStorageURI = claimCheckStorageURI
 
 	if c.protocol == config.ProtocolAvro {
+		registryConfig := common.NewConfig(c.protocol)
+		registryConfig.AvroSchemaRegistry = schemaRegistryURI
+		registryConfig.AvroSchemaRegistryType = schemaRegistryType
+		registryConfig.AvroGlueRegion = glueRegion
+		registryConfig.AvroGlueRegistryName = glueRegistryName
 		keySchemaM, valueSchemaM, err := avro.NewKeyAndValueSchemaManagers(
-			ctx, schemaRegistryURI, nil)
+			ctx, registryConfig, nil)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -562,11 +586,18 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 		decoder codec.RowEventDecoder
 		err     error
 	)
+	codecConfig := common.NewConfig(c.protocol)
+	codecConfig.EnableTiDBExtension = c.enableTiDBExtension
+	codecConfig.LargeMessageClaimCheckStorageURI = c.claimCheckStorageURI
 	switch c.protocol {
 	case config.ProtocolOpen, config.ProtocolDefault:
-		decoder = open.NewBatchDecoder()
+		decoder, err = open.NewBatchDecoder(session.Context(), codecConfig)
 	case config.ProtocolCanalJSON:
-		decoder = canal.NewBatchDecoder(c.enableTiDBExtension, "")
+		decoder, err = canal.NewBatchDecoder(session.Context(), codecConfig)
+	case config.ProtocolDebezium:
+		decoder = debezium.NewBatchDecoder("")
+	case config.ProtocolProtobuf:
+		decoder = protobuf.NewBatchDecoder()
 	case config.ProtocolAvro:
 		config := &common.Config{
 			EnableTiDBExtension: c.enableTiDBExtension,
@@ -710,6 +741,23 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				} else {
 					log.Info("redundant sink resolved ts", zap.Uint64("ts", ts), zap.Int32("partition", partition))
 				}
+			case model.MessageTypeSyncPoint:
+				syncPointDecoder, ok := decoder.(codec.SyncPointEventDecoder)
+				if !ok {
+					log.Panic("syncpoint event is not supported by the protocol",
+						zap.Any("protocol", c.protocol))
+				}
+				event, err := syncPointDecoder.NextSyncPointEvent()
+				if err != nil {
+					log.Panic("decode message value failed",
+						zap.ByteString("value", message.Value),
+						zap.Error(err))
+				}
+				// all the events before the syncpoint has been received by this partition.
+				log.Info("syncpoint received",
+					zap.Uint64("primaryTs", event.PrimaryTs),
+					zap.Uint64("secondaryTs", event.SecondaryTs),
+					zap.Int32("partition", partition))
 			}
 			session.MarkMessage(message, "")
 		}
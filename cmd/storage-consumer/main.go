@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/codec/csv"
+	"github.com/pingcap/tiflow/pkg/sink/codec/debezium"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	putil "github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
@@ -160,6 +161,7 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 	switch putil.GetOrZero(replicaConfig.Sink.Protocol) {
 	case config.ProtocolCsv.String():
 	case config.ProtocolCanalJSON.String():
+	case config.ProtocolDebezium.String():
 	default:
 		return nil, fmt.Errorf(
 			"data encoded in protocol %s is not supported yet",
@@ -267,6 +269,8 @@ func (c *consumer) getNewFiles(
 				// skip handling this file
 				return nil
 			}
+		} else if cloudstorage.IsSyncPointFile(path) {
+			log.Debug("ignore handling syncpoint file", zap.String("path", path))
 		} else if strings.HasSuffix(path, c.fileExtension) {
 			err := c.parseDMLFilePath(ctx, path)
 			if err != nil {
@@ -313,7 +317,18 @@ func (c *consumer) emitDMLEvents(
 	case config.ProtocolCanalJSON:
 		// Always enable tidb extension for canal-json protocol
 		// because we need to get the commit ts from the extension field.
-		decoder = canal.NewBatchDecoder(true, c.codecCfg.Terminator)
+		codecConfig := *c.codecCfg
+		codecConfig.EnableTiDBExtension = true
+		decoder, err = canal.NewBatchDecoder(ctx, &codecConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err := decoder.AddKeyValue(nil, content)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	case config.ProtocolDebezium:
+		decoder = debezium.NewBatchDecoder(c.codecCfg.Terminator)
 		err := decoder.AddKeyValue(nil, content)
 		if err != nil {
 			return errors.Trace(err)
@@ -1995,6 +1995,32 @@ var doc = `{
                 },
                 "is_owner": {
                     "type": "boolean"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "load": {
+                    "$ref": "#/definitions/v2.CaptureLoad"
+                }
+            }
+        },
+        "v2.CaptureLoad": {
+            "type": "object",
+            "properties": {
+                "load": {
+                    "type": "integer"
+                },
+                "region_count": {
+                    "type": "integer"
+                },
+                "sink_rows_per_second": {
+                    "type": "number"
+                },
+                "table_count": {
+                    "type": "integer"
                 }
             }
         },
@@ -2134,13 +2160,31 @@ var doc = `{
                     "description": "EnableTableAcrossNodes set true to split one table to multiple spans and\ndistribute to multiple TiCDC nodes.",
                     "type": "boolean"
                 },
+                "preferred_labels": {
+                    "description": "PreferredLabels are the labels of the captures preferred to replicate tables.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "region_threshold": {
                     "description": "RegionThreshold is the region count threshold of splitting a table.",
                     "type": "integer"
                 },
+                "required_labels": {
+                    "description": "RequiredLabels are the labels a capture must have to replicate tables.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "write_key_threshold": {
                     "description": "WriteKeyThreshold is the written keys threshold of splitting a table.",
                     "type": "integer"
+                },
+                "write_row_threshold": {
+                    "description": "WriteRowThreshold is the rows written per second threshold of\nsplitting a table while the changefeed is running.",
+                    "type": "integer"
                 }
             }
         },
@@ -15,11 +15,16 @@ package applier
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pingcap/log"
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/memquota"
 	"github.com/pingcap/tiflow/cdc/redo/reader"
@@ -29,6 +34,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
 	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/spanz"
@@ -41,6 +47,9 @@ const (
 	applierChangefeed = "redo-applier"
 	warnDuration      = 3 * time.Minute
 	flushWaitDuration = 200 * time.Millisecond
+	// defaultProgressInterval is the interval to flush all tables and record
+	// the progress when there is no DDL to apply.
+	defaultProgressInterval = time.Minute
 )
 
 var (
@@ -57,12 +66,37 @@ type RedoApplierConfig struct {
 	SinkURI string
 	Storage string
 	Dir     string
+	// TargetTs is the ts at which the applier stops, the redo logs with
+	// greater commit ts are not applied. Zero means the resolved ts in meta.
+	TargetTs uint64
+	// FilterRules restricts the tables to apply, the syntax is the same
+	// as the table filter of a changefeed. Empty means all tables.
+	FilterRules []string
+	// DryRunFile is the file to write the generated SQL statements into.
+	// If it is set, the statements are not executed on the sink.
+	DryRunFile string
+	// EncryptionKeyFile is the file of the key used to decrypt the redo logs
+	// encrypted by the changefeed.
+	EncryptionKeyFile string
+	// AllowPlaintext indicates whether the redo logs that are not encrypted
+	// can be read when the EncryptionKeyFile is specified.
+	AllowPlaintext bool
+	// ProgressFile is the file to record the commit ts before which all the
+	// redo logs are applied. The redo logs recorded in it are skipped when
+	// the applier runs again, so that the events are not sent to the sink
+	// more than once. Without it, the MQ and cloud storage sinks receive all
+	// the events again if the redo logs are applied again.
+	ProgressFile string
 }
 
 // RedoApplier implements a redo log applier
 type RedoApplier struct {
-	cfg *RedoApplierConfig
-	rd  reader.RedoLogReader
+	cfg    *RedoApplierConfig
+	rd     reader.RedoLogReader
+	filter filter.Filter
+
+	// sqlWriter is used instead of sinks in dry run mode.
+	sqlWriter *sqlWriter
 
 	ddlSink         ddlsink.Sink
 	appliedDDLCount uint64
@@ -78,6 +112,23 @@ type RedoApplier struct {
 	tableResolvedTsMap map[model.TableID]*memquota.MemConsumeRecord
 	appliedLogCount    uint64
 
+	// tableInfos is a map from tableID to the table info built from redo logs,
+	// the table infos are not recorded in redo logs but required by some sinks,
+	// such as the cloud storage sink.
+	tableInfos map[model.TableID]*model.TableInfo
+	// tableVersions is a map from table name to the commit ts of the last DDL,
+	// which is used as the version of the table info.
+	tableVersions map[model.TableName]uint64
+	// appliedRows is used to skip the duplicated rows in redo logs, which may
+	// be written more than once if the table is rescheduled. It's a map from
+	// tableID to the handle keys of the rows applied at the current commit ts.
+	appliedRows  map[model.TableID]map[string]struct{}
+	appliedTs    map[model.TableID]model.Ts
+	skippedCount uint64
+
+	progressInterval time.Duration
+	lastProgressTime time.Time
+
 	errCh chan error
 
 	// changefeedID is used to identify the changefeed that this applier belongs to.
@@ -88,8 +139,9 @@ type RedoApplier struct {
 // NewRedoApplier creates a new RedoApplier instance
 func NewRedoApplier(cfg *RedoApplierConfig) *RedoApplier {
 	return &RedoApplier{
-		cfg:   cfg,
-		errCh: make(chan error, 1024),
+		cfg:              cfg,
+		errCh:            make(chan error, 1024),
+		progressInterval: defaultProgressInterval,
 	}
 }
 
@@ -107,6 +159,8 @@ func (rac *RedoApplierConfig) toLogReaderConfig() (string, *reader.LogReaderConf
 		URI:                *uri,
 		Dir:                rac.Dir,
 		UseExternalStorage: redo.IsExternalStorage(uri.Scheme),
+		EncryptionKeyFile:  rac.EncryptionKeyFile,
+		AllowPlaintext:     rac.AllowPlaintext,
 	}
 	return uri.Scheme, cfg, nil
 }
@@ -122,19 +176,59 @@ func (ra *RedoApplier) catchError(ctx context.Context) error {
 	}
 }
 
+func (ra *RedoApplier) initFilter() (err error) {
+	replicaConfig := config.GetDefaultReplicaConfig()
+	if len(ra.cfg.FilterRules) != 0 {
+		replicaConfig.Filter.Rules = ra.cfg.FilterRules
+	}
+	ra.filter, err = filter.NewFilter(replicaConfig, "")
+	return err
+}
+
 func (ra *RedoApplier) initSink(ctx context.Context) (err error) {
+	ra.tableSinks = make(map[model.TableID]tablesink.TableSink)
+	ra.tableResolvedTsMap = make(map[model.TableID]*memquota.MemConsumeRecord)
+	ra.tableInfos = make(map[model.TableID]*model.TableInfo)
+	ra.tableVersions = make(map[model.TableName]uint64)
+	ra.appliedRows = make(map[model.TableID]map[string]struct{})
+	ra.appliedTs = make(map[model.TableID]model.Ts)
+	if ra.cfg.DryRunFile != "" {
+		ra.sqlWriter, err = newSQLWriter(ra.cfg.DryRunFile)
+		return err
+	}
+
+	sinkURI, err := url.Parse(ra.cfg.SinkURI)
+	if err != nil {
+		return errors.WrapError(errors.ErrSinkURIInvalid, err)
+	}
+	// adjust the replica config by the sink URI, such as the protocol
+	// used by the MQ and cloud storage sinks.
 	replicaConfig := config.GetDefaultReplicaConfig()
+	if err := replicaConfig.ValidateAndAdjust(sinkURI); err != nil {
+		return err
+	}
 	ra.sinkFactory, err = dmlfactory.New(ctx, ra.changefeedID, ra.cfg.SinkURI, replicaConfig, ra.errCh)
 	if err != nil {
 		return err
 	}
 	ra.ddlSink, err = ddlfactory.New(ctx, ra.changefeedID, ra.cfg.SinkURI, replicaConfig)
-	if err != nil {
+	return err
+}
+
+func (ra *RedoApplier) closeSink() error {
+	if ra.sinkFactory != nil {
+		ra.sinkFactory.Close()
+		ra.sinkFactory = nil
+	}
+	if ra.ddlSink != nil {
+		ra.ddlSink.Close()
+		ra.ddlSink = nil
+	}
+	if ra.sqlWriter != nil {
+		err := ra.sqlWriter.close()
+		ra.sqlWriter = nil
 		return err
 	}
-
-	ra.tableSinks = make(map[model.TableID]tablesink.TableSink)
-	ra.tableResolvedTsMap = make(map[model.TableID]*memquota.MemConsumeRecord)
 	return nil
 }
 
@@ -159,13 +253,50 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	targetTs := resolvedTs
+	if ra.cfg.TargetTs != 0 {
+		if ra.cfg.TargetTs < checkpointTs || ra.cfg.TargetTs > resolvedTs {
+			return errors.WrapError(errors.ErrRedoConfigInvalid, errors.Errorf(
+				"target ts %d should be in range [%d, %d]",
+				ra.cfg.TargetTs, checkpointTs, resolvedTs))
+		}
+		targetTs = ra.cfg.TargetTs
+	}
+	appliedTs, err := ra.readProgress()
+	if err != nil {
+		return err
+	}
+	if appliedTs > resolvedTs {
+		return errors.WrapError(errors.ErrRedoConfigInvalid, errors.Errorf(
+			"applied ts %d in progress file %s should not be greater than resolved ts %d",
+			appliedTs, ra.cfg.ProgressFile, resolvedTs))
+	}
+	if appliedTs >= targetTs {
+		log.Info("redo logs have been applied",
+			zap.Uint64("appliedTs", appliedTs),
+			zap.Uint64("targetTs", targetTs),
+			zap.String("progressFile", ra.cfg.ProgressFile))
+		return errApplyFinished
+	}
 	log.Info("apply redo log starts",
 		zap.Uint64("checkpointTs", checkpointTs),
-		zap.Uint64("resolvedTs", resolvedTs))
+		zap.Uint64("resolvedTs", resolvedTs),
+		zap.Uint64("appliedTs", appliedTs),
+		zap.Uint64("targetTs", targetTs),
+		zap.Strings("filterRules", ra.cfg.FilterRules),
+		zap.String("dryRunFile", ra.cfg.DryRunFile))
+	if err := ra.initFilter(); err != nil {
+		return err
+	}
 	if err := ra.initSink(ctx); err != nil {
 		return err
 	}
-	defer ra.sinkFactory.Close()
+	ra.lastProgressTime = time.Now()
+	defer func() {
+		if err := ra.closeSink(); err != nil {
+			log.Warn("close redo applier sink failed", zap.Error(err))
+		}
+	}()
 
 	shouldApplyDDL := func(row *model.RowChangedEvent, ddl *model.DDLEvent) bool {
 		if ddl == nil {
@@ -179,11 +310,37 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 		return row.CommitTs > ddl.CommitTs
 	}
 
-	row, err := ra.rd.ReadNextRow(ctx)
+	// The logs with commit ts greater than target ts are never applied,
+	// so they are treated as the end of the stream. The logs applied by
+	// the previous runs are skipped.
+	readNextRow := func() (*model.RowChangedEvent, error) {
+		for {
+			row, err := ra.rd.ReadNextRow(ctx)
+			if err != nil || row == nil || row.CommitTs > targetTs {
+				return nil, err
+			}
+			if row.CommitTs > appliedTs {
+				return row, nil
+			}
+		}
+	}
+	readNextDDL := func() (*model.DDLEvent, error) {
+		for {
+			ddl, err := ra.rd.ReadNextDDL(ctx)
+			if err != nil || ddl == nil || ddl.CommitTs > targetTs {
+				return nil, err
+			}
+			if ddl.CommitTs > appliedTs {
+				return ddl, nil
+			}
+		}
+	}
+
+	row, err := readNextRow()
 	if err != nil {
 		return err
 	}
-	ddl, err := ra.rd.ReadNextDDL(ctx)
+	ddl, err := readNextDDL()
 	if err != nil {
 		return err
 	}
@@ -195,30 +352,52 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 			if err := ra.applyDDL(ctx, ddl, checkpointTs); err != nil {
 				return err
 			}
-			if ddl, err = ra.rd.ReadNextDDL(ctx); err != nil {
+			if ddl, err = readNextDDL(); err != nil {
 				return err
 			}
 		} else {
+			if err := ra.flushProgress(ctx, row.CommitTs); err != nil {
+				return err
+			}
 			if err := ra.applyRow(row, checkpointTs); err != nil {
 				return err
 			}
-			if row, err = ra.rd.ReadNextRow(ctx); err != nil {
+			if row, err = readNextRow(); err != nil {
 				return err
 			}
 		}
 	}
 	// wait all tables to flush data
 	for tableID := range ra.tableResolvedTsMap {
-		if err := ra.waitTableFlush(ctx, tableID, resolvedTs); err != nil {
+		if err := ra.waitTableFlush(ctx, tableID, targetTs); err != nil {
 			return err
 		}
 		ra.tableSinks[tableID].Close()
 	}
+	// Emit the checkpoint to notify the consumers of MQ and cloud storage
+	// sinks that all events before target ts have been sent.
+	if ra.ddlSink != nil {
+		tables := make([]*model.TableInfo, 0, len(ra.tableInfos))
+		for _, info := range ra.tableInfos {
+			tables = append(tables, info)
+		}
+		if err := ra.ddlSink.WriteCheckpointTs(ctx, targetTs, tables); err != nil {
+			return err
+		}
+	}
+	if err := ra.writeProgress(targetTs); err != nil {
+		return err
+	}
 
 	log.Info("apply redo log finishes",
 		zap.Uint64("appliedLogCount", ra.appliedLogCount),
 		zap.Uint64("appliedDDLCount", ra.appliedDDLCount),
-		zap.Uint64("currentCheckpoint", resolvedTs))
+		zap.Uint64("skippedDuplicatedLogCount", ra.skippedCount),
+		zap.Uint64("currentCheckpoint", targetTs))
+	// close the sink here to report the error of flushing the dry run file.
+	if err := ra.closeSink(); err != nil {
+		return err
+	}
 	return errApplyFinished
 }
 
@@ -274,11 +453,23 @@ func (ra *RedoApplier) applyDDL(
 			log.Warn("ignore DDL without table info", zap.Any("ddl", ddl))
 			return true
 		}
+		if ra.shouldIgnoreDDL(ddl) {
+			log.Info("ignore DDL filtered by table filter", zap.Any("ddl", ddl))
+			return true
+		}
 		return false
 	}
 	if shouldSkip() {
 		return nil
 	}
+	ra.resetTableInfo(ddl)
+	if ra.sqlWriter != nil {
+		if err := ra.sqlWriter.writeDDL(ddl); err != nil {
+			return err
+		}
+		ra.appliedDDLCount++
+		return nil
+	}
 	log.Warn("apply DDL", zap.Any("ddl", ddl))
 	// Wait all tables to flush data before applying DDL.
 	// TODO: only block tables that are affected by this DDL.
@@ -291,12 +482,104 @@ func (ra *RedoApplier) applyDDL(
 		return err
 	}
 	ra.appliedDDLCount++
+	// All the redo logs before the DDL are flushed.
+	return ra.writeProgress(ddl.CommitTs)
+}
+
+// readProgress returns the commit ts recorded in the progress file, it
+// returns 0 if the progress file is not set or does not exist.
+func (ra *RedoApplier) readProgress() (model.Ts, error) {
+	if ra.cfg.ProgressFile == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(ra.cfg.ProgressFile)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	ts, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, errors.WrapError(errors.ErrRedoConfigInvalid, errors.Errorf(
+			"invalid progress file %s: %s", ra.cfg.ProgressFile, err))
+	}
+	return ts, nil
+}
+
+// writeProgress records that all the redo logs with commit ts not greater
+// than ts are applied. The file is replaced atomically.
+func (ra *RedoApplier) writeProgress(ts model.Ts) error {
+	if ra.cfg.ProgressFile == "" || ra.sqlWriter != nil {
+		return nil
+	}
+	tmp := ra.cfg.ProgressFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(ts, 10)), 0o644); err != nil {
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	if err := os.Rename(tmp, ra.cfg.ProgressFile); err != nil {
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	ra.lastProgressTime = time.Now()
 	return nil
 }
 
+// flushProgress flushes all tables and records the progress before the row
+// with commitTs is applied, so that a crash in a long range of redo logs
+// without DDLs does not send all the applied events again. It is done once
+// in progressInterval, and only if no row with commitTs is applied yet.
+func (ra *RedoApplier) flushProgress(ctx context.Context, commitTs model.Ts) error {
+	if ra.cfg.ProgressFile == "" || ra.sqlWriter != nil ||
+		time.Since(ra.lastProgressTime) < ra.progressInterval {
+		return nil
+	}
+	for _, record := range ra.tableResolvedTsMap {
+		if record.ResolvedTs.Ts >= commitTs {
+			return nil
+		}
+	}
+	for tableID, record := range ra.tableResolvedTsMap {
+		if err := ra.waitTableFlush(ctx, tableID, record.ResolvedTs.Ts); err != nil {
+			return err
+		}
+	}
+	return ra.writeProgress(commitTs - 1)
+}
+
+func (ra *RedoApplier) shouldIgnoreDDL(ddl *model.DDLEvent) bool {
+	if ddl.Type == timodel.ActionRenameTable && ddl.PreTableInfo == nil {
+		// The pre table info is not recorded in redo logs,
+		// so the table is matched by its new name.
+		return ra.filter.ShouldIgnoreTable(
+			ddl.TableInfo.TableName.Schema, ddl.TableInfo.TableName.Table)
+	}
+	ignore, err := ra.filter.ShouldIgnoreDDLEvent(ddl)
+	if err != nil {
+		// apply the DDL to be safe if it can not be matched.
+		log.Warn("filter DDL failed", zap.Any("ddl", ddl), zap.Error(err))
+		return false
+	}
+	return ignore
+}
+
 func (ra *RedoApplier) applyRow(
 	row *model.RowChangedEvent, checkpointTs model.Ts,
 ) error {
+	if ra.filter.ShouldIgnoreTable(row.Table.Schema, row.Table.Table) {
+		return nil
+	}
+	if ra.isDuplicated(row) {
+		ra.skippedCount++
+		return nil
+	}
+	if ra.sqlWriter != nil {
+		if err := ra.sqlWriter.writeRow(row); err != nil {
+			return err
+		}
+		ra.appliedLogCount++
+		return nil
+	}
+
 	rowSize := uint64(row.ApproximateBytes())
 	if rowSize > ra.pendingQuota {
 		if err := ra.resetQuota(uint64(row.ApproximateBytes())); err != nil {
@@ -327,6 +610,7 @@ func (ra *RedoApplier) applyRow(
 		}
 	}
 
+	ra.fillTableInfo(row, checkpointTs)
 	ra.tableSinks[tableID].AppendRowChangedEvents(row)
 	record := ra.tableResolvedTsMap[tableID]
 	record.Size += rowSize
@@ -351,6 +635,100 @@ func (ra *RedoApplier) applyRow(
 	return nil
 }
 
+// isDuplicated returns true if the row has been applied. The rows are read
+// in the order of commit ts, and in a transaction there is at most one row
+// change for a handle key, so the rows are deduplicated by the handle key
+// among the rows with the same commit ts of the table.
+func (ra *RedoApplier) isDuplicated(row *model.RowChangedEvent) bool {
+	key := handleKey(row)
+	if key == "" {
+		// the row without handle key can not be deduplicated.
+		return false
+	}
+	tableID := row.Table.TableID
+	if ra.appliedTs[tableID] != row.CommitTs {
+		ra.appliedTs[tableID] = row.CommitTs
+		ra.appliedRows[tableID] = make(map[string]struct{})
+	}
+	if _, ok := ra.appliedRows[tableID][key]; ok {
+		return true
+	}
+	ra.appliedRows[tableID][key] = struct{}{}
+	return false
+}
+
+func handleKey(row *model.RowChangedEvent) string {
+	var b strings.Builder
+	appendKey := func(cols []*model.Column) {
+		for _, col := range cols {
+			if col != nil && col.Flag.IsHandleKey() {
+				fmt.Fprintf(&b, "%s=%v,", col.Name, col.Value)
+			}
+		}
+		b.WriteByte(';')
+	}
+	appendKey(row.PreColumns)
+	appendKey(row.Columns)
+	if b.Len() == 2 {
+		return ""
+	}
+	return b.String()
+}
+
+// fillTableInfo fills the table info and column infos of the row, which are
+// built from the columns of the row since they are not recorded in redo logs.
+func (ra *RedoApplier) fillTableInfo(row *model.RowChangedEvent, checkpointTs model.Ts) {
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	info, ok := ra.tableInfos[row.Table.TableID]
+	if !ok || len(info.Columns) != len(cols) {
+		name := model.TableName{Schema: row.Table.Schema, Table: row.Table.Table}
+		version, ok := ra.tableVersions[name]
+		if !ok {
+			version = checkpointTs
+		}
+		tidbInfo := model.BuildTiDBTableInfo(cols, row.IndexColumns)
+		tidbInfo.ID = row.Table.TableID
+		tidbInfo.Name = timodel.NewCIStr(row.Table.Table)
+		info = &model.TableInfo{
+			TableInfo: tidbInfo,
+			TableName: *row.Table,
+			Version:   version,
+		}
+		ra.tableInfos[row.Table.TableID] = info
+	}
+	row.TableInfo = info
+	if len(row.ColInfos) == 0 {
+		row.ColInfos = make([]rowcodec.ColInfo, len(info.Columns))
+		for i, col := range info.Columns {
+			row.ColInfos[i] = rowcodec.ColInfo{
+				ID: col.ID,
+				Ft: &col.FieldType,
+			}
+		}
+	}
+}
+
+// resetTableInfo removes the table infos changed by the DDL, so that the
+// new table infos are built with the version of the DDL.
+func (ra *RedoApplier) resetTableInfo(ddl *model.DDLEvent) {
+	names := []model.TableName{ddl.TableInfo.TableName}
+	if ddl.PreTableInfo != nil {
+		names = append(names, ddl.PreTableInfo.TableName)
+	}
+	for _, name := range names {
+		name = model.TableName{Schema: name.Schema, Table: name.Table}
+		ra.tableVersions[name] = ddl.CommitTs
+		for tableID, info := range ra.tableInfos {
+			if info.TableName.Schema == name.Schema && info.TableName.Table == name.Table {
+				delete(ra.tableInfos, tableID)
+			}
+		}
+	}
+}
+
 func (ra *RedoApplier) waitTableFlush(
 	ctx context.Context, tableID model.TableID, rts model.Ts,
 ) error {
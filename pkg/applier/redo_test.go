@@ -17,12 +17,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/go-sql-driver/mysql"
+	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/phayes/freeport"
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo/reader"
 	mysqlDDL "github.com/pingcap/tiflow/cdc/sink/ddlsink/mysql"
@@ -226,6 +231,322 @@ func TestApplyMeetSinkError(t *testing.T) {
 	require.Regexp(t, "CDC:ErrMySQLConnectionError", err)
 }
 
+func TestApplyDryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpointTs := uint64(1000)
+	resolvedTs := uint64(2000)
+	redoLogCh := make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh := make(chan *model.DDLEvent, 1024)
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(checkpointTs, resolvedTs, redoLogCh, ddlEventCh), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	newRow := func(commitTs uint64, table string, pre, cur []*model.Column) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:    commitTs - 10,
+			CommitTs:   commitTs,
+			Table:      &model.TableName{Schema: "test", Table: table},
+			PreColumns: pre,
+			Columns:    cur,
+		}
+	}
+	newColumns := func(a int, b interface{}) []*model.Column {
+		return []*model.Column{
+			{Name: "a", Value: a, Flag: model.HandleKeyFlag},
+			{Name: "b", Value: b, Charset: "utf8mb4"},
+			{Name: "c", Value: 1, Flag: model.GeneratedColumnFlag},
+		}
+	}
+	dmls := []*model.RowChangedEvent{
+		newRow(1100, "t1", nil, newColumns(1, []byte("it's"))),
+		newRow(1200, "t2", nil, newColumns(1, "filtered")),
+		newRow(1300, "t1", newColumns(1, []byte("it's")), newColumns(2, nil)),
+		newRow(1500, "t1", newColumns(2, nil), nil),
+		// the rows after target ts are not applied.
+		newRow(1600, "t1", nil, newColumns(3, "x")),
+	}
+	for _, dml := range dmls {
+		redoLogCh <- dml
+	}
+	newDDL := func(commitTs uint64, table, query string) *model.DDLEvent {
+		return &model.DDLEvent{
+			CommitTs: commitTs,
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{Schema: "test", Table: table},
+			},
+			Query: query,
+			Type:  timodel.ActionAddColumn,
+		}
+	}
+	ddls := []*model.DDLEvent{
+		newDDL(1200, "t1", "alter table t1 add column d int"),
+		newDDL(1200, "t2", "alter table t2 add column d int"),
+		newDDL(1500, "t1", "alter table t1 add column e int;"),
+		newDDL(1600, "t1", "alter table t1 add column f int"),
+	}
+	for _, ddl := range ddls {
+		ddlEventCh <- ddl
+	}
+	close(redoLogCh)
+	close(ddlEventCh)
+
+	dryRunFile := filepath.Join(t.TempDir(), "redo.sql")
+	cfg := &RedoApplierConfig{
+		TargetTs:    1500,
+		FilterRules: []string{"test.t1"},
+		DryRunFile:  dryRunFile,
+	}
+	ap := NewRedoApplier(cfg)
+	err := ap.Apply(ctx)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(dryRunFile)
+	require.NoError(t, err)
+	require.Equal(t, "REPLACE INTO `test`.`t1` (`a`,`b`) VALUES (1,'it\\'s');\n"+
+		"USE `test`;\n"+
+		"alter table t1 add column d int;\n"+
+		"DELETE FROM `test`.`t1` WHERE `a` = 1 LIMIT 1;\n"+
+		"REPLACE INTO `test`.`t1` (`a`,`b`) VALUES (2,NULL);\n"+
+		"DELETE FROM `test`.`t1` WHERE `a` = 2 LIMIT 1;\n"+
+		"USE `test`;\n"+
+		"alter table t1 add column e int;\n", string(data))
+}
+
+func TestApplyInvalidTargetTs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(1000, 2000, nil, nil), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	for _, targetTs := range []uint64{999, 2001} {
+		cfg := &RedoApplierConfig{
+			TargetTs:   targetTs,
+			DryRunFile: filepath.Join(t.TempDir(), "redo.sql"),
+		}
+		err := NewRedoApplier(cfg).Apply(ctx)
+		require.ErrorContains(t, err, "ErrRedoConfigInvalid")
+	}
+}
+
+func TestApplyToStorage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpointTs := uint64(1000)
+	resolvedTs := uint64(2000)
+	redoLogCh := make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh := make(chan *model.DDLEvent, 1024)
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(checkpointTs, resolvedTs, redoLogCh, ddlEventCh), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	newRow := func(commitTs uint64, a int64) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:  commitTs - 10,
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: a, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+				{Name: "b", Type: mysql.TypeVarchar, Value: []byte("x")},
+			},
+			IndexColumns: [][]int{{0}},
+		}
+	}
+	// the rows of a transaction may be written more than once.
+	dmls := []*model.RowChangedEvent{
+		newRow(1100, 1), newRow(1100, 2), newRow(1100, 1), newRow(1100, 2),
+		newRow(1200, 1), newRow(1200, 1),
+	}
+	for _, dml := range dmls {
+		redoLogCh <- dml
+	}
+	close(redoLogCh)
+	close(ddlEventCh)
+
+	dir := t.TempDir()
+	progressFile := filepath.Join(t.TempDir(), "progress")
+	cfg := &RedoApplierConfig{
+		SinkURI:      fmt.Sprintf("file://%s?protocol=csv&flush-interval=2s", dir),
+		ProgressFile: progressFile,
+	}
+	ap := NewRedoApplier(cfg)
+	err := ap.Apply(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), ap.appliedLogCount)
+	require.Equal(t, uint64(3), ap.skippedCount)
+
+	var lines []string
+	err = filepath.Walk(filepath.Join(dir, "test", "t1"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || filepath.Ext(path) != ".csv" {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, strings.Split(strings.TrimSpace(string(data)), "\r\n")...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`"I","t1","test",1,"x"`,
+		`"I","t1","test",2,"x"`,
+		`"I","t1","test",1,"x"`,
+	}, lines)
+
+	// the checkpoint is written to the metadata file.
+	data, err := os.ReadFile(filepath.Join(dir, "metadata"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"checkpoint-ts":2000}`, string(data))
+
+	// The redo logs applied by the previous run are skipped.
+	data, err = os.ReadFile(progressFile)
+	require.NoError(t, err)
+	require.Equal(t, "2000", string(data))
+	redoLogCh = make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh = make(chan *model.DDLEvent, 1024)
+	ap = NewRedoApplier(cfg)
+	require.NoError(t, ap.Apply(ctx))
+	require.Equal(t, uint64(0), ap.appliedLogCount)
+}
+
+func TestApplyWithProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	redoLogCh := make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh := make(chan *model.DDLEvent, 1024)
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(1000, 2000, redoLogCh, ddlEventCh), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	for _, commitTs := range []uint64{1100, 1200, 1300} {
+		redoLogCh <- &model.RowChangedEvent{
+			StartTs:  commitTs - 10,
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: int64(commitTs), Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			},
+			IndexColumns: [][]int{{0}},
+		}
+	}
+	close(redoLogCh)
+	close(ddlEventCh)
+
+	dir := t.TempDir()
+	progressFile := filepath.Join(t.TempDir(), "progress")
+	cfg := &RedoApplierConfig{
+		SinkURI:      fmt.Sprintf("file://%s?protocol=csv&flush-interval=2s", dir),
+		ProgressFile: progressFile,
+	}
+	// The rows before the recorded progress are skipped.
+	require.NoError(t, os.WriteFile(progressFile, []byte("1200"), 0o644))
+	ap := NewRedoApplier(cfg)
+	require.NoError(t, ap.Apply(ctx))
+	require.Equal(t, uint64(1), ap.appliedLogCount)
+	data, err := os.ReadFile(progressFile)
+	require.NoError(t, err)
+	require.Equal(t, "2000", string(data))
+
+	// The progress must belong to the redo logs.
+	require.NoError(t, os.WriteFile(progressFile, []byte("3000"), 0o644))
+	err = NewRedoApplier(cfg).Apply(ctx)
+	require.ErrorContains(t, err, "ErrRedoConfigInvalid")
+	require.NoError(t, os.WriteFile(progressFile, []byte("abc"), 0o644))
+	err = NewRedoApplier(cfg).Apply(ctx)
+	require.ErrorContains(t, err, "invalid progress file")
+}
+
+func TestApplyRecordProgressWithoutDDL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	redoLogCh := make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh := make(chan *model.DDLEvent, 1024)
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(1000, 2000, redoLogCh, ddlEventCh), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	newRow := func(commitTs uint64) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:  commitTs - 10,
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: int64(commitTs), Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			},
+			IndexColumns: [][]int{{0}},
+		}
+	}
+	for _, commitTs := range []uint64{1100, 1200, 1300} {
+		redoLogCh <- newRow(commitTs)
+	}
+	close(ddlEventCh)
+
+	dir := t.TempDir()
+	progressFile := filepath.Join(t.TempDir(), "progress")
+	cfg := &RedoApplierConfig{
+		SinkURI:      fmt.Sprintf("file://%s?protocol=csv&flush-interval=1s", dir),
+		ProgressFile: progressFile,
+	}
+	// The applier is stopped before all redo logs are read, the progress
+	// is recorded after the rows before 1300 are flushed.
+	ap := NewRedoApplier(cfg)
+	ap.progressInterval = 0
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ap.Apply(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(progressFile)
+		return err == nil && string(data) == "1299"
+	}, 10*time.Second, 50*time.Millisecond)
+	cancel()
+	require.Error(t, <-errCh)
+
+	// The rows recorded in the progress are not applied again.
+	redoLogCh = make(chan *model.RowChangedEvent, 1024)
+	ddlEventCh = make(chan *model.DDLEvent, 1024)
+	for _, commitTs := range []uint64{1100, 1200, 1300} {
+		redoLogCh <- newRow(commitTs)
+	}
+	close(redoLogCh)
+	close(ddlEventCh)
+	ap = NewRedoApplier(cfg)
+	require.NoError(t, ap.Apply(context.Background()))
+	require.Equal(t, uint64(1), ap.appliedLogCount)
+}
+
 func getMockDB(t *testing.T) *sql.DB {
 	// normal db
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -233,11 +554,11 @@ func getMockDB(t *testing.T) *sql.DB {
 
 	// Before we write data to downstream, we need to check whether the downstream is TiDB.
 	// So we mock a select tidb_version() query.
-	mock.ExpectQuery("select tidb_version()").WillReturnError(&mysql.MySQLError{
+	mock.ExpectQuery("select tidb_version()").WillReturnError(&dmysql.MySQLError{
 		Number:  1305,
 		Message: "FUNCTION test.tidb_version does not exist",
 	})
-	mock.ExpectQuery("select tidb_version()").WillReturnError(&mysql.MySQLError{
+	mock.ExpectQuery("select tidb_version()").WillReturnError(&dmysql.MySQLError{
 		Number:  1305,
 		Message: "FUNCTION test.tidb_version does not exist",
 	})
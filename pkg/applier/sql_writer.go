@@ -0,0 +1,177 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+// sqlWriter writes the SQL statements generated from redo logs into a file
+// instead of executing them, which is used by the dry run mode.
+// The statements are generated in safe mode, that is, each insert is written
+// as a REPLACE and each update is written as a DELETE followed by a REPLACE.
+type sqlWriter struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newSQLWriter(path string) (*sqlWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	return &sqlWriter{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+func (w *sqlWriter) writeDDL(ddl *model.DDLEvent) error {
+	if schema := ddl.TableInfo.TableName.Schema; schema != "" {
+		if err := w.writeStmt("USE " + quotes.QuoteName(schema)); err != nil {
+			return err
+		}
+	}
+	return w.writeStmt(ddl.Query)
+}
+
+func (w *sqlWriter) writeRow(row *model.RowChangedEvent) error {
+	quoteTable := quotes.QuoteSchema(row.Table.Schema, row.Table.Table)
+	if len(row.PreColumns) != 0 {
+		stmt, err := buildDelete(quoteTable, row.PreColumns)
+		if err != nil {
+			return err
+		}
+		if err := w.writeStmt(stmt); err != nil {
+			return err
+		}
+	}
+	if len(row.Columns) != 0 {
+		stmt, err := buildReplace(quoteTable, row.Columns)
+		if err != nil {
+			return err
+		}
+		if err := w.writeStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *sqlWriter) writeStmt(stmt string) error {
+	if stmt == "" {
+		return nil
+	}
+	stmt = strings.TrimRight(strings.TrimSpace(stmt), ";")
+	if _, err := w.writer.WriteString(stmt + ";\n"); err != nil {
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	return nil
+}
+
+func (w *sqlWriter) close() error {
+	if err := w.writer.Flush(); err != nil {
+		w.file.Close()
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	if err := w.file.Close(); err != nil {
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	return nil
+}
+
+// buildReplace builds a statement as following
+// sql: `REPLACE INTO `test`.`t` (`a`,`b`) VALUES (1,'x')`
+func buildReplace(quoteTable string, cols []*model.Column) (string, error) {
+	names := make([]string, 0, len(cols))
+	values := make([]string, 0, len(cols))
+	for _, col := range cols {
+		if col == nil || col.Flag.IsGeneratedColumn() {
+			continue
+		}
+		value, err := formatValue(col)
+		if err != nil {
+			return "", err
+		}
+		names = append(names, quotes.QuoteName(col.Name))
+		values = append(values, value)
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	return "REPLACE INTO " + quoteTable + " (" + strings.Join(names, ",") +
+		") VALUES (" + strings.Join(values, ",") + ")", nil
+}
+
+// buildDelete builds a statement as following
+// sql: `DELETE FROM `test`.`t` WHERE `a` = 1 AND `b` IS NULL LIMIT 1`
+// The handle key columns are used in the where condition if there are any,
+// otherwise all columns are used.
+func buildDelete(quoteTable string, cols []*model.Column) (string, error) {
+	conds := make([]*model.Column, 0, len(cols))
+	for _, col := range cols {
+		if col != nil && col.Flag.IsHandleKey() {
+			conds = append(conds, col)
+		}
+	}
+	if len(conds) == 0 {
+		for _, col := range cols {
+			if col != nil && !col.Flag.IsGeneratedColumn() {
+				conds = append(conds, col)
+			}
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM " + quoteTable + " WHERE ")
+	for i, col := range conds {
+		if i > 0 {
+			builder.WriteString(" AND ")
+		}
+		if col.Value == nil {
+			builder.WriteString(quotes.QuoteName(col.Name) + " IS NULL")
+			continue
+		}
+		value, err := formatValue(col)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(quotes.QuoteName(col.Name) + " = " + value)
+	}
+	builder.WriteString(" LIMIT 1")
+	return builder.String(), nil
+}
+
+// formatValue returns the SQL literal of the column value. Like the MySQL
+// sink does, the []byte value of a non-binary column is written as a string,
+// so that it is not prefixed with the `_binary` charset.
+func formatValue(col *model.Column) (string, error) {
+	value := col.Value
+	if b, ok := value.([]byte); ok && col.Charset != "" && col.Charset != charset.CharsetBin {
+		value = string(b)
+	}
+	literal, err := sqlexec.EscapeSQL("%?", value)
+	if err != nil {
+		return "", errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	return literal, nil
+}
@@ -24,6 +24,9 @@ func TestMain(m *testing.M) {
 	opts := []goleak.Option{
 		// library used by log
 		goleak.IgnoreTopFunction("gopkg.in/natefinch/lumberjack%2ev2.(*Logger).millRun"),
+		// The pulsar client links keyring, which connects to the dbus session
+		// bus during initialization if the bus is available.
+		goleak.IgnoreCurrent(),
 	}
 	leakutil.SetUpLeakTest(m, opts...)
 }
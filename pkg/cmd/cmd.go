@@ -17,6 +17,7 @@ import (
 	"os"
 
 	"github.com/pingcap/tiflow/pkg/cmd/cli"
+	"github.com/pingcap/tiflow/pkg/cmd/consumer"
 	"github.com/pingcap/tiflow/pkg/cmd/redo"
 	"github.com/pingcap/tiflow/pkg/cmd/server"
 	"github.com/pingcap/tiflow/pkg/cmd/version"
@@ -46,6 +47,7 @@ func Run() {
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
 	cmd.AddCommand(redo.NewCmdRedo())
+	cmd.AddCommand(consumer.NewCmdConsumer())
 
 	if err := cmd.Execute(); err != nil {
 		cmd.PrintErrln(err)
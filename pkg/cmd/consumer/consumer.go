@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `consumer` command.
+type options struct {
+	logLevel string
+	logFile  string
+	timezone string
+}
+
+// newOptions creates new options for the `consumer` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+	cmd.PersistentFlags().StringVar(&o.logFile, "log-file", "", "log file path")
+	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "System", "specify time zone of the consumer")
+}
+
+// NewCmdConsumer creates the `consumer` command.
+func NewCmdConsumer() *cobra.Command {
+	o := newOptions()
+
+	cmds := &cobra.Command{
+		Use:   "consumer",
+		Short: "Consume the changes sent by TiCDC and apply them to the downstream",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Here we will initialize the logging configuration and set the current default context.
+			cancel := util.InitCmd(cmd, &logutil.Config{Level: o.logLevel, File: o.logFile})
+			util.LogHTTPProxies()
+			// the consumer exits once the context is canceled.
+			doneNotify := func() <-chan struct{} {
+				done := make(chan struct{})
+				close(done)
+				return done
+			}
+			util.InitSignalHandling(doneNotify, cancel)
+
+			// the timezone is used to decode the rows and connect to the downstream.
+			serverCfg := config.GetGlobalServerConfig().Clone()
+			serverCfg.TZ = o.timezone
+			config.StoreGlobalServerConfig(serverCfg)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+		},
+	}
+	o.addFlags(cmds)
+
+	// Add subcommands.
+	cmds.AddCommand(newCmdKafka())
+	cmds.AddCommand(newCmdStorage())
+
+	return cmds
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	pconsumer "github.com/pingcap/tiflow/pkg/consumer"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/spf13/cobra"
+)
+
+// kafkaOptions defines flags for the `consumer kafka` command.
+type kafkaOptions struct {
+	consumerID    string
+	upstreamURI   string
+	downstreamURI string
+
+	schemaRegistryURI    string
+	schemaRegistryType   string
+	glueRegion           string
+	glueRegistryName     string
+	claimCheckStorageURI string
+
+	ca, cert, key string
+}
+
+// newKafkaOptions creates new kafkaOptions for the `consumer kafka` command.
+func newKafkaOptions() *kafkaOptions {
+	return &kafkaOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *kafkaOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.consumerID, "consumer-id", "default",
+		"id of the consumer, which identifies the progress saved in the downstream")
+	cmd.Flags().StringVar(&o.upstreamURI, "upstream-uri", "",
+		"Kafka uri, e.g. \"kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true\"")
+	cmd.Flags().StringVar(&o.downstreamURI, "downstream-uri", "",
+		"uri of the MySQL-compatible downstream, e.g. \"mysql://root@127.0.0.1:3306/\"")
+	cmd.Flags().StringVar(&o.schemaRegistryURI, "schema-registry-uri", "",
+		"schema registry uri, which is required by the avro protocol")
+	cmd.Flags().StringVar(&o.schemaRegistryType, "schema-registry-type",
+		common.SchemaRegistryTypeConfluent, "schema registry type, confluent, apicurio or glue")
+	cmd.Flags().StringVar(&o.glueRegion, "glue-region", "", "AWS region of the Glue schema registry")
+	cmd.Flags().StringVar(&o.glueRegistryName, "glue-registry-name", "", "name of the Glue schema registry")
+	cmd.Flags().StringVar(&o.claimCheckStorageURI, "large-message-claim-check-storage-uri", "",
+		"external storage uri of the large messages sent by the claim check")
+	cmd.Flags().StringVar(&o.ca, "ca", "", "CA certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.cert, "cert", "", "Certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.key, "key", "", "Private key path for Kafka SSL connection")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("upstream-uri")   //nolint:errcheck
+	cmd.MarkFlagRequired("downstream-uri") //nolint:errcheck
+}
+
+func (o *kafkaOptions) complete() error {
+	if o.consumerID == "" {
+		return errors.New("consumer-id must not be empty")
+	}
+	return nil
+}
+
+// run runs the `consumer kafka` command.
+func (o *kafkaOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	cfg := &pconsumer.KafkaConfig{
+		ConsumerID:           o.consumerID,
+		UpstreamURI:          o.upstreamURI,
+		DownstreamURI:        o.downstreamURI,
+		SchemaRegistryURI:    o.schemaRegistryURI,
+		SchemaRegistryType:   o.schemaRegistryType,
+		GlueRegion:           o.glueRegion,
+		GlueRegistryName:     o.glueRegistryName,
+		ClaimCheckStorageURI: o.claimCheckStorageURI,
+		Credential: &security.Credential{
+			CAPath:   o.ca,
+			CertPath: o.cert,
+			KeyPath:  o.key,
+		},
+	}
+	c, err := pconsumer.NewKafkaConsumer(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	err = c.Run(ctx)
+	if errors.Cause(err) == context.Canceled {
+		cmd.Println("Kafka consumer exited")
+		return nil
+	}
+	return err
+}
+
+// newCmdKafka creates the `consumer kafka` command.
+func newCmdKafka() *cobra.Command {
+	o := newKafkaOptions()
+	command := &cobra.Command{
+		Use:   "kafka",
+		Short: "Consume the messages sent by the Kafka sink and apply them to a MySQL-compatible database",
+		Long: "Consume the messages of a topic sent by the Kafka sink and apply them to a " +
+			"MySQL-compatible database. The offsets of partitions and the progress of tables are " +
+			"saved in the downstream in the same transaction as the rows, so the consumer resumes " +
+			"from the saved progress after restarting.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.complete(); err != nil {
+				return err
+			}
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	pconsumer "github.com/pingcap/tiflow/pkg/consumer"
+	"github.com/spf13/cobra"
+)
+
+// storageOptions defines flags for the `consumer storage` command.
+type storageOptions struct {
+	consumerID     string
+	upstreamURIs   []string
+	downstreamURI  string
+	checkpointURI  string
+	configFile     string
+	interval       time.Duration
+	rescanInterval time.Duration
+}
+
+// newStorageOptions creates new storageOptions for the `consumer storage` command.
+func newStorageOptions() *storageOptions {
+	return &storageOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *storageOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.consumerID, "consumer-id", "default",
+		"id of the consumer, which identifies the checkpoints saved in the checkpoint storage")
+	cmd.Flags().StringArrayVar(&o.upstreamURIs, "upstream-uri", nil,
+		"storage uri written by the storage sink of a changefeed, e.g. \"s3://bucket/prefix?protocol=csv\", "+
+			"it can be specified multiple times to follow multiple changefeeds")
+	cmd.Flags().StringVar(&o.downstreamURI, "downstream-uri", "",
+		"sink uri of the downstream, e.g. \"mysql://root@127.0.0.1:3306/\", "+
+			"the safe mode of MySQL compatible downstreams is always enabled")
+	cmd.Flags().StringVar(&o.checkpointURI, "checkpoint-uri", "",
+		"storage uri where the checkpoints of the changefeeds are saved, e.g. \"s3://bucket/checkpoint\"")
+	cmd.Flags().StringVar(&o.configFile, "config", "",
+		"path of the changefeed configuration file, which is used to decode the files")
+	cmd.Flags().DurationVar(&o.interval, "interval", 10*time.Second,
+		"interval to check the new files")
+	cmd.Flags().DurationVar(&o.rescanInterval, "rescan-interval", 5*time.Minute,
+		"interval to walk all the files of the upstreams to find the new tables")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("upstream-uri")   //nolint:errcheck
+	cmd.MarkFlagRequired("downstream-uri") //nolint:errcheck
+	cmd.MarkFlagRequired("checkpoint-uri") //nolint:errcheck
+}
+
+func (o *storageOptions) complete() error {
+	if o.consumerID == "" {
+		return errors.New("consumer-id must not be empty")
+	}
+	return nil
+}
+
+// run runs the `consumer storage` command.
+func (o *storageOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	if o.configFile != "" {
+		err := util.StrictDecodeFile(o.configFile, "storage consumer", replicaConfig)
+		if err != nil {
+			return err
+		}
+	}
+	cfg := &pconsumer.StorageConfig{
+		ConsumerID:     o.consumerID,
+		UpstreamURIs:   o.upstreamURIs,
+		DownstreamURI:  o.downstreamURI,
+		CheckpointURI:  o.checkpointURI,
+		ReplicaConfig:  replicaConfig,
+		Interval:       o.interval,
+		RescanInterval: o.rescanInterval,
+	}
+	c, err := pconsumer.NewStorageConsumer(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	err = c.Run(ctx)
+	if errors.Cause(err) == context.Canceled {
+		cmd.Println("Storage consumer exited")
+		return nil
+	}
+	return err
+}
+
+// newCmdStorage creates the `consumer storage` command.
+func newCmdStorage() *cobra.Command {
+	o := newStorageOptions()
+	command := &cobra.Command{
+		Use:   "storage",
+		Short: "Replay the files written by the storage sinks of changefeeds to a downstream",
+		Long: "Replay the files written by the storage sinks of one or more changefeeds to a " +
+			"downstream, which can be any sink supported by changefeeds. The last handled file of " +
+			"each data directory and the last handled schema file of each table are saved in the " +
+			"checkpoint storage, so the consumer resumes from them after restarting.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.complete(); err != nil {
+				return err
+			}
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -16,16 +16,22 @@ package redo
 import (
 	"net/url"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/pkg/applier"
 	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/spf13/cobra"
 )
 
 // applyRedoOptions defines flags for the `redo apply` command.
 type applyRedoOptions struct {
 	options
-	sinkURI string
+	sinkURI      string
+	targetTs     uint64
+	filterRules  []string
+	dryRunFile   string
+	progressFile string
 }
 
 // newapplyRedoOptions creates new applyRedoOptions for the `redo apply` command.
@@ -36,18 +42,42 @@ func newapplyRedoOptions() *applyRedoOptions {
 // addFlags receives a *cobra.Command reference and binds
 // flags related to template printing to it.
 func (o *applyRedoOptions) addFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "target database sink-uri")
-	// the possible error returned from MarkFlagRequired is `no such flag`
-	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
+	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "",
+		"sink-uri of the target, which can be a MySQL-compatible database, an MQ or a cloud storage")
+	cmd.Flags().Uint64Var(&o.targetTs, "target-ts", 0,
+		"apply redo logs up to this ts, which should not be greater than the resolved ts in meta, "+
+			"0 means the resolved ts")
+	cmd.Flags().StringSliceVar(&o.filterRules, "filter", nil,
+		"table filter rules, only the redo logs of matched tables are applied, e.g. 'db1.*'")
+	cmd.Flags().StringVar(&o.dryRunFile, "dry-run-file", "",
+		"write the generated SQL statements into this file instead of executing them on the sink")
+	cmd.Flags().StringVar(&o.progressFile, "progress-file", "",
+		"record the applied progress into this file, the redo logs applied by previous runs "+
+			"with the same file are skipped. Without it, the events are sent to MQ and cloud "+
+			"storage sinks again if the redo logs are applied again")
 }
 
-//nolint:unparam
 func (o *applyRedoOptions) complete(cmd *cobra.Command) error {
+	if o.dryRunFile != "" {
+		if o.progressFile != "" {
+			return errors.New("progress-file can not be used with dry-run-file")
+		}
+		// the sink is not used in dry run mode.
+		return nil
+	}
+	if o.sinkURI == "" {
+		return errors.New("sink-uri is required unless dry-run-file is specified")
+	}
 	// parse sinkURI as a URI
 	sinkURI, err := url.Parse(o.sinkURI)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
+	if !sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
+		// the MQ and cloud storage sinks have no safe mode, the duplicated
+		// redo logs are skipped by the applier instead.
+		return nil
+	}
 	rawQuery := sinkURI.Query()
 	// set safe-mode to true if not set
 	if rawQuery.Get("safe-mode") != "true" {
@@ -63,15 +93,25 @@ func (o *applyRedoOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
 	cfg := &applier.RedoApplierConfig{
-		Storage: o.storage,
-		SinkURI: o.sinkURI,
-		Dir:     o.dir,
+		Storage:           o.storage,
+		SinkURI:           o.sinkURI,
+		Dir:               o.dir,
+		TargetTs:          o.targetTs,
+		FilterRules:       o.filterRules,
+		DryRunFile:        o.dryRunFile,
+		EncryptionKeyFile: o.encryptionKeyFile,
+		AllowPlaintext:    o.allowPlaintext,
+		ProgressFile:      o.progressFile,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	err := ap.Apply(ctx)
 	if err != nil {
 		return err
 	}
+	if o.dryRunFile != "" {
+		cmd.Printf("Write SQL statements of redo log to %s successfully\n", o.dryRunFile)
+		return nil
+	}
 	cmd.Println("Apply redo log successfully")
 	return nil
 }
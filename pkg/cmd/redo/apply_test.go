@@ -39,4 +39,28 @@ func TestComplete(t *testing.T) {
 	err = o.complete(cmd)
 	require.NoError(t, err)
 	require.Equal(t, "mysql://root@127.0.0.1:3306?time-zone=UTC&safe-mode=true", o.sinkURI)
+
+	// safe-mode is only set for MySQL-compatible sinks.
+	o.sinkURI = "kafka://127.0.0.1:9092/topic?protocol=canal-json"
+	err = o.complete(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "kafka://127.0.0.1:9092/topic?protocol=canal-json", o.sinkURI)
+}
+
+func TestCompleteDryRun(t *testing.T) {
+	cmd := &cobra.Command{
+		Use: "test",
+	}
+	o := newapplyRedoOptions()
+	err := o.complete(cmd)
+	require.ErrorContains(t, err, "sink-uri is required")
+
+	o.dryRunFile = "redo.sql"
+	err = o.complete(cmd)
+	require.NoError(t, err)
+	require.Empty(t, o.sinkURI)
+
+	o.progressFile = "progress"
+	err = o.complete(cmd)
+	require.ErrorContains(t, err, "progress-file can not be used with dry-run-file")
 }
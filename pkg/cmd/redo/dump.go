@@ -0,0 +1,293 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/builder"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/spf13/cobra"
+)
+
+const ddlFileName = "ddl"
+
+// dumpOptions defines flags for the `redo dump` command.
+type dumpOptions struct {
+	options
+	outputDir string
+	format    string
+}
+
+// newDumpOptions creates new dumpOptions for the `redo dump` command.
+func newDumpOptions() *dumpOptions {
+	return &dumpOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *dumpOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.outputDir, "output-dir", "",
+		"local directory to write the decoded redo logs into")
+	cmd.Flags().StringVar(&o.format, "format", config.ProtocolCanalJSON.String(),
+		"format of the decoded redo logs, canal-json or csv")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("output-dir") //nolint:errcheck
+}
+
+// dumper writes the rows of each table into a separate file named
+// `<schema>.<table>.<ext>`, and the DDLs into the file named `ddl.<ext>`.
+type dumper struct {
+	dir      string
+	ext      string
+	protocol config.Protocol
+	config   *common.Config
+
+	txnEncoder codec.TxnEventEncoder
+	// ddlEncoder is only used by canal-json.
+	ddlEncoder codec.RowEventEncoder
+
+	files   map[string]*os.File
+	writers map[string]*bufio.Writer
+}
+
+func newDumper(ctx context.Context, dir string, format string) (*dumper, error) {
+	protocol, err := config.ParseSinkProtocolFromString(format)
+	if err != nil {
+		return nil, err
+	}
+	if protocol != config.ProtocolCanalJSON && protocol != config.ProtocolCsv {
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(format)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+
+	csvConfig := config.GetDefaultReplicaConfig().Sink.CSVConfig
+	codecConfig := common.NewConfig(protocol)
+	codecConfig.EnableTiDBExtension = true
+	codecConfig.MaxMessageBytes = math.MaxInt
+	codecConfig.Terminator = config.CRLF
+	codecConfig.Delimiter = csvConfig.Delimiter
+	codecConfig.Quote = csvConfig.Quote
+	codecConfig.NullString = csvConfig.NullString
+	codecConfig.IncludeCommitTs = true
+
+	txnEncoderBuilder, err := builder.NewTxnEventEncoderBuilder(codecConfig)
+	if err != nil {
+		return nil, err
+	}
+	d := &dumper{
+		dir:        dir,
+		ext:        ".json",
+		protocol:   protocol,
+		config:     codecConfig,
+		txnEncoder: txnEncoderBuilder.Build(),
+		files:      make(map[string]*os.File),
+		writers:    make(map[string]*bufio.Writer),
+	}
+	if protocol == config.ProtocolCsv {
+		d.ext = ".csv"
+	} else {
+		ddlEncoderBuilder, err := canal.NewJSONRowEventEncoderBuilder(ctx, codecConfig)
+		if err != nil {
+			return nil, err
+		}
+		d.ddlEncoder = ddlEncoderBuilder.Build()
+	}
+	return d, nil
+}
+
+func (d *dumper) getWriter(name string) (*bufio.Writer, error) {
+	if w, ok := d.writers[name]; ok {
+		return w, nil
+	}
+	// the path separator is not allowed in the file name.
+	fileName := strings.ReplaceAll(name, string(filepath.Separator), "_") + d.ext
+	file, err := os.OpenFile(filepath.Join(d.dir, fileName),
+		os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	w := bufio.NewWriter(file)
+	d.files[name] = file
+	d.writers[name] = w
+	return w, nil
+}
+
+func (d *dumper) writeRow(row *model.RowChangedEvent) error {
+	if d.protocol == config.ProtocolCsv {
+		prepareCSVRow(row)
+	}
+	txn := &model.SingleTableTxn{
+		Table:    row.Table,
+		StartTs:  row.StartTs,
+		CommitTs: row.CommitTs,
+		Rows:     []*model.RowChangedEvent{row},
+	}
+	if err := d.txnEncoder.AppendTxnEvent(txn, nil); err != nil {
+		return errors.Trace(err)
+	}
+	w, err := d.getWriter(row.Table.Schema + "." + row.Table.Table)
+	if err != nil {
+		return err
+	}
+	for _, msg := range d.txnEncoder.Build() {
+		if _, err := w.Write(msg.Value); err != nil {
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+	}
+	return nil
+}
+
+func (d *dumper) writeDDL(ddl *model.DDLEvent) error {
+	if ddl.TableInfo == nil {
+		// the DDLs written by the old version of cdc do not have table info.
+		ddl.TableInfo = &model.TableInfo{}
+	}
+	w, err := d.getWriter(ddlFileName)
+	if err != nil {
+		return err
+	}
+
+	if d.protocol == config.ProtocolCsv {
+		// the csv protocol does not support DDL, so the DDL is written as a
+		// record of commit ts, schema, table and query.
+		csvWriter := csv.NewWriter(w)
+		csvWriter.UseCRLF = true
+		err := csvWriter.Write([]string{
+			strconv.FormatUint(ddl.CommitTs, 10),
+			ddl.TableInfo.TableName.Schema,
+			ddl.TableInfo.TableName.Table,
+			ddl.Query,
+		})
+		if err == nil {
+			csvWriter.Flush()
+			err = csvWriter.Error()
+		}
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+		return nil
+	}
+
+	msg, err := d.ddlEncoder.EncodeDDLEvent(ddl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := w.Write(append(msg.Value, d.config.Terminator...)); err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	return nil
+}
+
+func (d *dumper) close() error {
+	var firstErr error
+	for name, w := range d.writers {
+		if err := w.Flush(); err != nil && firstErr == nil {
+			firstErr = cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+		if err := d.files[name].Close(); err != nil && firstErr == nil {
+			firstErr = cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+	}
+	return firstErr
+}
+
+// prepareCSVRow fills the column infos required by the csv encoder, which are
+// not recorded in redo logs. The elements of enum and set are not recorded
+// either, so their values are written as the numbers.
+func prepareCSVRow(row *model.RowChangedEvent) {
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	row.ColInfos = make([]rowcodec.ColInfo, len(cols))
+	for i, col := range cols {
+		if col == nil {
+			continue
+		}
+		row.ColInfos[i].Ft = types.NewFieldType(col.Type)
+		if col.Type == mysql.TypeEnum || col.Type == mysql.TypeSet {
+			if v, ok := col.Value.(uint64); ok {
+				col.Value = strconv.FormatUint(v, 10)
+			}
+		}
+	}
+}
+
+// run runs the `redo dump` command.
+func (o *dumpOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	d, err := newDumper(ctx, o.outputDir, o.format)
+	if err != nil {
+		return err
+	}
+	rd, err := newLogReader(ctx, &o.options)
+	if err != nil {
+		d.close() //nolint:errcheck
+		return err
+	}
+	var rows, ddls uint64
+	err = readLogs(ctx, rd, func(row *model.RowChangedEvent) error {
+		rows++
+		return d.writeRow(row)
+	}, func(ddl *model.DDLEvent) error {
+		ddls++
+		return d.writeDDL(ddl)
+	})
+	if closeErr := d.close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Dump %d rows and %d DDLs of redo log to %s successfully\n",
+		rows, ddls, o.outputDir)
+	return nil
+}
+
+// newCmdDump creates the `redo dump` command.
+func newCmdDump(opt *options) *cobra.Command {
+	o := newDumpOptions()
+	command := &cobra.Command{
+		Use:   "dump",
+		Short: "Dump redo logs into local files in canal-json or csv format",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.options = *opt
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo/reader"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReader struct {
+	rows []*model.RowChangedEvent
+	ddls []*model.DDLEvent
+}
+
+func (r *mockReader) Run(ctx context.Context) error {
+	return nil
+}
+
+func (r *mockReader) ReadNextRow(ctx context.Context) (*model.RowChangedEvent, error) {
+	if len(r.rows) == 0 {
+		return nil, nil
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+func (r *mockReader) ReadNextDDL(ctx context.Context) (*model.DDLEvent, error) {
+	if len(r.ddls) == 0 {
+		return nil, nil
+	}
+	ddl := r.ddls[0]
+	r.ddls = r.ddls[1:]
+	return ddl, nil
+}
+
+func (r *mockReader) ReadMeta(ctx context.Context) (checkpointTs, resolvedTs uint64, err error) {
+	return 100, 200, nil
+}
+
+func mockLogReader(t *testing.T) {
+	newLogReaderBak := newLogReader
+	newLogReader = func(ctx context.Context, o *options) (reader.RedoLogReader, error) {
+		columns := func(id int64, name string) []*model.Column {
+			return []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag, Value: id},
+				{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+				{Name: "state", Type: mysql.TypeEnum, Value: uint64(2)},
+			}
+		}
+		t1 := &model.TableName{Schema: "test", Table: "t1", TableID: 1}
+		t2 := &model.TableName{Schema: "test", Table: "t2", TableID: 2}
+		return &mockReader{
+			rows: []*model.RowChangedEvent{
+				{StartTs: 105, CommitTs: 110, Table: t1, Columns: columns(1, "a")},
+				{StartTs: 115, CommitTs: 120, Table: t2, Columns: columns(1, "b")},
+				{StartTs: 125, CommitTs: 130, Table: t1, PreColumns: columns(1, "a"), Columns: columns(1, "c")},
+				{StartTs: 135, CommitTs: 140, Table: t1, PreColumns: columns(1, "c")},
+			},
+			ddls: []*model.DDLEvent{
+				{
+					CommitTs:  120,
+					TableInfo: &model.TableInfo{TableName: *t1},
+					Query:     "ALTER TABLE t1 ADD COLUMN c INT",
+					Type:      timodel.ActionAddColumn,
+				},
+			},
+		}, nil
+	}
+	t.Cleanup(func() {
+		newLogReader = newLogReaderBak
+	})
+}
+
+func TestStat(t *testing.T) {
+	mockLogReader(t)
+
+	dir := t.TempDir()
+	for i, fileType := range []string{redo.RedoRowLogFileType, redo.RedoRowLogFileType, redo.RedoDDLLogFileType} {
+		fileName := fmt.Sprintf(redo.RedoLogFileFormatV2, "capture", "default",
+			"changefeed", fileType, 110+i, "uuid", redo.LogEXT)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fileName), make([]byte, 10), 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta"+redo.MetaEXT), nil, 0o644))
+
+	o := newStatOptions()
+	o.storage = "file://" + dir
+	ctx := context.Background()
+	files, err := o.statFiles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*fileStat{
+		{fileType: redo.RedoDDLLogFileType, count: 1, size: 10, maxCommitTs: 112},
+		{fileType: redo.RedoMetaFileType, count: 1, size: 0},
+		{fileType: redo.RedoRowLogFileType, count: 2, size: 20, maxCommitTs: 111},
+	}, files)
+
+	checkpointTs, resolvedTs, tables, err := o.statTables(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), checkpointTs)
+	require.Equal(t, uint64(200), resolvedTs)
+	require.Equal(t, []*tableStat{
+		{
+			schema: "test", table: "t1", insert: 1, update: 1, delete: 1, ddl: 1,
+			minCommitTs: 110, maxCommitTs: 140,
+		},
+		{schema: "test", table: "t2", insert: 1, minCommitTs: 120, maxCommitTs: 120},
+	}, tables)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, printStat(out, checkpointTs, resolvedTs, files, tables))
+	require.Contains(t, out.String(), "checkpoint-ts:100, resolved-ts:200")
+	require.Regexp(t, `test\s+t1\s+1\s+1\s+1\s+1\s+110\s+140`, out.String())
+}
+
+func TestDump(t *testing.T) {
+	mockLogReader(t)
+	cmdcontext.SetDefaultContext(context.Background())
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetOut(&bytes.Buffer{})
+
+	dir := t.TempDir()
+	o := newDumpOptions()
+	o.outputDir = filepath.Join(dir, "csv")
+	o.format = "csv"
+	require.NoError(t, o.run(cmd))
+	data, err := os.ReadFile(filepath.Join(o.outputDir, "test.t1.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "\"I\",\"t1\",\"test\",110,1,\"a\",\"2\"\r\n"+
+		"\"U\",\"t1\",\"test\",130,1,\"c\",\"2\"\r\n"+
+		"\"D\",\"t1\",\"test\",140,1,\"c\",\"2\"\r\n", string(data))
+	data, err = os.ReadFile(filepath.Join(o.outputDir, "ddl.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "120,test,t1,ALTER TABLE t1 ADD COLUMN c INT\r\n", string(data))
+	require.FileExists(t, filepath.Join(o.outputDir, "test.t2.csv"))
+
+	o.outputDir = filepath.Join(dir, "json")
+	o.format = "canal-json"
+	require.NoError(t, o.run(cmd))
+	data, err = os.ReadFile(filepath.Join(o.outputDir, "test.t1.json"))
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\r\n"))
+	require.Len(t, lines, 3)
+	require.Contains(t, string(lines[0]), `"type":"INSERT"`)
+	require.Contains(t, string(lines[1]), `"type":"UPDATE"`)
+	require.Contains(t, string(lines[2]), `"type":"DELETE"`)
+	require.Contains(t, string(lines[2]), `"commitTs":140`)
+	data, err = os.ReadFile(filepath.Join(o.outputDir, "ddl.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"sql":"ALTER TABLE t1 ADD COLUMN c INT"`)
+
+	o.format = "avro"
+	require.ErrorContains(t, o.run(cmd), "ErrSinkUnknownProtocol")
+}
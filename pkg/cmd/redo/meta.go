@@ -34,8 +34,10 @@ func (o *metaOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
 	cfg := &applier.RedoApplierConfig{
-		Storage: o.storage,
-		Dir:     o.dir,
+		Storage:           o.storage,
+		Dir:               o.dir,
+		EncryptionKeyFile: o.encryptionKeyFile,
+		AllowPlaintext:    o.allowPlaintext,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	checkpointTs, resolvedTs, err := ap.ReadMeta(ctx)
@@ -14,16 +14,26 @@
 package redo
 
 import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo/reader"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 // options defines flags for the `redo` command.
 type options struct {
-	storage  string
-	dir      string
-	logLevel string
+	storage           string
+	dir               string
+	logLevel          string
+	encryptionKeyFile string
+	allowPlaintext    bool
 }
 
 // newOptions creates new options for the `server` command.
@@ -37,6 +47,8 @@ func (o *options) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&o.storage, "storage", "", "storage of redo log, specify the url where backup redo logs will store, eg, \"s3://bucket/path/prefix\"")
 	cmd.PersistentFlags().StringVar(&o.dir, "tmp-dir", "", "temporary path used to download redo log with S3 backend")
 	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+	cmd.PersistentFlags().StringVar(&o.encryptionKeyFile, "encryption-key-file", "", "file of the key used to decrypt the encrypted redo logs")
+	cmd.PersistentFlags().BoolVar(&o.allowPlaintext, "allow-plaintext", false, "allow reading the redo logs that are not encrypted when the encryption key file is specified")
 	// the possible error returned from MarkFlagRequired is `no such flag`
 	cmd.MarkFlagRequired("storage") //nolint:errcheck
 }
@@ -70,6 +82,81 @@ func NewCmdRedo() *cobra.Command {
 	// Add subcommands.
 	cmds.AddCommand(newCmdApply(o))
 	cmds.AddCommand(newCmdMeta(o))
+	cmds.AddCommand(newCmdStat(o))
+	cmds.AddCommand(newCmdDump(o))
 
 	return cmds
 }
+
+// storageURI parses the storage of redo logs, the local storage is
+// converted to the file scheme.
+func (o *options) storageURI() (*url.URL, error) {
+	uri, err := url.Parse(o.storage)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrConsistentStorage, err)
+	}
+	if redo.IsLocalStorage(uri.Scheme) {
+		uri.Scheme = "file"
+	}
+	return uri, nil
+}
+
+var newLogReader = newLogReaderImpl
+
+func newLogReaderImpl(ctx context.Context, o *options) (reader.RedoLogReader, error) {
+	uri, err := o.storageURI()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &reader.LogReaderConfig{
+		URI:                *uri,
+		Dir:                o.dir,
+		UseExternalStorage: redo.IsExternalStorage(uri.Scheme),
+		EncryptionKeyFile:  o.encryptionKeyFile,
+		AllowPlaintext:     o.allowPlaintext,
+	}
+	return reader.NewRedoLogReader(ctx, uri.Scheme, cfg)
+}
+
+// readLogs reads all the redo logs between the checkpoint ts and the resolved
+// ts, the rows and DDLs are passed to the handlers in the order of commit ts,
+// and a DDL is handled after all the rows with the same commit ts.
+func readLogs(
+	ctx context.Context, rd reader.RedoLogReader,
+	handleRow func(row *model.RowChangedEvent) error,
+	handleDDL func(ddl *model.DDLEvent) error,
+) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return rd.Run(egCtx)
+	})
+	eg.Go(func() error {
+		row, err := rd.ReadNextRow(egCtx)
+		if err != nil {
+			return err
+		}
+		ddl, err := rd.ReadNextDDL(egCtx)
+		if err != nil {
+			return err
+		}
+		for row != nil || ddl != nil {
+			if ddl != nil && (row == nil || row.CommitTs > ddl.CommitTs) {
+				if err := handleDDL(ddl); err != nil {
+					return err
+				}
+				if ddl, err = rd.ReadNextDDL(egCtx); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := handleRow(row); err != nil {
+				return err
+			}
+			if row, err = rd.ReadNextRow(egCtx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return eg.Wait()
+}
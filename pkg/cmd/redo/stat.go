@@ -0,0 +1,228 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/spf13/cobra"
+)
+
+// statOptions defines flags for the `redo stat` command.
+type statOptions struct {
+	options
+}
+
+// newStatOptions creates new statOptions for the `redo stat` command.
+func newStatOptions() *statOptions {
+	return &statOptions{}
+}
+
+// fileStat is the statistics of the redo log files of a file type.
+type fileStat struct {
+	fileType    string
+	count       int
+	size        int64
+	maxCommitTs uint64
+}
+
+// tableStat is the statistics of the redo logs of a table.
+type tableStat struct {
+	schema      string
+	table       string
+	insert      uint64
+	update      uint64
+	delete      uint64
+	ddl         uint64
+	minCommitTs uint64
+	maxCommitTs uint64
+}
+
+func (s *tableStat) updateCommitTs(commitTs uint64) {
+	if s.minCommitTs == 0 || commitTs < s.minCommitTs {
+		s.minCommitTs = commitTs
+	}
+	if commitTs > s.maxCommitTs {
+		s.maxCommitTs = commitTs
+	}
+}
+
+// statFiles walks the storage and collects the statistics of log files.
+func (o *statOptions) statFiles(ctx context.Context) ([]*fileStat, error) {
+	uri, err := o.storageURI()
+	if err != nil {
+		return nil, err
+	}
+	if redo.IsBlackholeStorage(uri.Scheme) {
+		return nil, nil
+	}
+	extStorage, err := redo.InitExternalStorage(ctx, *uri)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*fileStat)
+	err = extStorage.WalkDir(ctx, nil, func(filePath string, size int64) error {
+		commitTs, fileType, err := redo.ParseLogFileName(path.Base(filePath))
+		if err != nil || fileType == "" {
+			// skip the files which are not redo logs.
+			return nil
+		}
+		stat, ok := stats[fileType]
+		if !ok {
+			stat = &fileStat{fileType: fileType}
+			stats[fileType] = stat
+		}
+		stat.count++
+		stat.size += size
+		if commitTs > stat.maxCommitTs {
+			stat.maxCommitTs = commitTs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+
+	result := make([]*fileStat, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, stat)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].fileType < result[j].fileType
+	})
+	return result, nil
+}
+
+// statTables reads all the redo logs and collects the statistics of tables.
+func (o *statOptions) statTables(ctx context.Context) (
+	checkpointTs, resolvedTs uint64, result []*tableStat, err error,
+) {
+	rd, err := newLogReader(ctx, &o.options)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	checkpointTs, resolvedTs, err = rd.ReadMeta(ctx)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	stats := make(map[model.TableName]*tableStat)
+	getStat := func(name model.TableName) *tableStat {
+		// the table id is not used to distinguish tables.
+		name.TableID, name.IsPartition = 0, false
+		stat, ok := stats[name]
+		if !ok {
+			stat = &tableStat{schema: name.Schema, table: name.Table}
+			stats[name] = stat
+		}
+		return stat
+	}
+	err = readLogs(ctx, rd, func(row *model.RowChangedEvent) error {
+		stat := getStat(*row.Table)
+		switch {
+		case row.IsDelete():
+			stat.delete++
+		case row.IsUpdate():
+			stat.update++
+		default:
+			stat.insert++
+		}
+		stat.updateCommitTs(row.CommitTs)
+		return nil
+	}, func(ddl *model.DDLEvent) error {
+		if ddl.TableInfo == nil {
+			return nil
+		}
+		stat := getStat(ddl.TableInfo.TableName)
+		stat.ddl++
+		stat.updateCommitTs(ddl.CommitTs)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	result = make([]*tableStat, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, stat)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].schema != result[j].schema {
+			return result[i].schema < result[j].schema
+		}
+		return result[i].table < result[j].table
+	})
+	return checkpointTs, resolvedTs, result, nil
+}
+
+// run runs the `redo stat` command.
+func (o *statOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	files, err := o.statFiles(ctx)
+	if err != nil {
+		return err
+	}
+	checkpointTs, resolvedTs, tables, err := o.statTables(ctx)
+	if err != nil {
+		return err
+	}
+	return printStat(cmd.OutOrStdout(), checkpointTs, resolvedTs, files, tables)
+}
+
+func printStat(
+	out io.Writer, checkpointTs, resolvedTs uint64,
+	files []*fileStat, tables []*tableStat,
+) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "checkpoint-ts:%d, resolved-ts:%d\n\n", checkpointTs, resolvedTs)
+
+	fmt.Fprintln(w, "FILE TYPE\tFILES\tSIZE\tMAX COMMIT TS")
+	for _, f := range files {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", f.fileType, f.count, f.size, f.maxCommitTs)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "SCHEMA\tTABLE\tINSERT\tUPDATE\tDELETE\tDDL\tMIN COMMIT TS\tMAX COMMIT TS")
+	for _, t := range tables {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", t.schema, t.table,
+			t.insert, t.update, t.delete, t.ddl, t.minCommitTs, t.maxCommitTs)
+	}
+	return w.Flush()
+}
+
+// newCmdStat creates the `redo stat` command.
+func newCmdStat(opt *options) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "stat",
+		Short: "Show statistics of redo logs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o := newStatOptions()
+			o.options = *opt
+			return o.run(cmd)
+		},
+	}
+
+	return command
+}
@@ -198,7 +198,16 @@ func TestAndWriteExampleReplicaTOML(t *testing.T) {
 		},
 		ColumnSelectors: []*config.ColumnSelector{
 			{Matcher: []string{"test1.*", "test2.*"}, Columns: []string{"column1", "column2"}},
-			{Matcher: []string{"test3.*", "test4.*"}, Columns: []string{"!a", "column3"}},
+			{
+				Matcher: []string{"test3.*", "test4.*"},
+				Columns: []string{"!a", "column3"},
+				Masks: []*config.ColumnMask{
+					{Columns: []string{"column3"}, Action: "hash", Salt: "salt"},
+				},
+			},
+		},
+		RouteRules: []*config.RouteRule{
+			{Matcher: []string{"shard_*.order_*"}, TargetSchema: "shard", TargetTable: "order"},
 		},
 		CSVConfig: &config.CSVConfig{
 			Quote:      string(config.DoubleQuoteChar),
@@ -214,6 +223,13 @@ func TestAndWriteExampleReplicaTOML(t *testing.T) {
 		LargeMessageOnlyHandleKeyColumns: util.AddressOf(false),
 		Protocol:                         util.AddressOf("open-protocol"),
 	}, cfg.Sink)
+	require.Equal(t, &config.ErrorRetryPolicyConfig{
+		InitialInterval: 10 * time.Second,
+		MaxInterval:     30 * time.Minute,
+		MaxElapsedTime:  90 * time.Minute,
+		FatalErrors:     []string{"CDC:ErrKafkaNewProducer"},
+		RetryableErrors: []string{"CDC:ErrSinkURIInvalid"},
+	}, cfg.ErrorRetryPolicy)
 }
 
 func TestAndWriteStorageSinkTOML(t *testing.T) {
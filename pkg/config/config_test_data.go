@@ -16,6 +16,7 @@ package config
 const (
 	testCfgTestReplicaConfigOutDated = `{
   "memory-quota": 1073741824,
+  "enable-large-txn-spill": false,
   "case-sensitive": false,
   "enable-old-value": true,
   "force-replicate": true,
@@ -76,7 +77,16 @@ const (
   "integrity": {
     "integrity-check-level": "none",
     "corruption-handle-level": "warn"
- }
+ },
+  "error-retry-policy": {
+    "initial-interval": 10000000000,
+    "max-interval": 1800000000000,
+    "max-elapsed-time": 5400000000000
+  },
+  "throughput-limit": {
+    "rows-per-second": 0,
+    "bytes-per-second": 0
+  }
 }`
 
 	testCfgTestServerConfigMarshal = `{
@@ -163,6 +173,7 @@ const (
 
 	testCfgTestReplicaConfigMarshal1 = `{
   "memory-quota": 1073741824,
+  "enable-large-txn-spill": false,
   "case-sensitive": false,
   "enable-old-value": true,
   "force-replicate": true,
@@ -281,16 +292,33 @@ const (
     "region-per-span": 0,
     "region-threshold": 100001,
     "write-key-threshold": 100001,
-    "region-per-span": 0
+    "write-row-threshold": 100001,
+    "region-per-span": 0,
+    "required-labels": {
+      "zone": "z1"
+    },
+    "preferred-labels": {
+      "host": "h1"
+    }
   },
   "integrity": {
     "integrity-check-level": "none",
     "corruption-handle-level": "warn"
+  },
+  "error-retry-policy": {
+    "initial-interval": 10000000000,
+    "max-interval": 1800000000000,
+    "max-elapsed-time": 5400000000000
+  },
+  "throughput-limit": {
+    "rows-per-second": 0,
+    "bytes-per-second": 0
   }
 }`
 
 	testCfgTestReplicaConfigMarshal2 = `{
   "memory-quota": 1073741824,
+  "enable-large-txn-spill": false,
   "case-sensitive": false,
   "enable-old-value": true,
   "force-replicate": true,
@@ -407,11 +435,27 @@ const (
   "scheduler": {
     "enable-table-across-nodes": true,
     "region-threshold": 100001,
-    "write-key-threshold": 100001
+    "write-key-threshold": 100001,
+    "write-row-threshold": 100001,
+    "required-labels": {
+      "zone": "z1"
+    },
+    "preferred-labels": {
+      "host": "h1"
+    }
   },
   "integrity": {
     "integrity-check-level": "none",
     "corruption-handle-level": "warn"
+  },
+  "error-retry-policy": {
+    "initial-interval": 10000000000,
+    "max-interval": 1800000000000,
+    "max-elapsed-time": 5400000000000
+  },
+  "throughput-limit": {
+    "rows-per-second": 0,
+    "bytes-per-second": 0
   }
 }`
 )
@@ -28,6 +28,13 @@ type ConsistentConfig struct {
 	FlushIntervalInMs int64  `toml:"flush-interval" json:"flush-interval"`
 	Storage           string `toml:"storage" json:"storage"`
 	UseFileBackend    bool   `toml:"use-file-backend" json:"use-file-backend"`
+	// EncryptionKeyFile is the file of the hex-encoded AES key used to encrypt
+	// redo logs and meta. The redo logs are not encrypted if it is empty.
+	EncryptionKeyFile string `toml:"encryption-key-file" json:"encryption-key-file,omitempty"`
+	// AllowPlaintext indicates whether the redo logs and meta that are not
+	// encrypted can be read when the EncryptionKeyFile is specified, it's
+	// used to read the files written before the encryption is enabled.
+	AllowPlaintext bool `toml:"allow-plaintext" json:"allow-plaintext,omitempty"`
 }
 
 // ValidateAndAdjust validates the consistency config and adjusts it if necessary.
@@ -0,0 +1,132 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// DefaultErrorRetryInitialInterval is the default initial interval to
+	// restart a changefeed in error state.
+	DefaultErrorRetryInitialInterval = 10 * time.Second
+	// DefaultErrorRetryMaxInterval is the default max interval to restart
+	// a changefeed in error state.
+	DefaultErrorRetryMaxInterval = 30 * time.Minute
+	// DefaultErrorRetryMaxElapsedTime is the default max time a changefeed
+	// keeps retrying before it fails.
+	DefaultErrorRetryMaxElapsedTime = 90 * time.Minute
+)
+
+// ErrorRetryPolicyConfig is the policy to restart a changefeed when it meets
+// errors. The changefeed is restarted with an exponential backoff.
+type ErrorRetryPolicyConfig struct {
+	// InitialInterval is the interval to restart the changefeed for the first time.
+	InitialInterval time.Duration `toml:"initial-interval" json:"initial-interval"`
+	// MaxInterval is the upper limit of the interval to restart the changefeed.
+	MaxInterval time.Duration `toml:"max-interval" json:"max-interval"`
+	// MaxElapsedTime is the time the changefeed keeps retrying before it fails,
+	// 0 means the changefeed retries forever.
+	MaxElapsedTime time.Duration `toml:"max-elapsed-time" json:"max-elapsed-time"`
+	// FatalErrors are the error codes, such as "CDC:ErrKafkaNewProducer",
+	// the changefeed fails immediately without retrying when meeting them.
+	FatalErrors []string `toml:"fatal-errors" json:"fatal-errors,omitempty"`
+	// RetryableErrors are the error codes to retry even if they are
+	// unretryable by default, such as "CDC:ErrSinkURIInvalid". The fast
+	// fail errors, such as "CDC:ErrGCTTLExceeded", can not be retried.
+	RetryableErrors []string `toml:"retryable-errors" json:"retryable-errors,omitempty"`
+}
+
+// ValidateAndAdjust validates the error retry policy.
+func (c *ErrorRetryPolicyConfig) ValidateAndAdjust() error {
+	if c.InitialInterval <= 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"error-retry-policy.initial-interval must be larger than 0")
+	}
+	if c.MaxInterval < c.InitialInterval {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"error-retry-policy.max-interval must not be less than initial-interval")
+	}
+	if c.MaxElapsedTime < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"error-retry-policy.max-elapsed-time must not be negative")
+	}
+	for _, code := range c.FatalErrors {
+		if !strings.Contains(code, ":") {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("invalid error code %s in error-retry-policy.fatal-errors", code))
+		}
+		for _, retryable := range c.RetryableErrors {
+			if code == retryable {
+				return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+					fmt.Sprintf("error code %s can not be both fatal and retryable", code))
+			}
+		}
+	}
+	for _, code := range c.RetryableErrors {
+		if !strings.Contains(code, ":") {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("invalid error code %s in error-retry-policy.retryable-errors", code))
+		}
+		// The changefeed can never recover from the fast fail errors,
+		// such as the data being garbage collected.
+		if cerror.IsChangefeedFastFailErrorCode(errors.RFCErrorCode(code)) {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("error code %s in error-retry-policy.retryable-errors "+
+					"can not be retried", code))
+		}
+	}
+	return nil
+}
+
+// IsFatalError returns true if the error should fail the changefeed immediately.
+func (c *ErrorRetryPolicyConfig) IsFatalError(code, message string) bool {
+	return matchErrorCode(c.FatalErrors, code, message)
+}
+
+// IsRetryableError returns true if the error should be retried even if it
+// is unretryable by default.
+func (c *ErrorRetryPolicyConfig) IsRetryableError(code, message string) bool {
+	return matchErrorCode(c.RetryableErrors, code, message)
+}
+
+// errorCodePattern matches the error codes in error messages, such as
+// "[CDC:ErrKafkaNewProducer]".
+var errorCodePattern = regexp.MustCompile(`\[([\w-]+:[\w:-]+)\]`)
+
+// matchErrorCode checks both the code and the codes in the message, since
+// the error may be wrapped by another error with a different code.
+func matchErrorCode(codes []string, code, message string) bool {
+	if len(codes) == 0 {
+		return false
+	}
+	candidates := []string{code}
+	for _, match := range errorCodePattern.FindAllStringSubmatch(message, -1) {
+		candidates = append(candidates, match[1])
+	}
+	for _, c := range codes {
+		for _, candidate := range candidates {
+			if c == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRetryPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	sinkURL, err := url.Parse("blackhole://")
+	require.NoError(t, err)
+
+	conf := GetDefaultReplicaConfig()
+	conf.ErrorRetryPolicy.MaxElapsedTime = 0
+	conf.ErrorRetryPolicy.FatalErrors = []string{"CDC:ErrKafkaNewProducer"}
+	conf.ErrorRetryPolicy.RetryableErrors = []string{"CDC:ErrSinkURIInvalid"}
+	require.NoError(t, conf.ValidateAndAdjust(sinkURL))
+
+	// the policy of changefeeds created by old versions is nil.
+	conf = GetDefaultReplicaConfig()
+	conf.ErrorRetryPolicy = nil
+	require.NoError(t, conf.ValidateAndAdjust(sinkURL))
+
+	testCases := []struct {
+		adjust func(c *ErrorRetryPolicyConfig)
+		errMsg string
+	}{
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) { c.InitialInterval = 0 },
+			errMsg: "initial-interval must be larger than 0",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) { c.MaxInterval = time.Second },
+			errMsg: "max-interval must not be less than initial-interval",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) { c.MaxElapsedTime = -time.Second },
+			errMsg: "max-elapsed-time must not be negative",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) { c.FatalErrors = []string{"ErrKafkaNewProducer"} },
+			errMsg: "invalid error code ErrKafkaNewProducer",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) { c.RetryableErrors = []string{""} },
+			errMsg: "invalid error code  in error-retry-policy.retryable-errors",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) {
+				c.FatalErrors = []string{"CDC:ErrKafkaNewProducer"}
+				c.RetryableErrors = []string{"CDC:ErrKafkaNewProducer"}
+			},
+			errMsg: "can not be both fatal and retryable",
+		},
+		{
+			adjust: func(c *ErrorRetryPolicyConfig) {
+				c.RetryableErrors = []string{"CDC:ErrGCTTLExceeded"}
+			},
+			errMsg: "error code CDC:ErrGCTTLExceeded in error-retry-policy.retryable-errors can not be retried",
+		},
+	}
+	for _, tc := range testCases {
+		conf := GetDefaultReplicaConfig()
+		tc.adjust(conf.ErrorRetryPolicy)
+		err := conf.ValidateAndAdjust(sinkURL)
+		require.ErrorContains(t, err, "ErrInvalidReplicaConfig")
+		require.ErrorContains(t, err, tc.errMsg)
+	}
+}
+
+func TestErrorRetryPolicyMatch(t *testing.T) {
+	t.Parallel()
+
+	policy := &ErrorRetryPolicyConfig{
+		FatalErrors:     []string{"CDC:ErrKafkaNewProducer"},
+		RetryableErrors: []string{"CDC:ErrSinkURIInvalid"},
+	}
+	require.True(t, policy.IsFatalError("CDC:ErrKafkaNewProducer", ""))
+	// the error is wrapped by another error.
+	require.True(t, policy.IsFatalError("CDC:ErrProcessorUnknown",
+		"[CDC:ErrKafkaNewProducer]new kafka producer"))
+	require.False(t, policy.IsFatalError("CDC:ErrSinkURIInvalid", "sink uri invalid"))
+	require.True(t, policy.IsRetryableError("CDC:ErrSinkURIInvalid", ""))
+	require.False(t, policy.IsRetryableError("CDC:ErrKafkaNewProducer", ""))
+	// only the whole error codes are matched.
+	require.False(t, policy.IsFatalError("CDC:ErrKafkaNewProducerFailed",
+		"[CDC:ErrKafkaNewProducerFailed]new kafka producer"))
+	require.False(t, policy.IsFatalError("CDC:ErrProcessorUnknown",
+		"failed to create CDC:ErrKafkaNewProducer"))
+	require.True(t, policy.IsRetryableError("CDC:ErrProcessorUnknown",
+		"[CDC:ErrProcessorUnknown]wrapped: [CDC:ErrSinkURIInvalid]sink uri invalid"))
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// FollowerConfig makes a changefeed follow another changefeed. The checkpoint
+// of the follower changefeed is never ahead of the checkpoint of the leader
+// changefeed by more than MaxLag, so the downstream systems joining data from
+// both changefeeds see a bounded difference between them.
+type FollowerConfig struct {
+	// LeaderChangefeedID is the ID of the changefeed to follow, which must be
+	// in the same namespace as the follower changefeed.
+	LeaderChangefeedID string `toml:"leader-changefeed-id" json:"leader-changefeed-id"`
+	// MaxLag is the max time the follower changefeed can be ahead of the
+	// leader changefeed, 0 means the follower never gets ahead of the leader.
+	MaxLag time.Duration `toml:"max-lag" json:"max-lag"`
+}
+
+// ValidateAndAdjust validates the follower config.
+func (c *FollowerConfig) ValidateAndAdjust() error {
+	if c.LeaderChangefeedID == "" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"follower.leader-changefeed-id must be set")
+	}
+	if c.MaxLag < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"follower.max-lag must not be negative")
+	}
+	return nil
+}
@@ -80,11 +80,21 @@ var defaultReplicaConfig = &ReplicaConfig{
 		EnableTableAcrossNodes: false,
 		RegionThreshold:        100_000,
 		WriteKeyThreshold:      0,
+		WriteRowThreshold:      0,
 	},
 	Integrity: &integrity.Config{
 		IntegrityCheckLevel:   integrity.CheckLevelNone,
 		CorruptionHandleLevel: integrity.CorruptionHandleLevelWarn,
 	},
+	ErrorRetryPolicy: &ErrorRetryPolicyConfig{
+		InitialInterval: DefaultErrorRetryInitialInterval,
+		MaxInterval:     DefaultErrorRetryMaxInterval,
+		MaxElapsedTime:  DefaultErrorRetryMaxElapsedTime,
+	},
+	ThroughputLimit: &ThroughputLimitConfig{
+		RowsPerSecond:  0,
+		BytesPerSecond: 0,
+	},
 }
 
 // GetDefaultReplicaConfig returns the default replica config.
@@ -113,19 +123,26 @@ type replicaConfig struct {
 	EnableOldValue   bool   `toml:"enable-old-value" json:"enable-old-value"`
 	ForceReplicate   bool   `toml:"force-replicate" json:"force-replicate"`
 	CheckGCSafePoint bool   `toml:"check-gc-safe-point" json:"check-gc-safe-point"`
-	// EnableSyncPoint is only available when the downstream is a Database.
+	// EnableSyncPoint is available when the downstream is a Database, MQ or storage.
+	// The syncpoint is sent as a special message to the MQ,
+	// and written as a marker file to the storage.
 	EnableSyncPoint *bool `toml:"enable-sync-point" json:"enable-sync-point,omitempty"`
 	// IgnoreIneligibleTable is used to store the user's config when creating a changefeed.
 	// not used in the changefeed's lifecycle.
 	IgnoreIneligibleTable bool `toml:"ignore-ineligible-table" json:"ignore-ineligible-table"`
+	// EnableLargeTxnSpill is only available for MySQL compatible sinks when the
+	// transaction-atomicity is table. If it is enabled, the rows of a transaction
+	// exceeding the memory quota are staged in the sort engine, and streamed to
+	// the downstream in one transaction, instead of being held in memory.
+	EnableLargeTxnSpill bool `toml:"enable-large-txn-spill" json:"enable-large-txn-spill"`
 
 	// BDR(Bidirectional Replication) is a feature that allows users to
 	// replicate data of same tables from TiDB-1 to TiDB-2 and vice versa.
 	// This feature is only available for TiDB.
 	BDRMode *bool `toml:"bdr-mode" json:"bdr-mode,omitempty"`
-	// SyncPointInterval is only available when the downstream is DB.
+	// SyncPointInterval is only available when the syncpoint is enabled.
 	SyncPointInterval *time.Duration `toml:"sync-point-interval" json:"sync-point-interval,omitempty"`
-	// SyncPointRetention is only available when the downstream is DB.
+	// SyncPointRetention is only available when the downstream is DB or storage.
 	SyncPointRetention *time.Duration `toml:"sync-point-retention" json:"sync-point-retention,omitempty"`
 	Filter             *FilterConfig  `toml:"filter" json:"filter"`
 	Mounter            *MounterConfig `toml:"mounter" json:"mounter"`
@@ -136,6 +153,13 @@ type replicaConfig struct {
 	Scheduler *ChangefeedSchedulerConfig `toml:"scheduler" json:"scheduler"`
 	// Integrity is only available when the downstream is MQ.
 	Integrity *integrity.Config `toml:"integrity" json:"integrity"`
+	// ErrorRetryPolicy is the policy to restart the changefeed when it meets errors.
+	ErrorRetryPolicy *ErrorRetryPolicyConfig `toml:"error-retry-policy" json:"error-retry-policy"`
+	// ThroughputLimit limits the write speed of the changefeed.
+	ThroughputLimit *ThroughputLimitConfig `toml:"throughput-limit" json:"throughput-limit"`
+	// Follower caps the progress of the changefeed at the checkpoint of
+	// another changefeed.
+	Follower *FollowerConfig `toml:"follower" json:"follower,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ReplicationConfig
@@ -239,6 +263,9 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 	if c.Scheduler == nil {
 		c.FixScheduler(false)
 	}
+	if err := c.Scheduler.Validate(); err != nil {
+		return err
+	}
 	// TODO: Remove the hack once span replication is compatible with all sinks.
 	if !isSinkCompatibleWithSpanReplication(sinkURI) {
 		c.Scheduler.EnableTableAcrossNodes = false
@@ -259,6 +286,23 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		}
 	}
 
+	if c.EnableLargeTxnSpill && !sink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		log.Warn("large transaction spill only support mysql compatible sinks now, disable it")
+		c.EnableLargeTxnSpill = false
+	}
+
+	if c.ErrorRetryPolicy != nil {
+		if err := c.ErrorRetryPolicy.ValidateAndAdjust(); err != nil {
+			return err
+		}
+	}
+
+	if c.Follower != nil {
+		if err := c.Follower.ValidateAndAdjust(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
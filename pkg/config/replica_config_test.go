@@ -61,6 +61,9 @@ func TestReplicaConfigMarshal(t *testing.T) {
 	conf.Scheduler.EnableTableAcrossNodes = true
 	conf.Scheduler.RegionThreshold = 100001
 	conf.Scheduler.WriteKeyThreshold = 100001
+	conf.Scheduler.WriteRowThreshold = 100001
+	conf.Scheduler.RequiredLabels = map[string]string{"zone": "z1"}
+	conf.Scheduler.PreferredLabels = map[string]string{"host": "h1"}
 
 	conf.Sink.OnlyOutputUpdatedColumns = aws.Bool(true)
 	conf.Sink.DeleteOnlyOutputHandleKeyColumns = aws.Bool(true)
@@ -247,11 +250,35 @@ func TestValidateAndAdjust(t *testing.T) {
 	require.Nil(t, cfg.ValidateAndAdjust(sinkURL))
 	require.False(t, cfg.Scheduler.EnableTableAcrossNodes)
 
+	cfg = GetDefaultReplicaConfig()
+	cfg.Scheduler.RequiredLabels = map[string]string{"": "z1"}
+	require.ErrorContains(t, cfg.ValidateAndAdjust(sinkURL), "invalid required-labels")
+	cfg.Scheduler.RequiredLabels = map[string]string{"zone": "z1"}
+	cfg.Scheduler.PreferredLabels = map[string]string{"host": ""}
+	require.ErrorContains(t, cfg.ValidateAndAdjust(sinkURL), "invalid preferred-labels")
+	cfg.Scheduler.PreferredLabels = map[string]string{"host": "h1"}
+	require.Nil(t, cfg.ValidateAndAdjust(sinkURL))
+	cfg.Scheduler.WriteRowThreshold = -1
+	require.ErrorContains(t, cfg.ValidateAndAdjust(sinkURL), "write-row-threshold")
+	cfg.Scheduler.WriteRowThreshold = 1000
+	require.Nil(t, cfg.ValidateAndAdjust(sinkURL))
+
 	// enable the checksum verification, but use blackhole sink
 	cfg = GetDefaultReplicaConfig()
 	cfg.Integrity.IntegrityCheckLevel = integrity.CheckLevelCorrectness
 	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
 	require.Equal(t, integrity.CheckLevelNone, cfg.Integrity.IntegrityCheckLevel)
+
+	// enable the large transaction spill, but use blackhole sink
+	cfg = GetDefaultReplicaConfig()
+	cfg.EnableLargeTxnSpill = true
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURL))
+	require.False(t, cfg.EnableLargeTxnSpill)
+	cfg.EnableLargeTxnSpill = true
+	mysqlURL, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	require.NoError(t, cfg.ValidateAndAdjust(mysqlURL))
+	require.True(t, cfg.EnableLargeTxnSpill)
 }
 
 func TestIsSinkCompatibleWithSpanReplication(t *testing.T) {
@@ -383,3 +410,22 @@ func TestAdjustEnableOldValueAndVerifyForceReplicate(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, config.EnableOldValue)
 }
+
+func TestValidateAndAdjustFollower(t *testing.T) {
+	t.Parallel()
+
+	sinkURL, err := url.Parse("blackhole://")
+	require.NoError(t, err)
+
+	conf := GetDefaultReplicaConfig()
+	conf.Follower = &FollowerConfig{LeaderChangefeedID: "leader", MaxLag: time.Minute}
+	require.NoError(t, conf.ValidateAndAdjust(sinkURL))
+
+	conf.Follower.MaxLag = -time.Second
+	require.Regexp(t, ".*max-lag must not be negative.*",
+		conf.ValidateAndAdjust(sinkURL))
+
+	conf.Follower = &FollowerConfig{}
+	require.Regexp(t, ".*leader-changefeed-id must be set.*",
+		conf.ValidateAndAdjust(sinkURL))
+}
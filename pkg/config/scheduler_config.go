@@ -14,6 +14,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -28,8 +29,46 @@ type ChangefeedSchedulerConfig struct {
 	RegionThreshold int `toml:"region-threshold" json:"region-threshold"`
 	// WriteKeyThreshold is the written keys threshold of splitting a table.
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
+	// WriteRowThreshold is the threshold of rows written per second of
+	// splitting a table while the changefeed is running. A split table is
+	// merged back once its rows written per second drops below half of the
+	// threshold. 0 means tables are never split or merged by write rate.
+	WriteRowThreshold int `toml:"write-row-threshold" json:"write-row-threshold"`
 	// Deprecated.
 	RegionPerSpan int `toml:"region-per-span" json:"region-per-span"`
+	// RequiredLabels are the labels a capture must have to replicate the
+	// tables of the changefeed.
+	RequiredLabels map[string]string `toml:"required-labels" json:"required-labels,omitempty"`
+	// PreferredLabels are the labels of the captures that replicate the tables
+	// of the changefeed if there are any such captures having RequiredLabels.
+	PreferredLabels map[string]string `toml:"preferred-labels" json:"preferred-labels,omitempty"`
+}
+
+// Validate verifies that each parameter is valid.
+func (c *ChangefeedSchedulerConfig) Validate() error {
+	if c.WriteRowThreshold < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"write-row-threshold must not be negative")
+	}
+	if err := validateLabels(c.RequiredLabels); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("invalid required-labels: %s", err))
+	}
+	if err := validateLabels(c.PreferredLabels); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("invalid preferred-labels: %s", err))
+	}
+	return nil
+}
+
+// validateLabels checks that the keys and values of the labels are not empty.
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if key == "" || value == "" {
+			return fmt.Errorf("the key and value of label %q=%q must not be empty", key, value)
+		}
+	}
+	return nil
 }
 
 // SchedulerConfig configs TiCDC scheduler.
@@ -174,6 +174,10 @@ type ServerConfig struct {
 	Debug               *DebugConfig    `toml:"debug" json:"debug"`
 	ClusterID           string          `toml:"cluster-id" json:"cluster-id"`
 	MaxMemoryPercentage int             `toml:"max-memory-percentage" json:"max-memory-percentage"`
+	// Labels are reported through the capture info, and are used to place
+	// the tables of changefeeds on some captures, e.g. captures in the same
+	// zone as the downstream.
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ServerConfig
@@ -243,6 +247,9 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	if err := validateLabels(c.Labels); err != nil {
+		return cerror.ErrInvalidServerOption.GenWithStack("invalid labels: %s", err)
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
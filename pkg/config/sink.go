@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	filter "github.com/pingcap/tidb/util/table-filter"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -99,12 +100,15 @@ var ForceEnableOldValueProtocols = map[string]struct{}{
 	ProtocolCanal.String():     {},
 	ProtocolCanalJSON.String(): {},
 	ProtocolMaxwell.String():   {},
+	ProtocolDebezium.String():  {},
+	ProtocolProtobuf.String():  {},
 }
 
 // ForceDisableOldValueProtocols specifies protocols need to be forced to disable old value.
 var ForceDisableOldValueProtocols = map[string]struct{}{
-	ProtocolAvro.String(): {},
-	ProtocolCsv.String():  {},
+	ProtocolAvro.String():    {},
+	ProtocolCsv.String():     {},
+	ProtocolParquet.String(): {},
 }
 
 // SinkConfig represents sink config for a changefeed
@@ -117,10 +121,16 @@ type SinkConfig struct {
 	DispatchRules []*DispatchRule `toml:"dispatchers" json:"dispatchers,omitempty"`
 	// CSVConfig is only available when the downstream is Storage.
 	CSVConfig *CSVConfig `toml:"csv" json:"csv,omitempty"`
-	// ColumnSelectors is Deprecated.
+	// RouteRules routes the upstream tables to different downstream
+	// schemas and tables, it is available for all kinds of downstream.
+	RouteRules []*RouteRule `toml:"route-rules" json:"route-rules,omitempty"`
+	// ColumnSelectors selects and masks the columns of the rows sent to the
+	// downstream, it is available for all kinds of downstream.
 	ColumnSelectors []*ColumnSelector `toml:"column-selectors" json:"column-selectors,omitempty"`
 	// SchemaRegistry is only available when the downstream is MQ using avro protocol.
 	SchemaRegistry *string `toml:"schema-registry" json:"schema-registry,omitempty"`
+	// SchemaRegistryConfig is only available when the downstream is MQ using avro protocol.
+	SchemaRegistryConfig *SchemaRegistryConfig `toml:"schema-registry-config" json:"schema-registry-config,omitempty"`
 	// EncoderConcurrency is only available when the downstream is MQ.
 	EncoderConcurrency *int `toml:"encoder-concurrency" json:"encoder-concurrency,omitempty"`
 	// Terminator is NOT available when the downstream is DB.
@@ -143,6 +153,10 @@ type SinkConfig struct {
 	DeleteOnlyOutputHandleKeyColumns *bool `toml:"delete-only-output-handle-key-columns" json:"delete-only-output-handle-key-columns,omitempty"`
 	// LargeMessageOnlyHandleKeyColumns is only available when the downstream is MQ.
 	LargeMessageOnlyHandleKeyColumns *bool `toml:"large-message-only-handle-key-columns" json:"large-message-only-handle-key-columns,omitempty"`
+	// LargeMessageClaimCheckStorageURI is only available when the downstream is MQ.
+	// If it's set, the message larger than the max-message-bytes is written to the
+	// external storage, and only its handle key columns and location are sent.
+	LargeMessageClaimCheckStorageURI *string `toml:"large-message-claim-check-storage-uri" json:"large-message-claim-check-storage-uri,omitempty"`
 
 	// TiDBSourceID is the source ID of the upstream TiDB,
 	// which is used to set the `tidb_cdc_write_source` session variable.
@@ -152,6 +166,7 @@ type SinkConfig struct {
 	// SafeMode is only available when the downstream is DB.
 	SafeMode           *bool               `toml:"safe-mode" json:"safe-mode,omitempty"`
 	KafkaConfig        *KafkaConfig        `toml:"kafka-config" json:"kafka-config,omitempty"`
+	PulsarConfig       *PulsarConfig       `toml:"pulsar-config" json:"pulsar-config,omitempty"`
 	MySQLConfig        *MySQLConfig        `toml:"mysql-config" json:"mysql-config,omitempty"`
 	CloudStorageConfig *CloudStorageConfig `toml:"cloud-storage-config" json:"cloud-storage-config,omitempty"`
 }
@@ -256,13 +271,143 @@ type DispatchRule struct {
 	// PartitionRule is an alias added for DispatcherRule to mitigate confusions.
 	// In the future release, the DispatcherRule is expected to be removed .
 	PartitionRule string `toml:"partition" json:"partition"`
-	TopicRule     string `toml:"topic" json:"topic"`
+	// Columns are the columns used by the `columns` partition rule,
+	// rows which have the same values of these columns are dispatched
+	// to the same partition.
+	Columns   []string `toml:"columns" json:"columns,omitempty"`
+	TopicRule string   `toml:"topic" json:"topic"`
+}
+
+// validateColumns checks the columns are specified only for the `columns`
+// partition rule, the existence of the columns is checked against the
+// table schemas when the changefeed is created or updated.
+func (r *DispatchRule) validateColumns() error {
+	if !strings.EqualFold(r.PartitionRule, "columns") {
+		if len(r.Columns) != 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"columns can only be specified for the columns partition rule, "+
+					"but got partition rule %s for rule: %v", r.PartitionRule, r.Matcher)
+		}
+		return nil
+	}
+	if len(r.Columns) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"columns should be specified for the columns partition rule: %v", r.Matcher)
+	}
+	for _, col := range r.Columns {
+		if strings.TrimSpace(col) == "" {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"empty column name for the columns partition rule: %v", r.Matcher)
+		}
+	}
+	return nil
+}
+
+// RouteRule represents a rule which routes the matched upstream tables
+// to the target schema and table in the downstream.
+// The target names can contain the placeholders `{schema}` and `{table}`,
+// which are replaced by the upstream schema and table name. An empty
+// target keeps the upstream name unchanged.
+type RouteRule struct {
+	Matcher      []string `toml:"matcher" json:"matcher"`
+	TargetSchema string   `toml:"target-schema" json:"target-schema"`
+	TargetTable  string   `toml:"target-table" json:"target-table"`
+}
+
+func (r *RouteRule) validate() error {
+	if len(r.Matcher) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"matcher should be specified for the route rule: %+v", r)
+	}
+	if _, err := filter.Parse(r.Matcher); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	if r.TargetSchema == "" && r.TargetTable == "" {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"target-schema or target-table should be specified for the route rule: %v",
+			r.Matcher)
+	}
+	return nil
 }
 
 // ColumnSelector represents a column selector for a table.
+// Only the columns matched by the Columns rules are sent to the downstream,
+// all the columns are selected if no rule is given. The Masks are applied to
+// the selected columns, the first matched mask takes effect.
+// The handle key columns are always selected and never masked, since they
+// are required to keep the order of the rows in the downstream.
 type ColumnSelector struct {
-	Matcher []string `toml:"matcher" json:"matcher"`
+	Matcher []string      `toml:"matcher" json:"matcher"`
+	Columns []string      `toml:"columns" json:"columns"`
+	Masks   []*ColumnMask `toml:"masks" json:"masks,omitempty"`
+}
+
+const (
+	// ColumnMaskActionDrop drops the column.
+	ColumnMaskActionDrop = "drop"
+	// ColumnMaskActionHash replaces the value with the hex encoded
+	// SHA-256 digest of the salted value.
+	ColumnMaskActionHash = "hash"
+	// ColumnMaskActionRedact replaces the value with a fixed placeholder.
+	ColumnMaskActionRedact = "redact"
+	// ColumnMaskActionTruncate keeps the leading characters of the value.
+	ColumnMaskActionTruncate = "truncate"
+)
+
+// ColumnMask represents a masking rule for the matched columns.
+// The hash, redact and truncate actions can only be applied to the string
+// and binary columns, which is checked when the changefeed is created or
+// updated.
+type ColumnMask struct {
 	Columns []string `toml:"columns" json:"columns"`
+	Action  string   `toml:"action" json:"action"`
+	// Salt is only used by the hash action.
+	Salt string `toml:"salt" json:"salt,omitempty"`
+	// Length is only used by the truncate action.
+	Length int `toml:"length" json:"length,omitempty"`
+}
+
+func (c *ColumnSelector) validate() error {
+	if len(c.Matcher) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"matcher should be specified for the column selector: %+v", c)
+	}
+	if _, err := filter.Parse(c.Matcher); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	if _, err := filter.ParseColumnFilter(c.Columns); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	for _, m := range c.Masks {
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ColumnMask) validate() error {
+	if len(m.Columns) == 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"columns should be specified for the column mask: %+v", m)
+	}
+	if _, err := filter.ParseColumnFilter(m.Columns); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	switch m.Action {
+	case ColumnMaskActionDrop, ColumnMaskActionHash, ColumnMaskActionRedact:
+	case ColumnMaskActionTruncate:
+		if m.Length <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length should be positive for the truncate column mask: %v", m.Columns)
+		}
+	default:
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"action of the column mask could only be %s, %s, %s or %s, got %q",
+			ColumnMaskActionDrop, ColumnMaskActionHash,
+			ColumnMaskActionRedact, ColumnMaskActionTruncate, m.Action)
+	}
+	return nil
 }
 
 // CodecConfig represents a MQ codec configuration
@@ -312,6 +457,23 @@ type KafkaConfig struct {
 	CodecConfig                  *CodecConfig `toml:"codec-config" json:"codec-config,omitempty"`
 }
 
+// PulsarConfig represents a pulsar sink configuration
+type PulsarConfig struct {
+	MaxMessageBytes            *int    `toml:"max-message-bytes" json:"max-message-bytes,omitempty"`
+	Compression                *string `toml:"compression" json:"compression,omitempty"`
+	ConnectionTimeout          *string `toml:"connection-timeout" json:"connection-timeout,omitempty"`
+	OperationTimeout           *string `toml:"operation-timeout" json:"operation-timeout,omitempty"`
+	SendTimeout                *string `toml:"send-timeout" json:"send-timeout,omitempty"`
+	BatchingMaxMessages        *uint   `toml:"batching-max-messages" json:"batching-max-messages,omitempty"`
+	BatchingMaxPublishDelay    *string `toml:"batching-max-publish-delay" json:"batching-max-publish-delay,omitempty"`
+	AuthenticationToken        *string `toml:"authentication-token" json:"authentication-token,omitempty"`
+	TokenFromFile              *string `toml:"token-from-file" json:"token-from-file,omitempty"`
+	BasicUserName              *string `toml:"basic-user-name" json:"basic-user-name,omitempty"`
+	BasicPassword              *string `toml:"basic-password" json:"basic-password,omitempty"`
+	TLSTrustCertsFilePath      *string `toml:"tls-trust-certs-file-path" json:"tls-trust-certs-file-path,omitempty"`
+	TLSAllowInsecureConnection *bool   `toml:"tls-allow-insecure-connection" json:"tls-allow-insecure-connection,omitempty"`
+}
+
 // MySQLConfig represents a MySQL sink configuration
 type MySQLConfig struct {
 	WorkerCount                  *int    `toml:"worker-count" json:"worker-count,omitempty"`
@@ -331,11 +493,28 @@ type MySQLConfig struct {
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
 }
 
+// SchemaRegistryConfig represents the options of the schema registry
+// which the avro schemas are registered to.
+type SchemaRegistryConfig struct {
+	// Type is the type of the schema registry, it can be
+	// `confluent`, `apicurio` or `glue`, the default value is `confluent`.
+	Type *string `toml:"type" json:"type,omitempty"`
+	// SubjectNameStrategy decides the subject which the schema is registered under,
+	// it can be `topic`, `record` or `topic-record`, the default value is `topic`.
+	SubjectNameStrategy *string `toml:"subject-name-strategy" json:"subject-name-strategy,omitempty"`
+	// GlueRegion and GlueRegistryName are only available for the glue schema registry.
+	GlueRegion       *string `toml:"glue-region" json:"glue-region,omitempty"`
+	GlueRegistryName *string `toml:"glue-registry-name" json:"glue-registry-name,omitempty"`
+}
+
 // CloudStorageConfig represents a cloud storage sink configuration
 type CloudStorageConfig struct {
 	WorkerCount   *int    `toml:"worker-count" json:"worker-count,omitempty"`
 	FlushInterval *string `toml:"flush-interval" json:"flush-interval,omitempty"`
-	FileSize      *int    `toml:"file-size" json:"file-size,omitempty"`
+	// FileSize is the size of the encoded rows of a data file. For the parquet
+	// protocol it is measured on the intermediate rows before they are
+	// converted into parquet, so the written files are usually smaller.
+	FileSize *int `toml:"file-size" json:"file-size,omitempty"`
 }
 
 func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
@@ -343,6 +522,17 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		return err
 	}
 
+	for _, rule := range s.RouteRules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	for _, selector := range s.ColumnSelectors {
+		if err := selector.validate(); err != nil {
+			return err
+		}
+	}
+
 	if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -361,6 +551,9 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		if err := rule.validateColumns(); err != nil {
+			return err
+		}
 	}
 
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
@@ -379,6 +572,20 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 			"CSV protocol always output all columns for the delete event, " +
 				"do not set `delete-only-output-handle-key-columns` to true")
 	}
+	if protocol == ProtocolParquet && (sinkURI == nil || !sink.IsStorageScheme(sinkURI.Scheme)) {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"parquet protocol is only supported by the cloud storage sink")
+	}
+	if claimCheckURI := util.GetOrZero(s.LargeMessageClaimCheckStorageURI); claimCheckURI != "" {
+		if util.GetOrZero(s.LargeMessageOnlyHandleKeyColumns) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"`large-message-only-handle-key-columns` and " +
+					"`large-message-claim-check-storage-uri` cannot be set both")
+		}
+		if _, err := url.Parse(claimCheckURI); err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+	}
 
 	// validate storage sink related config
 	if sinkURI != nil && sink.IsStorageScheme(sinkURI.Scheme) {
@@ -431,7 +638,8 @@ func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 
 	// Validate that protocol is compatible with the scheme. For testing purposes,
 	// any protocol should be legal for blackhole.
-	if sink.IsMQScheme(sinkURI.Scheme) || sink.IsStorageScheme(sinkURI.Scheme) {
+	if sink.IsMQScheme(sinkURI.Scheme) || sink.IsStorageScheme(sinkURI.Scheme) ||
+		sink.IsWebhookScheme(sinkURI.Scheme) {
 		_, err := ParseSinkProtocolFromString(util.GetOrZero(s.Protocol))
 		if err != nil {
 			return err
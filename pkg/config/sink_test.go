@@ -367,4 +367,209 @@ func TestValidateAndAdjustStorageConfig(t *testing.T) {
 	err = s.ValidateAndAdjust(sinkURI)
 	require.NoError(t, err)
 	require.Equal(t, 16, util.GetOrZero(s.Sink.FileIndexWidth))
+
+	sinkURI, err = url.Parse("s3://bucket?protocol=parquet")
+	require.NoError(t, err)
+	s = GetDefaultReplicaConfig()
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092?protocol=parquet")
+	require.NoError(t, err)
+	s = GetDefaultReplicaConfig()
+	require.Regexp(t, "parquet protocol is only supported", s.ValidateAndAdjust(sinkURI))
+}
+
+func TestValidateAndAdjustColumnsDispatchRule(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		rule   *DispatchRule
+		errMsg string
+	}{
+		{
+			rule: &DispatchRule{
+				Matcher:       []string{"test.*"},
+				PartitionRule: "columns",
+				Columns:       []string{"tenant_id"},
+			},
+		},
+		{
+			rule: &DispatchRule{
+				Matcher:        []string{"test.*"},
+				DispatcherRule: "columns",
+				Columns:        []string{"tenant_id", "region"},
+			},
+		},
+		{
+			rule: &DispatchRule{
+				Matcher:       []string{"test.*"},
+				PartitionRule: "columns",
+			},
+			errMsg: "columns should be specified for the columns partition rule",
+		},
+		{
+			rule: &DispatchRule{
+				Matcher:       []string{"test.*"},
+				PartitionRule: "columns",
+				Columns:       []string{"tenant_id", " "},
+			},
+			errMsg: "empty column name for the columns partition rule",
+		},
+		{
+			rule: &DispatchRule{
+				Matcher:       []string{"test.*"},
+				PartitionRule: "ts",
+				Columns:       []string{"tenant_id"},
+			},
+			errMsg: "columns can only be specified for the columns partition rule",
+		},
+	}
+
+	for _, tc := range testCases {
+		s := GetDefaultReplicaConfig()
+		s.Sink.DispatchRules = []*DispatchRule{tc.rule}
+		err := s.ValidateAndAdjust(sinkURI)
+		if tc.errMsg == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tc.errMsg)
+		}
+	}
+}
+
+func TestValidateRouteRules(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		rule   *RouteRule
+		errMsg string
+	}{
+		{
+			rule: &RouteRule{
+				Matcher:      []string{"shard_*.order_*"},
+				TargetSchema: "shard",
+				TargetTable:  "order",
+			},
+		},
+		{
+			rule: &RouteRule{
+				Matcher:     []string{"test.*"},
+				TargetTable: "{schema}_{table}",
+			},
+		},
+		{
+			rule:   &RouteRule{TargetSchema: "test"},
+			errMsg: "matcher should be specified for the route rule",
+		},
+		{
+			rule:   &RouteRule{Matcher: []string{"test.*"}},
+			errMsg: "target-schema or target-table should be specified",
+		},
+		{
+			rule:   &RouteRule{Matcher: []string{"[test.*"}, TargetSchema: "test"},
+			errMsg: "ErrSinkInvalidConfig",
+		},
+	}
+
+	for _, tc := range testCases {
+		s := GetDefaultReplicaConfig()
+		s.Sink.RouteRules = []*RouteRule{tc.rule}
+		err := s.ValidateAndAdjust(sinkURI)
+		if tc.errMsg == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tc.errMsg)
+		}
+	}
+}
+
+func TestValidateColumnSelectors(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		selector *ColumnSelector
+		errMsg   string
+	}{
+		{
+			selector: &ColumnSelector{
+				Matcher: []string{"test.*"},
+				Columns: []string{"*", "!secret"},
+				Masks: []*ColumnMask{
+					{Columns: []string{"email"}, Action: ColumnMaskActionHash, Salt: "salt"},
+					{Columns: []string{"phone"}, Action: ColumnMaskActionTruncate, Length: 3},
+					{Columns: []string{"name"}, Action: ColumnMaskActionRedact},
+					{Columns: []string{"address"}, Action: ColumnMaskActionDrop},
+				},
+			},
+		},
+		{
+			selector: &ColumnSelector{Columns: []string{"*"}},
+			errMsg:   "matcher should be specified for the column selector",
+		},
+		{
+			selector: &ColumnSelector{Matcher: []string{"[test.*"}},
+			errMsg:   "ErrSinkInvalidConfig",
+		},
+		{
+			selector: &ColumnSelector{
+				Matcher: []string{"test.*"},
+				Masks:   []*ColumnMask{{Action: ColumnMaskActionHash}},
+			},
+			errMsg: "columns should be specified for the column mask",
+		},
+		{
+			selector: &ColumnSelector{
+				Matcher: []string{"test.*"},
+				Masks:   []*ColumnMask{{Columns: []string{"a"}, Action: "encrypt"}},
+			},
+			errMsg: "action of the column mask could only be drop, hash, redact or truncate",
+		},
+		{
+			selector: &ColumnSelector{
+				Matcher: []string{"test.*"},
+				Masks: []*ColumnMask{
+					{Columns: []string{"a"}, Action: ColumnMaskActionTruncate},
+				},
+			},
+			errMsg: "length should be positive for the truncate column mask",
+		},
+	}
+
+	for _, tc := range testCases {
+		s := GetDefaultReplicaConfig()
+		s.Sink.ColumnSelectors = []*ColumnSelector{tc.selector}
+		err := s.ValidateAndAdjust(sinkURI)
+		if tc.errMsg == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tc.errMsg)
+		}
+	}
+}
+
+func TestValidateLargeMessageClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092?protocol=open-protocol")
+	require.NoError(t, err)
+
+	s := GetDefaultReplicaConfig()
+	s.Sink.LargeMessageClaimCheckStorageURI = util.AddressOf("s3://bucket/prefix")
+	require.NoError(t, s.ValidateAndAdjust(sinkURI))
+
+	s.Sink.LargeMessageOnlyHandleKeyColumns = util.AddressOf(true)
+	require.ErrorContains(t, s.ValidateAndAdjust(sinkURI), "cannot be set both")
+
+	s.Sink.LargeMessageOnlyHandleKeyColumns = util.AddressOf(false)
+	s.Sink.LargeMessageClaimCheckStorageURI = util.AddressOf("s3://bucket/%zz")
+	require.ErrorContains(t, s.ValidateAndAdjust(sinkURI), "ErrSinkInvalidConfig")
 }
@@ -0,0 +1,31 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ThroughputLimitConfig limits the speed of a changefeed writing to the
+// downstream and the redo log storage. It can be updated without
+// restarting the changefeed.
+type ThroughputLimitConfig struct {
+	// RowsPerSecond is the max number of rows written per second,
+	// 0 means no limit.
+	RowsPerSecond uint64 `toml:"rows-per-second" json:"rows-per-second"`
+	// BytesPerSecond is the max number of bytes written per second,
+	// 0 means no limit.
+	BytesPerSecond uint64 `toml:"bytes-per-second" json:"bytes-per-second"`
+}
+
+// Enabled returns true if any limit is set.
+func (c *ThroughputLimitConfig) Enabled() bool {
+	return c != nil && (c.RowsPerSecond > 0 || c.BytesPerSecond > 0)
+}
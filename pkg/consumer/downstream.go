@@ -0,0 +1,406 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"sort"
+	"strings"
+
+	gmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	ddlmysql "github.com/pingcap/tiflow/cdc/sink/ddlsink/mysql"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/txn/mysql"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/errorutil"
+	"github.com/pingcap/tiflow/pkg/sink"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	// schemaName is the name of database where the progress tables sit.
+	schemaName = "tidb_cdc"
+	// kafkaOffsetTableName is the name of table where the offsets and
+	// the resolved ts of Kafka partitions sit.
+	kafkaOffsetTableName = "kafka_consumer_offset_v1"
+	// tableProgressTableName is the name of table where the resolved ts
+	// of downstream tables sit.
+	tableProgressTableName = "kafka_consumer_table_progress_v1"
+	// ddlProgressTableName is the name of table where the commit ts of the
+	// DDLs being executed sits.
+	ddlProgressTableName = "kafka_consumer_ddl_progress_v1"
+)
+
+// tableKey identifies a table in the downstream.
+type tableKey struct {
+	schema string
+	table  string
+}
+
+// partitionProgress is the persisted progress of a Kafka partition.
+type partitionProgress struct {
+	// offset is the offset of the first message to consume after restarting.
+	offset int64
+	// resolvedTs is the ts before which all rows of the partition are applied.
+	resolvedTs uint64
+}
+
+// progress is the persisted progress of a consumer.
+type progress struct {
+	partitions map[int32]partitionProgress
+	// tables is the commit ts of the last row or DDL applied to each table.
+	tables map[tableKey]uint64
+	// ddlTs is the commit ts of the DDLs being executed, it's 0 if no DDL is
+	// being executed. The DDLs at ddlTs may have been executed already if
+	// the consumer exits before the progress is saved again.
+	ddlTs uint64
+}
+
+func newProgress() *progress {
+	return &progress{
+		partitions: make(map[int32]partitionProgress),
+		tables:     make(map[tableKey]uint64),
+	}
+}
+
+// downstream applies the events and persists the progress of a consumer.
+type downstream interface {
+	// loadProgress loads the persisted progress of the consumer.
+	loadProgress(ctx context.Context) (*progress, error)
+	// apply writes the rows and saves the progress in one transaction.
+	apply(ctx context.Context, rows []*model.RowChangedEvent, p *progress) error
+	// execDDL executes the DDL in the downstream. If the DDL may have been
+	// executed before restarting, the errors caused by executing it again
+	// are ignored.
+	execDDL(ctx context.Context, ddl *model.DDLEvent, mayBeExecuted bool) error
+	// close closes the downstream.
+	close()
+}
+
+var _ downstream = (*mysqlDownstream)(nil)
+
+// mysqlDownstream is a downstream of MySQL compatible databases, the progress
+// is saved in the same database, so the rows are applied exactly once. DDLs
+// can't be executed in a transaction, so they are applied at least once.
+// The rows are always written in the safe mode, i.e. by REPLACE statements,
+// since Kafka may deliver a row more than once, e.g. after the producer
+// retries or the changefeed restarts.
+type mysqlDownstream struct {
+	db      *sql.DB
+	ddlSink ddlsink.Sink
+	// ddlTs is the ddl ts of the progress saved last time.
+	ddlTs uint64
+
+	consumerID string
+	topic      string
+}
+
+// newMySQLDownstream creates a mysqlDownstream and the progress tables.
+func newMySQLDownstream(
+	ctx context.Context, consumerID, topic string, sinkURIStr string,
+) (*mysqlDownstream, error) {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		return nil, cerror.ErrSinkURIInvalid.GenWithStack(
+			"the downstream of the consumer must be MySQL compatible, but got %s", sinkURI.Scheme)
+	}
+	changefeedID := model.DefaultChangeFeedID(consumerID)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	cfg := pmysql.NewConfig()
+	err = cfg.Apply(config.GetGlobalServerConfig().TZ, changefeedID, sinkURI, replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, cfg, pmysql.CreateMySQLDBConn)
+	if err != nil {
+		return nil, err
+	}
+	db, err := pmysql.CreateMySQLDBConn(ctx, dsnStr)
+	if err != nil {
+		return nil, err
+	}
+	ddlSink, err := ddlmysql.NewDDLSink(ctx, changefeedID, sinkURI, replicaConfig)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	d := &mysqlDownstream{
+		db:         db,
+		ddlSink:    ddlSink,
+		consumerID: consumerID,
+		topic:      topic,
+	}
+	if err := d.createProgressTables(ctx); err != nil {
+		d.close()
+		return nil, err
+	}
+	log.Info("MySQL downstream of the consumer is created",
+		zap.String("consumerID", consumerID),
+		zap.String("topic", topic))
+	return d, nil
+}
+
+func (d *mysqlDownstream) createProgressTables(ctx context.Context) error {
+	queries := []string{
+		"CREATE DATABASE IF NOT EXISTS " + schemaName,
+		`CREATE TABLE IF NOT EXISTS ` + schemaName + "." + kafkaOffsetTableName + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		topic varchar(255) NOT NULL,
+		partition_id int NOT NULL,
+		` + "`offset`" + ` bigint NOT NULL,
+		resolved_ts bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, topic, partition_id)
+	)`,
+		`CREATE TABLE IF NOT EXISTS ` + schemaName + "." + tableProgressTableName + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		topic varchar(255) NOT NULL,
+		schema_name varchar(255) NOT NULL,
+		table_name varchar(255) NOT NULL,
+		resolved_ts bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, topic, schema_name, table_name)
+	)`,
+		`CREATE TABLE IF NOT EXISTS ` + schemaName + "." + ddlProgressTableName + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		topic varchar(255) NOT NULL,
+		commit_ts bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, topic)
+	)`,
+	}
+	for _, query := range queries {
+		if _, err := d.db.ExecContext(ctx, query); err != nil {
+			return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+		}
+	}
+	return nil
+}
+
+func (d *mysqlDownstream) loadProgress(ctx context.Context) (*progress, error) {
+	p := newProgress()
+	rows, err := d.db.QueryContext(ctx,
+		"SELECT partition_id, `offset`, resolved_ts FROM "+schemaName+"."+kafkaOffsetTableName+
+			" WHERE consumer_id = ? AND topic = ?", d.consumerID, d.topic)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	for rows.Next() {
+		var (
+			partition int32
+			pp        partitionProgress
+		)
+		if err := rows.Scan(&partition, &pp.offset, &pp.resolvedTs); err != nil {
+			_ = rows.Close()
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		p.partitions[partition] = pp
+	}
+	if err := rows.Close(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+
+	rows, err = d.db.QueryContext(ctx,
+		"SELECT schema_name, table_name, resolved_ts FROM "+schemaName+"."+tableProgressTableName+
+			" WHERE consumer_id = ? AND topic = ?", d.consumerID, d.topic)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	for rows.Next() {
+		var (
+			key        tableKey
+			resolvedTs uint64
+		)
+		if err := rows.Scan(&key.schema, &key.table, &resolvedTs); err != nil {
+			_ = rows.Close()
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		p.tables[key] = resolvedTs
+	}
+	if err := rows.Close(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+
+	err = d.db.QueryRowContext(ctx,
+		"SELECT commit_ts FROM "+schemaName+"."+ddlProgressTableName+
+			" WHERE consumer_id = ? AND topic = ?", d.consumerID, d.topic).Scan(&p.ddlTs)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	d.ddlTs = p.ddlTs
+	return p, nil
+}
+
+func (d *mysqlDownstream) apply(
+	ctx context.Context, rows []*model.RowChangedEvent, p *progress,
+) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	rollback := func(err error) error {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Warn("rollback the transaction failed", zap.Error(rbErr))
+		}
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+
+	for _, row := range rows {
+		// The duplicated rows are applied again, REPLACE statements make
+		// them idempotent.
+		sqls, values := mysql.PrepareRowDMLs(row, false /* translateToInsert */, true)
+		for i := range sqls {
+			if _, err := tx.ExecContext(ctx, sqls[i], values[i]...); err != nil {
+				return rollback(err)
+			}
+		}
+	}
+	if len(p.tables) > 0 {
+		query, args := d.prepareTableProgress(p.tables)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return rollback(err)
+		}
+	}
+	if len(p.partitions) > 0 {
+		query, args := d.preparePartitionProgress(p.partitions)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return rollback(err)
+		}
+	}
+	// the ddl ts only changes before and after executing DDLs.
+	if p.ddlTs != d.ddlTs {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO "+schemaName+"."+ddlProgressTableName+
+			" (consumer_id, topic, commit_ts) VALUES (?,?,?)"+
+			" ON DUPLICATE KEY UPDATE commit_ts = VALUES(commit_ts)",
+			d.consumerID, d.topic, p.ddlTs); err != nil {
+			return rollback(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	d.ddlTs = p.ddlTs
+	return nil
+}
+
+func (d *mysqlDownstream) prepareTableProgress(
+	tables map[tableKey]uint64,
+) (string, []interface{}) {
+	keys := make([]tableKey, 0, len(tables))
+	for key := range tables {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].schema != keys[j].schema {
+			return keys[i].schema < keys[j].schema
+		}
+		return keys[i].table < keys[j].table
+	})
+
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO " + schemaName + "." + tableProgressTableName +
+		" (consumer_id, topic, schema_name, table_name, resolved_ts) VALUES ")
+	args := make([]interface{}, 0, len(keys)*5)
+	for i, key := range keys {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?,?,?,?,?)")
+		args = append(args, d.consumerID, d.topic, key.schema, key.table, tables[key])
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE resolved_ts = VALUES(resolved_ts)")
+	return builder.String(), args
+}
+
+func (d *mysqlDownstream) preparePartitionProgress(
+	partitions map[int32]partitionProgress,
+) (string, []interface{}) {
+	ids := make([]int32, 0, len(partitions))
+	for id := range partitions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO " + schemaName + "." + kafkaOffsetTableName +
+		" (consumer_id, topic, partition_id, `offset`, resolved_ts) VALUES ")
+	args := make([]interface{}, 0, len(ids)*5)
+	for i, id := range ids {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?,?,?,?,?)")
+		args = append(args, d.consumerID, d.topic, id,
+			partitions[id].offset, partitions[id].resolvedTs)
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE `offset` = VALUES(`offset`), " +
+		"resolved_ts = VALUES(resolved_ts)")
+	return builder.String(), args
+}
+
+func (d *mysqlDownstream) execDDL(
+	ctx context.Context, ddl *model.DDLEvent, mayBeExecuted bool,
+) error {
+	err := d.ddlSink.WriteDDLEvent(ctx, ddl)
+	if err != nil && mayBeExecuted && isReexecutedDDLError(err) {
+		log.Warn("execute the DDL again failed, the DDL is executed before restarting",
+			zap.Uint64("commitTs", ddl.CommitTs),
+			zap.String("query", ddl.Query),
+			zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// isReexecutedDDLError checks whether the error is caused by executing a DDL
+// again. The errors like "table already exists" are ignored by the DDL sink,
+// and the others like "table doesn't exist" are caused by the DDLs renaming
+// or dropping the objects, such as RENAME TABLE.
+func isReexecutedDDLError(err error) bool {
+	if errorutil.IsIgnorableMySQLDDLError(err) {
+		return true
+	}
+	mysqlErr, ok := errors.Cause(err).(*gmysql.MySQLError)
+	if !ok {
+		return false
+	}
+	switch mysqlErr.Number {
+	case tmysql.ErrNoSuchTable, tmysql.ErrBadDB, tmysql.ErrBadField:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *mysqlDownstream) close() {
+	d.ddlSink.Close()
+	if err := d.db.Close(); err != nil {
+		log.Warn("close the downstream database failed", zap.Error(err))
+	}
+}
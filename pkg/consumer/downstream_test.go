@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockMySQLDownstream(t *testing.T) (*mysqlDownstream, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	return &mysqlDownstream{
+		db:         db,
+		consumerID: "c1",
+		topic:      "topic",
+	}, mock
+}
+
+func TestMySQLDownstreamLoadProgress(t *testing.T) {
+	t.Parallel()
+
+	d, mock := newMockMySQLDownstream(t)
+	mock.ExpectQuery("SELECT partition_id, `offset`, resolved_ts FROM "+
+		"tidb_cdc.kafka_consumer_offset_v1 WHERE consumer_id = ? AND topic = ?").
+		WithArgs("c1", "topic").
+		WillReturnRows(sqlmock.NewRows([]string{"partition_id", "offset", "resolved_ts"}).
+			AddRow(0, 10, 100).AddRow(1, 20, 100))
+	mock.ExpectQuery("SELECT schema_name, table_name, resolved_ts FROM "+
+		"tidb_cdc.kafka_consumer_table_progress_v1 WHERE consumer_id = ? AND topic = ?").
+		WithArgs("c1", "topic").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "table_name", "resolved_ts"}).
+			AddRow("test", "t1", 90))
+	mock.ExpectQuery("SELECT commit_ts FROM "+
+		"tidb_cdc.kafka_consumer_ddl_progress_v1 WHERE consumer_id = ? AND topic = ?").
+		WithArgs("c1", "topic").
+		WillReturnRows(sqlmock.NewRows([]string{"commit_ts"}).AddRow(100))
+
+	p, err := d.loadProgress(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 10, resolvedTs: 100},
+		1: {offset: 20, resolvedTs: 100},
+	}, p.partitions)
+	require.Equal(t, map[tableKey]uint64{{schema: "test", table: "t1"}: 90}, p.tables)
+	require.Equal(t, uint64(100), p.ddlTs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLDownstreamApply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d, mock := newMockMySQLDownstream(t)
+	rows := []*model.RowChangedEvent{{
+		CommitTs: 90,
+		Table:    &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{{
+			Name:  "a",
+			Type:  mysql.TypeLong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: 1,
+		}},
+	}}
+	p := newProgress()
+	p.partitions[1] = partitionProgress{offset: 21, resolvedTs: 100}
+	p.partitions[0] = partitionProgress{offset: 11, resolvedTs: 100}
+	p.tables[tableKey{schema: "test", table: "t1"}] = 90
+
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t1` (`a`) VALUES (?)").
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO tidb_cdc.kafka_consumer_table_progress_v1 "+
+		"(consumer_id, topic, schema_name, table_name, resolved_ts) VALUES (?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE resolved_ts = VALUES(resolved_ts)").
+		WithArgs("c1", "topic", "test", "t1", 90).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO tidb_cdc.kafka_consumer_offset_v1 "+
+		"(consumer_id, topic, partition_id, `offset`, resolved_ts) VALUES (?,?,?,?,?),(?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE `offset` = VALUES(`offset`), resolved_ts = VALUES(resolved_ts)").
+		WithArgs("c1", "topic", 0, 11, 100, "c1", "topic", 1, 21, 100).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+	require.NoError(t, d.apply(ctx, rows, p))
+
+	// the progress is not saved if the rows fail to apply.
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t1` (`a`) VALUES (?)").
+		WithArgs(1).WillReturnError(errors.New("lost connection"))
+	mock.ExpectRollback()
+	require.ErrorContains(t, d.apply(ctx, rows, p), "lost connection")
+
+	// the ddl ts is saved only if it's changed.
+	p = newProgress()
+	p.ddlTs = 100
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.kafka_consumer_ddl_progress_v1 "+
+		"(consumer_id, topic, commit_ts) VALUES (?,?,?) "+
+		"ON DUPLICATE KEY UPDATE commit_ts = VALUES(commit_ts)").
+		WithArgs("c1", "topic", 100).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, d.apply(ctx, nil, p))
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	require.NoError(t, d.apply(ctx, nil, p))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsReexecutedDDLError(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, isReexecutedDDLError(errors.New("connection refused")))
+	require.False(t, isReexecutedDDLError(&gmysql.MySQLError{Number: mysql.ErrAccessDenied}))
+	require.True(t, isReexecutedDDLError(&gmysql.MySQLError{Number: mysql.ErrTableExists}))
+	// the error may be wrapped by the DDL sink.
+	require.True(t, isReexecutedDDLError(cerror.WrapChangefeedUnretryableErr(
+		cerror.WrapError(cerror.ErrMySQLTxnError,
+			&gmysql.MySQLError{Number: mysql.ErrNoSuchTable}))))
+}
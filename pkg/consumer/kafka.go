@@ -0,0 +1,423 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/avro"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/craft"
+	"github.com/pingcap/tiflow/pkg/sink/codec/debezium"
+	"github.com/pingcap/tiflow/pkg/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/sink/codec/protobuf"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultKafkaVersion = "2.4.0"
+
+// KafkaConfig is the config of the Kafka consumer.
+type KafkaConfig struct {
+	// ConsumerID identifies the progress of the consumer saved in the downstream.
+	ConsumerID string
+	// UpstreamURI is the Kafka uri, such as
+	// "kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true".
+	UpstreamURI string
+	// DownstreamURI is the uri of the MySQL compatible downstream.
+	DownstreamURI string
+
+	// the schema registry is required by the avro protocol.
+	SchemaRegistryURI  string
+	SchemaRegistryType string
+	// the AWS Glue schema registry is located by the region and the registry name.
+	GlueRegion       string
+	GlueRegistryName string
+
+	// ClaimCheckStorageURI is the external storage of the large messages
+	// sent by the claim check.
+	ClaimCheckStorageURI string
+
+	// Credential is used to connect to Kafka with TLS.
+	Credential *security.Credential
+}
+
+// kafkaURI is the parsed upstream uri of the Kafka consumer.
+type kafkaURI struct {
+	addrs       []string
+	topic       string
+	version     string
+	enableTLS   bool
+	codecConfig *common.Config
+}
+
+func parseKafkaURI(uriStr string) (*kafkaURI, error) {
+	uri, err := url.Parse(uriStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	scheme := strings.ToLower(uri.Scheme)
+	if !sink.IsKafkaScheme(scheme) {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"the scheme of upstream uri must be kafka or kafka+ssl, but got %s", uri.Scheme)
+	}
+	topic := strings.Trim(uri.Path, "/")
+	if topic == "" || strings.Contains(topic, ",") {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"exactly one topic must be specified in the upstream uri")
+	}
+	ku := &kafkaURI{
+		addrs:     strings.Split(uri.Host, ","),
+		topic:     topic,
+		version:   defaultKafkaVersion,
+		enableTLS: scheme == sink.KafkaSSLScheme,
+	}
+
+	query := uri.Query()
+	if s := query.Get("version"); s != "" {
+		ku.version = s
+	}
+	protocol, err := config.ParseSinkProtocolFromString(query.Get("protocol"))
+	if err != nil {
+		return nil, err
+	}
+	switch protocol {
+	case config.ProtocolOpen, config.ProtocolDefault, config.ProtocolCanalJSON,
+		config.ProtocolCraft, config.ProtocolAvro, config.ProtocolDebezium,
+		config.ProtocolProtobuf:
+	default:
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"protocol %s is not supported by the consumer", protocol)
+	}
+	ku.codecConfig = common.NewConfig(protocol)
+	if s := query.Get("enable-tidb-extension"); s != "" {
+		enable, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+		}
+		if enable && protocol != config.ProtocolCanalJSON &&
+			protocol != config.ProtocolAvro && protocol != config.ProtocolDebezium {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"enable-tidb-extension only works with canal-json, avro and debezium")
+		}
+		ku.codecConfig.EnableTiDBExtension = enable
+	}
+	if s := query.Get("enable-row-checksum"); s != "" {
+		enable, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+		}
+		if enable && protocol != config.ProtocolAvro {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"enable-row-checksum only works with avro")
+		}
+		ku.codecConfig.EnableRowChecksum = enable
+	}
+	if protocol == config.ProtocolAvro {
+		// the resolved ts is required to apply the rows in order.
+		ku.codecConfig.AvroEnableWatermark = true
+	}
+	return ku, nil
+}
+
+// KafkaConsumer consumes the messages of a topic sent by the Kafka sink, and
+// applies the events to a MySQL compatible downstream. The offsets of the
+// partitions and the resolved ts of the tables are saved in the downstream
+// in the same transaction as the rows, so the consumer resumes after
+// restarting without losing or reapplying the rows it has saved. The rows
+// that Kafka delivers more than once are written by REPLACE statements, so
+// applying them again is idempotent. DDLs can't be executed in a
+// transaction, so they are applied at least once, a DDL is executed again
+// if the consumer exits before its progress is saved.
+type KafkaConsumer struct {
+	cfg *KafkaConfig
+	uri *kafkaURI
+	tz  *time.Location
+
+	client     sarama.Client
+	consumer   sarama.Consumer
+	downstream downstream
+
+	// key and value schema managers, only used by the avro protocol.
+	keySchemaM   *avro.SchemaManager
+	valueSchemaM *avro.SchemaManager
+}
+
+// NewKafkaConsumer creates a KafkaConsumer.
+func NewKafkaConsumer(ctx context.Context, cfg *KafkaConfig) (*KafkaConsumer, error) {
+	uri, err := parseKafkaURI(cfg.UpstreamURI)
+	if err != nil {
+		return nil, err
+	}
+	uri.codecConfig.LargeMessageClaimCheckStorageURI = cfg.ClaimCheckStorageURI
+	tz, err := util.GetTimezone(config.GetGlobalServerConfig().TZ)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &KafkaConsumer{cfg: cfg, uri: uri, tz: tz}
+
+	if uri.codecConfig.Protocol == config.ProtocolAvro {
+		registryConfig := common.NewConfig(config.ProtocolAvro)
+		registryConfig.AvroSchemaRegistry = cfg.SchemaRegistryURI
+		registryConfig.AvroSchemaRegistryType = cfg.SchemaRegistryType
+		registryConfig.AvroGlueRegion = cfg.GlueRegion
+		registryConfig.AvroGlueRegistryName = cfg.GlueRegistryName
+		c.keySchemaM, c.valueSchemaM, err = avro.NewKeyAndValueSchemaManagers(
+			ctx, registryConfig, cfg.Credential)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	saramaConfig, err := c.newSaramaConfig()
+	if err != nil {
+		return nil, err
+	}
+	c.client, err = sarama.NewClient(uri.addrs, saramaConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewConsumer, err)
+	}
+	c.consumer, err = sarama.NewConsumerFromClient(c.client)
+	if err != nil {
+		_ = c.client.Close()
+		return nil, cerror.WrapError(cerror.ErrKafkaNewConsumer, err)
+	}
+	ds, err := newMySQLDownstream(ctx, cfg.ConsumerID, uri.topic, cfg.DownstreamURI)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	c.downstream = ds
+	return c, nil
+}
+
+func (c *KafkaConsumer) newSaramaConfig() (*sarama.Config, error) {
+	version, err := sarama.ParseKafkaVersion(c.uri.version)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidVersion, err)
+	}
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = "ticdc_kafka_consumer"
+	saramaConfig.Version = version
+	saramaConfig.Metadata.Retry.Max = 10000
+	saramaConfig.Metadata.Retry.Backoff = 500 * time.Millisecond
+	saramaConfig.Consumer.Retry.Backoff = 500 * time.Millisecond
+	saramaConfig.Consumer.Return.Errors = true
+
+	credential := c.cfg.Credential
+	if c.uri.enableTLS || (credential != nil && len(credential.CAPath) != 0) {
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			NextProtos: []string{"h2", "http/1.1"},
+		}
+		if credential != nil && len(credential.CAPath) != 0 {
+			saramaConfig.Net.TLS.Config, err = credential.ToTLSConfig()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	return saramaConfig, nil
+}
+
+// Run consumes all partitions of the topic until an error occurs or
+// the context is canceled.
+func (c *KafkaConsumer) Run(ctx context.Context) error {
+	p, err := c.downstream.loadProgress(ctx)
+	if err != nil {
+		return err
+	}
+	partitions, err := c.client.Partitions(c.uri.topic)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaConsumeMessage, err)
+	}
+	m := newMerger(c.downstream, partitions, p)
+	log.Info("kafka consumer started",
+		zap.String("consumerID", c.cfg.ConsumerID),
+		zap.String("topic", c.uri.topic),
+		zap.Int("partitions", len(partitions)),
+		zap.Uint64("checkpointTs", m.checkpointTs))
+
+	pcs := make(map[int32]sarama.PartitionConsumer, len(partitions))
+	defer func() {
+		for _, pc := range pcs {
+			pc.AsyncClose()
+		}
+	}()
+	for _, partition := range partitions {
+		pc, err := c.consumer.ConsumePartition(c.uri.topic, partition, m.offset(partition))
+		if err != nil {
+			return cerror.WrapError(cerror.ErrKafkaConsumeMessage, err)
+		}
+		pcs[partition] = pc
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	msgCh := make(chan *partitionMessage, 1024)
+	for partition, pc := range pcs {
+		decoder, err := c.newDecoder(ctx)
+		if err != nil {
+			return err
+		}
+		partition, pc := partition, pc
+		eg.Go(func() error {
+			return c.consumePartition(ctx, partition, pc, decoder, msgCh)
+		})
+	}
+	eg.Go(func() error {
+		return m.run(ctx, msgCh)
+	})
+	return eg.Wait()
+}
+
+func (c *KafkaConsumer) consumePartition(
+	ctx context.Context, partition int32, pc sarama.PartitionConsumer,
+	decoder codec.RowEventDecoder, msgCh chan<- *partitionMessage,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-pc.Errors():
+			return cerror.WrapError(cerror.ErrKafkaConsumeMessage, err)
+		case message := <-pc.Messages():
+			events, err := decodeMessage(decoder, message.Key, message.Value)
+			if err != nil {
+				log.Error("decode the message failed",
+					zap.Int32("partition", partition),
+					zap.Int64("offset", message.Offset),
+					zap.Error(err))
+				return err
+			}
+			msg := &partitionMessage{
+				partition: partition,
+				offset:    message.Offset,
+				events:    events,
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case msgCh <- msg:
+			}
+		}
+	}
+}
+
+func (c *KafkaConsumer) newDecoder(ctx context.Context) (codec.RowEventDecoder, error) {
+	codecConfig := c.uri.codecConfig
+	switch codecConfig.Protocol {
+	case config.ProtocolOpen, config.ProtocolDefault:
+		return open.NewBatchDecoder(ctx, codecConfig)
+	case config.ProtocolCanalJSON:
+		return canal.NewBatchDecoder(ctx, codecConfig)
+	case config.ProtocolCraft:
+		return craft.NewBatchDecoderWithAllocator(craft.NewSliceAllocator(64)), nil
+	case config.ProtocolDebezium:
+		return debezium.NewBatchDecoder(""), nil
+	case config.ProtocolProtobuf:
+		return protobuf.NewBatchDecoder(), nil
+	case config.ProtocolAvro:
+		return avro.NewDecoder(codecConfig, c.keySchemaM, c.valueSchemaM, c.uri.topic, c.tz), nil
+	}
+	return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+		"protocol %s is not supported by the consumer", codecConfig.Protocol)
+}
+
+// decodeMessage decodes all the events in a message.
+func decodeMessage(decoder codec.RowEventDecoder, key, value []byte) ([]event, error) {
+	if err := decoder.AddKeyValue(key, value); err != nil {
+		return nil, err
+	}
+	var events []event
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return nil, err
+		}
+		if !hasNext {
+			return events, nil
+		}
+		switch tp {
+		case model.MessageTypeRow:
+			row, onlyHandleKey, err := decoder.NextRowChangedEvent()
+			if err != nil {
+				return nil, err
+			}
+			if onlyHandleKey {
+				return nil, cerror.ErrDecodeFailed.GenWithStackByArgs(
+					"the row only contains the handle key columns, which can not be applied")
+			}
+			events = append(events, event{row: row})
+		case model.MessageTypeDDL:
+			ddl, err := decoder.NextDDLEvent()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event{ddl: ddl})
+		case model.MessageTypeResolved:
+			ts, err := decoder.NextResolvedEvent()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event{resolvedTs: ts})
+		case model.MessageTypeSyncPoint:
+			syncPointDecoder, ok := decoder.(codec.SyncPointEventDecoder)
+			if !ok {
+				return nil, cerror.ErrDecodeFailed.GenWithStackByArgs(
+					"the syncpoint event is not supported by the protocol")
+			}
+			// the syncpoint event is not applied to the downstream.
+			if _, err := syncPointDecoder.NextSyncPointEvent(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, cerror.ErrDecodeFailed.GenWithStackByArgs(
+				"unknown message type " + strconv.Itoa(int(tp)))
+		}
+	}
+}
+
+// Close closes the consumer.
+func (c *KafkaConsumer) Close() {
+	if c.downstream != nil {
+		c.downstream.close()
+	}
+	if c.consumer != nil {
+		if err := c.consumer.Close(); err != nil {
+			log.Warn("close the kafka consumer failed", zap.Error(err))
+		}
+	}
+	if c.client != nil {
+		if err := c.client.Close(); err != nil {
+			log.Warn("close the kafka client failed", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKafkaURI(t *testing.T) {
+	t.Parallel()
+
+	uri, err := parseKafkaURI("kafka://127.0.0.1:9092,127.0.0.2:9092/topic?" +
+		"protocol=canal-json&enable-tidb-extension=true&version=2.6.0")
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1:9092", "127.0.0.2:9092"}, uri.addrs)
+	require.Equal(t, "topic", uri.topic)
+	require.Equal(t, "2.6.0", uri.version)
+	require.False(t, uri.enableTLS)
+	require.Equal(t, config.ProtocolCanalJSON, uri.codecConfig.Protocol)
+	require.True(t, uri.codecConfig.EnableTiDBExtension)
+
+	uri, err = parseKafkaURI("kafka+ssl://127.0.0.1:9092/topic?protocol=avro&enable-row-checksum=true")
+	require.NoError(t, err)
+	require.Equal(t, defaultKafkaVersion, uri.version)
+	require.True(t, uri.enableTLS)
+	require.True(t, uri.codecConfig.EnableRowChecksum)
+	require.True(t, uri.codecConfig.AvroEnableWatermark)
+
+	for _, tc := range []struct {
+		uri string
+		err string
+	}{
+		{uri: "mysql://127.0.0.1:3306/topic?protocol=open-protocol", err: "must be kafka"},
+		{uri: "kafka://127.0.0.1:9092/?protocol=open-protocol", err: "exactly one topic"},
+		{uri: "kafka://127.0.0.1:9092/a,b?protocol=open-protocol", err: "exactly one topic"},
+		{uri: "kafka://127.0.0.1:9092/topic?protocol=maxwell", err: "not supported"},
+		{uri: "kafka://127.0.0.1:9092/topic?protocol=csv", err: "not supported"},
+		{
+			uri: "kafka://127.0.0.1:9092/topic?protocol=open-protocol&enable-tidb-extension=true",
+			err: "enable-tidb-extension only works",
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-row-checksum=true",
+			err: "enable-row-checksum only works",
+		},
+	} {
+		_, err := parseKafkaURI(tc.uri)
+		require.ErrorContains(t, err, tc.err, tc.uri)
+	}
+}
+
+type mockDecoder struct {
+	types  []model.MessageType
+	row    *model.RowChangedEvent
+	handle bool
+	ddl    *model.DDLEvent
+	ts     uint64
+}
+
+func (d *mockDecoder) AddKeyValue(_, _ []byte) error { return nil }
+
+func (d *mockDecoder) HasNext() (model.MessageType, bool, error) {
+	if len(d.types) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+	tp := d.types[0]
+	d.types = d.types[1:]
+	return tp, true, nil
+}
+
+func (d *mockDecoder) NextResolvedEvent() (uint64, error) { return d.ts, nil }
+
+func (d *mockDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, error) {
+	return d.row, d.handle, nil
+}
+
+func (d *mockDecoder) NextDDLEvent() (*model.DDLEvent, error) { return d.ddl, nil }
+
+func TestDecodeMessage(t *testing.T) {
+	t.Parallel()
+
+	row := &model.RowChangedEvent{CommitTs: 10}
+	ddl := &model.DDLEvent{CommitTs: 11}
+	events, err := decodeMessage(&mockDecoder{
+		types: []model.MessageType{
+			model.MessageTypeRow, model.MessageTypeDDL, model.MessageTypeResolved,
+		},
+		row: row, ddl: ddl, ts: 12,
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []event{{row: row}, {ddl: ddl}, {resolvedTs: 12}}, events)
+
+	_, err = decodeMessage(&mockDecoder{
+		types: []model.MessageType{model.MessageTypeRow}, row: row, handle: true,
+	}, nil, nil)
+	require.ErrorContains(t, err, "only contains the handle key columns")
+
+	_, err = decodeMessage(&mockDecoder{
+		types: []model.MessageType{model.MessageTypeSyncPoint},
+	}, nil, nil)
+	require.ErrorContains(t, err, "syncpoint event is not supported")
+}
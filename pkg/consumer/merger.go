@@ -0,0 +1,343 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+const defaultFlushInterval = 100 * time.Millisecond
+
+// event is a row, a DDL or a resolved ts decoded from a message.
+type event struct {
+	row        *model.RowChangedEvent
+	ddl        *model.DDLEvent
+	resolvedTs uint64
+}
+
+// partitionMessage is the events decoded from a message of a partition.
+type partitionMessage struct {
+	partition int32
+	offset    int64
+	events    []event
+}
+
+type bufferedRow struct {
+	row *model.RowChangedEvent
+	// offset is the offset of the message containing the row.
+	offset int64
+}
+
+type bufferedDDL struct {
+	ddl *model.DDLEvent
+	// offset is the offset of the message containing the DDL.
+	offset int64
+}
+
+type partitionState struct {
+	resolvedTs uint64
+	// nextOffset is the offset of the next message to receive, it's
+	// sarama.OffsetOldest if no message is received or persisted.
+	nextOffset int64
+	// rows are the rows whose commit ts are greater than the checkpoint ts.
+	rows []bufferedRow
+}
+
+// merger merges the events of all partitions, and applies them to the
+// downstream in the order of commit ts.
+//
+// The rows committed before the min resolved ts of all partitions are applied
+// in one transaction together with the progress, and a DDL is executed after
+// all the rows committed before it are applied. The persisted offset of a
+// partition is the offset of the first message containing any event not yet
+// applied, so the consumer resumes from it after restarting, the events
+// already applied are skipped by the checkpoint ts and the table progress.
+//
+// The commit ts of the DDLs is saved before executing them, and cleared
+// together with the table progress after they are executed. If the consumer
+// exits in between, the DDLs are executed again after restarting and the
+// errors caused by executing them twice are ignored, so the DDLs are applied
+// at least once.
+type merger struct {
+	downstream downstream
+	partitions map[int32]*partitionState
+	// ddls are received from partition 0 only, since the DDLs are
+	// dispatched to all partitions.
+	ddls []bufferedDDL
+	// checkpointTs is the ts before which all rows are applied.
+	checkpointTs uint64
+	// tableResolvedTs is the commit ts of the last row or DDL applied to
+	// each table.
+	tableResolvedTs map[tableKey]uint64
+	// ddlTs is the commit ts of the DDLs being executed, it's 0 if no DDL is
+	// being executed.
+	ddlTs uint64
+}
+
+func newMerger(ds downstream, partitions []int32, p *progress) *merger {
+	m := &merger{
+		downstream:      ds,
+		partitions:      make(map[int32]*partitionState, len(partitions)),
+		tableResolvedTs: make(map[tableKey]uint64, len(p.tables)),
+		ddlTs:           p.ddlTs,
+	}
+	m.checkpointTs = math.MaxUint64
+	for _, partition := range partitions {
+		state := &partitionState{nextOffset: sarama.OffsetOldest}
+		if pp, ok := p.partitions[partition]; ok {
+			state.nextOffset = pp.offset
+			state.resolvedTs = pp.resolvedTs
+		}
+		m.partitions[partition] = state
+		if state.resolvedTs < m.checkpointTs {
+			m.checkpointTs = state.resolvedTs
+		}
+	}
+	if len(partitions) == 0 {
+		m.checkpointTs = 0
+	}
+	for key, ts := range p.tables {
+		m.tableResolvedTs[key] = ts
+	}
+	return m
+}
+
+// offset returns the offset to consume from for the partition.
+func (m *merger) offset(partition int32) int64 {
+	return m.partitions[partition].nextOffset
+}
+
+func (m *merger) run(ctx context.Context, msgCh <-chan *partitionMessage) error {
+	ticker := time.NewTicker(defaultFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-msgCh:
+			m.handleMessage(msg)
+		case <-ticker.C:
+			if err := m.tryFlush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *merger) handleMessage(msg *partitionMessage) {
+	state := m.partitions[msg.partition]
+	for _, e := range msg.events {
+		switch {
+		case e.row != nil:
+			m.appendRow(state, e.row, msg.offset)
+		case e.ddl != nil:
+			if msg.partition == 0 {
+				m.appendDDL(e.ddl, msg.offset)
+			}
+		default:
+			// the resolved ts may be received again after restarting.
+			if e.resolvedTs > state.resolvedTs {
+				state.resolvedTs = e.resolvedTs
+			}
+		}
+	}
+	state.nextOffset = msg.offset + 1
+}
+
+func (m *merger) appendRow(state *partitionState, row *model.RowChangedEvent, offset int64) {
+	key := tableKey{schema: row.Table.Schema, table: row.Table.Table}
+	if row.CommitTs <= m.checkpointTs || row.CommitTs <= m.tableResolvedTs[key] {
+		log.Debug("skip the row already applied",
+			zap.Uint64("commitTs", row.CommitTs),
+			zap.Uint64("checkpointTs", m.checkpointTs),
+			zap.Uint64("tableResolvedTs", m.tableResolvedTs[key]),
+			zap.String("schema", key.schema), zap.String("table", key.table))
+		return
+	}
+	state.rows = append(state.rows, bufferedRow{row: row, offset: offset})
+}
+
+func (m *merger) appendDDL(ddl *model.DDLEvent, offset int64) {
+	key := ddlTableKey(ddl)
+	if ddl.CommitTs < m.checkpointTs || ddl.CommitTs <= m.tableResolvedTs[key] {
+		log.Info("skip the DDL already executed",
+			zap.Uint64("commitTs", ddl.CommitTs),
+			zap.Uint64("checkpointTs", m.checkpointTs),
+			zap.String("query", ddl.Query))
+		return
+	}
+	if len(m.ddls) > 0 {
+		last := m.ddls[len(m.ddls)-1].ddl
+		// A rename tables DDL job contains multiple DDL events with the same
+		// commit ts, so the DDL is redundant only if the query is the same.
+		if ddl.CommitTs < last.CommitTs ||
+			(ddl.CommitTs == last.CommitTs && ddl.Query == last.Query) {
+			log.Info("skip the redundant DDL",
+				zap.Uint64("commitTs", ddl.CommitTs),
+				zap.Uint64("lastCommitTs", last.CommitTs),
+				zap.String("query", ddl.Query))
+			return
+		}
+	}
+	m.ddls = append(m.ddls, bufferedDDL{ddl: ddl, offset: offset})
+	log.Info("DDL event received",
+		zap.Uint64("commitTs", ddl.CommitTs), zap.String("query", ddl.Query))
+}
+
+func (m *merger) minResolvedTs() uint64 {
+	resolvedTs := uint64(math.MaxUint64)
+	for _, state := range m.partitions {
+		if state.resolvedTs < resolvedTs {
+			resolvedTs = state.resolvedTs
+		}
+	}
+	return resolvedTs
+}
+
+// tryFlush applies the rows and the DDLs committed before the min resolved
+// ts of all partitions.
+func (m *merger) tryFlush(ctx context.Context) error {
+	if len(m.partitions) == 0 {
+		return nil
+	}
+	resolvedTs := m.minResolvedTs()
+	for len(m.ddls) > 0 && m.ddls[0].ddl.CommitTs <= resolvedTs {
+		commitTs := m.ddls[0].ddl.CommitTs
+		// the DDLs may have been executed if the consumer exited while
+		// executing them last time.
+		mayBeExecuted := m.ddlTs == commitTs
+		m.ddlTs = commitTs
+		if err := m.flush(ctx, commitTs); err != nil {
+			return err
+		}
+		tables := make(map[tableKey]uint64)
+		for len(m.ddls) > 0 && m.ddls[0].ddl.CommitTs == commitTs {
+			ddl := m.ddls[0].ddl
+			if err := m.downstream.execDDL(ctx, ddl, mayBeExecuted); err != nil {
+				return err
+			}
+			m.ddls = m.ddls[1:]
+			tables[ddlTableKey(ddl)] = commitTs
+		}
+		m.ddlTs = 0
+		p := m.progress(commitTs, m.partitionRows())
+		p.tables = tables
+		if err := m.downstream.apply(ctx, nil, p); err != nil {
+			return err
+		}
+		for key, ts := range tables {
+			m.tableResolvedTs[key] = ts
+		}
+	}
+	if resolvedTs > m.checkpointTs {
+		return m.flush(ctx, resolvedTs)
+	}
+	return nil
+}
+
+// flush applies the rows committed before the ts and advances the
+// checkpoint ts to it.
+func (m *merger) flush(ctx context.Context, ts uint64) error {
+	var rows []*model.RowChangedEvent
+	remaining := make(map[int32][]bufferedRow, len(m.partitions))
+	tables := make(map[tableKey]uint64)
+	for partition, state := range m.partitions {
+		var rest []bufferedRow
+		for _, r := range state.rows {
+			if r.row.CommitTs > ts {
+				rest = append(rest, r)
+				continue
+			}
+			rows = append(rows, r.row)
+			key := tableKey{schema: r.row.Table.Schema, table: r.row.Table.Table}
+			// the table progress is not advanced to ts, otherwise the DDL
+			// committed at ts is skipped after restarting.
+			if r.row.CommitTs > tables[key] {
+				tables[key] = r.row.CommitTs
+			}
+		}
+		remaining[partition] = rest
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].CommitTs < rows[j].CommitTs
+	})
+
+	p := m.progress(ts, remaining)
+	p.tables = tables
+	if err := m.downstream.apply(ctx, rows, p); err != nil {
+		return err
+	}
+	for partition, rest := range remaining {
+		m.partitions[partition].rows = rest
+	}
+	for key, commitTs := range tables {
+		m.tableResolvedTs[key] = commitTs
+	}
+	if ts > m.checkpointTs {
+		m.checkpointTs = ts
+	}
+	log.Debug("rows are applied to the downstream",
+		zap.Uint64("checkpointTs", ts), zap.Int("rows", len(rows)))
+	return nil
+}
+
+func (m *merger) partitionRows() map[int32][]bufferedRow {
+	rows := make(map[int32][]bufferedRow, len(m.partitions))
+	for partition, state := range m.partitions {
+		rows[partition] = state.rows
+	}
+	return rows
+}
+
+// progress returns the progress of partitions after the rows committed before
+// the ts are applied, the rows are the ones not applied yet.
+func (m *merger) progress(ts uint64, rows map[int32][]bufferedRow) *progress {
+	p := newProgress()
+	p.ddlTs = m.ddlTs
+	for partition, state := range m.partitions {
+		offset := state.nextOffset
+		for _, r := range rows[partition] {
+			if r.offset < offset {
+				offset = r.offset
+			}
+		}
+		if partition == 0 {
+			for _, d := range m.ddls {
+				if d.offset < offset {
+					offset = d.offset
+				}
+			}
+		}
+		p.partitions[partition] = partitionProgress{offset: offset, resolvedTs: ts}
+	}
+	return p
+}
+
+func ddlTableKey(ddl *model.DDLEvent) tableKey {
+	if ddl.TableInfo == nil || ddl.TableInfo.TableName.Schema == "" {
+		return tableKey{}
+	}
+	return tableKey{
+		schema: ddl.TableInfo.TableName.Schema,
+		table:  ddl.TableInfo.TableName.Table,
+	}
+}
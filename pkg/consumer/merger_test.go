@@ -0,0 +1,251 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDownstream struct {
+	rows       [][]*model.RowChangedEvent
+	progresses []*progress
+	ddls       []*model.DDLEvent
+	// mayBeExecuted is whether each DDL may have been executed.
+	mayBeExecuted []bool
+}
+
+func (d *mockDownstream) loadProgress(context.Context) (*progress, error) {
+	return newProgress(), nil
+}
+
+func (d *mockDownstream) apply(
+	_ context.Context, rows []*model.RowChangedEvent, p *progress,
+) error {
+	d.rows = append(d.rows, rows)
+	d.progresses = append(d.progresses, p)
+	return nil
+}
+
+func (d *mockDownstream) execDDL(
+	_ context.Context, ddl *model.DDLEvent, mayBeExecuted bool,
+) error {
+	d.ddls = append(d.ddls, ddl)
+	d.mayBeExecuted = append(d.mayBeExecuted, mayBeExecuted)
+	return nil
+}
+
+func (d *mockDownstream) close() {}
+
+func newRow(table string, commitTs uint64) event {
+	return event{row: &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: "test", Table: table},
+	}}
+}
+
+func newDDL(table string, commitTs uint64, query string) event {
+	return event{ddl: &model.DDLEvent{
+		CommitTs: commitTs,
+		Query:    query,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: table},
+		},
+	}}
+}
+
+func commitTsOf(rows []*model.RowChangedEvent) []uint64 {
+	ts := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ts = append(ts, row.CommitTs)
+	}
+	return ts
+}
+
+func TestMergerFlushRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ds := &mockDownstream{}
+	m := newMerger(ds, []int32{0, 1}, newProgress())
+	require.Equal(t, uint64(0), m.checkpointTs)
+	require.Equal(t, sarama.OffsetOldest, m.offset(0))
+
+	m.handleMessage(&partitionMessage{partition: 0, offset: 0, events: []event{newRow("t1", 20)}})
+	m.handleMessage(&partitionMessage{partition: 0, offset: 1, events: []event{newRow("t1", 10)}})
+	m.handleMessage(&partitionMessage{partition: 0, offset: 2, events: []event{{resolvedTs: 15}}})
+	m.handleMessage(&partitionMessage{partition: 1, offset: 0, events: []event{newRow("t2", 12)}})
+	// not all partitions are resolved.
+	require.NoError(t, m.tryFlush(ctx))
+	require.Empty(t, ds.rows)
+
+	m.handleMessage(&partitionMessage{partition: 1, offset: 1, events: []event{
+		newRow("t2", 22), {resolvedTs: 25},
+	}})
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.rows, 1)
+	require.Equal(t, []uint64{10, 12}, commitTsOf(ds.rows[0]))
+	p := ds.progresses[0]
+	// the row committed at 20 of partition 0 is not applied, and the row
+	// committed at 22 of partition 1 is in the last message.
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 0, resolvedTs: 15},
+		1: {offset: 1, resolvedTs: 15},
+	}, p.partitions)
+	require.Equal(t, map[tableKey]uint64{
+		{schema: "test", table: "t1"}: 10,
+		{schema: "test", table: "t2"}: 12,
+	}, p.tables)
+	require.Equal(t, uint64(15), m.checkpointTs)
+
+	// the resolved ts fallback is ignored.
+	m.handleMessage(&partitionMessage{partition: 0, offset: 3, events: []event{{resolvedTs: 14}}})
+	require.Equal(t, uint64(15), m.partitions[0].resolvedTs)
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.rows, 1)
+
+	m.handleMessage(&partitionMessage{partition: 0, offset: 4, events: []event{{resolvedTs: 30}}})
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.rows, 2)
+	require.Equal(t, []uint64{20, 22}, commitTsOf(ds.rows[1]))
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 5, resolvedTs: 25},
+		1: {offset: 2, resolvedTs: 25},
+	}, ds.progresses[1].partitions)
+	require.Equal(t, uint64(25), m.checkpointTs)
+}
+
+func TestMergerExecuteDDL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ds := &mockDownstream{}
+	m := newMerger(ds, []int32{0, 1}, newProgress())
+
+	m.handleMessage(&partitionMessage{partition: 0, offset: 0, events: []event{newRow("t1", 5)}})
+	m.handleMessage(&partitionMessage{partition: 0, offset: 1, events: []event{
+		newDDL("t1", 10, "ALTER TABLE t1 ADD COLUMN a INT"),
+	}})
+	// the DDLs of other partitions are ignored.
+	m.handleMessage(&partitionMessage{partition: 1, offset: 0, events: []event{
+		newDDL("t1", 10, "ALTER TABLE t1 ADD COLUMN a INT"),
+	}})
+	// the redundant DDL is ignored.
+	m.handleMessage(&partitionMessage{partition: 0, offset: 2, events: []event{
+		newDDL("t1", 10, "ALTER TABLE t1 ADD COLUMN a INT"),
+	}})
+	m.handleMessage(&partitionMessage{partition: 0, offset: 3, events: []event{
+		newRow("t1", 12), {resolvedTs: 20},
+	}})
+	m.handleMessage(&partitionMessage{partition: 1, offset: 1, events: []event{{resolvedTs: 20}}})
+	require.Len(t, m.ddls, 1)
+
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.ddls, 1)
+	require.Equal(t, []bool{false}, ds.mayBeExecuted)
+	require.Len(t, ds.progresses, 3)
+	// the rows before the DDL are applied first, and the DDL is not applied.
+	require.Equal(t, []uint64{5}, commitTsOf(ds.rows[0]))
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 1, resolvedTs: 10},
+		1: {offset: 2, resolvedTs: 10},
+	}, ds.progresses[0].partitions)
+	// the commit ts of the DDL is saved before executing it.
+	require.Equal(t, uint64(10), ds.progresses[0].ddlTs)
+	// the progress is saved after the DDL is executed.
+	require.Empty(t, ds.rows[1])
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 3, resolvedTs: 10},
+		1: {offset: 2, resolvedTs: 10},
+	}, ds.progresses[1].partitions)
+	require.Equal(t, map[tableKey]uint64{
+		{schema: "test", table: "t1"}: 10,
+	}, ds.progresses[1].tables)
+	require.Zero(t, ds.progresses[1].ddlTs)
+	// then the rows after the DDL.
+	require.Equal(t, []uint64{12}, commitTsOf(ds.rows[2]))
+	require.Equal(t, map[int32]partitionProgress{
+		0: {offset: 4, resolvedTs: 20},
+		1: {offset: 2, resolvedTs: 20},
+	}, ds.progresses[2].partitions)
+}
+
+func TestMergerRestart(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ds := &mockDownstream{}
+	p := newProgress()
+	p.partitions[0] = partitionProgress{offset: 3, resolvedTs: 20}
+	p.partitions[1] = partitionProgress{offset: 7, resolvedTs: 20}
+	p.tables[tableKey{schema: "test", table: "t1"}] = 18
+	p.tables[tableKey{schema: "test", table: "t2"}] = 10
+	m := newMerger(ds, []int32{0, 1, 2}, p)
+	// the new partition has no progress.
+	require.Equal(t, uint64(0), m.checkpointTs)
+	require.Equal(t, int64(3), m.offset(0))
+	require.Equal(t, int64(7), m.offset(1))
+	require.Equal(t, sarama.OffsetOldest, m.offset(2))
+
+	m = newMerger(ds, []int32{0, 1}, p)
+	require.Equal(t, uint64(20), m.checkpointTs)
+
+	m.handleMessage(&partitionMessage{partition: 0, offset: 3, events: []event{
+		// the rows already applied are skipped.
+		newRow("t1", 18), newRow("t2", 19),
+		// the DDL already executed is skipped.
+		newDDL("t1", 18, "ALTER TABLE t1 ADD COLUMN a INT"),
+		// the DDL at the checkpoint ts is not executed yet.
+		newDDL("t2", 20, "ALTER TABLE t2 ADD COLUMN a INT"),
+		newRow("t1", 21), {resolvedTs: 25},
+	}})
+	m.handleMessage(&partitionMessage{partition: 1, offset: 7, events: []event{{resolvedTs: 25}}})
+	require.Len(t, m.partitions[0].rows, 1)
+	require.Len(t, m.ddls, 1)
+
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.ddls, 1)
+	require.Equal(t, "ALTER TABLE t2 ADD COLUMN a INT", ds.ddls[0].Query)
+	require.Equal(t, []uint64{21}, commitTsOf(ds.rows[len(ds.rows)-1]))
+	require.Equal(t, uint64(25), m.checkpointTs)
+}
+
+func TestMergerRestartWhileExecutingDDL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ds := &mockDownstream{}
+	// the consumer exited after saving the ddl ts, the DDL may have been
+	// executed.
+	p := newProgress()
+	p.partitions[0] = partitionProgress{offset: 3, resolvedTs: 20}
+	p.partitions[1] = partitionProgress{offset: 7, resolvedTs: 20}
+	p.ddlTs = 20
+	m := newMerger(ds, []int32{0, 1}, p)
+
+	m.handleMessage(&partitionMessage{partition: 0, offset: 3, events: []event{
+		newDDL("t1", 20, "RENAME TABLE t1 TO t2"),
+		newDDL("t2", 30, "ALTER TABLE t2 ADD COLUMN a INT"), {resolvedTs: 30},
+	}})
+	m.handleMessage(&partitionMessage{partition: 1, offset: 7, events: []event{{resolvedTs: 30}}})
+	require.NoError(t, m.tryFlush(ctx))
+	require.Len(t, ds.ddls, 2)
+	// only the DDL being executed before restarting may have been executed.
+	require.Equal(t, []bool{true, false}, ds.mayBeExecuted)
+	require.Zero(t, ds.progresses[len(ds.progresses)-1].ddlTs)
+}
@@ -0,0 +1,664 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	sinkutil "github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/csv"
+	"github.com/pingcap/tiflow/pkg/sink/codec/debezium"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// fakePartitionNumForSchemaFile is used to sort a schema file before the
+	// data files of the same table version.
+	fakePartitionNumForSchemaFile = -1
+
+	defaultStorageInterval       = 10 * time.Second
+	defaultStorageRescanInterval = 5 * time.Minute
+)
+
+// StorageConfig is the config of the storage consumer.
+type StorageConfig struct {
+	// ConsumerID identifies the checkpoints of the consumer.
+	ConsumerID string
+	// UpstreamURIs are the storage uris written by the storage sinks of
+	// changefeeds, such as "s3://bucket/prefix?protocol=csv". Each of them is
+	// followed independently with its own checkpoint.
+	UpstreamURIs []string
+	// DownstreamURI is the uri of the sink to replay the events to.
+	DownstreamURI string
+	// CheckpointURI is the storage uri where the checkpoints are saved.
+	CheckpointURI string
+	// ReplicaConfig is the config of the changefeeds writing the files,
+	// the default one is used if it's nil.
+	ReplicaConfig *config.ReplicaConfig
+	// Interval is the interval to check the new files.
+	Interval time.Duration
+	// RescanInterval is the interval to walk all the files of the upstream,
+	// the new files are found by the index files and the schema directories
+	// of the known tables between two walks.
+	RescanInterval time.Duration
+}
+
+// StorageConsumer replays the files written by the storage sinks of one or
+// more changefeeds to a downstream. The progress of each changefeed is saved
+// in a checkpoint file after every schema file and data file is handled, so
+// the consumer resumes from it after restarting.
+type StorageConsumer struct {
+	followers []*storageFollower
+}
+
+// NewStorageConsumer creates a StorageConsumer.
+func NewStorageConsumer(ctx context.Context, cfg *StorageConfig) (*StorageConsumer, error) {
+	if len(cfg.UpstreamURIs) == 0 {
+		return nil, cerror.ErrStorageConsumerInvalidConfig.GenWithStack(
+			"at least one upstream uri is required")
+	}
+	if cfg.CheckpointURI == "" {
+		return nil, cerror.ErrStorageConsumerInvalidConfig.GenWithStack(
+			"the checkpoint uri is required")
+	}
+	checkpointStorage, err := util.GetExternalStorageFromURI(ctx, cfg.CheckpointURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	c := &StorageConsumer{}
+	checkpointFiles := make(map[string]string, len(cfg.UpstreamURIs))
+	for i, uri := range cfg.UpstreamURIs {
+		f, err := newStorageFollower(ctx, cfg, i, uri, checkpointStorage)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.followers = append(c.followers, f)
+		if prev, ok := checkpointFiles[f.checkpointFile]; ok {
+			c.Close()
+			return nil, cerror.ErrStorageConsumerInvalidConfig.GenWithStack(
+				"the upstream uris %s and %s are the same location",
+				maskURI(prev), maskURI(uri))
+		}
+		checkpointFiles[f.checkpointFile] = uri
+	}
+	return c, nil
+}
+
+// Run follows all the upstreams until an error occurs or the context is canceled.
+func (c *StorageConsumer) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, f := range c.followers {
+		f := f
+		eg.Go(func() error {
+			return f.run(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// Close closes the consumer.
+func (c *StorageConsumer) Close() {
+	for _, f := range c.followers {
+		f.sink.close()
+	}
+}
+
+// storageFollower follows the files written by the storage sink of a changefeed.
+type storageFollower struct {
+	upstreamURI       string
+	storage           storage.ExternalStorage
+	checkpointStorage storage.ExternalStorage
+	checkpointFile    string
+	sink              eventSink
+
+	codecConfig    *common.Config
+	extension      string
+	dateSeparator  string
+	fileIndexWidth int
+	interval       time.Duration
+	rescanInterval time.Duration
+	lastRescan     time.Time
+
+	checkpoint *storageCheckpoint
+	// tableDefs are the schema files found and not superseded by a newer
+	// schema file handled.
+	tableDefs map[cloudstorage.SchemaPathKey]*cloudstorage.TableDefinition
+	// dataDirs are the data directories found and the max file index in them.
+	dataDirs map[cloudstorage.DmlPathKey]uint64
+}
+
+func newStorageFollower(
+	ctx context.Context, cfg *StorageConfig, id int, uri string,
+	checkpointStorage storage.ExternalStorage,
+) (*storageFollower, error) {
+	upstreamURI, err := url.Parse(uri)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageConsumerInvalidConfig, err)
+	}
+	replicaConfig := config.GetDefaultReplicaConfig()
+	if cfg.ReplicaConfig != nil {
+		replicaConfig = cfg.ReplicaConfig.Clone()
+	}
+	if err := replicaConfig.ValidateAndAdjust(upstreamURI); err != nil {
+		return nil, err
+	}
+	protocol, err := config.ParseSinkProtocolFromString(util.GetOrZero(replicaConfig.Sink.Protocol))
+	if err != nil {
+		return nil, err
+	}
+	switch protocol {
+	case config.ProtocolCsv, config.ProtocolCanalJSON, config.ProtocolDebezium:
+	default:
+		return nil, cerror.ErrStorageConsumerInvalidConfig.GenWithStack(
+			"protocol %s is not supported by the consumer", protocol)
+	}
+	codecConfig := common.NewConfig(protocol)
+	if err := codecConfig.Apply(upstreamURI, replicaConfig); err != nil {
+		return nil, err
+	}
+	// the commit ts is only in the extension field of the canal-json protocol.
+	codecConfig.EnableTiDBExtension = true
+	fileIndexWidth := util.GetOrZero(replicaConfig.Sink.FileIndexWidth)
+	if fileIndexWidth < config.MinFileIndexWidth || fileIndexWidth > config.MaxFileIndexWidth {
+		fileIndexWidth = config.DefaultFileIndexWidth
+	}
+
+	checkpointFile := checkpointFileName(cfg.ConsumerID, upstreamURI)
+	cp, err := loadStorageCheckpoint(ctx, checkpointStorage, checkpointFile)
+	if err != nil {
+		return nil, err
+	}
+	s, err := util.GetExternalStorageFromURI(ctx, uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	changefeedID := model.DefaultChangeFeedID(fmt.Sprintf("%s-%d", cfg.ConsumerID, id))
+	sink, err := newFactoryEventSink(ctx, changefeedID, cfg.DownstreamURI)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &storageFollower{
+		upstreamURI:       maskURI(uri),
+		storage:           s,
+		checkpointStorage: checkpointStorage,
+		checkpointFile:    checkpointFile,
+		sink:              sink,
+		codecConfig:       codecConfig,
+		extension:         sinkutil.GetFileExtension(protocol),
+		dateSeparator:     util.GetOrZero(replicaConfig.Sink.DateSeparator),
+		fileIndexWidth:    fileIndexWidth,
+		interval:          cfg.Interval,
+		rescanInterval:    cfg.RescanInterval,
+		checkpoint:        cp,
+		tableDefs:         make(map[cloudstorage.SchemaPathKey]*cloudstorage.TableDefinition),
+		dataDirs:          make(map[cloudstorage.DmlPathKey]uint64),
+	}
+	if f.interval <= 0 {
+		f.interval = defaultStorageInterval
+	}
+	if f.rescanInterval <= 0 {
+		f.rescanInterval = defaultStorageRescanInterval
+	}
+	log.Info("storage follower created",
+		zap.String("upstreamURI", f.upstreamURI),
+		zap.String("checkpointFile", checkpointFile),
+		zap.Int("tables", len(cp.tableVersions)),
+		zap.Int("dataDirs", len(cp.fileIndexes)))
+	return f, nil
+}
+
+func (f *storageFollower) run(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		if err := f.discover(ctx); err != nil {
+			return err
+		}
+		if err := f.handle(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// discover finds the new schema files and data files. All the files are
+// walked every rescan interval, otherwise only the schema directories of the
+// known tables and the index files of the known data directories are read.
+func (f *storageFollower) discover(ctx context.Context) error {
+	now := time.Now()
+	if f.lastRescan.IsZero() || now.Sub(f.lastRescan) >= f.rescanInterval {
+		if err := f.rescan(ctx); err != nil {
+			return err
+		}
+		f.lastRescan = now
+	} else if err := f.scanSchemaDirs(ctx); err != nil {
+		return err
+	}
+	// The index files are read after the schema files are found, so all the
+	// data files of the old table versions are known when a newer schema file
+	// is handled, since a schema file is written after the data files before it.
+	return f.readIndexFiles(ctx, now)
+}
+
+func (f *storageFollower) rescan(ctx context.Context) error {
+	return f.walk(ctx, "", true)
+}
+
+// walk walks the files in the sub directory, the data files are ignored
+// if withData is false.
+func (f *storageFollower) walk(ctx context.Context, subDir string, withData bool) error {
+	opt := &storage.WalkOption{SubDir: subDir}
+	err := f.storage.WalkDir(ctx, opt, func(filePath string, _ int64) error {
+		switch {
+		case cloudstorage.IsSchemaFile(filePath):
+			return f.loadSchemaFile(ctx, filePath)
+		case cloudstorage.IsSyncPointFile(filePath):
+		case withData && strings.HasSuffix(filePath, f.extension):
+			f.addDataFile(filePath)
+		}
+		return nil
+	})
+	return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+}
+
+// scanSchemaDirs walks the schema directories of the known tables and
+// databases, and the data directories of the new table versions found.
+func (f *storageFollower) scanSchemaDirs(ctx context.Context) error {
+	dirs := make(map[string]struct{})
+	for key := range f.tableDefs {
+		dirs[schemaDir(key)] = struct{}{}
+	}
+	known := make(map[cloudstorage.SchemaPathKey]struct{}, len(f.tableDefs))
+	for key := range f.tableDefs {
+		known[key] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := f.walk(ctx, dir, false); err != nil {
+			return err
+		}
+	}
+	for key := range f.tableDefs {
+		if _, ok := known[key]; ok || key.Table == "" {
+			continue
+		}
+		dir := path.Join(key.Schema, key.Table, strconv.FormatUint(key.TableVersion, 10))
+		if err := f.walk(ctx, dir, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maskURI masks the credentials in the uri for logging.
+func maskURI(uri string) string {
+	masked, err := util.MaskSinkURI(uri)
+	if err != nil {
+		return ""
+	}
+	return masked
+}
+
+func schemaDir(key cloudstorage.SchemaPathKey) string {
+	if key.Table == "" {
+		return path.Join(key.Schema, "meta")
+	}
+	return path.Join(key.Schema, key.Table, "meta")
+}
+
+func (f *storageFollower) loadSchemaFile(ctx context.Context, filePath string) error {
+	var key cloudstorage.SchemaPathKey
+	checksumInFile, err := key.ParseSchemaFilePath(filePath)
+	if err != nil {
+		log.Warn("ignore the invalid schema file",
+			zap.String("path", filePath), zap.Error(err))
+		return nil
+	}
+	if _, ok := f.tableDefs[key]; ok ||
+		key.TableVersion < f.checkpoint.tableVersions[key.GetKey()] {
+		return nil
+	}
+	content, err := f.storage.ReadFile(ctx, filePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var def cloudstorage.TableDefinition
+	if err := json.Unmarshal(content, &def); err != nil {
+		return cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	checksum, err := def.Sum32(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if checksum != checksumInFile || def.TableVersion != key.TableVersion {
+		return cerror.ErrDecodeFailed.GenWithStackByArgs(fmt.Sprintf(
+			"the checksum %d or the table version %d of the schema file %s mismatch",
+			checksum, def.TableVersion, filePath))
+	}
+	f.tableDefs[key] = &def
+	log.Info("schema file found",
+		zap.String("upstreamURI", f.upstreamURI), zap.String("path", filePath))
+	return nil
+}
+
+func (f *storageFollower) addDataFile(filePath string) {
+	var key cloudstorage.DmlPathKey
+	idx, err := key.ParseDMLFilePath(f.dateSeparator, filePath)
+	if err != nil {
+		log.Debug("ignore the invalid data file",
+			zap.String("path", filePath), zap.Error(err))
+		return
+	}
+	if f.checkpoint.isHandled(key) {
+		return
+	}
+	if idx > f.dataDirs[key] {
+		f.dataDirs[key] = idx
+	}
+}
+
+// readIndexFiles reads the index files of the known data directories, and
+// the ones of the current date of the latest table versions, since the
+// storage sink moves to a new data directory when the date changes.
+func (f *storageFollower) readIndexFiles(ctx context.Context, now time.Time) error {
+	date := dateString(f.dateSeparator, now)
+	keys := make([]cloudstorage.DmlPathKey, 0, len(f.dataDirs))
+	probes := make(map[cloudstorage.DmlPathKey]struct{})
+	for key := range f.dataDirs {
+		keys = append(keys, key)
+		if date == "" || key.Date == date || !f.isLatestVersion(key.SchemaPathKey) {
+			continue
+		}
+		probe := key
+		probe.Date = date
+		if _, ok := f.dataDirs[probe]; !ok {
+			probes[probe] = struct{}{}
+		}
+	}
+	for key := range probes {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		indexFile := path.Join(path.Dir(key.GenerateDMLFilePath(1, f.extension, f.fileIndexWidth)),
+			"meta", "CDC.index")
+		exists, err := f.storage.FileExists(ctx, indexFile)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+		}
+		if !exists {
+			continue
+		}
+		content, err := f.storage.ReadFile(ctx, indexFile)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+		}
+		idx, err := parseIndexFile(string(content), f.extension)
+		if err != nil {
+			return err
+		}
+		if idx > f.dataDirs[key] {
+			f.dataDirs[key] = idx
+		}
+	}
+	return nil
+}
+
+// parseIndexFile parses the index from the content of an index file,
+// which is the name of the last data file, such as "CDC000005.csv\n".
+func parseIndexFile(content, extension string) (uint64, error) {
+	name := strings.TrimSuffix(content, "\n")
+	if !strings.HasPrefix(name, "CDC") || !strings.HasSuffix(name, extension) {
+		return 0, cerror.ErrStorageSinkInvalidFileName.GenWithStack(
+			"invalid data file name %s in the index file", name)
+	}
+	idx, err := strconv.ParseUint(strings.TrimSuffix(name[len("CDC"):], extension), 10, 64)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrStorageSinkInvalidFileName, err)
+	}
+	return idx, nil
+}
+
+// dateString returns the date directory of the time, which is the same as
+// the one generated by the storage sink.
+func dateString(dateSeparator string, t time.Time) string {
+	switch dateSeparator {
+	case config.DateSeparatorYear.String():
+		return t.Format("2006")
+	case config.DateSeparatorMonth.String():
+		return t.Format("2006-01")
+	case config.DateSeparatorDay.String():
+		return t.Format("2006-01-02")
+	}
+	return ""
+}
+
+func (f *storageFollower) isLatestVersion(key cloudstorage.SchemaPathKey) bool {
+	for k := range f.tableDefs {
+		if k.Schema == key.Schema && k.Table == key.Table && k.TableVersion > key.TableVersion {
+			return false
+		}
+	}
+	return true
+}
+
+// isSuperseded returns whether the storage sink has moved from the data
+// directory to a newer table version or a newer date.
+func (f *storageFollower) isSuperseded(key cloudstorage.DmlPathKey) bool {
+	if !f.isLatestVersion(key.SchemaPathKey) {
+		return true
+	}
+	for k := range f.dataDirs {
+		if k.SchemaPathKey == key.SchemaPathKey && k.PartitionNum == key.PartitionNum &&
+			k.Date > key.Date {
+			return true
+		}
+	}
+	return false
+}
+
+// handle handles the new schema files and data files in the order of table
+// version, so a DDL is executed after all the rows of the old table version.
+func (f *storageFollower) handle(ctx context.Context) error {
+	var keys []cloudstorage.DmlPathKey
+	for key := range f.tableDefs {
+		if key.TableVersion > f.checkpoint.tableVersions[key.GetKey()] {
+			keys = append(keys, cloudstorage.DmlPathKey{
+				SchemaPathKey: key,
+				PartitionNum:  fakePartitionNumForSchemaFile,
+			})
+		}
+	}
+	maxIndexes := make(map[cloudstorage.DmlPathKey]uint64, len(f.dataDirs))
+	for key, idx := range f.dataDirs {
+		if idx <= f.checkpoint.fileIndexes[key] {
+			continue
+		}
+		if _, ok := f.tableDefs[key.SchemaPathKey]; !ok {
+			log.Debug("the schema file of the data directory is not found yet",
+				zap.String("upstreamURI", f.upstreamURI), zap.Any("key", key))
+			continue
+		}
+		keys = append(keys, key)
+		maxIndexes[key] = idx
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessDmlPathKey(keys[i], keys[j])
+	})
+
+	for _, key := range keys {
+		if key.PartitionNum == fakePartitionNumForSchemaFile && key.Date == "" {
+			if err := f.handleSchemaFile(ctx, key.SchemaPathKey); err != nil {
+				return err
+			}
+			continue
+		}
+		for idx := f.checkpoint.fileIndexes[key] + 1; idx <= maxIndexes[key]; idx++ {
+			handled, err := f.handleDataFile(ctx, key, idx)
+			if err != nil {
+				return err
+			}
+			if !handled {
+				// the following files depend on this one, wait for the next round.
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (f *storageFollower) handleSchemaFile(ctx context.Context, key cloudstorage.SchemaPathKey) error {
+	def := f.tableDefs[key]
+	if len(def.Query) > 0 {
+		ddl, err := def.ToDDLEvent()
+		if err != nil {
+			return err
+		}
+		if err := f.sink.writeDDL(ctx, ddl); err != nil {
+			return errors.Trace(err)
+		}
+		log.Info("execute ddl event successfully",
+			zap.String("upstreamURI", f.upstreamURI), zap.String("query", def.Query))
+	}
+	f.checkpoint.tableVersions[key.GetKey()] = key.TableVersion
+	for k := range f.tableDefs {
+		if k.GetKey() == key.GetKey() && k.TableVersion < key.TableVersion {
+			delete(f.tableDefs, k)
+		}
+	}
+	for k := range f.dataDirs {
+		if f.checkpoint.isHandled(k) {
+			delete(f.dataDirs, k)
+		}
+	}
+	return f.checkpoint.save(ctx, f.checkpointStorage, f.checkpointFile)
+}
+
+// handleDataFile replays the rows of the data file, it returns false if the
+// file is not written yet.
+func (f *storageFollower) handleDataFile(
+	ctx context.Context, key cloudstorage.DmlPathKey, idx uint64,
+) (bool, error) {
+	filePath := key.GenerateDMLFilePath(idx, f.extension, f.fileIndexWidth)
+	exists, err := f.storage.FileExists(ctx, filePath)
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	if exists {
+		content, err := f.storage.ReadFile(ctx, filePath)
+		if err != nil {
+			return false, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+		}
+		rows, err := f.decodeRows(ctx, f.tableDefs[key.SchemaPathKey], content)
+		if err != nil {
+			return false, err
+		}
+		if err := f.sink.writeRows(ctx, key, rows); err != nil {
+			return false, err
+		}
+		log.Debug("data file is replayed",
+			zap.String("upstreamURI", f.upstreamURI),
+			zap.String("path", filePath), zap.Int("rows", len(rows)))
+	} else {
+		// The index file is written before the data file, and the index is
+		// reused after the changefeed restarts if the data file is not
+		// written, so the file is skipped only if it will never be written.
+		if !f.isSuperseded(key) {
+			return false, nil
+		}
+		log.Warn("skip the data file never written",
+			zap.String("upstreamURI", f.upstreamURI), zap.String("path", filePath))
+	}
+	f.checkpoint.fileIndexes[key] = idx
+	return true, f.checkpoint.save(ctx, f.checkpointStorage, f.checkpointFile)
+}
+
+func (f *storageFollower) decodeRows(
+	ctx context.Context, def *cloudstorage.TableDefinition, content []byte,
+) ([]*model.RowChangedEvent, error) {
+	var decoder codec.RowEventDecoder
+	switch f.codecConfig.Protocol {
+	case config.ProtocolCsv:
+		tableInfo, err := def.ToTableInfo()
+		if err != nil {
+			return nil, err
+		}
+		decoder, err = csv.NewBatchDecoder(ctx, f.codecConfig, tableInfo, content)
+		if err != nil {
+			return nil, err
+		}
+	case config.ProtocolCanalJSON:
+		var err error
+		decoder, err = canal.NewBatchDecoder(ctx, f.codecConfig)
+		if err != nil {
+			return nil, err
+		}
+	case config.ProtocolDebezium:
+		decoder = debezium.NewBatchDecoder(f.codecConfig.Terminator)
+	}
+	events, err := decodeMessage(decoder, nil, content)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*model.RowChangedEvent, 0, len(events))
+	for _, e := range events {
+		if e.row != nil {
+			rows = append(rows, e.row)
+		}
+	}
+	return rows, nil
+}
+
+// lessDmlPathKey sorts the keys by table version first, and the fake key of
+// a schema file is before the data directories of the same table version.
+func lessDmlPathKey(a, b cloudstorage.DmlPathKey) bool {
+	if a.TableVersion != b.TableVersion {
+		return a.TableVersion < b.TableVersion
+	}
+	if a.PartitionNum != b.PartitionNum {
+		return a.PartitionNum < b.PartitionNum
+	}
+	if a.Date != b.Date {
+		return a.Date < b.Date
+	}
+	if a.Schema != b.Schema {
+		return a.Schema < b.Schema
+	}
+	return a.Table < b.Table
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"sort"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+)
+
+// storageCheckpoint is the progress of a storage follower, which is saved in
+// the checkpoint storage after each schema file or data file is handled.
+type storageCheckpoint struct {
+	// tableVersions is the version of the last schema file handled of each
+	// table or database, keyed by SchemaPathKey.GetKey().
+	tableVersions map[string]uint64
+	// fileIndexes is the index of the last data file handled in each data
+	// directory, the fake partition number is never used here.
+	fileIndexes map[cloudstorage.DmlPathKey]uint64
+}
+
+// fileCheckpoint is the persisted form of an entry of fileIndexes.
+type fileCheckpoint struct {
+	Schema       string `json:"schema"`
+	Table        string `json:"table"`
+	TableVersion uint64 `json:"table-version"`
+	PartitionNum int64  `json:"partition-num"`
+	Date         string `json:"date"`
+	Index        uint64 `json:"index"`
+}
+
+type storageCheckpointFile struct {
+	TableVersions map[string]uint64 `json:"table-versions"`
+	Files         []fileCheckpoint  `json:"files"`
+}
+
+func newStorageCheckpoint() *storageCheckpoint {
+	return &storageCheckpoint{
+		tableVersions: make(map[string]uint64),
+		fileIndexes:   make(map[cloudstorage.DmlPathKey]uint64),
+	}
+}
+
+// checkpointFileName returns the name of the checkpoint file of the upstream.
+// The query of the upstream uri is not used since it may contain credentials
+// and does not change the location of the files.
+func checkpointFileName(consumerID string, upstreamURI *url.URL) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(upstreamURI.Scheme + "://" + upstreamURI.Host + upstreamURI.Path))
+	return fmt.Sprintf("storage_consumer_%s_%016x.json", consumerID, h.Sum64())
+}
+
+// loadStorageCheckpoint reads the checkpoint from the file, an empty
+// checkpoint is returned if the file does not exist.
+func loadStorageCheckpoint(
+	ctx context.Context, s storage.ExternalStorage, name string,
+) (*storageCheckpoint, error) {
+	cp := newStorageCheckpoint()
+	exists, err := s.FileExists(ctx, name)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	if !exists {
+		return cp, nil
+	}
+	data, err := s.ReadFile(ctx, name)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	var f storageCheckpointFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	for key, version := range f.TableVersions {
+		cp.tableVersions[key] = version
+	}
+	for _, fc := range f.Files {
+		cp.fileIndexes[fileCheckpointKey(fc)] = fc.Index
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint to the file. The data directories of the table
+// versions older than the last handled schema file are not saved, since all
+// their files are handled before the schema file.
+func (cp *storageCheckpoint) save(
+	ctx context.Context, s storage.ExternalStorage, name string,
+) error {
+	cp.compact()
+	f := storageCheckpointFile{
+		TableVersions: cp.tableVersions,
+		Files:         make([]fileCheckpoint, 0, len(cp.fileIndexes)),
+	}
+	for key, index := range cp.fileIndexes {
+		f.Files = append(f.Files, fileCheckpoint{
+			Schema:       key.Schema,
+			Table:        key.Table,
+			TableVersion: key.TableVersion,
+			PartitionNum: key.PartitionNum,
+			Date:         key.Date,
+			Index:        index,
+		})
+	}
+	sort.Slice(f.Files, func(i, j int) bool {
+		return lessDmlPathKey(fileCheckpointKey(f.Files[i]), fileCheckpointKey(f.Files[j]))
+	})
+	data, err := json.Marshal(f)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	if err := s.WriteFile(ctx, name, data); err != nil {
+		return cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	return nil
+}
+
+// isHandled returns whether all the files of the data directory are handled,
+// which is true if a newer schema file of the table is handled.
+func (cp *storageCheckpoint) isHandled(key cloudstorage.DmlPathKey) bool {
+	return key.TableVersion < cp.tableVersions[key.GetKey()]
+}
+
+func (cp *storageCheckpoint) compact() {
+	for key := range cp.fileIndexes {
+		if cp.isHandled(key) {
+			delete(cp.fileIndexes, key)
+		}
+	}
+}
+
+func fileCheckpointKey(fc fileCheckpoint) cloudstorage.DmlPathKey {
+	return cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{
+			Schema:       fc.Schema,
+			Table:        fc.Table,
+			TableVersion: fc.TableVersion,
+		},
+		PartitionNum: fc.PartitionNum,
+		Date:         fc.Date,
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointFileName(t *testing.T) {
+	t.Parallel()
+
+	parse := func(uri string) *url.URL {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		return u
+	}
+	name := checkpointFileName("c1", parse("s3://bucket/prefix?protocol=csv&access-key=a"))
+	require.Regexp(t, `^storage_consumer_c1_[0-9a-f]{16}\.json$`, name)
+	// the query does not change the location of the files.
+	require.Equal(t, name, checkpointFileName("c1", parse("s3://bucket/prefix?protocol=csv")))
+	require.NotEqual(t, name, checkpointFileName("c2", parse("s3://bucket/prefix?protocol=csv")))
+	require.NotEqual(t, name, checkpointFileName("c1", parse("s3://bucket/prefix2?protocol=csv")))
+}
+
+func TestStorageCheckpointSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, err := util.GetExternalStorageFromURI(ctx, "file://"+t.TempDir())
+	require.NoError(t, err)
+
+	cp, err := loadStorageCheckpoint(ctx, s, "cp.json")
+	require.NoError(t, err)
+	require.Empty(t, cp.tableVersions)
+	require.Empty(t, cp.fileIndexes)
+
+	oldKey := cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{Schema: "test", Table: "t1", TableVersion: 100},
+		Date:          "2023-10-01",
+	}
+	newKey := cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{Schema: "test", Table: "t1", TableVersion: 200},
+		PartitionNum:  66,
+		Date:          "2023-10-02",
+	}
+	cp.tableVersions[newKey.GetKey()] = 200
+	cp.fileIndexes[oldKey] = 3
+	cp.fileIndexes[newKey] = 5
+	require.NoError(t, cp.save(ctx, s, "cp.json"))
+
+	loaded, err := loadStorageCheckpoint(ctx, s, "cp.json")
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"`test`.`t1`": 200}, loaded.tableVersions)
+	// the data directory of the old table version is handled.
+	require.True(t, loaded.isHandled(oldKey))
+	require.Equal(t, map[cloudstorage.DmlPathKey]uint64{newKey: 5}, loaded.fileIndexes)
+
+	require.NoError(t, s.WriteFile(ctx, "cp.json", []byte("{")))
+	_, err = loadStorageCheckpoint(ctx, s, "cp.json")
+	require.ErrorContains(t, err, "unmarshal failed")
+}
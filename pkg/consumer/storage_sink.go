@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	ddlfactory "github.com/pingcap/tiflow/cdc/sink/ddlsink/factory"
+	dmlfactory "github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const defaultFlushWaitInterval = 10 * time.Millisecond
+
+// eventSink replays the events decoded from the files of the storage sink.
+type eventSink interface {
+	// writeRows writes the rows of a data file and waits until they are
+	// flushed to the downstream.
+	writeRows(ctx context.Context, key cloudstorage.DmlPathKey, rows []*model.RowChangedEvent) error
+	// writeDDL executes the DDL in the downstream.
+	writeDDL(ctx context.Context, ddl *model.DDLEvent) error
+	close()
+}
+
+type physicalTableKey struct {
+	schema    string
+	table     string
+	partition int64
+}
+
+// factoryEventSink replays the events through the sink factories used by
+// changefeeds, so the downstream can be any sink supported by changefeeds.
+type factoryEventSink struct {
+	changefeedID model.ChangeFeedID
+	dmlFactory   *dmlfactory.SinkFactory
+	ddlSink      ddlsink.Sink
+	errCh        chan error
+
+	// tableIDs are the fake table ids of the physical tables, since the
+	// table ids of the upstream are not recorded in the files.
+	tableIDs   map[physicalTableKey]model.TableID
+	tableSinks map[model.TableID]tablesink.TableSink
+	resolvedTs map[model.TableID]model.ResolvedTs
+}
+
+func newFactoryEventSink(
+	ctx context.Context, changefeedID model.ChangeFeedID, sinkURI string,
+) (*factoryEventSink, error) {
+	sinkURI, err := forceSafeMode(sinkURI)
+	if err != nil {
+		return nil, err
+	}
+	errCh := make(chan error, 1)
+	dmlFactory, err := dmlfactory.New(ctx, changefeedID, sinkURI,
+		config.GetDefaultReplicaConfig(), errCh)
+	if err != nil {
+		return nil, err
+	}
+	ddlSink, err := ddlfactory.New(ctx, changefeedID, sinkURI, config.GetDefaultReplicaConfig())
+	if err != nil {
+		dmlFactory.Close()
+		return nil, err
+	}
+	return &factoryEventSink{
+		changefeedID: changefeedID,
+		dmlFactory:   dmlFactory,
+		ddlSink:      ddlSink,
+		errCh:        errCh,
+		tableIDs:     make(map[physicalTableKey]model.TableID),
+		tableSinks:   make(map[model.TableID]tablesink.TableSink),
+		resolvedTs:   make(map[model.TableID]model.ResolvedTs),
+	}, nil
+}
+
+// forceSafeMode enables the safe mode of a MySQL compatible downstream.
+// The checkpoint is saved after the rows of a file are flushed, so the file
+// is replayed after the consumer restarts, which must not fail on duplicate
+// keys.
+func forceSafeMode(sinkURI string) (string, error) {
+	uri, err := url.Parse(sinkURI)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsMySQLCompatibleScheme(uri.Scheme) {
+		return sinkURI, nil
+	}
+	rawQuery := uri.Query()
+	if rawQuery.Get("safe-mode") != "true" {
+		log.Info("force the safe mode of the downstream to make replaying idempotent")
+		rawQuery.Set("safe-mode", "true")
+		uri.RawQuery = rawQuery.Encode()
+	}
+	return uri.String(), nil
+}
+
+func (s *factoryEventSink) tableID(key cloudstorage.DmlPathKey) model.TableID {
+	pk := physicalTableKey{schema: key.Schema, table: key.Table, partition: key.PartitionNum}
+	if id, ok := s.tableIDs[pk]; ok {
+		return id
+	}
+	id := model.TableID(len(s.tableIDs) + 1)
+	s.tableIDs[pk] = id
+	return id
+}
+
+func (s *factoryEventSink) writeRows(
+	ctx context.Context, key cloudstorage.DmlPathKey, rows []*model.RowChangedEvent,
+) error {
+	tableID := s.tableID(key)
+	resolvedTs := s.resolvedTs[tableID]
+	appended := 0
+	for _, row := range rows {
+		// the resolved ts of a table sink can not fall back.
+		if row.CommitTs < resolvedTs.Ts {
+			log.Warn("row changed event commit ts fallback, ignore",
+				zap.Uint64("commitTs", row.CommitTs),
+				zap.Any("tableResolvedTs", resolvedTs),
+				zap.Any("row", row))
+			continue
+		}
+		tableSink, ok := s.tableSinks[tableID]
+		if !ok {
+			tableSink = s.dmlFactory.CreateTableSinkForConsumer(
+				s.changefeedID, spanz.TableIDToComparableSpan(tableID), row.CommitTs,
+				prometheus.NewCounter(prometheus.CounterOpts{}))
+			s.tableSinks[tableID] = tableSink
+		}
+		if row.CommitTs > resolvedTs.Ts {
+			resolvedTs = model.ResolvedTs{
+				Mode:    model.BatchResolvedMode,
+				Ts:      row.CommitTs,
+				BatchID: 1,
+			}
+		}
+		row.Table.TableID = tableID
+		tableSink.AppendRowChangedEvents(row)
+		appended++
+	}
+	if appended == 0 {
+		return nil
+	}
+	s.resolvedTs[tableID] = resolvedTs
+	return s.waitFlushed(ctx, tableID)
+}
+
+// waitFlushed waits until all the rows appended to the table sink are flushed.
+func (s *factoryEventSink) waitFlushed(ctx context.Context, tableID model.TableID) error {
+	tableSink := s.tableSinks[tableID]
+	resolvedTs := s.resolvedTs[tableID]
+	ticker := time.NewTicker(defaultFlushWaitInterval)
+	defer ticker.Stop()
+	for {
+		if err := tableSink.UpdateResolvedTs(resolvedTs); err != nil {
+			return errors.Trace(err)
+		}
+		if tableSink.GetCheckpointTs().Equal(resolvedTs) {
+			// the rows of the next file may have the same commit ts.
+			s.resolvedTs[tableID] = resolvedTs.AdvanceBatch()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-s.errCh:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *factoryEventSink) writeDDL(ctx context.Context, ddl *model.DDLEvent) error {
+	return s.ddlSink.WriteDDLEvent(ctx, ddl)
+}
+
+func (s *factoryEventSink) close() {
+	s.dmlFactory.Close()
+	s.ddlSink.Close()
+}
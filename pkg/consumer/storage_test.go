@@ -0,0 +1,278 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventSink struct {
+	rows []*model.RowChangedEvent
+	ddls []string
+}
+
+func (s *mockEventSink) writeRows(
+	_ context.Context, _ cloudstorage.DmlPathKey, rows []*model.RowChangedEvent,
+) error {
+	s.rows = append(s.rows, rows...)
+	return nil
+}
+
+func (s *mockEventSink) writeDDL(_ context.Context, ddl *model.DDLEvent) error {
+	s.ddls = append(s.ddls, ddl.Query)
+	return nil
+}
+
+func (s *mockEventSink) close() {}
+
+type storageWriter struct {
+	t   *testing.T
+	dir string
+}
+
+func (w *storageWriter) write(name, content string) {
+	p := filepath.Join(w.dir, name)
+	require.NoError(w.t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(w.t, os.WriteFile(p, []byte(content), 0o644))
+}
+
+func (w *storageWriter) writeSchema(version uint64, query string) {
+	def := &cloudstorage.TableDefinition{
+		Table:        "t1",
+		Schema:       "test",
+		Version:      1,
+		TableVersion: version,
+		Query:        query,
+		Type:         timodel.ActionCreateTable,
+		Columns: []cloudstorage.TableCol{
+			{Name: "id", Tp: "INT", IsPK: "true", Precision: "11"},
+			{Name: "name", Tp: "VARCHAR", Precision: "255"},
+		},
+		TotalColumns: 2,
+	}
+	p, err := def.GenerateSchemaFilePath()
+	require.NoError(w.t, err)
+	data, err := def.MarshalWithQuery()
+	require.NoError(w.t, err)
+	w.write(p, string(data))
+}
+
+// writeData writes a data file of test.t1 and updates the index file, the
+// commit ts of the rows are used as the ids.
+func (w *storageWriter) writeData(version uint64, idx uint64, commitTs ...uint64) {
+	dir := fmt.Sprintf("test/t1/%d", version)
+	name := fmt.Sprintf("CDC%06d.csv", idx)
+	w.write(dir+"/meta/CDC.index", name+"\n")
+	if len(commitTs) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, ts := range commitTs {
+		fmt.Fprintf(&b, "\"I\",\"t1\",\"test\",%d,%d,\"a\"\r\n", ts, ts)
+	}
+	w.write(dir+"/"+name, b.String())
+}
+
+func newTestStorageFollower(
+	t *testing.T, upstreamDir, checkpointDir string,
+) (*storageFollower, *mockEventSink) {
+	ctx := context.Background()
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.CSVConfig.IncludeCommitTs = true
+	replicaConfig.Sink.DateSeparator = util.AddressOf(config.DateSeparatorNone.String())
+	replicaConfig.Sink.FileIndexWidth = util.AddressOf(6)
+	checkpointStorage, err := util.GetExternalStorageFromURI(ctx, "file://"+checkpointDir)
+	require.NoError(t, err)
+	f, err := newStorageFollower(ctx, &StorageConfig{
+		ConsumerID:     "c1",
+		DownstreamURI:  "blackhole://",
+		ReplicaConfig:  replicaConfig,
+		RescanInterval: time.Hour,
+	}, 0, "file://"+upstreamDir+"?protocol=csv", checkpointStorage)
+	require.NoError(t, err)
+	f.sink.close()
+	sink := &mockEventSink{}
+	f.sink = sink
+	return f, sink
+}
+
+func roundOf(t *testing.T, f *storageFollower) {
+	ctx := context.Background()
+	require.NoError(t, f.discover(ctx))
+	require.NoError(t, f.handle(ctx))
+}
+
+func rowIDs(rows []*model.RowChangedEvent) []uint64 {
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.CommitTs)
+	}
+	return ids
+}
+
+func TestStorageFollowerResume(t *testing.T) {
+	t.Parallel()
+
+	upstreamDir, checkpointDir := t.TempDir(), t.TempDir()
+	w := &storageWriter{t: t, dir: upstreamDir}
+	w.writeSchema(100, "CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(255))")
+	w.writeData(100, 1, 101, 102)
+
+	f, sink := newTestStorageFollower(t, upstreamDir, checkpointDir)
+	roundOf(t, f)
+	require.Equal(t, []string{"CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(255))"}, sink.ddls)
+	require.Equal(t, []uint64{101, 102}, rowIDs(sink.rows))
+
+	// the new file is found by the index file without walking all files.
+	w.writeData(100, 2, 103)
+	roundOf(t, f)
+	require.Equal(t, []uint64{101, 102, 103}, rowIDs(sink.rows))
+
+	// the index file is written before the data file.
+	w.writeData(100, 3)
+	roundOf(t, f)
+	require.Len(t, sink.rows, 3)
+
+	// the changefeed restarts with a new table version before the data file
+	// is written, so the data file is skipped.
+	w.writeSchema(200, "ALTER TABLE t1 ADD INDEX idx(name)")
+	w.writeData(200, 1, 201)
+	roundOf(t, f)
+	require.Equal(t, []string{
+		"CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(255))",
+		"ALTER TABLE t1 ADD INDEX idx(name)",
+	}, sink.ddls)
+	require.Equal(t, []uint64{101, 102, 103, 201}, rowIDs(sink.rows))
+	require.Equal(t, map[string]uint64{"`test`.`t1`": 200}, f.checkpoint.tableVersions)
+
+	// the consumer resumes from the checkpoint after restarting.
+	w.writeData(200, 2, 202)
+	f, sink = newTestStorageFollower(t, upstreamDir, checkpointDir)
+	roundOf(t, f)
+	require.Empty(t, sink.ddls)
+	require.Equal(t, []uint64{202}, rowIDs(sink.rows))
+	require.Equal(t, []byte("a"), sink.rows[0].Columns[1].Value)
+}
+
+func TestParseIndexFile(t *testing.T) {
+	t.Parallel()
+
+	idx, err := parseIndexFile("CDC000005.csv\n", ".csv")
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	idx, err = parseIndexFile("CDC00000000000000000012.json\n", ".json")
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), idx)
+
+	_, err = parseIndexFile("CDC000005.json\n", ".csv")
+	require.ErrorContains(t, err, "invalid data file name")
+	_, err = parseIndexFile("CDCabc.csv\n", ".csv")
+	require.Error(t, err)
+}
+
+func TestNewStorageConsumer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir1, dir2, checkpointDir := t.TempDir(), t.TempDir(), t.TempDir()
+	cfg := &StorageConfig{
+		ConsumerID:    "c1",
+		UpstreamURIs:  []string{"file://" + dir1 + "?protocol=csv", "file://" + dir2 + "?protocol=canal-json"},
+		DownstreamURI: "blackhole://",
+		CheckpointURI: "file://" + checkpointDir,
+	}
+	c, err := NewStorageConsumer(ctx, cfg)
+	require.NoError(t, err)
+	require.Len(t, c.followers, 2)
+	require.NotEqual(t, c.followers[0].checkpointFile, c.followers[1].checkpointFile)
+	require.Equal(t, ".json", c.followers[1].extension)
+	c.Close()
+
+	cfg.UpstreamURIs = []string{"file://" + dir1 + "?protocol=csv", "file://" + dir1 + "?protocol=csv"}
+	_, err = NewStorageConsumer(ctx, cfg)
+	require.ErrorContains(t, err, "same location")
+
+	cfg.UpstreamURIs = []string{"file://" + dir1 + "?protocol=open-protocol"}
+	_, err = NewStorageConsumer(ctx, cfg)
+	require.Error(t, err)
+
+	cfg.CheckpointURI = ""
+	_, err = NewStorageConsumer(ctx, cfg)
+	require.ErrorContains(t, err, "checkpoint uri is required")
+}
+
+func TestForceSafeMode(t *testing.T) {
+	t.Parallel()
+
+	uri, err := forceSafeMode("mysql://root@127.0.0.1:3306/?worker-count=4")
+	require.NoError(t, err)
+	require.Equal(t, "mysql://root@127.0.0.1:3306/?safe-mode=true&worker-count=4", uri)
+	uri, err = forceSafeMode("tidb://root@127.0.0.1:4000/?safe-mode=false")
+	require.NoError(t, err)
+	require.Equal(t, "tidb://root@127.0.0.1:4000/?safe-mode=true", uri)
+	uri, err = forceSafeMode("blackhole://")
+	require.NoError(t, err)
+	require.Equal(t, "blackhole://", uri)
+	_, err = forceSafeMode("mysql://root@127.0.0.1:3306/%")
+	require.Error(t, err)
+}
+
+func TestFactoryEventSink(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s, err := newFactoryEventSink(ctx, model.DefaultChangeFeedID("test"), "blackhole://")
+	require.NoError(t, err)
+	defer s.close()
+
+	key := cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{Schema: "test", Table: "t1", TableVersion: 100},
+	}
+	newRows := func(commitTs ...uint64) []*model.RowChangedEvent {
+		rows := make([]*model.RowChangedEvent, 0, len(commitTs))
+		for _, ts := range commitTs {
+			rows = append(rows, &model.RowChangedEvent{
+				CommitTs: ts,
+				Table:    &model.TableName{Schema: "test", Table: "t1"},
+			})
+		}
+		return rows
+	}
+	require.NoError(t, s.writeRows(ctx, key, newRows(101, 102)))
+	tableID := s.tableID(key)
+	require.Equal(t, uint64(102), s.tableSinks[tableID].GetCheckpointTs().Ts)
+	// the rows of the next file may have the same commit ts.
+	require.NoError(t, s.writeRows(ctx, key, newRows(102, 103)))
+	require.Equal(t, uint64(103), s.tableSinks[tableID].GetCheckpointTs().Ts)
+	// the rows fall back are ignored.
+	require.NoError(t, s.writeRows(ctx, key, newRows(100)))
+
+	otherKey := key
+	otherKey.Table = "t2"
+	require.NotEqual(t, tableID, s.tableID(otherKey))
+}
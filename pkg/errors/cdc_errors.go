@@ -203,6 +203,42 @@ var (
 		"kafka config item not found",
 		errors.RFCCodeText("CDC:ErrKafkaConfigNotFound"),
 	)
+	ErrKafkaNewConsumer = errors.Normalize(
+		"new kafka consumer",
+		errors.RFCCodeText("CDC:ErrKafkaNewConsumer"),
+	)
+	ErrKafkaConsumeMessage = errors.Normalize(
+		"consume kafka message failed",
+		errors.RFCCodeText("CDC:ErrKafkaConsumeMessage"),
+	)
+	ErrPulsarNewProducer = errors.Normalize(
+		"new pulsar producer",
+		errors.RFCCodeText("CDC:ErrPulsarNewProducer"),
+	)
+	ErrPulsarProducerClosed = errors.Normalize(
+		"pulsar producer closed",
+		errors.RFCCodeText("CDC:ErrPulsarProducerClosed"),
+	)
+	ErrPulsarSendMessage = errors.Normalize(
+		"pulsar send message failed",
+		errors.RFCCodeText("CDC:ErrPulsarSendMessage"),
+	)
+	ErrPulsarInvalidConfig = errors.Normalize(
+		"pulsar config invalid",
+		errors.RFCCodeText("CDC:ErrPulsarInvalidConfig"),
+	)
+	ErrPulsarTopicPartitions = errors.Normalize(
+		"get partitions of pulsar topic %s failed",
+		errors.RFCCodeText("CDC:ErrPulsarTopicPartitions"),
+	)
+	ErrWebhookInvalidConfig = errors.Normalize(
+		"webhook sink config invalid",
+		errors.RFCCodeText("CDC:ErrWebhookInvalidConfig"),
+	)
+	ErrWebhookRequestFailed = errors.Normalize(
+		"webhook request failed, status code: %d, response: %s",
+		errors.RFCCodeText("CDC:ErrWebhookRequestFailed"),
+	)
 	ErrRedoConfigInvalid = errors.Normalize(
 		"redo log config invalid",
 		errors.RFCCodeText("CDC:ErrRedoConfigInvalid"),
@@ -227,6 +263,14 @@ var (
 		"initialize meta for redo log",
 		errors.RFCCodeText("CDC:ErrRedoMetaInitialize"),
 	)
+	ErrRedoEncryptionKey = errors.Normalize(
+		"invalid redo log encryption key: %s",
+		errors.RFCCodeText("CDC:ErrRedoEncryptionKey"),
+	)
+	ErrRedoDecryptFailed = errors.Normalize(
+		"decrypt redo log failed: %s",
+		errors.RFCCodeText("CDC:ErrRedoDecryptFailed"),
+	)
 	ErrFileSizeExceed = errors.Normalize(
 		"rawData size %d exceeds maximum file size %d",
 		errors.RFCCodeText("CDC:ErrFileSizeExceed"),
@@ -320,6 +364,22 @@ var (
 		"canal encode failed",
 		errors.RFCCodeText("CDC:ErrCanalEncodeFailed"),
 	)
+	ErrDebeziumEncodeFailed = errors.Normalize(
+		"debezium encode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumEncodeFailed"),
+	)
+	ErrDebeziumDecodeFailed = errors.Normalize(
+		"debezium decode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumDecodeFailed"),
+	)
+	ErrProtobufEncodeFailed = errors.Normalize(
+		"protobuf encode failed",
+		errors.RFCCodeText("CDC:ErrProtobufEncodeFailed"),
+	)
+	ErrProtobufDecodeFailed = errors.Normalize(
+		"protobuf decode failed",
+		errors.RFCCodeText("CDC:ErrProtobufDecodeFailed"),
+	)
 	ErrOldValueNotEnabled = errors.Normalize(
 		"old value is not enabled",
 		errors.RFCCodeText("CDC:ErrOldValueNotEnabled"),
@@ -352,6 +412,10 @@ var (
 		"csv decode failed",
 		errors.RFCCodeText("CDC:ErrCSVDecodeFailed"),
 	)
+	ErrParquetEncodeFailed = errors.Normalize(
+		"parquet encode failed",
+		errors.RFCCodeText("CDC:ErrParquetEncodeFailed"),
+	)
 	ErrStorageSinkInvalidConfig = errors.Normalize(
 		"storage sink config invalid",
 		errors.RFCCodeText("CDC:ErrStorageSinkInvalidConfig"),
@@ -360,6 +424,10 @@ var (
 		"filename in storage sink is invalid",
 		errors.RFCCodeText("CDC:ErrStorageSinkInvalidFileName"),
 	)
+	ErrStorageConsumerInvalidConfig = errors.Normalize(
+		"storage consumer config invalid",
+		errors.RFCCodeText("CDC:ErrStorageConsumerInvalidConfig"),
+	)
 
 	// utilities related errors
 	ErrToTLSConfigFailed = errors.Normalize(
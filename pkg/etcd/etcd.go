@@ -149,6 +149,11 @@ type CDCEtcdClient interface {
 		changeFeedID model.ChangeFeedID,
 	) error
 
+	PatchChangeFeedInfo(ctx context.Context,
+		changeFeedID model.ChangeFeedID,
+		patch func(info *model.ChangeFeedInfo) error,
+	) (*model.ChangeFeedInfo, error)
+
 	PutCaptureInfo(context.Context, *model.CaptureInfo, clientv3.LeaseID) error
 
 	DeleteCaptureInfo(context.Context, model.CaptureID) error
@@ -516,6 +521,50 @@ func (c *CDCEtcdClientImpl) UpdateChangefeedAndUpstream(ctx context.Context,
 	return nil
 }
 
+// PatchChangeFeedInfo updates the changefeed info in etcd by the patch function.
+// The info is only updated if it isn't changed by others since it is read,
+// so it's safe to update the info of a running changefeed.
+func (c *CDCEtcdClientImpl) PatchChangeFeedInfo(ctx context.Context,
+	changeFeedID model.ChangeFeedID,
+	patch func(info *model.ChangeFeedInfo) error,
+) (*model.ChangeFeedInfo, error) {
+	key := GetEtcdKeyChangeFeedInfo(c.ClusterID, changeFeedID)
+	resp, err := c.Client.Get(ctx, key)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+	}
+	if resp.Count == 0 {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(key)
+	}
+	info := &model.ChangeFeedInfo{}
+	if err := info.Unmarshal(resp.Kvs[0].Value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := patch(info); err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := info.Marshal()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cmps := []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision),
+	}
+	opsThen := []clientv3.Op{clientv3.OpPut(key, value)}
+	txnResp, err := c.Client.Txn(ctx, cmps, opsThen, TxnEmptyOpsElse)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+	}
+	if !txnResp.Succeeded {
+		log.Warn("changefeed info is changed concurrently",
+			zap.String("namespace", changeFeedID.Namespace),
+			zap.String("changefeed", changeFeedID.ID))
+		return nil, cerror.ErrChangefeedUpdateFailedTransaction.GenWithStackByArgs(changeFeedID)
+	}
+	return info, nil
+}
+
 // SaveChangeFeedInfo stores change feed info into etcd
 // TODO: this should be called from outer system, such as from a TiDB client
 func (c *CDCEtcdClientImpl) SaveChangeFeedInfo(ctx context.Context,
@@ -258,6 +258,21 @@ func (mr *MockCDCEtcdClientMockRecorder) GetUpstreamInfo(ctx, upstreamID, namesp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetUpstreamInfo), ctx, upstreamID, namespace)
 }
 
+// PatchChangeFeedInfo mocks base method.
+func (m *MockCDCEtcdClient) PatchChangeFeedInfo(ctx context.Context, changeFeedID model.ChangeFeedID, patch func(*model.ChangeFeedInfo) error) (*model.ChangeFeedInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchChangeFeedInfo", ctx, changeFeedID, patch)
+	ret0, _ := ret[0].(*model.ChangeFeedInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchChangeFeedInfo indicates an expected call of PatchChangeFeedInfo.
+func (mr *MockCDCEtcdClientMockRecorder) PatchChangeFeedInfo(ctx, changeFeedID, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchChangeFeedInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).PatchChangeFeedInfo), ctx, changeFeedID, patch)
+}
+
 // PutCaptureInfo mocks base method.
 func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 *model.CaptureInfo, arg2 clientv3.LeaseID) error {
 	m.ctrl.T.Helper()
@@ -328,3 +328,45 @@ func TestShouldIgnoreDDL(t *testing.T) {
 		}
 	}
 }
+
+func TestUnionFilter(t *testing.T) {
+	t.Parallel()
+
+	oldFilter, err := NewFilter(&config.ReplicaConfig{
+		Filter: &config.FilterConfig{Rules: []string{"sns.*", "!sns.log"}},
+	}, "")
+	require.Nil(t, err)
+	newFilter, err := NewFilter(&config.ReplicaConfig{
+		Filter: &config.FilterConfig{Rules: []string{"ecom.*"}},
+	}, "")
+	require.Nil(t, err)
+	filter := NewUnionFilter(oldFilter, newFilter)
+
+	require.False(t, filter.ShouldIgnoreTable("sns", "user"))
+	require.False(t, filter.ShouldIgnoreTable("ecom", "order"))
+	require.True(t, filter.ShouldIgnoreTable("sns", "log"))
+	require.True(t, filter.ShouldIgnoreTable("other", "what"))
+	require.False(t, filter.ShouldIgnoreSchema("ecom"))
+	require.True(t, filter.ShouldIgnoreSchema("other"))
+	require.False(t, filter.ShouldDiscardDDL(timodel.ActionCreateTable, "ecom", "order"))
+	require.True(t, filter.ShouldDiscardDDL(timodel.ActionCreateTable, "other", "what"))
+
+	ignore, err := filter.ShouldIgnoreDMLEvent(&model.RowChangedEvent{
+		Table: &model.TableName{Schema: "ecom", Table: "order"},
+	}, model.RowChangedDatums{}, nil)
+	require.Nil(t, err)
+	require.False(t, ignore)
+	ignore, err = filter.ShouldIgnoreDMLEvent(&model.RowChangedEvent{
+		Table: &model.TableName{Schema: "sns", Table: "log"},
+	}, model.RowChangedDatums{}, nil)
+	require.Nil(t, err)
+	require.True(t, ignore)
+
+	ignore, err = filter.ShouldIgnoreDDLEvent(&model.DDLEvent{
+		Type:      timodel.ActionAddColumn,
+		Query:     "ALTER TABLE sns.user ADD COLUMN c INT",
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "sns", Table: "user"}},
+	})
+	require.Nil(t, err)
+	require.False(t, ignore)
+}
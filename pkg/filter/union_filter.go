@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// unionFilter implements Filter. It ignores an event only if all the inner
+// filters ignore it, so the tables matched by any of the filters are kept.
+type unionFilter struct {
+	filters []Filter
+}
+
+// NewUnionFilter creates a filter that keeps the events kept by any of the
+// given filters. It is used when the filter of a changefeed is updated, the
+// tables matched by either the old or the new filter must be tracked until
+// the tables are rescheduled.
+func NewUnionFilter(filters ...Filter) Filter {
+	return &unionFilter{filters: filters}
+}
+
+// ShouldIgnoreDMLEvent implements Filter.
+func (f *unionFilter) ShouldIgnoreDMLEvent(
+	dml *model.RowChangedEvent,
+	rawRow model.RowChangedDatums,
+	ti *model.TableInfo,
+) (bool, error) {
+	for _, inner := range f.filters {
+		ignore, err := inner.ShouldIgnoreDMLEvent(dml, rawRow, ti)
+		if err != nil || !ignore {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ShouldIgnoreDDLEvent implements Filter.
+func (f *unionFilter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
+	for _, inner := range f.filters {
+		ignore, err := inner.ShouldIgnoreDDLEvent(ddl)
+		if err != nil || !ignore {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ShouldDiscardDDL implements Filter.
+func (f *unionFilter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table string) bool {
+	for _, inner := range f.filters {
+		if !inner.ShouldDiscardDDL(ddlType, schema, table) {
+			return false
+		}
+	}
+	return true
+}
+
+// ShouldIgnoreTable implements Filter.
+func (f *unionFilter) ShouldIgnoreTable(schema, table string) bool {
+	for _, inner := range f.filters {
+		if !inner.ShouldIgnoreTable(schema, table) {
+			return false
+		}
+	}
+	return true
+}
+
+// ShouldIgnoreSchema implements Filter.
+func (f *unionFilter) ShouldIgnoreSchema(schema string) bool {
+	for _, inner := range f.filters {
+		if !inner.ShouldIgnoreSchema(schema) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify implements Filter.
+func (f *unionFilter) Verify(tableInfos []*model.TableInfo) error {
+	for _, inner := range f.filters {
+		if err := inner.Verify(tableInfos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
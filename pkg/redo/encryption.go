@@ -0,0 +1,242 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// encryptionMagic is written at the beginning of encrypted redo files.
+	encryptionMagic = "TIREDOE1"
+	// encryptionKeyIDSize is the size of the key id in the file header.
+	encryptionKeyIDSize = 8
+	// encryptionFileIDSize is the size of the random file id in the file header.
+	encryptionFileIDSize = 8
+	// EncryptionHeaderSize is the size of the header of encrypted redo files,
+	// which consists of the magic, the key id and the file id. It is a
+	// multiple of 8, so the frames of log files following the header are
+	// still aligned.
+	EncryptionHeaderSize = len(encryptionMagic) + encryptionKeyIDSize + encryptionFileIDSize
+)
+
+// Cipher encrypts and decrypts redo log files and meta files with AES-GCM.
+//
+// An encrypted file starts with a header of the magic, the key id and a
+// random file id, the key id is derived from the key and is used to detect
+// a wrong key.
+// The payload of each frame of log files is encrypted separately, since the
+// log files are written incrementally, and the header and the index of the
+// frame are bound as the additional data, so that the frames can't be
+// reordered, dropped or moved to other files. The meta files are encrypted
+// as a whole with the header and the file name as the additional data.
+// Each encrypted payload is prefixed with a random nonce.
+type Cipher struct {
+	keyID [encryptionKeyIDSize]byte
+	aead  cipher.AEAD
+	// allowPlaintext indicates whether the files that are not encrypted can
+	// be read, it's used to read the files written before the encryption is
+	// enabled.
+	allowPlaintext bool
+}
+
+// NewCipherFromFile creates a Cipher with the key in the given file, which
+// contains a hex-encoded AES-128, AES-192 or AES-256 key. A nil Cipher is
+// returned if the file is not specified, which means encryption is disabled.
+// The plaintext files are rejected by the Cipher unless allowPlaintext is true.
+func NewCipherFromFile(keyFile string, allowPlaintext bool) (*Cipher, error) {
+	if keyFile == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoEncryptionKey, err, keyFile)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoEncryptionKey, err, keyFile)
+	}
+	c, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	c.allowPlaintext = allowPlaintext
+	return c, nil
+}
+
+// NewCipher creates a Cipher with the given AES key.
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoEncryptionKey, err, "invalid key size")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoEncryptionKey, err, "invalid key")
+	}
+	c := &Cipher{aead: aead}
+	sum := sha256.Sum256(key)
+	copy(c.keyID[:], sum[:])
+	return c, nil
+}
+
+// KeyID returns the hex-encoded id of the key.
+func (c *Cipher) KeyID() string {
+	return hex.EncodeToString(c.keyID[:])
+}
+
+// NewHeader returns a new header with a random file id for a file encrypted
+// by the Cipher.
+func (c *Cipher) NewHeader() ([]byte, error) {
+	header := make([]byte, EncryptionHeaderSize)
+	copy(header, encryptionMagic)
+	copy(header[len(encryptionMagic):], c.keyID[:])
+	if _, err := io.ReadFull(rand.Reader,
+		header[len(encryptionMagic)+encryptionKeyIDSize:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return header, nil
+}
+
+// SealedSize returns the size of the data returned by SealFrame for a
+// plaintext of the given size.
+func (c *Cipher) SealedSize(size int) int {
+	return c.aead.NonceSize() + size + c.aead.Overhead()
+}
+
+// SealFrame encrypts the plaintext of the index-th frame of the file with
+// the given header, and returns the nonce followed by the ciphertext.
+func (c *Cipher) SealFrame(header []byte, index uint64, plaintext []byte) ([]byte, error) {
+	return c.seal(plaintext, frameAdditionalData(header, index))
+}
+
+// OpenFrame decrypts the index-th frame of the file with the given header.
+func (c *Cipher) OpenFrame(header []byte, index uint64, data []byte) ([]byte, error) {
+	return c.open(data, frameAdditionalData(header, index))
+}
+
+func (c *Cipher) seal(plaintext, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	buf := make([]byte, nonceSize, c.SealedSize(len(plaintext)))
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.aead.Seal(buf, buf, plaintext, additionalData), nil
+}
+
+func (c *Cipher) open(data, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.ErrRedoDecryptFailed.GenWithStackByArgs("data is too short")
+	}
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], additionalData)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrRedoDecryptFailed, err, "authentication failed")
+	}
+	return plaintext, nil
+}
+
+func frameAdditionalData(header []byte, index uint64) []byte {
+	ad := make([]byte, len(header), len(header)+8)
+	copy(ad, header)
+	return binary.BigEndian.AppendUint64(ad, index)
+}
+
+func fileAdditionalData(header []byte, name string) []byte {
+	ad := make([]byte, len(header), len(header)+len(name))
+	copy(ad, header)
+	return append(ad, path.Base(name)...)
+}
+
+// CheckHeader checks whether the header is written by a Cipher with the same
+// key. It's safe to call CheckHeader on a nil Cipher.
+func (c *Cipher) CheckHeader(header []byte) error {
+	if !IsEncrypted(header) {
+		return errors.ErrRedoDecryptFailed.GenWithStackByArgs("invalid header")
+	}
+	keyID := header[len(encryptionMagic) : len(encryptionMagic)+encryptionKeyIDSize]
+	if c == nil {
+		return errors.ErrRedoDecryptFailed.GenWithStackByArgs(
+			"the file is encrypted by key " + hex.EncodeToString(keyID) +
+				", but no key is specified")
+	}
+	if !bytes.Equal(c.keyID[:], keyID) {
+		return errors.ErrRedoDecryptFailed.GenWithStackByArgs(
+			"the file is encrypted by key " + hex.EncodeToString(keyID) +
+				", but the key is " + c.KeyID())
+	}
+	return nil
+}
+
+// CheckPlaintext checks whether a file that is not encrypted can be read.
+// It's safe to call CheckPlaintext on a nil Cipher.
+func (c *Cipher) CheckPlaintext() error {
+	if c == nil || c.allowPlaintext {
+		return nil
+	}
+	return errors.ErrRedoDecryptFailed.GenWithStackByArgs(
+		"the file is not encrypted, but the key " + c.KeyID() + " is specified")
+}
+
+// EncryptFile encrypts the whole content of the file with the given name and
+// prepends the header. The data is returned as is if the Cipher is nil.
+func (c *Cipher) EncryptFile(name string, data []byte) ([]byte, error) {
+	if c == nil {
+		return data, nil
+	}
+	header, err := c.NewHeader()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := c.seal(data, fileAdditionalData(header, name))
+	if err != nil {
+		return nil, err
+	}
+	return append(header, sealed...), nil
+}
+
+// DecryptFile decrypts the content of the file with the given name encrypted
+// by EncryptFile. The data is returned as is if it is not encrypted and the
+// plaintext files are allowed.
+func (c *Cipher) DecryptFile(name string, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		if err := c.CheckPlaintext(); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	if err := c.CheckHeader(data); err != nil {
+		return nil, err
+	}
+	header := data[:EncryptionHeaderSize]
+	return c.open(data[EncryptionHeaderSize:], fileAdditionalData(header, name))
+}
+
+// IsEncrypted returns whether the data starts with the header of encrypted files.
+func IsEncrypted(data []byte) bool {
+	return len(data) >= EncryptionHeaderSize &&
+		string(data[:len(encryptionMagic)]) == encryptionMagic
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCipherFromFile(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCipherFromFile("", false)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	dir := t.TempDir()
+	_, err = NewCipherFromFile(filepath.Join(dir, "not-exist"), false)
+	require.ErrorContains(t, err, "invalid redo log encryption key")
+
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a hex key"), 0o600))
+	_, err = NewCipherFromFile(keyFile, false)
+	require.ErrorContains(t, err, "invalid redo log encryption key")
+
+	// the key size must be 16, 24 or 32 bytes.
+	require.NoError(t, os.WriteFile(keyFile, []byte("0011223344"), 0o600))
+	_, err = NewCipherFromFile(keyFile, false)
+	require.ErrorContains(t, err, "invalid key size")
+
+	key := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	require.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0o600))
+	c, err = NewCipherFromFile(keyFile, false)
+	require.NoError(t, err)
+	require.Len(t, c.KeyID(), 2*encryptionKeyIDSize)
+	header, err := c.NewHeader()
+	require.NoError(t, err)
+	require.Len(t, header, EncryptionHeaderSize)
+	require.NoError(t, c.CheckHeader(header))
+	// each file has a random file id.
+	header2, err := c.NewHeader()
+	require.NoError(t, err)
+	require.NotEqual(t, header, header2)
+}
+
+func TestCipherSealAndOpenFrame(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCipher(bytes.Repeat([]byte{1}, 16))
+	require.NoError(t, err)
+	header, err := c.NewHeader()
+	require.NoError(t, err)
+	plaintext := []byte("redo log")
+	sealed, err := c.SealFrame(header, 0, plaintext)
+	require.NoError(t, err)
+	require.Len(t, sealed, c.SealedSize(len(plaintext)))
+	require.NotContains(t, string(sealed), string(plaintext))
+	// the nonce is random, so the same plaintext is sealed differently.
+	sealed2, err := c.SealFrame(header, 0, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, sealed2)
+
+	opened, err := c.OpenFrame(header, 0, sealed)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+
+	// the frame can't be opened at another index or in another file.
+	_, err = c.OpenFrame(header, 1, sealed)
+	require.ErrorContains(t, err, "authentication failed")
+	otherHeader, err := c.NewHeader()
+	require.NoError(t, err)
+	_, err = c.OpenFrame(otherHeader, 0, sealed)
+	require.ErrorContains(t, err, "authentication failed")
+
+	sealed[len(sealed)-1] ^= 0xff
+	_, err = c.OpenFrame(header, 0, sealed)
+	require.ErrorContains(t, err, "authentication failed")
+	_, err = c.OpenFrame(header, 0, []byte{1, 2})
+	require.ErrorContains(t, err, "data is too short")
+}
+
+func TestCipherEncryptFile(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	data := []byte("redo meta")
+	encrypted, err := c.EncryptFile("dir/a.meta", data)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NoError(t, c.CheckHeader(encrypted))
+
+	// only the base name of the file is bound.
+	decrypted, err := c.DecryptFile("a.meta", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+	// the content can't be moved to another file.
+	_, err = c.DecryptFile("b.meta", encrypted)
+	require.ErrorContains(t, err, "authentication failed")
+
+	// the plaintext is rejected unless it is allowed.
+	_, err = c.DecryptFile("a.meta", data)
+	require.ErrorContains(t, err, "the file is not encrypted")
+	c.allowPlaintext = true
+	decrypted, err = c.DecryptFile("a.meta", data)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// nil cipher means encryption is disabled.
+	var nilCipher *Cipher
+	plain, err := nilCipher.EncryptFile("a.meta", data)
+	require.NoError(t, err)
+	require.Equal(t, data, plain)
+	plain, err = nilCipher.DecryptFile("a.meta", data)
+	require.NoError(t, err)
+	require.Equal(t, data, plain)
+	_, err = nilCipher.DecryptFile("a.meta", encrypted)
+	require.ErrorContains(t, err, "no key is specified")
+
+	other, err := NewCipher(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = other.DecryptFile("a.meta", encrypted)
+	require.ErrorContains(t, err, "the file is encrypted by key "+c.KeyID())
+}
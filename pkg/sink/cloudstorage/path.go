@@ -46,15 +46,46 @@ const (
 	// The table schema is stored in the following path:
 	// <schema>/<table>/meta/schema_{tableVersion}_{checksum}.json
 	tableSchemaPrefix = "%s/%s/meta/"
+	// SyncPointDir is the directory of the syncpoint marker files,
+	// the marker is stored in the following path:
+	// syncpoint/{primaryTs}.json
+	SyncPointDir = "syncpoint"
 )
 
-var schemaRE = regexp.MustCompile(`meta/schema_\d+_\d{10}\.json$`)
+var (
+	schemaRE    = regexp.MustCompile(`meta/schema_\d+_\d{10}\.json$`)
+	syncPointRE = regexp.MustCompile(`^syncpoint/(\d+)\.json$`)
+)
 
 // IsSchemaFile checks whether the file is a schema file.
 func IsSchemaFile(path string) bool {
 	return schemaRE.MatchString(path)
 }
 
+// IsSyncPointFile checks whether the file is a syncpoint marker file.
+func IsSyncPointFile(path string) bool {
+	return syncPointRE.MatchString(path)
+}
+
+// GenerateSyncPointFilePath generates the path of the syncpoint marker file.
+func GenerateSyncPointFilePath(primaryTs uint64) string {
+	return path.Join(SyncPointDir, fmt.Sprintf("%d.json", primaryTs))
+}
+
+// ParseSyncPointFilePath parses the primary ts from the syncpoint marker file path.
+func ParseSyncPointFilePath(path string) (uint64, error) {
+	matches := syncPointRE.FindStringSubmatch(path)
+	if len(matches) != 2 {
+		return 0, errors.ErrStorageSinkInvalidFileName.GenWithStack(
+			"invalid syncpoint file path %s", path)
+	}
+	primaryTs, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, errors.WrapError(errors.ErrStorageSinkInvalidFileName, err)
+	}
+	return primaryTs, nil
+}
+
 // mustParseSchemaName parses the version from the schema file name.
 func mustParseSchemaName(path string) (uint64, uint32) {
 	reportErr := func(err error) {
@@ -275,3 +275,25 @@ func TestIsSchemaFile(t *testing.T) {
 			"testCase: %s, path: %v", tt.name, tt.path)
 	}
 }
+
+func TestSyncPointFilePath(t *testing.T) {
+	t.Parallel()
+
+	path := GenerateSyncPointFilePath(441349361156227074)
+	require.Equal(t, "syncpoint/441349361156227074.json", path)
+	require.True(t, IsSyncPointFile(path))
+	primaryTs, err := ParseSyncPointFilePath(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(441349361156227074), primaryTs)
+
+	for _, path := range []string{
+		"syncpoint/table/441349361156227074.json",
+		"schema/syncpoint/441349361156227074.json",
+		"syncpoint/abc.json",
+		"syncpoint/441349361156227074.csv",
+	} {
+		require.False(t, IsSyncPointFile(path), path)
+		_, err := ParseSyncPointFilePath(path)
+		require.ErrorContains(t, err, "ErrStorageSinkInvalidFileName")
+	}
+}
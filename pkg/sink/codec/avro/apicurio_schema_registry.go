@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+)
+
+const (
+	apicurioAPIPath = "/apis/registry/v2"
+	// all the schemas are registered into the default group,
+	// the artifact id is the subject.
+	apicurioGroup = "default"
+)
+
+type apicurioArtifactMetaData struct {
+	GlobalID int64  `json:"globalId"`
+	ID       string `json:"id"`
+	Version  string `json:"version"`
+}
+
+// apicurioSchemaRegistry is the client of the Apicurio schema registry.
+// https://www.apicur.io/registry/docs/apicurio-registry/2.4.x/assets-attachments/registry-rest-api.htm
+type apicurioSchemaRegistry struct {
+	apiURL     string
+	credential *security.Credential
+}
+
+func newApicurioSchemaRegistry(
+	ctx context.Context,
+	registryURL string,
+	credential *security.Credential,
+) (*apicurioSchemaRegistry, error) {
+	apiURL := strings.TrimRight(registryURL, "/") + apicurioAPIPath
+	httpCli, err := httputil.NewClient(credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := httpCli.Get(ctx, apiURL+"/system/info")
+	if err != nil {
+		log.Error("Test connection to Apicurio Registry failed", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		log.Error("Unexpected response from Apicurio Registry",
+			zap.Int("status", resp.StatusCode))
+		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Unexpected response from Apicurio Registry, status = %d", resp.StatusCode)
+	}
+
+	log.Info("Successfully tested connectivity to Apicurio Registry",
+		zap.String("registryURL", registryURL))
+
+	return &apicurioSchemaRegistry{
+		apiURL:     apiURL,
+		credential: credential,
+	}, nil
+}
+
+// Register implements the SchemaRegistry interface.
+// The artifact is created if not exists, and a new version is
+// created only if the schema differs from the existing versions.
+func (r *apicurioSchemaRegistry) Register(
+	ctx context.Context,
+	subject string,
+	schema string,
+) (SchemaID, error) {
+	uri := r.apiURL + "/groups/" + apicurioGroup + "/artifacts?ifExists=RETURN_OR_UPDATE"
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewReader([]byte(schema)))
+	if err != nil {
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-Registry-ArtifactId", subject)
+	req.Header.Add("X-Registry-ArtifactType", "AVRO")
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return SchemaID{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Error("Failed to register schema to the Apicurio Registry, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("subject", subject),
+			zap.ByteString("responseBody", body))
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Failed to register schema to the Apicurio Registry, status = %d",
+			resp.StatusCode)
+	}
+
+	var metaData apicurioArtifactMetaData
+	if err := json.Unmarshal(body, &metaData); err != nil {
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	if metaData.GlobalID == 0 {
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Illegal global ID returned from Apicurio Registry %d", metaData.GlobalID)
+	}
+
+	log.Info("Registered schema to Apicurio Registry successfully",
+		zap.String("subject", subject),
+		zap.String("version", metaData.Version),
+		zap.Int64("globalID", metaData.GlobalID))
+	return SchemaID{ID: metaData.GlobalID}, nil
+}
+
+// Lookup implements the SchemaRegistry interface.
+func (r *apicurioSchemaRegistry) Lookup(ctx context.Context, id SchemaID) (string, error) {
+	uri := r.apiURL + "/ids/globalIds/" + strconv.FormatInt(id.ID, 10)
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		log.Warn("Specified schema not found in Apicurio Registry",
+			zap.Int64("globalID", id.ID))
+		return "", cerror.ErrAvroSchemaAPIError.GenWithStackByArgs(
+			"Schema not found in Registry",
+		)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Error("Failed to query schema from the Apicurio Registry, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("uri", uri),
+			zap.ByteString("responseBody", body))
+		return "", cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Failed to query schema from the Apicurio Registry, status = %d",
+			resp.StatusCode)
+	}
+	return string(body), nil
+}
+
+// Clear implements the SchemaRegistry interface.
+func (r *apicurioSchemaRegistry) Clear(ctx context.Context, subject string) error {
+	uri := r.apiURL + "/groups/" + apicurioGroup + "/artifacts/" + url.PathEscape(subject)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", uri, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK, http.StatusNotFound:
+		return nil
+	}
+	log.Error("Error when clearing Apicurio Registry", zap.Int("status", resp.StatusCode))
+	return cerror.ErrAvroSchemaAPIError.GenWithStack(
+		"Error when clearing Apicurio Registry, status = %d", resp.StatusCode)
+}
+
+// Header implements the SchemaRegistry interface.
+// It's the default wire format of the Apicurio serdes,
+// the magic byte followed by the 8 bytes global id.
+func (r *apicurioSchemaRegistry) Header(id SchemaID) []byte {
+	header := make([]byte, 9)
+	header[0] = magicByte
+	binary.BigEndian.PutUint64(header[1:], uint64(id.ID))
+	return header
+}
+
+// ParseHeader implements the SchemaRegistry interface.
+func (r *apicurioSchemaRegistry) ParseHeader(data []byte) (SchemaID, []byte, error) {
+	if len(data) < 9 || data[0] != magicByte {
+		return SchemaID{}, nil, cerror.ErrAvroInvalidMessage.FastGenByArgs()
+	}
+	return SchemaID{ID: int64(binary.BigEndian.Uint64(data[1:9]))}, data[9:], nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApicurioSchemaRegistry(t *testing.T) {
+	startHTTPInterceptForTestingRegistry()
+	defer stopHTTPInterceptForTestingRegistry()
+
+	c := common.NewConfig(config.ProtocolAvro)
+	c.AvroSchemaRegistry = "http://127.0.0.1:8081/"
+	c.AvroSchemaRegistryType = common.SchemaRegistryTypeApicurio
+	registry, err := NewSchemaRegistry(getTestingContext(), c, nil)
+	require.NoError(t, err)
+	require.IsType(t, &apicurioSchemaRegistry{}, registry)
+
+	subject := "cdctest-value"
+	require.NoError(t, registry.Clear(getTestingContext(), subject))
+
+	_, err = registry.Lookup(getTestingContext(), SchemaID{ID: 1})
+	require.Regexp(t, `.*not\sfound.*`, err)
+
+	codec, err := goavro.NewCodec(`{
+       "type": "record",
+       "name": "test",
+       "fields": [{"type": "string", "name": "field1"}]
+     }`)
+	require.NoError(t, err)
+
+	id, err := registry.Register(getTestingContext(), subject, codec.Schema())
+	require.NoError(t, err)
+	require.Greater(t, id.ID, int64(0))
+	// registering the same schema again returns the same id.
+	id1, err := registry.Register(getTestingContext(), subject, codec.Schema())
+	require.NoError(t, err)
+	require.Equal(t, id, id1)
+
+	schema, err := registry.Lookup(getTestingContext(), id)
+	require.NoError(t, err)
+	require.Equal(t, codec.Schema(), schema)
+
+	header := registry.Header(id)
+	require.Len(t, header, 9)
+	require.Equal(t, magicByte, header[0])
+	parsedID, data, err := registry.ParseHeader(append(header, 1, 2))
+	require.NoError(t, err)
+	require.Equal(t, id, parsedID)
+	require.Equal(t, []byte{1, 2}, data)
+	_, _, err = registry.ParseHeader([]byte{magicByte, 0, 0, 0, 1})
+	require.Error(t, err)
+
+	require.NoError(t, registry.Clear(getTestingContext(), subject))
+	_, err = registry.Lookup(getTestingContext(), id)
+	require.Error(t, err)
+}
@@ -69,9 +69,9 @@ func (r *avroEncodeInput) Swap(i, j int) {
 
 type avroEncodeResult struct {
 	data []byte
-	// schemaID is encoded into the avro message, consumer should use this to fetch the schema.
-	// it's the global schema id for all schema in the registry.
-	schemaID int
+	// header is the wire format header generated by the schema registry,
+	// it carries the schema id, consumer should use this to fetch the schema.
+	header []byte
 }
 
 // AppendRowChangedEvent appends a row change event to the encoder
@@ -266,9 +266,11 @@ func (a *BatchEncoder) avroEncode(
 		return schema, nil
 	}
 
+	recordName := namespace + "." + sanitizeName(e.Table.Table)
 	avroCodec, schemaID, err := schemaManager.GetCachedOrRegister(
 		ctx,
 		topic,
+		recordName,
 		e.TableInfo.Version,
 		schemaGen,
 	)
@@ -302,8 +304,8 @@ func (a *BatchEncoder) avroEncode(
 	}
 
 	return &avroEncodeResult{
-		data:     bin,
-		schemaID: schemaID,
+		data:   bin,
+		header: schemaManager.registry.Header(schemaID),
 	}, nil
 }
 
@@ -959,12 +961,12 @@ const (
 	checkpointByte = uint8(2)
 )
 
-// confluent avro wire format, confluent avro is not same as apache avro
+// the wire format depends on the schema registry, e.g. confluent avro is not same as apache avro
 // https://rmoff.net/2020/07/03/why-json-isnt-the-same-as-json-schema-in-kafka-connect-converters \
 // -and-ksqldb-viewing-kafka-messages-bytes-as-hex/
 func (r *avroEncodeResult) toEnvelope() ([]byte, error) {
 	buf := new(bytes.Buffer)
-	data := []interface{}{magicByte, int32(r.schemaID), r.data}
+	data := []interface{}{r.header, r.data}
 	for _, v := range data {
 		err := binary.Write(buf, binary.BigEndian, v)
 		if err != nil {
@@ -992,7 +994,7 @@ func NewBatchEncoderBuilder(ctx context.Context,
 	config *common.Config,
 ) (codec.RowEventEncoderBuilder, error) {
 	keySchemaManager, valueSchemaManager, err := NewKeyAndValueSchemaManagers(
-		ctx, config.AvroSchemaRegistry, nil)
+		ctx, config, nil)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1023,7 +1025,10 @@ func SetupEncoderAndSchemaRegistry4Testing(
 	config *common.Config,
 ) (*BatchEncoder, error) {
 	startHTTPInterceptForTestingRegistry()
-	keySchemaM, valueSchemaM, err := NewKeyAndValueSchemaManagers(ctx, "http://127.0.0.1:8081", nil)
+	registryConfig := *config
+	registryConfig.AvroSchemaRegistry = "http://127.0.0.1:8081"
+	registryConfig.AvroSchemaRegistryType = common.SchemaRegistryTypeConfluent
+	keySchemaM, valueSchemaM, err := NewKeyAndValueSchemaManagers(ctx, &registryConfig, nil)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
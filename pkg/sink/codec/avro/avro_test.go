@@ -985,8 +985,8 @@ func TestAvroEnvelope(t *testing.T) {
 	require.NoError(t, err)
 
 	res := avroEncodeResult{
-		data:     bin,
-		schemaID: 7,
+		data:   bin,
+		header: (&confluentSchemaRegistry{}).Header(SchemaID{ID: 7}),
 	}
 
 	evlp, err := res.toEnvelope()
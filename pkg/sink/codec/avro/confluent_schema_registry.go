@@ -0,0 +1,287 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+)
+
+const confluentAcceptHeader = "application/vnd.schemaregistry.v1+json, " +
+	"application/vnd.schemaregistry+json, application/json"
+
+type registerRequest struct {
+	Schema string `json:"schema"`
+	// Commented out for compatibility with Confluent 5.4.x
+	// SchemaType string `json:"schemaType"`
+}
+
+type registerResponse struct {
+	SchemaID int `json:"id"`
+}
+
+type lookupResponse struct {
+	Name     string `json:"name"`
+	SchemaID int    `json:"id"`
+	Schema   string `json:"schema"`
+}
+
+// confluentSchemaRegistry is the client of the Confluent schema registry.
+// https://docs.confluent.io/platform/current/schema-registry/develop/api.html
+type confluentSchemaRegistry struct {
+	registryURL string
+	credential  *security.Credential // placeholder, currently always nil
+}
+
+func newConfluentSchemaRegistry(
+	ctx context.Context,
+	registryURL string,
+	credential *security.Credential,
+) (*confluentSchemaRegistry, error) {
+	registryURL = strings.TrimRight(registryURL, "/")
+	httpCli, err := httputil.NewClient(credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := httpCli.Get(ctx, registryURL)
+	if err != nil {
+		log.Error("Test connection to Schema Registry failed", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	defer resp.Body.Close()
+
+	text, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error("Reading response from Schema Registry failed", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if string(text[:]) != "{}" {
+		log.Error("Unexpected response from Schema Registry", zap.ByteString("response", text))
+		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Unexpected response from Schema Registry",
+		)
+	}
+
+	log.Info(
+		"Successfully tested connectivity to Schema Registry",
+		zap.String("registryURL", registryURL),
+	)
+
+	return &confluentSchemaRegistry{
+		registryURL: registryURL,
+		credential:  credential,
+	}, nil
+}
+
+// Register implements the SchemaRegistry interface.
+func (r *confluentSchemaRegistry) Register(
+	ctx context.Context,
+	subject string,
+	schema string,
+) (SchemaID, error) {
+	// The Schema Registry expects the JSON to be without newline characters
+	buffer := new(bytes.Buffer)
+	err := json.Compact(buffer, []byte(schema))
+	if err != nil {
+		log.Error("Could not compact schema", zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	reqBody := registerRequest{
+		Schema: buffer.String(),
+	}
+	payload, err := json.Marshal(&reqBody)
+	if err != nil {
+		log.Error("Could not marshal request to the Registry", zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	uri := r.registryURL + "/subjects/" + url.QueryEscape(subject) + "/versions"
+	log.Info("Registering schema", zap.String("uri", uri), zap.ByteString("payload", payload))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewReader(payload))
+	if err != nil {
+		log.Error("Failed to NewRequestWithContext", zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add("Accept", confluentAcceptHeader)
+	req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return SchemaID{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error("Failed to read response from Registry", zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if resp.StatusCode != 200 {
+		// https://docs.confluent.io/platform/current/schema-registry/develop/api.html \
+		// #post--subjects-(string-%20subject)-versions
+		// 409 for incompatible schema
+		log.Error(
+			"Failed to register schema to the Registry, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("uri", uri),
+			zap.ByteString("requestBody", payload),
+			zap.ByteString("responseBody", body),
+		)
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStackByArgs()
+	}
+
+	var jsonResp registerResponse
+	err = json.Unmarshal(body, &jsonResp)
+
+	if err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if jsonResp.SchemaID == 0 {
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Illegal schema ID returned from Registry %d",
+			jsonResp.SchemaID,
+		)
+	}
+
+	log.Info("Registered schema successfully",
+		zap.Int("schemaID", jsonResp.SchemaID),
+		zap.String("uri", uri),
+		zap.ByteString("body", body))
+
+	return SchemaID{ID: int64(jsonResp.SchemaID)}, nil
+}
+
+// Lookup implements the SchemaRegistry interface.
+func (r *confluentSchemaRegistry) Lookup(
+	ctx context.Context,
+	id SchemaID,
+) (string, error) {
+	uri := r.registryURL + "/schemas/ids/" + strconv.FormatInt(id.ID, 10)
+	log.Debug("Querying for latest schema", zap.String("uri", uri))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		log.Error("Error constructing request for Registry lookup", zap.Error(err))
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add("Accept", confluentAcceptHeader)
+
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if resp.StatusCode != 200 && resp.StatusCode != 404 {
+		log.Error("Failed to query schema from the Registry, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("uri", uri),
+			zap.ByteString("responseBody", body))
+		return "", cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Failed to query schema from the Registry, HTTP error",
+		)
+	}
+
+	if resp.StatusCode == 404 {
+		log.Warn("Specified schema not found in Registry",
+			zap.Int64("schemaID", id.ID))
+		return "", cerror.ErrAvroSchemaAPIError.GenWithStackByArgs(
+			"Schema not found in Registry",
+		)
+	}
+
+	var jsonResp lookupResponse
+	err = json.Unmarshal(body, &jsonResp)
+	if err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	return jsonResp.Schema, nil
+}
+
+// Clear implements the SchemaRegistry interface.
+func (r *confluentSchemaRegistry) Clear(ctx context.Context, subject string) error {
+	uri := r.registryURL + "/subjects/" + url.QueryEscape(subject)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", uri, nil)
+	if err != nil {
+		log.Error("Could not construct request for clearRegistry", zap.String("uri", uri))
+		return cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add("Accept", confluentAcceptHeader)
+	resp, err := httpRetry(ctx, r.credential, req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == 200 {
+		log.Info("Clearing Registry successful")
+		return nil
+	}
+
+	if resp.StatusCode == 404 {
+		log.Info("Registry already cleaned")
+		return nil
+	}
+
+	log.Error("Error when clearing Registry", zap.Int("status", resp.StatusCode))
+	return cerror.ErrAvroSchemaAPIError.GenWithStack(
+		"Error when clearing Registry, status = %d",
+		resp.StatusCode,
+	)
+}
+
+// Header implements the SchemaRegistry interface.
+// confluent avro wire format, confluent avro is not same as apache avro
+// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+func (r *confluentSchemaRegistry) Header(id SchemaID) []byte {
+	header := make([]byte, 5)
+	header[0] = magicByte
+	binary.BigEndian.PutUint32(header[1:], uint32(id.ID))
+	return header
+}
+
+// ParseHeader implements the SchemaRegistry interface.
+func (r *confluentSchemaRegistry) ParseHeader(data []byte) (SchemaID, []byte, error) {
+	if len(data) < 5 || data[0] != magicByte {
+		return SchemaID{}, nil, cerror.ErrAvroInvalidMessage.FastGenByArgs()
+	}
+	return SchemaID{ID: int64(binary.BigEndian.Uint32(data[1:5]))}, data[5:], nil
+}
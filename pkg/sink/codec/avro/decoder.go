@@ -86,13 +86,17 @@ func (d *decoder) HasNext() (model.MessageType, bool, error) {
 		return model.MessageTypeUnknown, false, errors.ErrAvroInvalidMessage.FastGenByArgs()
 	}
 	switch d.value[0] {
-	case magicByte:
-		return model.MessageTypeRow, true, nil
 	case ddlByte:
 		return model.MessageTypeDDL, true, nil
 	case checkpointByte:
 		return model.MessageTypeResolved, true, nil
 	}
+	// the header of the row message is determined by the schema registry.
+	if d.valueSchemaM != nil {
+		if _, _, err := d.valueSchemaM.registry.ParseHeader(d.value); err == nil {
+			return model.MessageTypeRow, true, nil
+		}
+	}
 	return model.MessageTypeUnknown, false, errors.ErrAvroInvalidMessage.FastGenByArgs()
 }
 
@@ -376,28 +380,17 @@ func (d *decoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return result, nil
 }
 
-// return the schema ID and the encoded binary data
-// schemaID can be used to fetch the corresponding schema from schema registry,
-// which should be used to decode the binary data.
-func extractSchemaIDAndBinaryData(data []byte) (int, []byte, error) {
-	if len(data) < 5 {
-		return 0, nil, errors.ErrAvroInvalidMessage.FastGenByArgs()
-	}
-	if data[0] != magicByte {
-		return 0, nil, errors.ErrAvroInvalidMessage.FastGenByArgs()
-	}
-	return int(binary.BigEndian.Uint32(data[1:5])), data[5:], nil
-}
-
 func decodeRawBytes(
-	ctx context.Context, schemaM *SchemaManager, data []byte, topic string,
+	ctx context.Context, schemaM *SchemaManager, data []byte,
 ) (map[string]interface{}, map[string]interface{}, error) {
-	schemaID, binary, err := extractSchemaIDAndBinaryData(data)
+	// the schema ID can be used to fetch the corresponding schema from
+	// the schema registry, which should be used to decode the binary data.
+	schemaID, binary, err := schemaM.registry.ParseHeader(data)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	codec, err := schemaM.Lookup(ctx, topic, schemaID)
+	codec, err := schemaM.Lookup(ctx, schemaID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -423,13 +416,13 @@ func decodeRawBytes(
 func (d *decoder) decodeKey(ctx context.Context) (map[string]interface{}, map[string]interface{}, error) {
 	data := d.key
 	d.key = nil
-	return decodeRawBytes(ctx, d.keySchemaM, data, d.topic)
+	return decodeRawBytes(ctx, d.keySchemaM, data)
 }
 
 func (d *decoder) decodeValue(ctx context.Context) (map[string]interface{}, map[string]interface{}, error) {
 	data := d.value
 	d.value = nil
-	return decodeRawBytes(ctx, d.valueSchemaM, data, d.topic)
+	return decodeRawBytes(ctx, d.valueSchemaM, data)
 }
 
 // calculate the checksum value, and compare it with the expected one, return error if not identical.
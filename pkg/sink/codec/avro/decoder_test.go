@@ -34,6 +34,7 @@ func TestDecodeEvent(t *testing.T) {
 		EnableTiDBExtension:            true,
 		AvroDecimalHandlingMode:        "precise",
 		AvroBigintUnsignedHandlingMode: "long",
+		AvroSchemaRegistry:             "http://127.0.0.1:8081",
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -118,7 +119,7 @@ func TestDecodeEvent(t *testing.T) {
 	message := messages[0]
 
 	keySchemaM, valueSchemaM, err := NewKeyAndValueSchemaManagers(
-		ctx, "http://127.0.0.1:8081", nil)
+		ctx, config, nil)
 	require.NoError(t, err)
 
 	tz, err := util.GetLocalTimezone()
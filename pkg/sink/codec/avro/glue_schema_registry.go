@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// the wire format of the AWS Glue serdes, the header version byte,
+	// the compression byte, followed by the 16 bytes schema version uuid.
+	glueHeaderVersion    = byte(3)
+	glueCompressionNone  = byte(0)
+	glueHeaderLength     = 18
+	glueDataFormat       = "AVRO"
+	glueCompatibility    = "BACKWARD"
+	glueWaitAvailableGap = 200 * time.Millisecond
+	glueMaxWaitAvailable = 50
+)
+
+// glueSchemaRegistry is the client of the AWS Glue schema registry.
+// The credentials are resolved by the default chain of the AWS SDK.
+type glueSchemaRegistry struct {
+	client       *glue.Glue
+	registryName string
+}
+
+func newGlueSchemaRegistry(
+	ctx context.Context,
+	endpoint string,
+	region string,
+	registryName string,
+) (*glueSchemaRegistry, error) {
+	awsConfig := aws.NewConfig().WithRegion(region)
+	if endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(endpoint)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	client := glue.New(sess)
+
+	_, err = client.GetRegistryWithContext(ctx, &glue.GetRegistryInput{
+		RegistryId: &glue.RegistryId{RegistryName: aws.String(registryName)},
+	})
+	if err != nil {
+		log.Error("Test connection to AWS Glue Schema Registry failed",
+			zap.String("region", region),
+			zap.String("registryName", registryName),
+			zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	log.Info("Successfully tested connectivity to AWS Glue Schema Registry",
+		zap.String("region", region),
+		zap.String("registryName", registryName))
+
+	return &glueSchemaRegistry{
+		client:       client,
+		registryName: registryName,
+	}, nil
+}
+
+// Register implements the SchemaRegistry interface.
+// The schema named by the subject is created on its first registration.
+func (r *glueSchemaRegistry) Register(
+	ctx context.Context,
+	subject string,
+	schema string,
+) (SchemaID, error) {
+	var versionID, status string
+	output, err := r.client.RegisterSchemaVersionWithContext(ctx,
+		&glue.RegisterSchemaVersionInput{
+			SchemaId: &glue.SchemaId{
+				RegistryName: aws.String(r.registryName),
+				SchemaName:   aws.String(subject),
+			},
+			SchemaDefinition: aws.String(schema),
+		})
+	if err == nil {
+		versionID = aws.StringValue(output.SchemaVersionId)
+		status = aws.StringValue(output.Status)
+	} else if isGlueEntityNotFound(err) {
+		created, err := r.client.CreateSchemaWithContext(ctx, &glue.CreateSchemaInput{
+			RegistryId:       &glue.RegistryId{RegistryName: aws.String(r.registryName)},
+			SchemaName:       aws.String(subject),
+			DataFormat:       aws.String(glueDataFormat),
+			Compatibility:    aws.String(glueCompatibility),
+			SchemaDefinition: aws.String(schema),
+		})
+		if err != nil {
+			log.Error("Failed to create schema in AWS Glue Schema Registry",
+				zap.String("subject", subject), zap.Error(err))
+			return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+		}
+		versionID = aws.StringValue(created.SchemaVersionId)
+		status = aws.StringValue(created.SchemaVersionStatus)
+	} else {
+		log.Error("Failed to register schema to AWS Glue Schema Registry",
+			zap.String("subject", subject), zap.Error(err))
+		return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if _, err := uuid.Parse(versionID); err != nil {
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Illegal schema version id returned from AWS Glue Schema Registry %s", versionID)
+	}
+	// the schema version is validated asynchronously by the registry,
+	// it can be used only after it becomes available.
+	for i := 0; status == glue.SchemaVersionStatusPending; i++ {
+		if i >= glueMaxWaitAvailable {
+			return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+				"schema version %s is still pending in AWS Glue Schema Registry", versionID)
+		}
+		select {
+		case <-ctx.Done():
+			return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, ctx.Err())
+		case <-time.After(glueWaitAvailableGap):
+		}
+		version, err := r.client.GetSchemaVersionWithContext(ctx, &glue.GetSchemaVersionInput{
+			SchemaVersionId: aws.String(versionID),
+		})
+		if err != nil {
+			return SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+		}
+		status = aws.StringValue(version.Status)
+	}
+	if status != glue.SchemaVersionStatusAvailable {
+		return SchemaID{}, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"schema version %s is %s in AWS Glue Schema Registry", versionID, status)
+	}
+
+	log.Info("Registered schema to AWS Glue Schema Registry successfully",
+		zap.String("subject", subject),
+		zap.String("schemaVersionID", versionID))
+	return SchemaID{UUID: versionID}, nil
+}
+
+// Lookup implements the SchemaRegistry interface.
+func (r *glueSchemaRegistry) Lookup(ctx context.Context, id SchemaID) (string, error) {
+	output, err := r.client.GetSchemaVersionWithContext(ctx, &glue.GetSchemaVersionInput{
+		SchemaVersionId: aws.String(id.UUID),
+	})
+	if err != nil {
+		log.Error("Failed to query schema from AWS Glue Schema Registry",
+			zap.String("schemaVersionID", id.UUID), zap.Error(err))
+		return "", cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	return aws.StringValue(output.SchemaDefinition), nil
+}
+
+// Clear implements the SchemaRegistry interface.
+func (r *glueSchemaRegistry) Clear(ctx context.Context, subject string) error {
+	_, err := r.client.DeleteSchemaWithContext(ctx, &glue.DeleteSchemaInput{
+		SchemaId: &glue.SchemaId{
+			RegistryName: aws.String(r.registryName),
+			SchemaName:   aws.String(subject),
+		},
+	})
+	if err != nil && !isGlueEntityNotFound(err) {
+		log.Error("Error when clearing AWS Glue Schema Registry",
+			zap.String("subject", subject), zap.Error(err))
+		return cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	return nil
+}
+
+// Header implements the SchemaRegistry interface.
+func (r *glueSchemaRegistry) Header(id SchemaID) []byte {
+	header := make([]byte, 2, glueHeaderLength)
+	header[0] = glueHeaderVersion
+	header[1] = glueCompressionNone
+	versionID, err := uuid.Parse(id.UUID)
+	if err != nil {
+		// the uuid is always validated when the schema is registered.
+		log.Panic("invalid schema version id", zap.String("schemaVersionID", id.UUID))
+	}
+	return append(header, versionID[:]...)
+}
+
+// ParseHeader implements the SchemaRegistry interface.
+func (r *glueSchemaRegistry) ParseHeader(data []byte) (SchemaID, []byte, error) {
+	if len(data) < glueHeaderLength ||
+		data[0] != glueHeaderVersion || data[1] != glueCompressionNone {
+		return SchemaID{}, nil, cerror.ErrAvroInvalidMessage.FastGenByArgs()
+	}
+	versionID, err := uuid.FromBytes(data[2:glueHeaderLength])
+	if err != nil {
+		return SchemaID{}, nil, cerror.WrapError(cerror.ErrAvroInvalidMessage, err)
+	}
+	return SchemaID{UUID: versionID.String()}, data[glueHeaderLength:], nil
+}
+
+func isGlueEntityNotFound(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == glue.ErrCodeEntityNotFoundException
+}
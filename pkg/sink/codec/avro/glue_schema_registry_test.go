@@ -0,0 +1,93 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newGlueConfigForTesting(t *testing.T, registryName string) *common.Config {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
+	// a custom CA bundle makes the SDK replace the mocked http transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	c := common.NewConfig(config.ProtocolAvro)
+	c.AvroSchemaRegistry = "http://127.0.0.1:8081/glue"
+	c.AvroSchemaRegistryType = common.SchemaRegistryTypeGlue
+	c.AvroGlueRegion = "us-west-2"
+	c.AvroGlueRegistryName = registryName
+	return c
+}
+
+func TestGlueSchemaRegistry(t *testing.T) {
+	startHTTPInterceptForTestingRegistry()
+	defer stopHTTPInterceptForTestingRegistry()
+
+	registry, err := NewSchemaRegistry(
+		getTestingContext(), newGlueConfigForTesting(t, "test-registry"), nil)
+	require.NoError(t, err)
+	require.IsType(t, &glueSchemaRegistry{}, registry)
+
+	subject := "cdctest-value"
+	require.NoError(t, registry.Clear(getTestingContext(), subject))
+
+	codec, err := goavro.NewCodec(`{
+       "type": "record",
+       "name": "test",
+       "fields": [{"type": "string", "name": "field1"}]
+     }`)
+	require.NoError(t, err)
+
+	// the schema is created on the first registration, and the
+	// version is not available until it's validated.
+	id, err := registry.Register(getTestingContext(), subject, codec.Schema())
+	require.NoError(t, err)
+	require.NotEmpty(t, id.UUID)
+	id1, err := registry.Register(getTestingContext(), subject, codec.Schema())
+	require.NoError(t, err)
+	require.Equal(t, id, id1)
+
+	schema, err := registry.Lookup(getTestingContext(), id)
+	require.NoError(t, err)
+	require.Equal(t, codec.Schema(), schema)
+
+	header := registry.Header(id)
+	require.Len(t, header, glueHeaderLength)
+	require.Equal(t, glueHeaderVersion, header[0])
+	parsedID, data, err := registry.ParseHeader(append(header, 1, 2))
+	require.NoError(t, err)
+	require.Equal(t, id, parsedID)
+	require.Equal(t, []byte{1, 2}, data)
+	_, _, err = registry.ParseHeader((&confluentSchemaRegistry{}).Header(SchemaID{ID: 1}))
+	require.Error(t, err)
+
+	require.NoError(t, registry.Clear(getTestingContext(), subject))
+	_, err = registry.Lookup(getTestingContext(), id)
+	require.Error(t, err)
+}
+
+func TestGlueSchemaRegistryNotFound(t *testing.T) {
+	startHTTPInterceptForTestingRegistry()
+	defer stopHTTPInterceptForTestingRegistry()
+
+	_, err := NewSchemaRegistry(
+		getTestingContext(), newGlueConfigForTesting(t, "not-exist"), nil)
+	require.ErrorContains(t, err, "EntityNotFoundException")
+}
@@ -17,8 +17,10 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/jarcoal/httpmock"
 )
 
@@ -26,6 +28,8 @@ type mockRegistrySchema struct {
 	content string
 	version int
 	ID      int
+	// UUID is the schema version id used by the mocked AWS Glue Schema Registry.
+	UUID string
 }
 
 type mockRegistry struct {
@@ -129,6 +133,9 @@ func startHTTPInterceptForTestingRegistry() {
 			return httpmock.NewJsonResponse(200, []int{item.version})
 		})
 
+	registerApicurioResponders(&registry)
+	registerGlueResponders(&registry)
+
 	failCounter := 0
 	httpmock.RegisterResponder("POST", `=~^http://127.0.0.1:8081/may-fail`,
 		func(req *http.Request) (*http.Response, error) {
@@ -141,6 +148,196 @@ func startHTTPInterceptForTestingRegistry() {
 		})
 }
 
+// register upserts the schema of the subject, it returns the latest schema.
+func (r *mockRegistry) register(subject, schema string) *mockRegistrySchema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	item, exists := r.subjects[subject]
+	if !exists {
+		item = &mockRegistrySchema{version: 0}
+		r.subjects[subject] = item
+	} else if item.content == schema {
+		return item
+	}
+	item.content = schema
+	item.version++
+	item.ID = r.newID
+	item.UUID = uuid.New().String()
+	r.newID++
+	return item
+}
+
+func (r *mockRegistry) find(match func(item *mockRegistrySchema) bool) *mockRegistrySchema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, item := range r.subjects {
+		if match(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (r *mockRegistry) exists(subject string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.subjects[subject]
+	return exists
+}
+
+func (r *mockRegistry) remove(subject string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.subjects[subject]
+	delete(r.subjects, subject)
+	return exists
+}
+
+func registerApicurioResponders(registry *mockRegistry) {
+	const prefix = "http://127.0.0.1:8081" + apicurioAPIPath
+
+	httpmock.RegisterResponder("GET", prefix+"/system/info",
+		httpmock.NewStringResponder(200, `{"name":"Apicurio Registry (In Memory)"}`))
+
+	httpmock.RegisterResponder("POST", `=~^`+prefix+`/groups/default/artifacts`,
+		func(req *http.Request) (*http.Response, error) {
+			schema, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			subject := req.Header.Get("X-Registry-ArtifactId")
+			item := registry.register(subject, string(schema))
+			return httpmock.NewJsonResponse(200, &apicurioArtifactMetaData{
+				GlobalID: int64(item.ID),
+				ID:       subject,
+				Version:  strconv.Itoa(item.version),
+			})
+		})
+
+	httpmock.RegisterResponder("GET", `=~^`+prefix+`/ids/globalIds/(\d+)`,
+		func(req *http.Request) (*http.Response, error) {
+			id, err := httpmock.GetSubmatchAsInt(req, 1)
+			if err != nil {
+				return httpmock.NewStringResponse(500, "Internal Server Error"), err
+			}
+			item := registry.find(func(item *mockRegistrySchema) bool {
+				return item.ID == int(id)
+			})
+			if item == nil {
+				return httpmock.NewStringResponse(404, "Not Found"), nil
+			}
+			return httpmock.NewStringResponse(200, item.content), nil
+		})
+
+	httpmock.RegisterResponder("DELETE", `=~^`+prefix+`/groups/default/artifacts/(.+)`,
+		func(req *http.Request) (*http.Response, error) {
+			subject, err := httpmock.GetSubmatch(req, 1)
+			if err != nil {
+				return nil, err
+			}
+			if !registry.remove(subject) {
+				return httpmock.NewStringResponse(404, ""), nil
+			}
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+}
+
+// registerGlueResponders mocks the AWS Glue JSON 1.1 protocol, the operation
+// is specified by the X-Amz-Target header.
+// Newly created schemas are pending, they become available once queried.
+func registerGlueResponders(registry *mockRegistry) {
+	pending := make(map[string]bool)
+	notFound := func() (*http.Response, error) {
+		return httpmock.NewJsonResponse(400, map[string]string{
+			"__type":  "EntityNotFoundException",
+			"Message": "Schema is not found.",
+		})
+	}
+
+	httpmock.RegisterResponder("POST", `=~^http://127.0.0.1:8081/glue`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			input := struct {
+				RegistryID struct {
+					RegistryName string
+				} `json:"RegistryId"`
+				SchemaID struct {
+					RegistryName string
+					SchemaName   string
+				} `json:"SchemaId"`
+				SchemaName       string
+				SchemaDefinition string
+				SchemaVersionID  string `json:"SchemaVersionId"`
+			}{}
+			if err := json.Unmarshal(body, &input); err != nil {
+				return nil, err
+			}
+
+			switch req.Header.Get("X-Amz-Target") {
+			case "AWSGlue.GetRegistry":
+				if input.RegistryID.RegistryName != "test-registry" {
+					return notFound()
+				}
+				return httpmock.NewJsonResponse(200, map[string]string{
+					"RegistryName": input.RegistryID.RegistryName,
+					"Status":       "AVAILABLE",
+				})
+			case "AWSGlue.RegisterSchemaVersion":
+				if !registry.exists(input.SchemaID.SchemaName) {
+					return notFound()
+				}
+				item := registry.register(input.SchemaID.SchemaName, input.SchemaDefinition)
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"SchemaVersionId": item.UUID,
+					"Status":          "AVAILABLE",
+					"VersionNumber":   item.version,
+				})
+			case "AWSGlue.CreateSchema":
+				item := registry.register(input.SchemaName, input.SchemaDefinition)
+				registry.mu.Lock()
+				pending[item.UUID] = true
+				registry.mu.Unlock()
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"SchemaName":          input.SchemaName,
+					"SchemaVersionId":     item.UUID,
+					"SchemaVersionStatus": "PENDING",
+				})
+			case "AWSGlue.GetSchemaVersion":
+				item := registry.find(func(item *mockRegistrySchema) bool {
+					return item.UUID == input.SchemaVersionID
+				})
+				if item == nil {
+					return notFound()
+				}
+				registry.mu.Lock()
+				status := "AVAILABLE"
+				if pending[item.UUID] {
+					status = "PENDING"
+					delete(pending, item.UUID)
+				}
+				registry.mu.Unlock()
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"SchemaDefinition": item.content,
+					"SchemaVersionId":  item.UUID,
+					"Status":           status,
+					"VersionNumber":    item.version,
+				})
+			case "AWSGlue.DeleteSchema":
+				if !registry.remove(input.SchemaID.SchemaName) {
+					return notFound()
+				}
+				return httpmock.NewJsonResponse(200, map[string]string{
+					"SchemaName": input.SchemaID.SchemaName,
+					"Status":     "DELETING",
+				})
+			}
+			return httpmock.NewStringResponse(400, "unknown operation"), nil
+		})
+}
+
 func stopHTTPInterceptForTestingRegistry() {
 	httpmock.DeactivateAndReset()
 }
@@ -16,12 +16,8 @@ package avro
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"io"
 	"net/http"
-	"net/url"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -32,20 +28,68 @@ import (
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/httputil"
 	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 )
 
+// SchemaID is the identifier of a schema in the schema registry,
+// it is encoded into the message header to allow the consumer to
+// fetch the schema used to decode the message.
+type SchemaID struct {
+	// ID is the schema id of the Confluent schema registry,
+	// or the global id of the Apicurio schema registry.
+	ID int64
+	// UUID is the schema version id of the AWS Glue schema registry.
+	UUID string
+}
+
+// SchemaRegistry is the client of a schema registry server.
+type SchemaRegistry interface {
+	// Register registers the schema under the subject, registering an existing
+	// schema shall return the same id.
+	Register(ctx context.Context, subject string, schema string) (SchemaID, error)
+	// Lookup fetches the schema of the given id.
+	Lookup(ctx context.Context, id SchemaID) (string, error)
+	// Clear deletes the subject and all the schemas under it. Should be idempotent.
+	Clear(ctx context.Context, subject string) error
+	// Header returns the header of the wire format which carries the schema id,
+	// it's prepended to the avro binary data.
+	Header(id SchemaID) []byte
+	// ParseHeader splits the message into the schema id and the avro binary data.
+	ParseHeader(data []byte) (SchemaID, []byte, error)
+}
+
+// NewSchemaRegistry creates a schema registry client according to the config,
+// and tests connectivity to the schema registry.
+func NewSchemaRegistry(
+	ctx context.Context,
+	config *common.Config,
+	credential *security.Credential,
+) (SchemaRegistry, error) {
+	switch config.AvroSchemaRegistryType {
+	case common.SchemaRegistryTypeApicurio:
+		return newApicurioSchemaRegistry(ctx, config.AvroSchemaRegistry, credential)
+	case common.SchemaRegistryTypeGlue:
+		return newGlueSchemaRegistry(ctx, config.AvroSchemaRegistry,
+			config.AvroGlueRegion, config.AvroGlueRegistryName)
+	default:
+		return newConfluentSchemaRegistry(ctx, config.AvroSchemaRegistry, credential)
+	}
+}
+
 // SchemaManager is used to register Avro Schemas to the Registry server,
 // look up local cache according to the table's name, and fetch from the Registry
 // in cache the local cache entry is missing.
 type SchemaManager struct {
-	registryURL   string
-	subjectSuffix string
-
-	credential *security.Credential // placeholder, currently always nil
+	registry            SchemaRegistry
+	subjectNameStrategy string
+	subjectSuffix       string
 
 	cacheRWLock sync.RWMutex
-	cache       map[string]*schemaCacheEntry
+	// cache is used by the encoder, the key is the subject.
+	cache map[string]*schemaCacheEntry
+	// lookupCache is used by the decoder, the key is the schema id.
+	lookupCache map[SchemaID]*goavro.Codec
 }
 
 type schemaCacheEntry struct {
@@ -54,268 +98,84 @@ type schemaCacheEntry struct {
 	tableVersion uint64
 	// schemaID is the unique identifier of a schema in schema registry.
 	// for each message should carry this id to allow the decoder fetch the corresponding schema
-	// decoder use it as the cache key.
-	schemaID int
-	// codec is associated with the schemaID, used to decode the message
+	schemaID SchemaID
+	// codec is associated with the schemaID, used to encode the message
 	codec *goavro.Codec
 }
 
-type registerRequest struct {
-	Schema string `json:"schema"`
-	// Commented out for compatibility with Confluent 5.4.x
-	// SchemaType string `json:"schemaType"`
-}
-
-type registerResponse struct {
-	SchemaID int `json:"id"`
-}
-
-type lookupResponse struct {
-	Name     string `json:"name"`
-	SchemaID int    `json:"id"`
-	Schema   string `json:"schema"`
-}
-
 // NewKeyAndValueSchemaManagers create key and value schema managers respectively,
 // and test connectivity to the schema registry
 func NewKeyAndValueSchemaManagers(
 	ctx context.Context,
-	registryURL string,
+	config *common.Config,
 	credential *security.Credential,
 ) (*SchemaManager, *SchemaManager, error) {
-	keyManager, err := newAvroSchemaManager(ctx, registryURL, keySchemaSuffix, credential)
+	registry, err := NewSchemaRegistry(ctx, config, credential)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, errors.Trace(err)
 	}
-
-	valueManager, err := newAvroSchemaManager(ctx, registryURL, valueSchemaSuffix, credential)
-	if err != nil {
-		return nil, nil, err
-	}
-
+	keyManager := newAvroSchemaManager(registry, config.AvroSubjectNameStrategy, keySchemaSuffix)
+	valueManager := newAvroSchemaManager(registry, config.AvroSubjectNameStrategy, valueSchemaSuffix)
 	return keyManager, valueManager, nil
 }
 
 func newAvroSchemaManager(
-	ctx context.Context,
-	registryURL string,
+	registry SchemaRegistry,
+	subjectNameStrategy string,
 	subjectSuffix string,
-	credential *security.Credential,
-) (*SchemaManager, error) {
-	registryURL = strings.TrimRight(registryURL, "/")
-	httpCli, err := httputil.NewClient(credential)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	resp, err := httpCli.Get(ctx, registryURL)
-	if err != nil {
-		log.Error("Test connection to Schema Registry failed", zap.Error(err))
-		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	defer resp.Body.Close()
-
-	text, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Error("Reading response from Schema Registry failed", zap.Error(err))
-		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-
-	if string(text[:]) != "{}" {
-		log.Error("Unexpected response from Schema Registry", zap.ByteString("response", text))
-		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
-			"Unexpected response from Schema Registry",
-		)
-	}
-
-	log.Info(
-		"Successfully tested connectivity to Schema Registry",
-		zap.String("registryURL", registryURL),
-	)
-
+) *SchemaManager {
 	return &SchemaManager{
-		registryURL:   registryURL,
-		cache:         make(map[string]*schemaCacheEntry, 1),
-		subjectSuffix: subjectSuffix,
-	}, nil
+		registry:            registry,
+		subjectNameStrategy: subjectNameStrategy,
+		subjectSuffix:       subjectSuffix,
+		cache:               make(map[string]*schemaCacheEntry, 1),
+		lookupCache:         make(map[SchemaID]*goavro.Codec, 1),
+	}
 }
 
 // Register a schema in schema registry, no cache
 func (m *SchemaManager) Register(
 	ctx context.Context,
-	topicName string,
+	subject string,
 	schema string,
-) (int, error) {
-	// The Schema Registry expects the JSON to be without newline characters
-	buffer := new(bytes.Buffer)
-	err := json.Compact(buffer, []byte(schema))
-	if err != nil {
-		log.Error("Could not compact schema", zap.Error(err))
-		return 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	reqBody := registerRequest{
-		Schema: buffer.String(),
-	}
-	payload, err := json.Marshal(&reqBody)
-	if err != nil {
-		log.Error("Could not marshal request to the Registry", zap.Error(err))
-		return 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	uri := m.registryURL + "/subjects/" + url.QueryEscape(
-		m.topicNameToSchemaSubject(topicName),
-	) + "/versions"
-	log.Info("Registering schema", zap.String("uri", uri), zap.ByteString("payload", payload))
-
-	req, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewReader(payload))
-	if err != nil {
-		log.Error("Failed to NewRequestWithContext", zap.Error(err))
-		return 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	req.Header.Add(
-		"Accept",
-		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
-			"application/json",
-	)
-	req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
-	resp, err := httpRetry(ctx, m.credential, req)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Error("Failed to read response from Registry", zap.Error(err))
-		return 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-
-	if resp.StatusCode != 200 {
-		// https://docs.confluent.io/platform/current/schema-registry/develop/api.html \
-		// #post--subjects-(string-%20subject)-versions
-		// 409 for incompatible schema
-		log.Error(
-			"Failed to register schema to the Registry, HTTP error",
-			zap.Int("status", resp.StatusCode),
-			zap.String("uri", uri),
-			zap.ByteString("requestBody", payload),
-			zap.ByteString("responseBody", body),
-		)
-		return 0, cerror.ErrAvroSchemaAPIError.GenWithStackByArgs()
-	}
-
-	var jsonResp registerResponse
-	err = json.Unmarshal(body, &jsonResp)
-
-	if err != nil {
-		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-
-	if jsonResp.SchemaID == 0 {
-		return 0, cerror.ErrAvroSchemaAPIError.GenWithStack(
-			"Illegal schema ID returned from Registry %d",
-			jsonResp.SchemaID,
-		)
-	}
-
-	log.Info("Registered schema successfully",
-		zap.Int("schemaID", jsonResp.SchemaID),
-		zap.String("uri", uri),
-		zap.ByteString("body", body))
-
-	return jsonResp.SchemaID, nil
+) (SchemaID, error) {
+	return m.registry.Register(ctx, subject, schema)
 }
 
 // Lookup the cached schema entry first, if not found, fetch from the Registry server.
 func (m *SchemaManager) Lookup(
 	ctx context.Context,
-	topicName string,
-	schemaID int,
+	schemaID SchemaID,
 ) (*goavro.Codec, error) {
-	key := m.topicNameToSchemaSubject(topicName)
 	m.cacheRWLock.RLock()
-	entry, exists := m.cache[key]
-	if exists && entry.schemaID == schemaID {
-		log.Debug("Avro schema lookup cache hit",
-			zap.String("key", key),
-			zap.Int("schemaID", entry.schemaID))
-		m.cacheRWLock.RUnlock()
-		return entry.codec, nil
-	}
+	codec, exists := m.lookupCache[schemaID]
 	m.cacheRWLock.RUnlock()
-
-	log.Info("Avro schema lookup cache miss",
-		zap.String("key", key),
-		zap.Int("schemaID", schemaID))
-
-	uri := m.registryURL + "/schemas/ids/" + strconv.Itoa(schemaID)
-	log.Debug("Querying for latest schema", zap.String("uri", uri))
-
-	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
-	if err != nil {
-		log.Error("Error constructing request for Registry lookup", zap.Error(err))
-		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	req.Header.Add(
-		"Accept",
-		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
-			"application/json",
-	)
-
-	resp, err := httpRetry(ctx, m.credential, req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	if exists {
+		log.Debug("Avro schema lookup cache hit", zap.Any("schemaID", schemaID))
+		return codec, nil
 	}
 
-	if resp.StatusCode != 200 && resp.StatusCode != 404 {
-		log.Error("Failed to query schema from the Registry, HTTP error",
-			zap.Int("status", resp.StatusCode),
-			zap.String("uri", uri),
-			zap.ByteString("responseBody", body))
-		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
-			"Failed to query schema from the Registry, HTTP error",
-		)
-	}
+	log.Info("Avro schema lookup cache miss", zap.Any("schemaID", schemaID))
 
-	if resp.StatusCode == 404 {
-		log.Warn("Specified schema not found in Registry",
-			zap.String("key", key),
-			zap.Int("schemaID", schemaID))
-		return nil, cerror.ErrAvroSchemaAPIError.GenWithStackByArgs(
-			"Schema not found in Registry",
-		)
-	}
-
-	var jsonResp lookupResponse
-	err = json.Unmarshal(body, &jsonResp)
+	schema, err := m.registry.Lookup(ctx, schemaID)
 	if err != nil {
-		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+		return nil, errors.Trace(err)
 	}
-
-	cacheEntry := new(schemaCacheEntry)
-	cacheEntry.codec, err = goavro.NewCodec(jsonResp.Schema)
+	codec, err = goavro.NewCodec(schema)
 	if err != nil {
 		log.Error("Creating Avro codec failed", zap.Error(err))
 		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
 	}
-	cacheEntry.schemaID = schemaID
 
 	m.cacheRWLock.Lock()
-	m.cache[key] = cacheEntry
+	m.lookupCache[schemaID] = codec
 	m.cacheRWLock.Unlock()
 
 	log.Info("Avro schema lookup successful with cache miss",
-		zap.Int("schemaID", cacheEntry.schemaID),
-		zap.String("schema", cacheEntry.codec.Schema()))
+		zap.Any("schemaID", schemaID),
+		zap.String("schema", codec.Schema()))
 
-	return cacheEntry.codec, nil
+	return codec, nil
 }
 
 // SchemaGenerator represents a function that returns an Avro schema in JSON.
@@ -326,19 +186,22 @@ type SchemaGenerator func() (string, error)
 // If not, a new schema is generated, registered and cached.
 // Re-registering an existing schema shall return the same id(and version), so even if the
 // cache is out-of-sync with schema registry, we could reload it.
+// The recordName is the full name of the avro record, it's used to
+// name the subject by the record based subject name strategies.
 func (m *SchemaManager) GetCachedOrRegister(
 	ctx context.Context,
 	topicName string,
+	recordName string,
 	tableVersion uint64,
 	schemaGen SchemaGenerator,
-) (*goavro.Codec, int, error) {
-	key := m.topicNameToSchemaSubject(topicName)
+) (*goavro.Codec, SchemaID, error) {
+	key := m.subject(topicName, recordName)
 	m.cacheRWLock.RLock()
 	if entry, exists := m.cache[key]; exists && entry.tableVersion == tableVersion {
 		log.Debug("Avro schema GetCachedOrRegister cache hit",
 			zap.String("key", key),
 			zap.Uint64("tableVersion", tableVersion),
-			zap.Int("schemaID", entry.schemaID))
+			zap.Any("schemaID", entry.schemaID))
 		m.cacheRWLock.RUnlock()
 		return entry.codec, entry.schemaID, nil
 	}
@@ -350,19 +213,19 @@ func (m *SchemaManager) GetCachedOrRegister(
 
 	schema, err := schemaGen()
 	if err != nil {
-		return nil, 0, err
+		return nil, SchemaID{}, err
 	}
 
 	codec, err := goavro.NewCodec(schema)
 	if err != nil {
 		log.Error("GetCachedOrRegister: Could not make goavro codec", zap.Error(err))
-		return nil, 0, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+		return nil, SchemaID{}, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
 	}
 
-	id, err := m.Register(ctx, topicName, codec.Schema())
+	id, err := m.Register(ctx, key, codec.Schema())
 	if err != nil {
 		log.Error("GetCachedOrRegister: Could not register schema", zap.Error(err))
-		return nil, 0, errors.Trace(err)
+		return nil, SchemaID{}, errors.Trace(err)
 	}
 
 	cacheEntry := new(schemaCacheEntry)
@@ -376,53 +239,32 @@ func (m *SchemaManager) GetCachedOrRegister(
 
 	log.Info("Avro schema GetCachedOrRegister successful with cache miss",
 		zap.Uint64("tableVersion", cacheEntry.tableVersion),
-		zap.Int("schemaID", cacheEntry.schemaID),
+		zap.Any("schemaID", cacheEntry.schemaID),
 		zap.String("schema", cacheEntry.codec.Schema()))
 
 	return codec, id, nil
 }
 
-// ClearRegistry clears the Registry subject for the given table. Should be idempotent.
+// ClearRegistry clears the Registry subject. Should be idempotent.
 // Exported for testing.
 // NOT USED for now, reserved for future use.
-func (m *SchemaManager) ClearRegistry(ctx context.Context, topicName string) error {
-	uri := m.registryURL + "/subjects/" + url.QueryEscape(
-		m.topicNameToSchemaSubject(topicName),
-	)
-	req, err := http.NewRequestWithContext(ctx, "DELETE", uri, nil)
-	if err != nil {
-		log.Error("Could not construct request for clearRegistry", zap.String("uri", uri))
-		return cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	req.Header.Add(
-		"Accept",
-		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
-			"application/json",
-	)
-	resp, err := httpRetry(ctx, m.credential, req)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode == 200 {
-		log.Info("Clearing Registry successful")
-		return nil
-	}
+func (m *SchemaManager) ClearRegistry(ctx context.Context, subject string) error {
+	return m.registry.Clear(ctx, subject)
+}
 
-	if resp.StatusCode == 404 {
-		log.Info("Registry already cleaned")
-		return nil
+// subject returns the subject which the schema is registered under.
+// The key and value schemas of a table share the same record name,
+// so the suffix is always appended to tell them apart.
+func (m *SchemaManager) subject(topicName, recordName string) string {
+	switch m.subjectNameStrategy {
+	case common.SubjectNameStrategyRecord:
+		return recordName + m.subjectSuffix
+	case common.SubjectNameStrategyTopicRecord:
+		return topicName + "-" + recordName + m.subjectSuffix
+	default:
+		// TopicNameStrategy, ksqlDB only supports this
+		return topicName + m.subjectSuffix
 	}
-
-	log.Error("Error when clearing Registry", zap.Int("status", resp.StatusCode))
-	return cerror.ErrAvroSchemaAPIError.GenWithStack(
-		"Error when clearing Registry, status = %d",
-		resp.StatusCode,
-	)
 }
 
 func httpRetry(
@@ -481,8 +323,3 @@ func httpRetry(
 
 	return resp, nil
 }
-
-// TopicNameStrategy, ksqlDB only supports this
-func (m *SchemaManager) topicNameToSchemaSubject(topicName string) string {
-	return topicName + m.subjectSuffix
-}
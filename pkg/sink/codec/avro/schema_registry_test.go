@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,16 +36,17 @@ func TestSchemaRegistry(t *testing.T) {
 	startHTTPInterceptForTestingRegistry()
 	defer stopHTTPInterceptForTestingRegistry()
 
-	manager, err := newAvroSchemaManager(
-		getTestingContext(), "http://127.0.0.1:8081", "-value", nil)
+	registry, err := newConfluentSchemaRegistry(
+		getTestingContext(), "http://127.0.0.1:8081", nil)
 	require.NoError(t, err)
+	manager := newAvroSchemaManager(registry, common.SubjectNameStrategyTopic, "-value")
 
-	topic := "cdctest"
+	subject := "cdctest-value"
 
-	err = manager.ClearRegistry(getTestingContext(), topic)
+	err = manager.ClearRegistry(getTestingContext(), subject)
 	require.NoError(t, err)
 
-	_, err = manager.Lookup(getTestingContext(), topic, 1)
+	_, err = manager.Lookup(getTestingContext(), SchemaID{ID: 1})
 	require.Regexp(t, `.*not\sfound.*`, err)
 
 	codec, err := goavro.NewCodec(`{
@@ -60,10 +62,10 @@ func TestSchemaRegistry(t *testing.T) {
      }`)
 	require.NoError(t, err)
 
-	schemaID, err := manager.Register(getTestingContext(), topic, codec.Schema())
+	schemaID, err := manager.Register(getTestingContext(), subject, codec.Schema())
 	require.NoError(t, err)
 
-	codec2, err := manager.Lookup(getTestingContext(), topic, schemaID)
+	codec2, err := manager.Lookup(getTestingContext(), schemaID)
 	require.NoError(t, err)
 	require.Equal(t, codec.CanonicalSchema(), codec2.CanonicalSchema())
 
@@ -87,10 +89,10 @@ func TestSchemaRegistry(t *testing.T) {
           ]
      }`)
 	require.NoError(t, err)
-	schemaID, err = manager.Register(getTestingContext(), topic, codec.Schema())
+	schemaID, err = manager.Register(getTestingContext(), subject, codec.Schema())
 	require.NoError(t, err)
 
-	codec2, err = manager.Lookup(getTestingContext(), topic, schemaID)
+	codec2, err = manager.Lookup(getTestingContext(), schemaID)
 	require.NoError(t, err)
 	require.Equal(t, codec.CanonicalSchema(), codec2.CanonicalSchema())
 }
@@ -99,12 +101,12 @@ func TestSchemaRegistryBad(t *testing.T) {
 	startHTTPInterceptForTestingRegistry()
 	defer stopHTTPInterceptForTestingRegistry()
 
-	_, err := newAvroSchemaManager(
-		getTestingContext(), "http://127.0.0.1:808", "-value", nil)
+	_, err := newConfluentSchemaRegistry(
+		getTestingContext(), "http://127.0.0.1:808", nil)
 	require.NotNil(t, err)
 
-	_, err = newAvroSchemaManager(
-		getTestingContext(), "https://127.0.0.1:8080", "-value", nil)
+	_, err = newConfluentSchemaRegistry(
+		getTestingContext(), "https://127.0.0.1:8080", nil)
 	require.NotNil(t, err)
 }
 
@@ -112,14 +114,15 @@ func TestSchemaRegistryIdempotent(t *testing.T) {
 	startHTTPInterceptForTestingRegistry()
 	defer stopHTTPInterceptForTestingRegistry()
 
-	manager, err := newAvroSchemaManager(
-		getTestingContext(), "http://127.0.0.1:8081", "-value", nil)
+	registry, err := newConfluentSchemaRegistry(
+		getTestingContext(), "http://127.0.0.1:8081", nil)
 	require.NoError(t, err)
+	manager := newAvroSchemaManager(registry, common.SubjectNameStrategyTopic, "-value")
 
-	topic := "cdctest"
+	subject := "cdctest-value"
 
 	for i := 0; i < 20; i++ {
-		err = manager.ClearRegistry(getTestingContext(), topic)
+		err = manager.ClearRegistry(getTestingContext(), subject)
 		require.NoError(t, err)
 	}
 
@@ -144,11 +147,11 @@ func TestSchemaRegistryIdempotent(t *testing.T) {
      }`)
 	require.NoError(t, err)
 
-	id := 0
+	id := SchemaID{}
 	for i := 0; i < 20; i++ {
-		id1, err := manager.Register(getTestingContext(), topic, codec.Schema())
+		id1, err := manager.Register(getTestingContext(), subject, codec.Schema())
 		require.NoError(t, err)
-		require.True(t, id == 0 || id == id1)
+		require.True(t, id.ID == 0 || id == id1)
 		id = id1
 	}
 }
@@ -157,9 +160,10 @@ func TestGetCachedOrRegister(t *testing.T) {
 	startHTTPInterceptForTestingRegistry()
 	defer stopHTTPInterceptForTestingRegistry()
 
-	manager, err := newAvroSchemaManager(
-		getTestingContext(), "http://127.0.0.1:8081", "-value", nil)
+	registry, err := newConfluentSchemaRegistry(
+		getTestingContext(), "http://127.0.0.1:8081", nil)
 	require.NoError(t, err)
+	manager := newAvroSchemaManager(registry, common.SubjectNameStrategyTopic, "-value")
 
 	called := 0
 	// nolint:unparam
@@ -188,18 +192,18 @@ func TestGetCachedOrRegister(t *testing.T) {
 	}
 	topic := "cdctest"
 
-	codec, id, err := manager.GetCachedOrRegister(getTestingContext(), topic, 1, schemaGen)
+	codec, id, err := manager.GetCachedOrRegister(getTestingContext(), topic, "test1", 1, schemaGen)
 	require.NoError(t, err)
-	require.Greater(t, id, 0)
+	require.Greater(t, id.ID, int64(0))
 	require.NotNil(t, codec)
 	require.Equal(t, 1, called)
 
-	codec1, _, err := manager.GetCachedOrRegister(getTestingContext(), topic, 1, schemaGen)
+	codec1, _, err := manager.GetCachedOrRegister(getTestingContext(), topic, "test1", 1, schemaGen)
 	require.NoError(t, err)
 	require.True(t, codec == codec1) // check identity
 	require.Equal(t, 1, called)
 
-	codec2, _, err := manager.GetCachedOrRegister(getTestingContext(), topic, 2, schemaGen)
+	codec2, _, err := manager.GetCachedOrRegister(getTestingContext(), topic, "test1", 2, schemaGen)
 	require.NoError(t, err)
 	require.NotEqual(t, codec, codec2)
 	require.Equal(t, 2, called)
@@ -236,11 +240,12 @@ func TestGetCachedOrRegister(t *testing.T) {
 				codec, id, err := manager.GetCachedOrRegister(
 					getTestingContext(),
 					topic,
+					"test1",
 					uint64(finalI),
 					schemaGen,
 				)
 				require.NoError(t, err)
-				require.Greater(t, id, 0)
+				require.Greater(t, id.ID, int64(0))
 				require.NotNil(t, codec)
 			}
 		}()
@@ -265,3 +270,27 @@ func TestHTTPRetry(t *testing.T) {
 	require.Equal(t, 200, resp.StatusCode)
 	_ = resp.Body.Close()
 }
+
+func TestSubjectNameStrategy(t *testing.T) {
+	t.Parallel()
+
+	recordName := "default.test.t"
+	cases := []struct {
+		strategy string
+		key      string
+		value    string
+	}{
+		{common.SubjectNameStrategyTopic, "cdctest-key", "cdctest-value"},
+		{common.SubjectNameStrategyRecord, "default.test.t-key", "default.test.t-value"},
+		{
+			common.SubjectNameStrategyTopicRecord,
+			"cdctest-default.test.t-key", "cdctest-default.test.t-value",
+		},
+	}
+	for _, c := range cases {
+		keyManager := newAvroSchemaManager(nil, c.strategy, keySchemaSuffix)
+		valueManager := newAvroSchemaManager(nil, c.strategy, valueSchemaSuffix)
+		require.Equal(t, c.key, keyManager.subject("cdctest", recordName))
+		require.Equal(t, c.value, valueManager.subject("cdctest", recordName))
+	}
+}
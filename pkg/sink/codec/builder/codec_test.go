@@ -304,7 +304,10 @@ func BenchmarkCraftDecoding(b *testing.B) {
 func BenchmarkJsonDecoding(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		for _, message := range codecJSONEncodedRowChanges {
-			decoder := open.NewBatchDecoder()
+			decoder, err := open.NewBatchDecoder(context.Background(), &common.Config{})
+			if err != nil {
+				panic(err)
+			}
 			if err := decoder.AddKeyValue(message.Key, message.Value); err != nil {
 				panic(err)
 			} else {
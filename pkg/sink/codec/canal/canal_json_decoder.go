@@ -15,12 +15,16 @@ package canal
 
 import (
 	"bytes"
+	"context"
 
 	"github.com/goccy/go-json"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/claimcheck"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 )
 
@@ -30,17 +34,30 @@ type batchDecoder struct {
 	msg                 canalJSONMessageInterface
 	enableTiDBExtension bool
 	terminator          string
+
+	ctx context.Context
+	// claimCheck is used to read the large message back from the external storage.
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // NewBatchDecoder return a decoder for canal-json
 func NewBatchDecoder(
-	enableTiDBExtension bool,
-	terminator string,
-) codec.RowEventDecoder {
-	return &batchDecoder{
-		enableTiDBExtension: enableTiDBExtension,
-		terminator:          terminator,
+	ctx context.Context, config *common.Config,
+) (codec.RowEventDecoder, error) {
+	var claimCheck *claimcheck.ClaimCheck
+	if config.LargeMessageClaimCheckStorageURI != "" {
+		var err error
+		claimCheck, err = claimcheck.New(ctx, config.LargeMessageClaimCheckStorageURI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
+	return &batchDecoder{
+		enableTiDBExtension: config.EnableTiDBExtension,
+		terminator:          config.Terminator,
+		ctx:                 ctx,
+		claimCheck:          claimCheck,
+	}, nil
 }
 
 // AddKeyValue implements the RowEventDecoder interface
@@ -97,12 +114,48 @@ func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, erro
 		return nil, false, cerror.ErrCanalDecodeFailed.
 			GenWithStack("not found row changed event message")
 	}
-	result, err := canalJSONMessage2RowChange(b.msg)
+	msg := b.msg
+	onlyHandleKey := false
+	if withExtension, ok := msg.(*canalJSONMessageWithTiDBExtension); ok {
+		onlyHandleKey = withExtension.Extensions.OnlyHandleKey
+		// the message only contains the handle key columns if the full message
+		// is stored by the claim check, so read it back from the external storage.
+		if location := withExtension.Extensions.ClaimCheckLocation; location != "" {
+			fullMsg, err := b.readClaimCheckMessage(location)
+			if err != nil {
+				return nil, false, err
+			}
+			msg = fullMsg
+			onlyHandleKey = false
+		}
+	}
+	result, err := canalJSONMessage2RowChange(msg)
 	if err != nil {
 		return nil, false, err
 	}
 	b.msg = nil
-	return result, false, nil
+	return result, onlyHandleKey, nil
+}
+
+func (b *batchDecoder) readClaimCheckMessage(location string) (canalJSONMessageInterface, error) {
+	if b.claimCheck == nil {
+		return nil, cerror.ErrCanalDecodeFailed.GenWithStack(
+			"claim check storage is not configured, cannot read the message at %s", location)
+	}
+	message, err := b.claimCheck.ReadMessage(b.ctx, location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	msg := &canalJSONMessageWithTiDBExtension{
+		JSONMessage: &JSONMessage{},
+		Extensions:  &tidbExtension{},
+	}
+	if err := json.Unmarshal(message.Value, msg); err != nil {
+		log.Error("canal-json decoder unmarshal claim check message failed",
+			zap.Error(err), zap.String("location", location))
+		return nil, cerror.WrapError(cerror.ErrCanalDecodeFailed, err)
+	}
+	return msg, nil
 }
 
 // NextDDLEvent implements the RowEventDecoder interface
@@ -136,3 +189,25 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 	b.msg = nil
 	return withExtensionEvent.Extensions.WatermarkTs, nil
 }
+
+// NextSyncPointEvent implements the SyncPointEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextSyncPointEvent() (*model.SyncPointEvent, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeSyncPoint {
+		return nil, cerror.ErrCanalDecodeFailed.
+			GenWithStack("not found syncpoint event message")
+	}
+
+	withExtensionEvent, ok := b.msg.(*canalJSONMessageWithTiDBExtension)
+	if !ok {
+		log.Error("canal-json syncpoint event message should have tidb extension, but not found",
+			zap.Any("msg", b.msg))
+		return nil, cerror.ErrCanalDecodeFailed.
+			GenWithStack("MessageTypeSyncPoint tidb extension not found")
+	}
+	b.msg = nil
+	return &model.SyncPointEvent{
+		PrimaryTs:   withExtensionEvent.Extensions.SyncPointPrimaryTs,
+		SecondaryTs: withExtensionEvent.Extensions.SyncPointSecondaryTs,
+	}, nil
+}
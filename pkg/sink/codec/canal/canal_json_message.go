@@ -25,7 +25,10 @@ import (
 	canal "github.com/pingcap/tiflow/proto/canal"
 )
 
-const tidbWaterMarkType = "TIDB_WATERMARK"
+const (
+	tidbWaterMarkType = "TIDB_WATERMARK"
+	tidbSyncPointType = "TIDB_SYNCPOINT"
+)
 
 // The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
 // canalJSONMessageInterface is used to support this without affect the original format.
@@ -115,6 +118,10 @@ func (c *JSONMessage) messageType() model.MessageType {
 		return model.MessageTypeResolved
 	}
 
+	if c.EventType == tidbSyncPointType {
+		return model.MessageTypeSyncPoint
+	}
+
 	return model.MessageTypeRow
 }
 
@@ -133,6 +140,12 @@ func (c *JSONMessage) pkNameSet() map[string]struct{} {
 type tidbExtension struct {
 	CommitTs    uint64 `json:"commitTs,omitempty"`
 	WatermarkTs uint64 `json:"watermarkTs,omitempty"`
+
+	SyncPointPrimaryTs   uint64 `json:"syncPointPrimaryTs,omitempty"`
+	SyncPointSecondaryTs uint64 `json:"syncPointSecondaryTs,omitempty"`
+
+	OnlyHandleKey      bool   `json:"onlyHandleKey,omitempty"`
+	ClaimCheckLocation string `json:"claimCheckLocation,omitempty"`
 }
 
 type canalJSONMessageWithTiDBExtension struct {
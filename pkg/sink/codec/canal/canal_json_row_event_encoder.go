@@ -25,14 +25,20 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/claimcheck"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 )
 
+// newJSONMessageForDML encodes the row changed event, if onlyHandleKey is true,
+// only the handle key columns are encoded, and the claimCheckLocation is set
+// into the tidb extension if the full message is stored by the claim check.
 func newJSONMessageForDML(
 	builder *canalEntryBuilder,
 	e *model.RowChangedEvent,
 	config *common.Config,
+	onlyHandleKey bool,
+	claimCheckLocation string,
 ) ([]byte, error) {
 	isDelete := e.IsDelete()
 	mysqlTypeMap := make(map[string]string, len(e.Columns))
@@ -155,7 +161,8 @@ func newJSONMessageForDML(
 		emptyColumn := true
 		for _, col := range columns {
 			if col != nil {
-				if isDelete && config.DeleteOnlyHandleKeyColumns && !col.Flag.IsHandleKey() {
+				if (onlyHandleKey || isDelete && config.DeleteOnlyHandleKeyColumns) &&
+					!col.Flag.IsHandleKey() {
 					continue
 				}
 				if emptyColumn {
@@ -206,13 +213,14 @@ func newJSONMessageForDML(
 	if e.IsDelete() {
 		out.RawString(",\"old\":null")
 		out.RawString(",\"data\":")
-		if err := filling(e.PreColumns, out, false, config.DeleteOnlyHandleKeyColumns, nil); err != nil {
+		if err := filling(e.PreColumns, out, false,
+			onlyHandleKey || config.DeleteOnlyHandleKeyColumns, nil); err != nil {
 			return nil, err
 		}
 	} else if e.IsInsert() {
 		out.RawString(",\"old\":null")
 		out.RawString(",\"data\":")
-		if err := filling(e.Columns, out, false, false, nil); err != nil {
+		if err := filling(e.Columns, out, false, onlyHandleKey, nil); err != nil {
 			return nil, err
 		}
 	} else if e.IsUpdate() {
@@ -224,11 +232,12 @@ func newJSONMessageForDML(
 			}
 		}
 		out.RawString(",\"old\":")
-		if err := filling(e.PreColumns, out, config.OnlyOutputUpdatedColumns, false, newColsMap); err != nil {
+		if err := filling(e.PreColumns, out, config.OnlyOutputUpdatedColumns,
+			onlyHandleKey, newColsMap); err != nil {
 			return nil, err
 		}
 		out.RawString(",\"data\":")
-		if err := filling(e.Columns, out, false, false, nil); err != nil {
+		if err := filling(e.Columns, out, false, onlyHandleKey, nil); err != nil {
 			return nil, err
 		}
 	} else {
@@ -241,6 +250,13 @@ func newJSONMessageForDML(
 		out.RawByte('{')
 		out.RawString("\"commitTs\":")
 		out.Uint64(e.CommitTs)
+		if onlyHandleKey {
+			out.RawString(",\"onlyHandleKey\":true")
+		}
+		if claimCheckLocation != "" {
+			out.RawString(",\"claimCheckLocation\":")
+			out.String(claimCheckLocation)
+		}
 		out.RawByte('}')
 	}
 	out.RawByte('}')
@@ -264,6 +280,8 @@ type JSONRowEventEncoder struct {
 	messages []*common.Message
 
 	config *common.Config
+	// claimCheck is not nil if the large message claim check is enabled.
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // newJSONRowEventEncoder creates a new JSONRowEventEncoder
@@ -328,14 +346,49 @@ func (c *JSONRowEventEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message,
 	return common.NewResolvedMsg(config.ProtocolCanalJSON, nil, value, ts), nil
 }
 
+func (c *JSONRowEventEncoder) newJSONMessage4SyncPointEvent(
+	e *model.SyncPointEvent,
+) *canalJSONMessageWithTiDBExtension {
+	return &canalJSONMessageWithTiDBExtension{
+		JSONMessage: &JSONMessage{
+			ID:            0,
+			IsDDL:         false,
+			EventType:     tidbSyncPointType,
+			ExecutionTime: convertToCanalTs(e.PrimaryTs),
+			BuildTime:     time.Now().UnixNano() / int64(time.Millisecond), // converts to milliseconds
+		},
+		Extensions: &tidbExtension{
+			SyncPointPrimaryTs:   e.PrimaryTs,
+			SyncPointSecondaryTs: e.SecondaryTs,
+		},
+	}
+}
+
+// EncodeSyncPointEvent implements the SyncPointEventEncoder interface
+func (c *JSONRowEventEncoder) EncodeSyncPointEvent(e *model.SyncPointEvent) (*common.Message, error) {
+	// the syncpoint is carried by the tidb extension,
+	// it cannot be consumed by the official canal consumer.
+	if !c.config.EnableTiDBExtension {
+		return nil, cerror.ErrCanalEncodeFailed.GenWithStack(
+			"syncpoint event requires the tidb extension of canal-json")
+	}
+
+	msg := c.newJSONMessage4SyncPointEvent(e)
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCanalEncodeFailed, err)
+	}
+	return common.NewSyncPointMsg(config.ProtocolCanalJSON, nil, value, e.PrimaryTs), nil
+}
+
 // AppendRowChangedEvent implements the interface EventJSONBatchEncoder
 func (c *JSONRowEventEncoder) AppendRowChangedEvent(
-	_ context.Context,
+	ctx context.Context,
 	_ string,
 	e *model.RowChangedEvent,
 	callback func(),
 ) error {
-	value, err := newJSONMessageForDML(c.builder, e, c.config)
+	value, err := newJSONMessageForDML(c.builder, e, c.config, false, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -347,7 +400,13 @@ func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 			zap.Int("maxMessageBytes", c.config.MaxMessageBytes),
 			zap.Int("length", length),
 			zap.Any("table", e.Table))
-		return cerror.ErrMessageTooLarge.GenWithStackByArgs()
+		if c.claimCheck == nil {
+			return cerror.ErrMessageTooLarge.GenWithStackByArgs()
+		}
+		value, err = c.buildClaimCheckMessage(ctx, e, value)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 	m := &common.Message{
 		Key:      nil,
@@ -365,6 +424,34 @@ func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 	return nil
 }
 
+// buildClaimCheckMessage writes the full message into the external storage,
+// and returns the message which only contains the handle key columns and
+// the location of the full message.
+func (c *JSONRowEventEncoder) buildClaimCheckMessage(
+	ctx context.Context, e *model.RowChangedEvent, value []byte,
+) ([]byte, error) {
+	location, err := c.claimCheck.WriteMessage(ctx, nil, value, e.CommitTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err = newJSONMessageForDML(c.builder, e, c.config, true, location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	length := len(value) + common.MaxRecordOverhead
+	if length > c.config.MaxMessageBytes {
+		log.Warn("Single message is still too large for canal-json with claim check",
+			zap.Int("maxMessageBytes", c.config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", e.Table))
+		return nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	log.Warn("canal-json: message too large, send it by the claim check",
+		zap.Any("table", e.Table), zap.Uint64("commitTs", e.CommitTs),
+		zap.String("location", location))
+	return value, nil
+}
+
 // Build implements the RowEventEncoder interface
 func (c *JSONRowEventEncoder) Build() []*common.Message {
 	if len(c.messages) == 0 {
@@ -387,17 +474,32 @@ func (c *JSONRowEventEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message
 }
 
 type jsonRowEventEncoderBuilder struct {
-	config *common.Config
+	config     *common.Config
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // NewJSONRowEventEncoderBuilder creates a canal-json batchEncoderBuilder.
-func NewJSONRowEventEncoderBuilder(config *common.Config) codec.RowEventEncoderBuilder {
-	return &jsonRowEventEncoderBuilder{config: config}
+func NewJSONRowEventEncoderBuilder(
+	ctx context.Context, config *common.Config,
+) (codec.RowEventEncoderBuilder, error) {
+	var (
+		claimCheck *claimcheck.ClaimCheck
+		err        error
+	)
+	if config.LargeMessageClaimCheckStorageURI != "" {
+		claimCheck, err = claimcheck.New(ctx, config.LargeMessageClaimCheckStorageURI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &jsonRowEventEncoderBuilder{config: config, claimCheck: claimCheck}, nil
 }
 
 // Build a `jsonRowEventEncoderBuilder`
 func (b *jsonRowEventEncoderBuilder) Build() codec.RowEventEncoder {
-	return newJSONRowEventEncoder(b.config)
+	encoder := newJSONRowEventEncoder(b.config).(*JSONRowEventEncoder)
+	encoder.claimCheck = b.claimCheck
+	return encoder
 }
 
 func shouldIgnoreColumn(col *model.Column,
@@ -16,11 +16,13 @@ package canal
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/text/encoding/charmap"
@@ -47,7 +49,7 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 	encoder, ok := e.(*JSONRowEventEncoder)
 	require.True(t, ok)
 
-	data, err := newJSONMessageForDML(encoder.builder, testCaseInsert, encoder.config)
+	data, err := newJSONMessageForDML(encoder.builder, testCaseInsert, encoder.config, false, "")
 	require.Nil(t, err)
 	var msg canalJSONMessageInterface = &JSONMessage{}
 	err = json.Unmarshal(data, msg)
@@ -96,7 +98,7 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 		require.Equal(t, item.expectedEncodedValue, obtainedValue)
 	}
 
-	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config)
+	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config, false, "")
 	require.Nil(t, err)
 	jsonMsg = &JSONMessage{}
 	err = json.Unmarshal(data, jsonMsg)
@@ -114,7 +116,7 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 		require.Contains(t, jsonMsg.Old[0], col.Name)
 	}
 
-	data, err = newJSONMessageForDML(encoder.builder, testCaseDelete, encoder.config)
+	data, err = newJSONMessageForDML(encoder.builder, testCaseDelete, encoder.config, false, "")
 	require.Nil(t, err)
 	jsonMsg = &JSONMessage{}
 	err = json.Unmarshal(data, jsonMsg)
@@ -127,7 +129,8 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 		require.Contains(t, jsonMsg.Data[0], col.Name)
 	}
 
-	data, err = newJSONMessageForDML(encoder.builder, testCaseDelete, &common.Config{DeleteOnlyHandleKeyColumns: true})
+	data, err = newJSONMessageForDML(encoder.builder, testCaseDelete,
+		&common.Config{DeleteOnlyHandleKeyColumns: true}, false, "")
 	require.NoError(t, err)
 	jsonMsg = &JSONMessage{}
 	err = json.Unmarshal(data, jsonMsg)
@@ -156,7 +159,7 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 
 	encoder, ok = e.(*JSONRowEventEncoder)
 	require.True(t, ok)
-	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config)
+	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config, false, "")
 	require.Nil(t, err)
 
 	withExtension := &canalJSONMessageWithTiDBExtension{}
@@ -168,7 +171,7 @@ func TestNewCanalJSONMessage4DML(t *testing.T) {
 
 	encoder, ok = e.(*JSONRowEventEncoder)
 	require.True(t, ok)
-	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config)
+	data, err = newJSONMessageForDML(encoder.builder, testCaseUpdate, encoder.config, false, "")
 	require.Nil(t, err)
 
 	withExtension = &canalJSONMessageWithTiDBExtension{}
@@ -271,7 +274,8 @@ func TestEncodeCheckpointEvent(t *testing.T) {
 		}
 
 		require.NotNil(t, msg)
-		decoder := NewBatchDecoder(enable, "")
+		decoder, err := NewBatchDecoder(context.Background(), config)
+		require.NoError(t, err)
 
 		err = decoder.AddKeyValue(msg.Key, msg.Value)
 		require.NoError(t, err)
@@ -296,6 +300,44 @@ func TestEncodeCheckpointEvent(t *testing.T) {
 	}
 }
 
+func TestEncodeSyncPointEvent(t *testing.T) {
+	t.Parallel()
+	event := &model.SyncPointEvent{PrimaryTs: 2333, SecondaryTs: 2334}
+
+	encoder := &JSONRowEventEncoder{
+		builder: newCanalEntryBuilder(),
+		config:  &common.Config{EnableTiDBExtension: false},
+	}
+	_, err := encoder.EncodeSyncPointEvent(event)
+	require.ErrorContains(t, err, "tidb extension")
+
+	config := &common.Config{EnableTiDBExtension: true}
+	encoder.config = config
+	msg, err := encoder.EncodeSyncPointEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+	require.Equal(t, event.PrimaryTs, msg.Ts)
+
+	decoder, err := NewBatchDecoder(context.Background(), config)
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(msg.Key, msg.Value)
+	require.NoError(t, err)
+
+	ty, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, ty)
+	_, err = decoder.NextResolvedEvent()
+	require.Error(t, err)
+	decoded, err := decoder.(codec.SyncPointEventDecoder).NextSyncPointEvent()
+	require.NoError(t, err)
+	require.Equal(t, event, decoded)
+
+	_, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.False(t, hasNext)
+}
+
 func TestCheckpointEventValueMarshal(t *testing.T) {
 	t.Parallel()
 	var watermark uint64 = 1024
@@ -484,13 +526,91 @@ func TestMaxMessageBytes(t *testing.T) {
 	// the test message length is smaller than max-message-bytes
 	maxMessageBytes := 300
 	cfg := common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(maxMessageBytes)
-	encoder := NewJSONRowEventEncoderBuilder(cfg).Build()
-	err := encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
+	builder, err := NewJSONRowEventEncoderBuilder(ctx, cfg)
+	require.NoError(t, err)
+	encoder := builder.Build()
+	err = encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.Nil(t, err)
 
 	// the test message length is larger than max-message-bytes
 	cfg = cfg.WithMaxMessageBytes(100)
-	encoder = NewJSONRowEventEncoderBuilder(cfg).Build()
+	encoder = builder.Build()
 	err = encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.NotNil(t, err)
 }
+
+func TestCanalJSONClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	largeEvent := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{
+				Name:  "id",
+				Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+				Type:  mysql.TypeLong,
+				Value: int64(1),
+			},
+			{
+				Name:  "content",
+				Type:  mysql.TypeVarchar,
+				Value: []byte(strings.Repeat("a", 1024)),
+			},
+		},
+	}
+
+	ctx := context.Background()
+	cfg := common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(800)
+	cfg.EnableTiDBExtension = true
+	cfg.LargeMessageClaimCheckStorageURI = "file://" + t.TempDir()
+	builder, err := NewJSONRowEventEncoderBuilder(ctx, cfg)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
+	err = encoder.AppendRowChangedEvent(ctx, "", largeEvent, nil)
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.LessOrEqual(t, messages[0].Length(), cfg.MaxMessageBytes)
+
+	// only the handle key columns and the location are sent.
+	var msg canalJSONMessageWithTiDBExtension
+	err = json.Unmarshal(messages[0].Value, &msg)
+	require.NoError(t, err)
+	require.True(t, msg.Extensions.OnlyHandleKey)
+	require.NotEmpty(t, msg.Extensions.ClaimCheckLocation)
+	require.Equal(t, map[string]interface{}{"id": "1"}, msg.Data[0])
+
+	decoder, err := NewBatchDecoder(ctx, cfg)
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+
+	// the full message is read back from the external storage.
+	obtained, onlyHandleKey, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.False(t, onlyHandleKey)
+	require.Equal(t, largeEvent.CommitTs, obtained.CommitTs)
+	require.Len(t, obtained.Columns, 2)
+	for _, col := range obtained.Columns {
+		if col.Name == "content" {
+			require.Equal(t, strings.Repeat("a", 1024), col.Value)
+		}
+	}
+
+	// the claim check storage is not configured.
+	decoder, err = NewBatchDecoder(ctx, &common.Config{EnableTiDBExtension: true})
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+	_, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	_, _, err = decoder.NextRowChangedEvent()
+	require.ErrorContains(t, err, "claim check storage is not configured")
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Message is the full encoded message stored in the external storage.
+type Message struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// ClaimCheck writes the large messages into the external storage,
+// only the location of the message is sent to the downstream MQ,
+// and the consumer reads the message back by the location.
+type ClaimCheck struct {
+	storage storage.ExternalStorage
+}
+
+// New creates a new ClaimCheck backed by the external storage.
+func New(ctx context.Context, storageURI string) (*ClaimCheck, error) {
+	s, err := util.GetExternalStorageFromURI(ctx, storageURI)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("claim check storage created", zap.String("storageURI", s.URI()))
+	return &ClaimCheck{storage: s}, nil
+}
+
+// WriteMessage writes the message into the external storage,
+// and returns the location of it, which is relative to the storage URI.
+func (c *ClaimCheck) WriteMessage(
+	ctx context.Context, key, value []byte, commitTs uint64,
+) (string, error) {
+	data, err := json.Marshal(&Message{Key: key, Value: value})
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	location := newFileName(commitTs)
+	if err := c.storage.WriteFile(ctx, location, data); err != nil {
+		return "", cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	return location, nil
+}
+
+// ReadMessage reads the message stored in the location.
+func (c *ClaimCheck) ReadMessage(ctx context.Context, location string) (*Message, error) {
+	// the location is always a file name in the root of the storage.
+	data, err := c.storage.ReadFile(ctx, path.Base(location))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrExternalStorageAPI, err)
+	}
+	message := new(Message)
+	if err := json.Unmarshal(data, message); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	return message, nil
+}
+
+// newFileName returns a unique file name for the message,
+// the commit ts prefix makes it easy to clean up the expired messages.
+func newFileName(commitTs uint64) string {
+	return fmt.Sprintf("%d-%s.json", commitTs, uuid.NewString())
+}
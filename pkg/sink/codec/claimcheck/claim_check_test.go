@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claimcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimCheckWriteAndReadMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	claimCheck, err := New(ctx, "file://"+dir)
+	require.NoError(t, err)
+
+	key := []byte("key")
+	value := []byte("value")
+	location, err := claimCheck.WriteMessage(ctx, key, value, 100)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(location, "100-"))
+	_, err = os.Stat(filepath.Join(dir, location))
+	require.NoError(t, err)
+
+	// each message is stored in its own file.
+	another, err := claimCheck.WriteMessage(ctx, key, value, 100)
+	require.NoError(t, err)
+	require.NotEqual(t, location, another)
+
+	message, err := claimCheck.ReadMessage(ctx, location)
+	require.NoError(t, err)
+	require.Equal(t, key, message.Key)
+	require.Equal(t, value, message.Value)
+
+	_, err = claimCheck.ReadMessage(ctx, "not-exist.json")
+	require.ErrorContains(t, err, "ErrS3StorageAPI")
+
+	err = os.WriteFile(filepath.Join(dir, "corrupted.json"), []byte("{"), 0o644)
+	require.NoError(t, err)
+	_, err = claimCheck.ReadMessage(ctx, "corrupted.json")
+	require.ErrorContains(t, err, "ErrUnmarshalFailed")
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -45,11 +46,23 @@ type Config struct {
 	// for message large then the MaxMessageBytes only output the handle key columns.
 	LargeMessageOnlyHandleKeyColumns bool
 
+	// LargeMessageClaimCheckStorageURI is not empty,
+	// for message large then the MaxMessageBytes, the full message is written to
+	// the external storage, and only the handle key columns with its location are sent.
+	LargeMessageClaimCheckStorageURI string
+
+	// EnableSyncPoint is true, the syncpoint event is sent to the downstream MQ.
+	EnableSyncPoint bool
+
 	EnableTiDBExtension bool
 	EnableRowChecksum   bool
 
 	// avro only
 	AvroSchemaRegistry             string
+	AvroSchemaRegistryType         string
+	AvroSubjectNameStrategy        string
+	AvroGlueRegion                 string
+	AvroGlueRegistryName           string
 	AvroDecimalHandlingMode        string
 	AvroBigintUnsignedHandlingMode string
 
@@ -82,6 +95,8 @@ func NewConfig(protocol config.Protocol) *Config {
 		EnableRowChecksum:   false,
 
 		AvroSchemaRegistry:             "",
+		AvroSchemaRegistryType:         SchemaRegistryTypeConfluent,
+		AvroSubjectNameStrategy:        SubjectNameStrategyTopic,
 		AvroDecimalHandlingMode:        "precise",
 		AvroBigintUnsignedHandlingMode: "long",
 		AvroEnableWatermark:            false,
@@ -96,6 +111,10 @@ const (
 	codecOPTAvroDecimalHandlingMode        = "avro-decimal-handling-mode"
 	codecOPTAvroBigintUnsignedHandlingMode = "avro-bigint-unsigned-handling-mode"
 	codecOPTAvroSchemaRegistry             = "schema-registry"
+	codecOPTAvroSchemaRegistryType         = "schema-registry-type"
+	codecOPTAvroSubjectNameStrategy        = "subject-name-strategy"
+	codecOPTAvroGlueRegion                 = "glue-region"
+	codecOPTAvroGlueRegistryName           = "glue-registry-name"
 
 	codecOPTOnlyOutputUpdatedColumns = "only-output-updated-columns"
 )
@@ -111,6 +130,23 @@ const (
 	BigintUnsignedHandlingModeLong = "long"
 )
 
+const (
+	// SchemaRegistryTypeConfluent is the Confluent schema registry
+	SchemaRegistryTypeConfluent = "confluent"
+	// SchemaRegistryTypeApicurio is the Apicurio schema registry
+	SchemaRegistryTypeApicurio = "apicurio"
+	// SchemaRegistryTypeGlue is the AWS Glue schema registry
+	SchemaRegistryTypeGlue = "glue"
+
+	// SubjectNameStrategyTopic registers the schema under the subject named after the topic
+	SubjectNameStrategyTopic = "topic"
+	// SubjectNameStrategyRecord registers the schema under the subject named after the record
+	SubjectNameStrategyRecord = "record"
+	// SubjectNameStrategyTopicRecord registers the schema under the subject
+	// named after both the topic and the record
+	SubjectNameStrategyTopicRecord = "topic-record"
+)
+
 type urlConfig struct {
 	EnableTiDBExtension            *bool   `form:"enable-tidb-extension"`
 	MaxBatchSize                   *int    `form:"max-batch-size"`
@@ -123,8 +159,12 @@ type urlConfig struct {
 	// confluent official consumer cannot handle watermark.
 	AvroEnableWatermark *bool `form:"avro-enable-watermark"`
 
-	AvroSchemaRegistry       string `form:"schema-registry"`
-	OnlyOutputUpdatedColumns *bool  `form:"only-output-updated-columns"`
+	AvroSchemaRegistry       string  `form:"schema-registry"`
+	AvroSchemaRegistryType   *string `form:"schema-registry-type"`
+	AvroSubjectNameStrategy  *string `form:"subject-name-strategy"`
+	AvroGlueRegion           *string `form:"glue-region"`
+	AvroGlueRegistryName     *string `form:"glue-registry-name"`
+	OnlyOutputUpdatedColumns *bool   `form:"only-output-updated-columns"`
 }
 
 // Apply fill the Config
@@ -168,6 +208,20 @@ func (c *Config) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) er
 	if urlParameter.AvroSchemaRegistry != "" {
 		c.AvroSchemaRegistry = urlParameter.AvroSchemaRegistry
 	}
+	if urlParameter.AvroSchemaRegistryType != nil &&
+		*urlParameter.AvroSchemaRegistryType != "" {
+		c.AvroSchemaRegistryType = *urlParameter.AvroSchemaRegistryType
+	}
+	if urlParameter.AvroSubjectNameStrategy != nil &&
+		*urlParameter.AvroSubjectNameStrategy != "" {
+		c.AvroSubjectNameStrategy = *urlParameter.AvroSubjectNameStrategy
+	}
+	if urlParameter.AvroGlueRegion != nil {
+		c.AvroGlueRegion = *urlParameter.AvroGlueRegion
+	}
+	if urlParameter.AvroGlueRegistryName != nil {
+		c.AvroGlueRegistryName = *urlParameter.AvroGlueRegistryName
+	}
 
 	if replicaConfig.Sink != nil {
 		c.Terminator = util.GetOrZero(replicaConfig.Sink.Terminator)
@@ -199,6 +253,18 @@ func (c *Config) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) er
 			"if the full message's size is larger than max-message-bytes, only send the handle key columns",
 			zap.Any("protocol", c.Protocol))
 	}
+	c.LargeMessageClaimCheckStorageURI = util.GetOrZero(replicaConfig.Sink.LargeMessageClaimCheckStorageURI)
+	if c.LargeMessageClaimCheckStorageURI != "" {
+		log.Info("large message claim check is enabled, "+
+			"if the full message's size is larger than max-message-bytes, "+
+			"write it to the external storage",
+			zap.Any("protocol", c.Protocol))
+	}
+	// the syncpoint of the storage sink is written as the marker file,
+	// so only the MQ sink needs to encode it.
+	if sink.IsMQScheme(sinkURI.Scheme) {
+		c.EnableSyncPoint = util.GetOrZero(replicaConfig.EnableSyncPoint)
+	}
 
 	return nil
 }
@@ -210,6 +276,13 @@ func mergeConfig(
 	dest := &urlConfig{}
 	if replicaConfig.Sink != nil {
 		dest.AvroSchemaRegistry = util.GetOrZero(replicaConfig.Sink.SchemaRegistry)
+		if replicaConfig.Sink.SchemaRegistryConfig != nil {
+			registryConfig := replicaConfig.Sink.SchemaRegistryConfig
+			dest.AvroSchemaRegistryType = registryConfig.Type
+			dest.AvroSubjectNameStrategy = registryConfig.SubjectNameStrategy
+			dest.AvroGlueRegion = registryConfig.GlueRegion
+			dest.AvroGlueRegistryName = registryConfig.GlueRegistryName
+		}
 		dest.OnlyOutputUpdatedColumns = replicaConfig.Sink.OnlyOutputUpdatedColumns
 		if replicaConfig.Sink.KafkaConfig != nil {
 			dest.MaxMessageBytes = replicaConfig.Sink.KafkaConfig.MaxMessageBytes
@@ -238,19 +311,17 @@ func (c *Config) WithMaxMessageBytes(bytes int) *Config {
 // Validate the Config
 func (c *Config) Validate() error {
 	if c.EnableTiDBExtension &&
-		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro) {
+		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro ||
+			c.Protocol == config.ProtocolDebezium) {
 		log.Warn("ignore invalid config, enable-tidb-extension"+
-			"only supports canal-json/avro protocol",
+			"only supports canal-json/avro/debezium protocol",
 			zap.Bool("enableTidbExtension", c.EnableTiDBExtension),
 			zap.String("protocol", c.Protocol.String()))
 	}
 
 	if c.Protocol == config.ProtocolAvro {
-		if c.AvroSchemaRegistry == "" {
-			return cerror.ErrCodecInvalidConfig.GenWithStack(
-				`Avro protocol requires parameter "%s"`,
-				codecOPTAvroSchemaRegistry,
-			)
+		if err := c.validateSchemaRegistry(); err != nil {
+			return err
 		}
 
 		if c.AvroDecimalHandlingMode != DecimalHandlingModePrecise &&
@@ -286,6 +357,18 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.LargeMessageClaimCheckStorageURI != "" {
+		if err := c.validateClaimCheck(); err != nil {
+			return err
+		}
+	}
+
+	if c.EnableSyncPoint {
+		if err := c.validateSyncPoint(); err != nil {
+			return err
+		}
+	}
+
 	if c.MaxMessageBytes <= 0 {
 		return cerror.ErrCodecInvalidConfig.Wrap(
 			errors.Errorf("invalid max-message-bytes %d", c.MaxMessageBytes),
@@ -300,3 +383,86 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+func (c *Config) validateClaimCheck() error {
+	if c.LargeMessageOnlyHandleKeyColumns {
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			"large message only handle key columns and claim check cannot be enabled both")
+	}
+	switch c.Protocol {
+	case config.ProtocolDefault, config.ProtocolOpen:
+	case config.ProtocolCanalJSON:
+		// the location of the claim check message is carried by the tidb extension.
+		if !c.EnableTiDBExtension {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`large message claim check with canal-json protocol requires "%s" to be true`,
+				codecOPTEnableTiDBExtension)
+		}
+	default:
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			"large message claim check is only supported by open-protocol and canal-json, got %s",
+			c.Protocol.String())
+	}
+	return nil
+}
+
+func (c *Config) validateSyncPoint() error {
+	switch c.Protocol {
+	case config.ProtocolDefault, config.ProtocolOpen:
+	case config.ProtocolCanalJSON:
+		// the syncpoint event is carried by the tidb extension.
+		if !c.EnableTiDBExtension {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`syncpoint with canal-json protocol requires "%s" to be true`,
+				codecOPTEnableTiDBExtension)
+		}
+	default:
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			"syncpoint is only supported by open-protocol and canal-json, got %s",
+			c.Protocol.String())
+	}
+	return nil
+}
+
+func (c *Config) validateSchemaRegistry() error {
+	switch c.AvroSchemaRegistryType {
+	case SchemaRegistryTypeConfluent, SchemaRegistryTypeApicurio:
+		if c.AvroSchemaRegistry == "" {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`Avro protocol requires parameter "%s"`,
+				codecOPTAvroSchemaRegistry,
+			)
+		}
+	case SchemaRegistryTypeGlue:
+		// The schema registry uri is optional for the glue schema registry,
+		// it overrides the default endpoint of the region if set.
+		if c.AvroGlueRegion == "" || c.AvroGlueRegistryName == "" {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`glue schema registry requires parameter "%s" and "%s"`,
+				codecOPTAvroGlueRegion,
+				codecOPTAvroGlueRegistryName,
+			)
+		}
+	default:
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			`%s value could only be "%s", "%s" or "%s"`,
+			codecOPTAvroSchemaRegistryType,
+			SchemaRegistryTypeConfluent,
+			SchemaRegistryTypeApicurio,
+			SchemaRegistryTypeGlue,
+		)
+	}
+
+	switch c.AvroSubjectNameStrategy {
+	case SubjectNameStrategyTopic, SubjectNameStrategyRecord, SubjectNameStrategyTopicRecord:
+	default:
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			`%s value could only be "%s", "%s" or "%s"`,
+			codecOPTAvroSubjectNameStrategy,
+			SubjectNameStrategyTopic,
+			SubjectNameStrategyRecord,
+			SubjectNameStrategyTopicRecord,
+		)
+	}
+	return nil
+}
@@ -382,3 +382,148 @@ func TestMergeConfig(t *testing.T) {
 	require.Equal(t, 123, c.MaxMessageBytes)
 	require.Equal(t, 456, c.MaxBatchSize)
 }
+
+func TestConfigApplyValidate4SchemaRegistry(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.SchemaRegistry = util.AddressOf("http://127.0.0.1:8081")
+
+	uri := "kafka://127.0.0.1:9092/abc?protocol=avro"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	c := NewConfig(config.ProtocolAvro)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, SchemaRegistryTypeConfluent, c.AvroSchemaRegistryType)
+	require.Equal(t, SubjectNameStrategyTopic, c.AvroSubjectNameStrategy)
+	require.NoError(t, c.Validate())
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro" +
+		"&schema-registry-type=apicurio&subject-name-strategy=topic-record"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolAvro)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, SchemaRegistryTypeApicurio, c.AvroSchemaRegistryType)
+	require.Equal(t, SubjectNameStrategyTopicRecord, c.AvroSubjectNameStrategy)
+	require.NoError(t, c.Validate())
+
+	c.AvroSubjectNameStrategy = "unknown"
+	require.ErrorContains(t, c.Validate(), "subject-name-strategy value could only be")
+	c.AvroSubjectNameStrategy = SubjectNameStrategyRecord
+	c.AvroSchemaRegistryType = "unknown"
+	require.ErrorContains(t, c.Validate(), "schema-registry-type value could only be")
+
+	// the glue schema registry is configured by the replica config,
+	// the region and the registry name are required.
+	replicaConfig = config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.SchemaRegistryConfig = &config.SchemaRegistryConfig{
+		Type:                util.AddressOf(SchemaRegistryTypeGlue),
+		SubjectNameStrategy: util.AddressOf(SubjectNameStrategyRecord),
+		GlueRegion:          util.AddressOf("us-west-2"),
+	}
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolAvro)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, SchemaRegistryTypeGlue, c.AvroSchemaRegistryType)
+	require.Equal(t, SubjectNameStrategyRecord, c.AvroSubjectNameStrategy)
+	require.Equal(t, "us-west-2", c.AvroGlueRegion)
+	require.ErrorContains(t, c.Validate(), "glue schema registry requires parameter")
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro&glue-registry-name=test"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, "test", c.AvroGlueRegistryName)
+	require.NoError(t, c.Validate())
+}
+
+func TestConfigApplyValidate4ClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.LargeMessageClaimCheckStorageURI = util.AddressOf("file:///tmp/claim-check")
+
+	uri := "kafka://127.0.0.1:9092/abc?protocol=open-protocol"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	c := NewConfig(config.ProtocolOpen)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, "file:///tmp/claim-check", c.LargeMessageClaimCheckStorageURI)
+	require.NoError(t, c.Validate())
+
+	c.LargeMessageOnlyHandleKeyColumns = true
+	require.ErrorContains(t, c.Validate(), "cannot be enabled both")
+
+	// the canal-json protocol requires the tidb extension.
+	uri = "kafka://127.0.0.1:9092/abc?protocol=canal-json"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCanalJSON)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(), "enable-tidb-extension")
+	c.EnableTiDBExtension = true
+	require.NoError(t, c.Validate())
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=maxwell"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolMaxwell)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(),
+		"only supported by open-protocol and canal-json")
+}
+
+func TestConfigApplyValidate4SyncPoint(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.EnableSyncPoint = util.AddressOf(true)
+
+	uri := "kafka://127.0.0.1:9092/abc?protocol=open-protocol"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	c := NewConfig(config.ProtocolOpen)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, c.EnableSyncPoint)
+	require.NoError(t, c.Validate())
+
+	// the canal-json protocol requires the tidb extension.
+	uri = "kafka://127.0.0.1:9092/abc?protocol=canal-json"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCanalJSON)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(), "enable-tidb-extension")
+	c.EnableTiDBExtension = true
+	require.NoError(t, c.Validate())
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=maxwell"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolMaxwell)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(),
+		"syncpoint is only supported by open-protocol and canal-json")
+
+	// the storage sink writes the syncpoint as the marker file.
+	uri = "s3://bucket/prefix?protocol=csv"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCsv)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.False(t, c.EnableSyncPoint)
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"go.uber.org/zap"
+)
+
+// batchDecoder decodes the Debezium JSON messages into the original events.
+// The column types and flags can only be restored if the messages are
+// encoded with the TiDB extension enabled.
+type batchDecoder struct {
+	data       []byte
+	msg        *message
+	terminator string
+}
+
+// NewBatchDecoder returns a decoder for debezium.
+func NewBatchDecoder(terminator string) codec.RowEventDecoder {
+	return &batchDecoder{
+		terminator: terminator,
+	}
+}
+
+// AddKeyValue implements the RowEventDecoder interface
+func (b *batchDecoder) AddKeyValue(_, value []byte) error {
+	b.data = value
+	return nil
+}
+
+// HasNext implements the RowEventDecoder interface
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	var encodedData []byte
+	if len(b.terminator) > 0 {
+		idx := bytes.Index(b.data, []byte(b.terminator))
+		if idx >= 0 {
+			encodedData = b.data[:idx]
+			b.data = b.data[idx+len(b.terminator):]
+		} else {
+			encodedData = b.data
+			b.data = nil
+		}
+	} else {
+		encodedData = b.data
+		b.data = nil
+	}
+
+	if len(encodedData) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+
+	msg := &message{}
+	decoder := json.NewDecoder(bytes.NewReader(encodedData))
+	decoder.UseNumber()
+	if err := decoder.Decode(msg); err != nil {
+		log.Error("debezium decoder unmarshal data failed",
+			zap.Error(err), zap.ByteString("data", encodedData))
+		return model.MessageTypeUnknown, false,
+			cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+	}
+	b.msg = msg
+	return msg.messageType(), true, nil
+}
+
+// NextResolvedEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeResolved {
+		return 0, cerror.ErrDebeziumDecodeFailed.
+			GenWithStack("not found resolved event message")
+	}
+	ts := b.msg.Extension.WatermarkTs
+	b.msg = nil
+	return ts, nil
+}
+
+// NextRowChangedEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeRow {
+		return nil, false, cerror.ErrDebeziumDecodeFailed.
+			GenWithStack("not found row changed event message")
+	}
+	result, err := messageToRowChange(b.msg)
+	if err != nil {
+		return nil, false, err
+	}
+	b.msg = nil
+	return result, false, nil
+}
+
+// NextDDLEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeDDL {
+		return nil, cerror.ErrDebeziumDecodeFailed.
+			GenWithStack("not found ddl event message")
+	}
+	result, err := messageToDDLEvent(b.msg)
+	if err != nil {
+		return nil, err
+	}
+	b.msg = nil
+	return result, nil
+}
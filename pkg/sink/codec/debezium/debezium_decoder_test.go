@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func sortedColumns(columns []*model.Column) []*model.Column {
+	if len(columns) == 0 {
+		return nil
+	}
+	result := make([]*model.Column, 0, len(columns))
+	for _, name := range []string{"amount", "created", "data", "id", "name", "nullable", "price"} {
+		for _, col := range columns {
+			if col.Name == name {
+				result = append(result, col)
+			}
+		}
+	}
+	return result
+}
+
+func TestDecodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	events := []*model.RowChangedEvent{
+		{
+			CommitTs: 1,
+			Table:    &model.TableName{Schema: "test", Table: "t"},
+			Columns:  testColumns,
+		},
+		{
+			CommitTs:   2,
+			Table:      &model.TableName{Schema: "test", Table: "t"},
+			Columns:    testColumns,
+			PreColumns: testPreColumns,
+		},
+		{
+			CommitTs:   3,
+			Table:      &model.TableName{Schema: "test", Table: "t"},
+			PreColumns: testPreColumns,
+		},
+	}
+
+	encoder := newRowEventEncoder(newTestConfig())
+	decoder := NewBatchDecoder("")
+	for _, event := range events {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+		require.NoError(t, err)
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+
+		require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+
+		decoded, _, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.Equal(t, event.CommitTs, decoded.CommitTs)
+		require.Equal(t, event.Table, decoded.Table)
+		require.Equal(t, sortedColumns(event.Columns), decoded.Columns)
+		require.Equal(t, sortedColumns(event.PreColumns), decoded.PreColumns)
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+}
+
+func TestDecodeWithTerminator(t *testing.T) {
+	t.Parallel()
+
+	c := newTestConfig()
+	c.Terminator = "\n"
+	encoder := NewTxnEventEncoderBuilder(c).Build()
+	row := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns:  testColumns,
+	}
+	txn := &model.SingleTableTxn{
+		CommitTs: 1,
+		Table:    row.Table,
+		Rows:     []*model.RowChangedEvent{row, row, row},
+	}
+	require.NoError(t, encoder.AppendTxnEvent(txn, nil))
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	decoder := NewBatchDecoder(c.Terminator)
+	require.NoError(t, decoder.AddKeyValue(nil, messages[0].Value))
+	count := 0
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		if !hasNext {
+			break
+		}
+		require.Equal(t, model.MessageTypeRow, tp)
+		_, _, err = decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 3, count)
+}
+
+func TestDecodeWithoutTiDBExtension(t *testing.T) {
+	t.Parallel()
+
+	decoder := NewBatchDecoder("")
+	value := `{"before":null,"after":{"id":1,"name":"tidb","price":1.5},` +
+		`"source":{"connector":"tidb","db":"test","table":"t","commit_ts":10},"op":"c","ts_ms":0}`
+	require.NoError(t, decoder.AddKeyValue(nil, []byte(value)))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	event, _, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), event.CommitTs)
+	require.Equal(t, []*model.Column{
+		{Name: "id", Value: int64(1)},
+		{Name: "name", Value: "tidb"},
+		{Name: "price", Value: 1.5},
+	}, event.Columns)
+}
+
+func TestDecodeDDLAndResolvedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(newTestConfig())
+	decoder := NewBatchDecoder("")
+
+	message, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 5,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test"},
+		},
+		Query: "CREATE DATABASE test",
+		Type:  timodel.ActionCreateSchema,
+	})
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(message.Key, message.Value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	_, _, err = decoder.NextRowChangedEvent()
+	require.Error(t, err)
+	ddl, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), ddl.CommitTs)
+	require.Equal(t, "test", ddl.TableInfo.TableName.Schema)
+	require.Equal(t, "CREATE DATABASE test", ddl.Query)
+	require.Equal(t, timodel.ActionCreateSchema, ddl.Type)
+
+	message, err = encoder.EncodeCheckpointEvent(6)
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(message.Key, message.Value))
+	tp, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), ts)
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+func encodeRowChangedEvent(
+	e *model.RowChangedEvent, config *common.Config,
+) ([]byte, error) {
+	msg := rowChangeToMessage(e, config.DeleteOnlyHandleKeyColumns, config.EnableTiDBExtension)
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	length := len(value) + common.MaxRecordOverhead
+	// For single message that is longer than max-message-bytes, do not send it.
+	if length > config.MaxMessageBytes {
+		log.Warn("Single message is too large for debezium",
+			zap.Int("maxMessageBytes", config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", e.Table))
+		return nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	return value, nil
+}
+
+// RowEventEncoder encodes row events in the Debezium JSON format,
+// every row changed event is encoded into one message.
+type RowEventEncoder struct {
+	messages []*common.Message
+
+	config *common.Config
+}
+
+// newRowEventEncoder creates a new RowEventEncoder.
+func newRowEventEncoder(config *common.Config) codec.RowEventEncoder {
+	return &RowEventEncoder{
+		messages: make([]*common.Message, 0, 1),
+		config:   config,
+	}
+}
+
+// EncodeCheckpointEvent implements the RowEventEncoder interface.
+// There is no such a corresponding type to ResolvedEvent in Debezium,
+// so the watermark is only sent when the TiDB extension is enabled.
+func (d *RowEventEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	if !d.config.EnableTiDBExtension {
+		return nil, nil
+	}
+	msg := &watermarkMessage{
+		Source:    newSource("", "", ts),
+		TsMs:      time.Now().UnixMilli(),
+		Extension: &tidbExtension{WatermarkTs: ts},
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	return common.NewResolvedMsg(config.ProtocolDebezium, nil, value, ts), nil
+}
+
+// AppendRowChangedEvent implements the RowEventEncoder interface.
+func (d *RowEventEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	key, err := rowChangeToKey(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	value, err := encodeRowChangedEvent(e, d.config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m := &common.Message{
+		Key:      key,
+		Value:    value,
+		Ts:       e.CommitTs,
+		Schema:   &e.Table.Schema,
+		Table:    &e.Table.Table,
+		Type:     model.MessageTypeRow,
+		Protocol: config.ProtocolDebezium,
+		Callback: callback,
+	}
+	m.IncRowsCount()
+
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// EncodeDDLEvent implements the RowEventEncoder interface.
+func (d *RowEventEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
+	msg := ddlEventToMessage(e)
+	key, err := json.Marshal(map[string]string{"databaseName": msg.DatabaseName})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	return common.NewDDLMsg(config.ProtocolDebezium, key, value, e), nil
+}
+
+// Build implements the RowEventEncoder interface.
+func (d *RowEventEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a debezium batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.RowEventEncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a `RowEventEncoder`
+func (b *batchEncoderBuilder) Build() codec.RowEventEncoder {
+	return newRowEventEncoder(b.config)
+}
+
+// TxnEventEncoder encodes txn events in the Debezium JSON format,
+// rows are separated by the terminator.
+type TxnEventEncoder struct {
+	config *common.Config
+
+	// the symbol separating two lines
+	terminator []byte
+	valueBuf   *bytes.Buffer
+	batchSize  int
+	callback   func()
+
+	// Store some fields of the txn event.
+	txnCommitTs uint64
+	txnSchema   *string
+	txnTable    *string
+}
+
+// newTxnEventEncoder creates a new TxnEventEncoder.
+func newTxnEventEncoder(config *common.Config) codec.TxnEventEncoder {
+	return &TxnEventEncoder{
+		valueBuf:   &bytes.Buffer{},
+		terminator: []byte(config.Terminator),
+		config:     config,
+	}
+}
+
+// AppendTxnEvent appends a txn event to the encoder.
+func (d *TxnEventEncoder) AppendTxnEvent(
+	txn *model.SingleTableTxn,
+	callback func(),
+) error {
+	for _, row := range txn.Rows {
+		value, err := encodeRowChangedEvent(row, d.config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.valueBuf.Write(value)
+		d.valueBuf.Write(d.terminator)
+		d.batchSize++
+	}
+	d.callback = callback
+	d.txnCommitTs = txn.CommitTs
+	d.txnSchema = &txn.Table.Schema
+	d.txnTable = &txn.Table.Table
+	return nil
+}
+
+// Build builds a message from the encoder and resets the encoder.
+func (d *TxnEventEncoder) Build() []*common.Message {
+	if d.batchSize == 0 {
+		return nil
+	}
+
+	ret := common.NewMsg(config.ProtocolDebezium, nil,
+		d.valueBuf.Bytes(), d.txnCommitTs, model.MessageTypeRow, d.txnSchema, d.txnTable)
+	ret.SetRowsCount(d.batchSize)
+	ret.Callback = d.callback
+	d.valueBuf.Reset()
+	d.callback = nil
+	d.batchSize = 0
+	d.txnCommitTs = 0
+	d.txnSchema = nil
+	d.txnTable = nil
+
+	return []*common.Message{ret}
+}
+
+type txnEventEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewTxnEventEncoderBuilder creates a debezium txnEventEncoderBuilder.
+func NewTxnEventEncoderBuilder(config *common.Config) codec.TxnEventEncoderBuilder {
+	return &txnEventEncoderBuilder{config: config}
+}
+
+// Build a `TxnEventEncoder`
+func (b *txnEventEncoderBuilder) Build() codec.TxnEventEncoder {
+	return newTxnEventEncoder(b.config)
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testColumns = []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("tidb")},
+		{
+			Name:  "data",
+			Type:  mysql.TypeBlob,
+			Flag:  model.BinaryFlag,
+			Value: []byte{0x01, 0x02, 0xff},
+		},
+		{
+			Name:  "amount",
+			Type:  mysql.TypeLong,
+			Flag:  model.UnsignedFlag,
+			Value: uint64(100),
+		},
+		{Name: "price", Type: mysql.TypeDouble, Value: 3.14},
+		{Name: "created", Type: mysql.TypeDatetime, Value: "2023-01-01 00:00:00"},
+		{Name: "nullable", Type: mysql.TypeVarchar, Value: nil},
+	}
+	testPreColumns = []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("tikv")},
+		{
+			Name:  "data",
+			Type:  mysql.TypeBlob,
+			Flag:  model.BinaryFlag,
+			Value: []byte{0x03},
+		},
+		{
+			Name:  "amount",
+			Type:  mysql.TypeLong,
+			Flag:  model.UnsignedFlag,
+			Value: uint64(99),
+		},
+		{Name: "price", Type: mysql.TypeDouble, Value: 2.71},
+		{Name: "created", Type: mysql.TypeDatetime, Value: "2022-01-01 00:00:00"},
+		{Name: "nullable", Type: mysql.TypeVarchar, Value: []byte("x")},
+	}
+)
+
+func newTestConfig() *common.Config {
+	c := common.NewConfig(config.ProtocolDebezium)
+	c.EnableTiDBExtension = true
+	return c
+}
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(newTestConfig())
+	insert := &model.RowChangedEvent{
+		CommitTs: 424316552636792833,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns:  testColumns,
+	}
+	count := 0
+	err := encoder.AppendRowChangedEvent(context.Background(), "", insert, func() { count++ })
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, `{"id":1}`, string(messages[0].Key))
+	messages[0].Callback()
+	require.Equal(t, 1, count)
+	require.Nil(t, encoder.Build())
+
+	var value map[string]interface{}
+	require.NoError(t, json.Unmarshal(messages[0].Value, &value))
+	require.Equal(t, "c", value["op"])
+	require.Nil(t, value["before"])
+	after := value["after"].(map[string]interface{})
+	require.Equal(t, "tidb", after["name"])
+	// binary data is encoded in base64.
+	require.Equal(t, "AQL/", after["data"])
+	require.Equal(t, "2023-01-01 00:00:00", after["created"])
+	source := value["source"].(map[string]interface{})
+	require.Equal(t, "tidb", source["connector"])
+	require.Equal(t, "test", source["db"])
+	require.Equal(t, "t", source["table"])
+	require.Equal(t, "false", source["snapshot"])
+	require.Equal(t, float64(1618639193103), source["ts_ms"])
+	require.Contains(t, value, "_tidb")
+}
+
+func TestEncodeDeleteOnlyHandleKeyColumns(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolDebezium)
+	c.DeleteOnlyHandleKeyColumns = true
+	encoder := newRowEventEncoder(c)
+	event := &model.RowChangedEvent{
+		CommitTs:   1,
+		Table:      &model.TableName{Schema: "test", Table: "t"},
+		PreColumns: testPreColumns,
+	}
+	err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	var value map[string]interface{}
+	require.NoError(t, json.Unmarshal(messages[0].Value, &value))
+	require.Equal(t, "d", value["op"])
+	require.Nil(t, value["after"])
+	require.Equal(t, map[string]interface{}{"id": float64(1)}, value["before"])
+	// The TiDB extension is disabled by default.
+	require.NotContains(t, value, "_tidb")
+}
+
+func TestEncodeMessageTooLarge(t *testing.T) {
+	t.Parallel()
+
+	c := newTestConfig().WithMaxMessageBytes(100)
+	encoder := newRowEventEncoder(c)
+	event := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns:  testColumns,
+	}
+	err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+	require.ErrorContains(t, err, "message is too large")
+}
+
+func TestEncodeDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	ft := types.NewFieldType(mysql.TypeLonglong)
+	ft.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag | mysql.AutoIncrementFlag)
+	ft.SetFlen(20)
+	tableInfo := &model.TableInfo{
+		TableName: model.TableName{Schema: "test", Table: "t"},
+		TableInfo: &timodel.TableInfo{
+			Name:    timodel.NewCIStr("t"),
+			Charset: "utf8mb4",
+			Columns: []*timodel.ColumnInfo{
+				{Name: timodel.NewCIStr("id"), FieldType: *ft},
+				{
+					Name:      timodel.NewCIStr("name"),
+					FieldType: *types.NewFieldType(mysql.TypeVarchar),
+				},
+			},
+		},
+	}
+	encoder := newRowEventEncoder(newTestConfig())
+
+	message, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs:  1,
+		TableInfo: tableInfo,
+		Query:     "CREATE TABLE t (id BIGINT PRIMARY KEY AUTO_INCREMENT, name VARCHAR(32))",
+		Type:      timodel.ActionCreateTable,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, message.Type)
+	require.Equal(t, `{"databaseName":"test"}`, string(message.Key))
+
+	msg := &schemaChangeMessage{}
+	require.NoError(t, json.Unmarshal(message.Value, msg))
+	require.Equal(t, "test", msg.DatabaseName)
+	require.Len(t, msg.TableChanges, 1)
+	change := msg.TableChanges[0]
+	require.Equal(t, tableChangeCreate, change.Type)
+	require.Equal(t, `"test"."t"`, change.ID)
+	require.Equal(t, []string{"id"}, change.Table.PrimaryKeyColumnNames)
+	require.Len(t, change.Table.Columns, 2)
+	require.Equal(t, "BIGINT", change.Table.Columns[0].TypeName)
+	require.False(t, change.Table.Columns[0].Optional)
+	require.True(t, change.Table.Columns[0].AutoIncremented)
+	require.Equal(t, 2, change.Table.Columns[1].Position)
+	require.True(t, change.Table.Columns[1].Optional)
+
+	// rename table
+	message, err = encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs:  2,
+		TableInfo: tableInfo,
+		PreTableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: "t_old"},
+		},
+		Query: "RENAME TABLE t_old TO t",
+		Type:  timodel.ActionRenameTable,
+	})
+	require.NoError(t, err)
+	msg = &schemaChangeMessage{}
+	require.NoError(t, json.Unmarshal(message.Value, msg))
+	require.Equal(t, tableChangeAlter, msg.TableChanges[0].Type)
+	require.Equal(t, `"test"."t_old"`, msg.TableChanges[0].PreviousID)
+
+	// schema level DDL has no table changes.
+	message, err = encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 3,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test"},
+		},
+		Query: "CREATE DATABASE test",
+		Type:  timodel.ActionCreateSchema,
+	})
+	require.NoError(t, err)
+	msg = &schemaChangeMessage{}
+	require.NoError(t, json.Unmarshal(message.Value, msg))
+	require.Len(t, msg.TableChanges, 0)
+}
+
+func TestEncodeCheckpointEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(common.NewConfig(config.ProtocolDebezium))
+	message, err := encoder.EncodeCheckpointEvent(1)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	encoder = newRowEventEncoder(newTestConfig())
+	message, err = encoder.EncodeCheckpointEvent(1)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeResolved, message.Type)
+}
+
+func TestTxnEventEncoder(t *testing.T) {
+	t.Parallel()
+
+	c := newTestConfig()
+	c.Terminator = "\r\n"
+	encoder := NewTxnEventEncoderBuilder(c).Build()
+	txn := &model.SingleTableTxn{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Rows: []*model.RowChangedEvent{
+			{
+				CommitTs: 1,
+				Table:    &model.TableName{Schema: "test", Table: "t"},
+				Columns:  testColumns,
+			},
+			{
+				CommitTs:   1,
+				Table:      &model.TableName{Schema: "test", Table: "t"},
+				Columns:    testColumns,
+				PreColumns: testPreColumns,
+			},
+		},
+	}
+	err := encoder.AppendTxnEvent(txn, nil)
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t, uint64(1), messages[0].Ts)
+	require.Nil(t, encoder.Build())
+}
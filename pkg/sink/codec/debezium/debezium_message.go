@@ -0,0 +1,511 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/internal"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// connectorName is the name of the connector in the `source` block.
+	connectorName = "tidb"
+
+	opCreate = "c"
+	opUpdate = "u"
+	opDelete = "d"
+
+	tableChangeCreate = "CREATE"
+	tableChangeAlter  = "ALTER"
+	tableChangeDrop   = "DROP"
+)
+
+// source is the `source` block of the Debezium envelope, which describes
+// where the change comes from.
+type source struct {
+	Version   string `json:"version"`
+	Connector string `json:"connector"`
+	// TsMs is the physical time of the commit ts in milliseconds.
+	TsMs     int64  `json:"ts_ms"`
+	Snapshot string `json:"snapshot"`
+	DB       string `json:"db"`
+	Table    string `json:"table,omitempty"`
+	// CommitTs is specific to the TiDB connector, it is the ts of
+	// the transaction which the change belongs to.
+	CommitTs uint64 `json:"commit_ts"`
+}
+
+// columnMeta records the type information which is lost in the JSON value.
+type columnMeta struct {
+	Type byte                 `json:"t"`
+	Flag model.ColumnFlagType `json:"f"`
+}
+
+// tidbExtension is a TiCDC custom field that is not part of the Debezium envelope.
+type tidbExtension struct {
+	WatermarkTs uint64                 `json:"watermarkTs,omitempty"`
+	Columns     map[string]*columnMeta `json:"columns,omitempty"`
+}
+
+// rowMessage is the Debezium envelope of a row changed event.
+type rowMessage struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Source *source                `json:"source"`
+	Op     string                 `json:"op"`
+	// TsMs is the time at which the message is built, in milliseconds.
+	TsMs      int64          `json:"ts_ms"`
+	Extension *tidbExtension `json:"_tidb,omitempty"`
+}
+
+// tableColumn describes a column of the table in a schema change event.
+type tableColumn struct {
+	Name            string   `json:"name"`
+	JdbcType        int32    `json:"jdbcType"`
+	TypeName        string   `json:"typeName"`
+	TypeExpression  string   `json:"typeExpression"`
+	CharsetName     string   `json:"charsetName,omitempty"`
+	Length          int      `json:"length,omitempty"`
+	Scale           *int     `json:"scale,omitempty"`
+	Position        int      `json:"position"`
+	Optional        bool     `json:"optional"`
+	AutoIncremented bool     `json:"autoIncremented"`
+	Generated       bool     `json:"generated"`
+	EnumValues      []string `json:"enumValues,omitempty"`
+}
+
+// table describes the structure of the table in a schema change event.
+type table struct {
+	DefaultCharsetName    string         `json:"defaultCharsetName,omitempty"`
+	PrimaryKeyColumnNames []string       `json:"primaryKeyColumnNames"`
+	Columns               []*tableColumn `json:"columns"`
+}
+
+// tableChange is an item of the `tableChanges` of a schema change event.
+type tableChange struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	PreviousID string `json:"previousId,omitempty"`
+	Table      *table `json:"table"`
+}
+
+// schemaChangeMessage is the Debezium schema change event of a DDL.
+type schemaChangeMessage struct {
+	Source       *source        `json:"source"`
+	TsMs         int64          `json:"ts_ms"`
+	DatabaseName string         `json:"databaseName"`
+	SchemaName   *string        `json:"schemaName"`
+	DDL          string         `json:"ddl"`
+	TableChanges []*tableChange `json:"tableChanges"`
+}
+
+// watermarkMessage is used to send the checkpoint ts,
+// it only exists when the TiDB extension is enabled.
+type watermarkMessage struct {
+	Source    *source        `json:"source"`
+	TsMs      int64          `json:"ts_ms"`
+	Extension *tidbExtension `json:"_tidb"`
+}
+
+// message is a union of all kinds of messages, only used in decoding.
+type message struct {
+	Before       map[string]interface{} `json:"before"`
+	After        map[string]interface{} `json:"after"`
+	Source       *source                `json:"source"`
+	Op           string                 `json:"op"`
+	DatabaseName string                 `json:"databaseName"`
+	DDL          string                 `json:"ddl"`
+	TableChanges []*tableChange         `json:"tableChanges"`
+	Extension    *tidbExtension         `json:"_tidb"`
+}
+
+func (m *message) messageType() model.MessageType {
+	if m.DDL != "" {
+		return model.MessageTypeDDL
+	}
+	if m.Op != "" {
+		return model.MessageTypeRow
+	}
+	if m.Extension != nil && m.Extension.WatermarkTs != 0 {
+		return model.MessageTypeResolved
+	}
+	return model.MessageTypeUnknown
+}
+
+func newSource(schema, table string, commitTs uint64) *source {
+	return &source{
+		Version:   version.ReleaseVersion,
+		Connector: connectorName,
+		TsMs:      oracle.ExtractPhysical(commitTs),
+		Snapshot:  "false",
+		DB:        schema,
+		Table:     table,
+		CommitTs:  commitTs,
+	}
+}
+
+// formatColumnValue converts the value of the column to the representation
+// used by the Debezium JSON converter: binary data is encoded in base64,
+// and character data is encoded as plain string.
+func formatColumnValue(col *model.Column) interface{} {
+	if col.Value == nil {
+		return nil
+	}
+	switch col.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		var data []byte
+		switch v := col.Value.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			return col.Value
+		}
+		if col.Flag.IsBinary() {
+			return data
+		}
+		return string(data)
+	default:
+		return col.Value
+	}
+}
+
+func columnsToMap(
+	columns []*model.Column, onlyHandleKeyColumns bool,
+	metas map[string]*columnMeta,
+) map[string]interface{} {
+	if len(columns) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		if onlyHandleKeyColumns && !col.Flag.IsHandleKey() {
+			continue
+		}
+		result[col.Name] = formatColumnValue(col)
+		if metas != nil {
+			metas[col.Name] = &columnMeta{Type: col.Type, Flag: col.Flag}
+		}
+	}
+	return result
+}
+
+// rowChangeToKey returns the key of the row changed event, which is composed
+// of the handle key columns like the key of the Debezium MySQL connector.
+// A nil key is returned if the table has no handle key.
+func rowChangeToKey(e *model.RowChangedEvent) ([]byte, error) {
+	columns := e.Columns
+	if e.IsDelete() {
+		columns = e.PreColumns
+	}
+	key := make(map[string]interface{})
+	for _, col := range columns {
+		if col != nil && col.Flag.IsHandleKey() {
+			key[col.Name] = formatColumnValue(col)
+		}
+	}
+	if len(key) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(key)
+	return data, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+}
+
+func rowChangeToMessage(
+	e *model.RowChangedEvent,
+	onlyHandleKeyColumns bool,
+	enableTiDBExtension bool,
+) *rowMessage {
+	var metas map[string]*columnMeta
+	if enableTiDBExtension {
+		metas = make(map[string]*columnMeta, len(e.Columns))
+	}
+	msg := &rowMessage{
+		Source: newSource(e.Table.Schema, e.Table.Table, e.CommitTs),
+		TsMs:   time.Now().UnixMilli(),
+	}
+	switch {
+	case e.IsDelete():
+		msg.Op = opDelete
+		msg.Before = columnsToMap(e.PreColumns, onlyHandleKeyColumns, metas)
+	case e.IsInsert():
+		msg.Op = opCreate
+		msg.After = columnsToMap(e.Columns, false, metas)
+	default:
+		msg.Op = opUpdate
+		msg.Before = columnsToMap(e.PreColumns, false, metas)
+		msg.After = columnsToMap(e.Columns, false, metas)
+	}
+	if enableTiDBExtension {
+		msg.Extension = &tidbExtension{Columns: metas}
+	}
+	return msg
+}
+
+func quoteTableID(schema, table string) string {
+	return fmt.Sprintf("%q.%q", schema, table)
+}
+
+func tableInfoToTable(tableInfo *model.TableInfo) *table {
+	if tableInfo == nil || tableInfo.TableInfo == nil {
+		return nil
+	}
+	result := &table{
+		DefaultCharsetName:    tableInfo.Charset,
+		PrimaryKeyColumnNames: make([]string, 0),
+		Columns:               make([]*tableColumn, 0, len(tableInfo.Columns)),
+	}
+	for i, col := range tableInfo.Columns {
+		tp := col.GetType()
+		flag := col.GetFlag()
+		typeName := strings.ToUpper(types.TypeToStr(tp, col.GetCharset()))
+		column := &tableColumn{
+			Name: col.Name.O,
+			JdbcType: int32(internal.MySQLType2JavaType(
+				tp, mysql.HasBinaryFlag(flag))),
+			TypeName:        typeName,
+			TypeExpression:  typeName,
+			CharsetName:     col.GetCharset(),
+			Length:          col.GetFlen(),
+			Position:        i + 1,
+			Optional:        !mysql.HasNotNullFlag(flag),
+			AutoIncremented: mysql.HasAutoIncrementFlag(flag),
+			Generated:       col.IsGenerated(),
+		}
+		if tp == mysql.TypeNewDecimal {
+			scale := col.GetDecimal()
+			column.Scale = &scale
+		}
+		if tp == mysql.TypeEnum || tp == mysql.TypeSet {
+			column.EnumValues = col.GetElems()
+		}
+		if mysql.HasPriKeyFlag(flag) {
+			result.PrimaryKeyColumnNames = append(result.PrimaryKeyColumnNames, col.Name.O)
+		}
+		result.Columns = append(result.Columns, column)
+	}
+	return result
+}
+
+func ddlEventToMessage(e *model.DDLEvent) *schemaChangeMessage {
+	tableName := e.TableInfo.TableName
+	msg := &schemaChangeMessage{
+		Source:       newSource(tableName.Schema, tableName.Table, e.CommitTs),
+		TsMs:         time.Now().UnixMilli(),
+		DatabaseName: tableName.Schema,
+		DDL:          e.Query,
+		TableChanges: make([]*tableChange, 0, 1),
+	}
+	// Schema level DDLs do not change any table.
+	if tableName.Table == "" {
+		return msg
+	}
+
+	change := &tableChange{
+		ID: quoteTableID(tableName.Schema, tableName.Table),
+	}
+	switch e.Type {
+	case timodel.ActionCreateTable, timodel.ActionCreateView,
+		timodel.ActionCreateTables:
+		change.Type = tableChangeCreate
+		change.Table = tableInfoToTable(e.TableInfo)
+	case timodel.ActionDropTable, timodel.ActionDropView:
+		change.Type = tableChangeDrop
+	default:
+		change.Type = tableChangeAlter
+		change.Table = tableInfoToTable(e.TableInfo)
+		if e.PreTableInfo != nil {
+			preName := e.PreTableInfo.TableName
+			if preName.Schema != tableName.Schema || preName.Table != tableName.Table {
+				change.PreviousID = quoteTableID(preName.Schema, preName.Table)
+			}
+		}
+	}
+	msg.TableChanges = append(msg.TableChanges, change)
+	return msg
+}
+
+// parseColumnValue converts the JSON value back to the value of the column
+// generated by the mounter, according to the type information.
+func parseColumnValue(value interface{}, meta *columnMeta) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if meta == nil {
+		// The type information is lost without the TiDB extension,
+		// keep the JSON value as it is.
+		if number, ok := value.(json.Number); ok {
+			if v, err := number.Int64(); err == nil {
+				return v, nil
+			}
+			return number.Float64()
+		}
+		return value, nil
+	}
+
+	switch meta.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		str, ok := value.(string)
+		if !ok {
+			return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+				"invalid value %v for string column", value)
+		}
+		if meta.Flag.IsBinary() {
+			return base64.StdEncoding.DecodeString(str)
+		}
+		return []byte(str), nil
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeInt24, mysql.TypeYear:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+				"invalid value %v for integer column", value)
+		}
+		if meta.Flag.IsUnsigned() {
+			return strconv.ParseUint(number.String(), 10, 64)
+		}
+		return strconv.ParseInt(number.String(), 10, 64)
+	case mysql.TypeFloat, mysql.TypeDouble:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+				"invalid value %v for float column", value)
+		}
+		return number.Float64()
+	case mysql.TypeBit, mysql.TypeEnum, mysql.TypeSet:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+				"invalid value %v for bit/enum/set column", value)
+		}
+		return strconv.ParseUint(number.String(), 10, 64)
+	default:
+		return value, nil
+	}
+}
+
+func mapToColumns(
+	data map[string]interface{}, metas map[string]*columnMeta,
+) ([]*model.Column, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	result := make([]*model.Column, 0, len(data))
+	for name, value := range data {
+		meta := metas[name]
+		v, err := parseColumnValue(value, meta)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+		}
+		col := &model.Column{Name: name, Value: v}
+		if meta != nil {
+			col.Type = meta.Type
+			col.Flag = meta.Flag
+		}
+		result = append(result, col)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.Compare(result[i].Name, result[j].Name) < 0
+	})
+	return result, nil
+}
+
+func messageToRowChange(msg *message) (*model.RowChangedEvent, error) {
+	if msg.Source == nil {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"source not found in the row changed event")
+	}
+	var metas map[string]*columnMeta
+	if msg.Extension != nil {
+		metas = msg.Extension.Columns
+	}
+	result := &model.RowChangedEvent{
+		CommitTs: msg.Source.CommitTs,
+		Table: &model.TableName{
+			Schema: msg.Source.DB,
+			Table:  msg.Source.Table,
+		},
+	}
+
+	var err error
+	switch msg.Op {
+	case opCreate:
+		result.Columns, err = mapToColumns(msg.After, metas)
+	case opUpdate:
+		result.Columns, err = mapToColumns(msg.After, metas)
+		if err == nil {
+			result.PreColumns, err = mapToColumns(msg.Before, metas)
+		}
+	case opDelete:
+		result.PreColumns, err = mapToColumns(msg.Before, metas)
+	default:
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"unknown op %s", msg.Op)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func messageToDDLEvent(msg *message) (*model.DDLEvent, error) {
+	if msg.Source == nil {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"source not found in the schema change event")
+	}
+	result := &model.DDLEvent{
+		CommitTs: msg.Source.CommitTs,
+		Query:    msg.DDL,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: msg.Source.DB,
+				Table:  msg.Source.Table,
+			},
+		},
+	}
+	// The DDL type is lost, only the DDL query is kept.
+	// Hack the type of schema level DDLs, so that they can be handled by the MySQL sink.
+	query := strings.ToLower(strings.TrimSpace(msg.DDL))
+	switch {
+	case strings.HasPrefix(query, "create schema"),
+		strings.HasPrefix(query, "create database"):
+		result.Type = timodel.ActionCreateSchema
+	case strings.HasPrefix(query, "drop schema"),
+		strings.HasPrefix(query, "drop database"):
+		result.Type = timodel.ActionDropSchema
+	default:
+		result.Type = timodel.ActionNone
+	}
+	return result, nil
+}
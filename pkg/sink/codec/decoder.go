@@ -37,3 +37,9 @@ type RowEventDecoder interface {
 	// NextDDLEvent returns the next DDL event if exists
 	NextDDLEvent() (*model.DDLEvent, error)
 }
+
+// SyncPointEventDecoder is an abstraction for the decoders which support the syncpoint event.
+type SyncPointEventDecoder interface {
+	// NextSyncPointEvent returns the next syncpoint event if exists
+	NextSyncPointEvent() (*model.SyncPointEvent, error)
+}
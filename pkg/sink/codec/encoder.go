@@ -35,6 +35,13 @@ type DDLEventBatchEncoder interface {
 	EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error)
 }
 
+// SyncPointEventEncoder is an abstraction for the encoders which support the syncpoint event.
+type SyncPointEventEncoder interface {
+	// EncodeSyncPointEvent encodes a syncpoint event.
+	// This event will be broadcast to all partitions to signal a consistent snapshot.
+	EncodeSyncPointEvent(e *model.SyncPointEvent) (*common.Message, error)
+}
+
 // MessageBuilder is an abstraction to build message.
 type MessageBuilder interface {
 	// Build builds the batch and returns the bytes of key and value.
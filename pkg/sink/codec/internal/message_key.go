@@ -30,6 +30,8 @@ type MessageKey struct {
 	Type      model.MessageType `json:"t"`
 	// Only Handle Key Columns encoded in the message's value part.
 	OnlyHandleKey bool `json:"ohk,omitempty"`
+	// ClaimCheckLocation is the location of the full message in the external storage.
+	ClaimCheckLocation string `json:"ccl,omitempty"`
 }
 
 // Encode encodes the message key to a byte slice.
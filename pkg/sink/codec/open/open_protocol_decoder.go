@@ -14,12 +14,15 @@
 package open
 
 import (
+	"context"
 	"encoding/binary"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/claimcheck"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/codec/internal"
 )
 
@@ -130,6 +133,10 @@ type BatchDecoder struct {
 	valueBytes []byte
 	nextKey    *internal.MessageKey
 	nextKeyLen uint64
+
+	ctx context.Context
+	// claimCheck is used to read the large message back from the external storage.
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // HasNext implements the RowEventDecoder interface
@@ -175,6 +182,16 @@ func (b *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, erro
 	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
 	value := b.valueBytes[8 : valueLen+8]
 	b.valueBytes = b.valueBytes[valueLen+8:]
+	// the value only contains the handle key columns if the full message
+	// is stored by the claim check, so read it back from the external storage.
+	if b.nextKey.ClaimCheckLocation != "" {
+		rowEvent, err := b.assembleClaimCheckRowChangedEvent(b.nextKey.ClaimCheckLocation)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		b.nextKey = nil
+		return rowEvent, false, nil
+	}
 	rowMsg := new(messageRow)
 	if err := rowMsg.decode(value); err != nil {
 		return nil, false, errors.Trace(err)
@@ -185,6 +202,28 @@ func (b *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, erro
 	return rowEvent, onlyHandleKey, nil
 }
 
+// assembleClaimCheckRowChangedEvent reads the full message from the
+// external storage, and decodes it into the row changed event.
+func (b *BatchDecoder) assembleClaimCheckRowChangedEvent(location string) (*model.RowChangedEvent, error) {
+	if b.claimCheck == nil {
+		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack(
+			"claim check storage is not configured, cannot read the message at %s", location)
+	}
+	message, err := b.claimCheck.ReadMessage(b.ctx, location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	msgKey := new(internal.MessageKey)
+	if err := msgKey.Decode(message.Key); err != nil {
+		return nil, errors.Trace(err)
+	}
+	rowMsg := new(messageRow)
+	if err := rowMsg.decode(message.Value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return msgToRowChange(msgKey, rowMsg), nil
+}
+
 // NextDDLEvent implements the RowEventDecoder interface
 func (b *BatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	if b.nextKey == nil {
@@ -208,6 +247,29 @@ func (b *BatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return ddlEvent, nil
 }
 
+// NextSyncPointEvent implements the SyncPointEventDecoder interface
+func (b *BatchDecoder) NextSyncPointEvent() (*model.SyncPointEvent, error) {
+	if b.nextKey == nil {
+		if err := b.decodeNextKey(); err != nil {
+			return nil, err
+		}
+	}
+	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
+	if b.nextKey.Type != model.MessageTypeSyncPoint {
+		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found syncpoint event message")
+	}
+	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
+	value := b.valueBytes[8 : valueLen+8]
+	b.valueBytes = b.valueBytes[valueLen+8:]
+	syncPointMsg := new(messageSyncPoint)
+	if err := syncPointMsg.decode(value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	event := msgToSyncPointEvent(b.nextKey, syncPointMsg)
+	b.nextKey = nil
+	return event, nil
+}
+
 func (b *BatchDecoder) hasNext() bool {
 	return len(b.keyBytes) > 0 && len(b.valueBytes) > 0
 }
@@ -226,9 +288,19 @@ func (b *BatchDecoder) decodeNextKey() error {
 }
 
 // NewBatchDecoder creates a new BatchDecoder.
-func NewBatchDecoder() codec.RowEventDecoder {
-	return &BatchDecoder{}
-
+func NewBatchDecoder(ctx context.Context, config *common.Config) (codec.RowEventDecoder, error) {
+	var claimCheck *claimcheck.ClaimCheck
+	if config.LargeMessageClaimCheckStorageURI != "" {
+		var err error
+		claimCheck, err = claimcheck.New(ctx, config.LargeMessageClaimCheckStorageURI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &BatchDecoder{
+		ctx:        ctx,
+		claimCheck: claimCheck,
+	}, nil
 }
 
 // AddKeyValue implements the RowEventDecoder interface
@@ -15,11 +15,13 @@ package open
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/stretchr/testify/require"
 )
@@ -51,16 +53,19 @@ var (
 
 func TestDecodeEvent(t *testing.T) {
 	config := common.NewConfig(config.ProtocolOpen)
-	encoder := NewBatchEncoderBuilder(config).Build()
-
 	ctx := context.Background()
+	builder, err := NewBatchEncoderBuilder(ctx, config)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
 	topic := "test"
-	err := encoder.AppendRowChangedEvent(ctx, topic, insertEvent, nil)
+	err = encoder.AppendRowChangedEvent(ctx, topic, insertEvent, nil)
 	require.NoError(t, err)
 
 	message := encoder.Build()[0]
 
-	decoder := NewBatchDecoder()
+	decoder, err := NewBatchDecoder(ctx, config)
+	require.NoError(t, err)
 	err = decoder.AddKeyValue(message.Key, message.Value)
 	require.NoError(t, err)
 	tp, hasNext, err := decoder.HasNext()
@@ -87,16 +92,19 @@ func TestDecodeEventOnlyHandleKeyColumns(t *testing.T) {
 	config.LargeMessageOnlyHandleKeyColumns = true
 	config.MaxMessageBytes = 185
 
-	encoder := NewBatchEncoderBuilder(config).Build()
-
 	ctx := context.Background()
+	builder, err := NewBatchEncoderBuilder(ctx, config)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
 	topic := "test"
-	err := encoder.AppendRowChangedEvent(ctx, topic, insertEvent, nil)
+	err = encoder.AppendRowChangedEvent(ctx, topic, insertEvent, nil)
 	require.NoError(t, err)
 
 	message := encoder.Build()[0]
 
-	decoder := NewBatchDecoder()
+	decoder, err := NewBatchDecoder(ctx, config)
+	require.NoError(t, err)
 	err = decoder.AddKeyValue(message.Key, message.Value)
 	require.NoError(t, err)
 	tp, hasNext, err := decoder.HasNext()
@@ -121,3 +129,105 @@ func TestDecodeEventOnlyHandleKeyColumns(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeEventWithClaimCheck(t *testing.T) {
+	largeEvent := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{
+				Name:  "a",
+				Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+				Type:  mysql.TypeLong,
+				Value: int64(1),
+			},
+			{
+				Name:  "b",
+				Type:  mysql.TypeVarchar,
+				Value: []byte(strings.Repeat("a", 1024)),
+			},
+		},
+	}
+
+	config := common.NewConfig(config.ProtocolOpen)
+	config.LargeMessageClaimCheckStorageURI = "file://" + t.TempDir()
+	config.MaxMessageBytes = 512
+
+	ctx := context.Background()
+	builder, err := NewBatchEncoderBuilder(ctx, config)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
+	err = encoder.AppendRowChangedEvent(ctx, "test", largeEvent, nil)
+	require.NoError(t, err)
+	message := encoder.Build()[0]
+	require.LessOrEqual(t, message.Length(), config.MaxMessageBytes)
+
+	decoder, err := NewBatchDecoder(ctx, config)
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(message.Key, message.Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+
+	// the full message is read back from the external storage.
+	obtained, onlyHandleKey, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.False(t, onlyHandleKey)
+	require.Equal(t, largeEvent.CommitTs, obtained.CommitTs)
+	require.Len(t, obtained.Columns, 2)
+	for _, col := range obtained.Columns {
+		if col.Name == "b" {
+			require.Equal(t, largeEvent.Columns[1].Value, col.Value)
+		}
+	}
+
+	// the claim check storage is not configured.
+	decoder, err = NewBatchDecoder(ctx, common.NewConfig(config.Protocol))
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(message.Key, message.Value)
+	require.NoError(t, err)
+	_, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	_, _, err = decoder.NextRowChangedEvent()
+	require.ErrorContains(t, err, "claim check storage is not configured")
+
+	// the message is too large even only the handle key columns are sent.
+	config.MaxMessageBytes = 150
+	encoder = builder.Build()
+	err = encoder.AppendRowChangedEvent(ctx, "test", largeEvent, nil)
+	require.ErrorContains(t, err, "ErrMessageTooLarge")
+}
+
+func TestDecodeSyncPointEvent(t *testing.T) {
+	config := common.NewConfig(config.ProtocolOpen)
+	ctx := context.Background()
+	builder, err := NewBatchEncoderBuilder(ctx, config)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
+	event := &model.SyncPointEvent{PrimaryTs: 100, SecondaryTs: 200}
+	message, err := encoder.(codec.SyncPointEventEncoder).EncodeSyncPointEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, message.Type)
+
+	decoder, err := NewBatchDecoder(ctx, config)
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(message.Key, message.Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, tp)
+
+	obtained, err := decoder.(codec.SyncPointEventDecoder).NextSyncPointEvent()
+	require.NoError(t, err)
+	require.Equal(t, event, obtained)
+
+	_, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.False(t, hasNext)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/claimcheck"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 )
@@ -35,14 +36,19 @@ type BatchEncoder struct {
 	curBatchSize int
 
 	config *common.Config
+	// claimCheck is not nil if the large message claim check is enabled.
+	claimCheck *claimcheck.ClaimCheck
 }
 
-func (d *BatchEncoder) buildMessageOnlyHandleKeyColumns(e *model.RowChangedEvent) ([]byte, []byte, error) {
+func (d *BatchEncoder) buildMessageOnlyHandleKeyColumns(
+	e *model.RowChangedEvent, claimCheckLocation string,
+) ([]byte, []byte, error) {
 	// set the `largeMessageOnlyHandleKeyColumns` to true to only encode handle key columns.
 	keyMsg, valueMsg, err := rowChangeToMsg(e, d.config.DeleteOnlyHandleKeyColumns, true)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	keyMsg.ClaimCheckLocation = claimCheckLocation
 	key, err := keyMsg.Encode()
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -62,15 +68,21 @@ func (d *BatchEncoder) buildMessageOnlyHandleKeyColumns(e *model.RowChangedEvent
 		return nil, nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
 	}
 
-	log.Warn("open-protocol: message too large, only send handle key columns",
-		zap.Any("table", e.Table), zap.Uint64("commitTs", e.CommitTs))
+	if claimCheckLocation != "" {
+		log.Warn("open-protocol: message too large, send it by the claim check",
+			zap.Any("table", e.Table), zap.Uint64("commitTs", e.CommitTs),
+			zap.String("location", claimCheckLocation))
+	} else {
+		log.Warn("open-protocol: message too large, only send handle key columns",
+			zap.Any("table", e.Table), zap.Uint64("commitTs", e.CommitTs))
+	}
 
 	return key, value, nil
 }
 
 // AppendRowChangedEvent implements the RowEventEncoder interface
 func (d *BatchEncoder) AppendRowChangedEvent(
-	_ context.Context,
+	ctx context.Context,
 	_ string,
 	e *model.RowChangedEvent,
 	callback func(),
@@ -97,11 +109,18 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 			zap.Int("length", length),
 			zap.Any("table", e.Table),
 			zap.Any("key", key))
-		if !d.config.LargeMessageOnlyHandleKeyColumns {
+		if !d.config.LargeMessageOnlyHandleKeyColumns && d.claimCheck == nil {
 			return cerror.ErrMessageTooLarge.GenWithStackByArgs()
 		}
 
-		key, value, err = d.buildMessageOnlyHandleKeyColumns(e)
+		var claimCheckLocation string
+		if d.claimCheck != nil {
+			claimCheckLocation, err = d.claimCheck.WriteMessage(ctx, key, value, e.CommitTs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		key, value, err = d.buildMessageOnlyHandleKeyColumns(e, claimCheckLocation)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -204,6 +223,38 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 	return ret, nil
 }
 
+// EncodeSyncPointEvent implements the SyncPointEventEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(e *model.SyncPointEvent) (*common.Message, error) {
+	keyMsg, valueMsg := syncPointEventToMsg(e)
+	key, err := keyMsg.Encode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := valueMsg.encode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var keyLenByte [8]byte
+	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+	var valueLenByte [8]byte
+	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
+
+	keyBuf := new(bytes.Buffer)
+	var versionByte [8]byte
+	binary.BigEndian.PutUint64(versionByte[:], codec.BatchVersion1)
+	keyBuf.Write(versionByte[:])
+	keyBuf.Write(keyLenByte[:])
+	keyBuf.Write(key)
+
+	valueBuf := new(bytes.Buffer)
+	valueBuf.Write(valueLenByte[:])
+	valueBuf.Write(value)
+
+	ret := common.NewSyncPointMsg(config.ProtocolOpen, keyBuf.Bytes(), valueBuf.Bytes(), e.PrimaryTs)
+	return ret, nil
+}
+
 // Build implements the RowEventEncoder interface
 func (d *BatchEncoder) Build() (messages []*common.Message) {
 	d.tryBuildCallback()
@@ -227,17 +278,33 @@ func (d *BatchEncoder) tryBuildCallback() {
 }
 
 type batchEncoderBuilder struct {
-	config *common.Config
+	config     *common.Config
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // Build a BatchEncoder
 func (b *batchEncoderBuilder) Build() codec.RowEventEncoder {
-	return NewBatchEncoder(b.config)
+	return &BatchEncoder{
+		config:     b.config,
+		claimCheck: b.claimCheck,
+	}
 }
 
 // NewBatchEncoderBuilder creates an open-protocol batchEncoderBuilder.
-func NewBatchEncoderBuilder(config *common.Config) codec.RowEventEncoderBuilder {
-	return &batchEncoderBuilder{config: config}
+func NewBatchEncoderBuilder(
+	ctx context.Context, config *common.Config,
+) (codec.RowEventEncoderBuilder, error) {
+	var (
+		claimCheck *claimcheck.ClaimCheck
+		err        error
+	)
+	if config.LargeMessageClaimCheckStorageURI != "" {
+		claimCheck, err = claimcheck.New(ctx, config.LargeMessageClaimCheckStorageURI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &batchEncoderBuilder{config: config, claimCheck: claimCheck}, nil
 }
 
 // NewBatchEncoder creates a new BatchEncoder.
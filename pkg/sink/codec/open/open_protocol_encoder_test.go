@@ -53,19 +53,21 @@ func TestMaxMessageBytes(t *testing.T) {
 	// for a single message, the overhead is 36(maxRecordOverhead) + 8(versionHea) = 44, just can hold it.
 	a := 88 + 44
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(a)
-	encoder := NewBatchEncoderBuilder(config).Build()
-	err := encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
+	builder, err := NewBatchEncoderBuilder(ctx, config)
+	require.NoError(t, err)
+	encoder := builder.Build()
+	err = encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.Nil(t, err)
 
 	// cannot hold a single message
 	config = config.WithMaxMessageBytes(a - 1)
-	encoder = NewBatchEncoderBuilder(config).Build()
+	encoder = builder.Build()
 	err = encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.NotNil(t, err)
 
 	// make sure each batch's `Length` not greater than `max-message-bytes`
 	config = config.WithMaxMessageBytes(256)
-	encoder = NewBatchEncoderBuilder(config).Build()
+	encoder = builder.Build()
 	for i := 0; i < 10000; i++ {
 		err := encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 		require.Nil(t, err)
@@ -81,7 +83,9 @@ func TestMaxBatchSize(t *testing.T) {
 	t.Parallel()
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(1048576)
 	config.MaxBatchSize = 64
-	encoder := NewBatchEncoderBuilder(config).Build()
+	builder, err := NewBatchEncoderBuilder(context.Background(), config)
+	require.NoError(t, err)
+	encoder := builder.Build()
 
 	testEvent := &model.RowChangedEvent{
 		CommitTs: 1,
@@ -99,7 +103,8 @@ func TestMaxBatchSize(t *testing.T) {
 	}
 
 	messages := encoder.Build()
-	decoder := NewBatchDecoder()
+	decoder, err := NewBatchDecoder(context.Background(), config)
+	require.NoError(t, err)
 	sum := 0
 	for _, msg := range messages {
 		err := decoder.AddKeyValue(msg.Key, msg.Value)
@@ -207,10 +212,15 @@ func TestOpenProtocolBatchCodec(t *testing.T) {
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(8192)
 	config.MaxBatchSize = 64
 	tester := internal.NewDefaultBatchTester()
-	tester.TestBatchCodec(t, NewBatchEncoderBuilder(config),
+	builder, err := NewBatchEncoderBuilder(context.Background(), config)
+	require.NoError(t, err)
+	tester.TestBatchCodec(t, builder,
 		func(key []byte, value []byte) (codec.RowEventDecoder, error) {
-			decoder := NewBatchDecoder()
-			err := decoder.AddKeyValue(key, value)
+			decoder, err := NewBatchDecoder(context.Background(), config)
+			if err != nil {
+				return nil, err
+			}
+			err = decoder.AddKeyValue(key, value)
 			return decoder, err
 		})
 }
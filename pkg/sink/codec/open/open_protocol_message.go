@@ -87,6 +87,35 @@ func (m *messageDDL) decode(data []byte) error {
 	return cerror.WrapError(cerror.ErrUnmarshalFailed, json.Unmarshal(data, m))
 }
 
+type messageSyncPoint struct {
+	SecondaryTs uint64 `json:"s"`
+}
+
+func (m *messageSyncPoint) encode() ([]byte, error) {
+	data, err := json.Marshal(m)
+	return data, cerror.WrapError(cerror.ErrMarshalFailed, err)
+}
+
+func (m *messageSyncPoint) decode(data []byte) error {
+	return cerror.WrapError(cerror.ErrUnmarshalFailed, json.Unmarshal(data, m))
+}
+
+func syncPointEventToMsg(e *model.SyncPointEvent) (*internal.MessageKey, *messageSyncPoint) {
+	key := &internal.MessageKey{
+		Ts:   e.PrimaryTs,
+		Type: model.MessageTypeSyncPoint,
+	}
+	value := &messageSyncPoint{SecondaryTs: e.SecondaryTs}
+	return key, value
+}
+
+func msgToSyncPointEvent(key *internal.MessageKey, value *messageSyncPoint) *model.SyncPointEvent {
+	return &model.SyncPointEvent{
+		PrimaryTs:   key.Ts,
+		SecondaryTs: value.SecondaryTs,
+	}
+}
+
 func newResolvedMessage(ts uint64) *internal.MessageKey {
 	return &internal.MessageKey{
 		Ts:   ts,
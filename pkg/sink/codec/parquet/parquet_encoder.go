@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+const (
+	operationInsert = "I"
+	operationDelete = "D"
+	operationUpdate = "U"
+)
+
+// rowRecord is the intermediate representation of a row changed event.
+// A parquet file can not be built by concatenating the encoded messages
+// like the other protocols, since its metadata is written in the footer.
+// So the rows are encoded in JSON lines first, and then all lines of a
+// data file are converted into a parquet file by EncodeFile.
+type rowRecord struct {
+	Op       string                 `json:"op"`
+	CommitTs uint64                 `json:"commitTs"`
+	Values   map[string]interface{} `json:"values"`
+}
+
+// TxnEventEncoder encodes the txn events into the intermediate rows.
+type TxnEventEncoder struct {
+	valueBuf  *bytes.Buffer
+	callback  func()
+	batchSize int
+
+	// Store some fields of the txn event.
+	txnCommitTs uint64
+	txnSchema   *string
+	txnTable    *string
+}
+
+// newTxnEventEncoder creates a new parquet TxnEventEncoder.
+func newTxnEventEncoder() codec.TxnEventEncoder {
+	return &TxnEventEncoder{
+		valueBuf: &bytes.Buffer{},
+	}
+}
+
+// AppendTxnEvent implements the TxnEventEncoder interface
+func (e *TxnEventEncoder) AppendTxnEvent(
+	txn *model.SingleTableTxn,
+	callback func(),
+) error {
+	for _, row := range txn.Rows {
+		record, err := rowChangedEventToRecord(row)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(record)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		e.valueBuf.Write(value)
+		e.valueBuf.WriteByte('\n')
+		e.batchSize++
+	}
+	e.callback = callback
+	e.txnCommitTs = txn.CommitTs
+	e.txnSchema = &txn.Table.Schema
+	e.txnTable = &txn.Table.Table
+	return nil
+}
+
+// Build implements the TxnEventEncoder interface
+func (e *TxnEventEncoder) Build() []*common.Message {
+	if e.batchSize == 0 {
+		return nil
+	}
+
+	value := make([]byte, e.valueBuf.Len())
+	copy(value, e.valueBuf.Bytes())
+	ret := common.NewMsg(config.ProtocolParquet, nil,
+		value, e.txnCommitTs, model.MessageTypeRow, e.txnSchema, e.txnTable)
+	ret.SetRowsCount(e.batchSize)
+	ret.Callback = e.callback
+	e.valueBuf.Reset()
+	e.callback = nil
+	e.batchSize = 0
+	e.txnCommitTs = 0
+	e.txnSchema = nil
+	e.txnTable = nil
+
+	return []*common.Message{ret}
+}
+
+type txnEventEncoderBuilder struct{}
+
+// NewTxnEventEncoderBuilder creates a parquet txnEventEncoderBuilder.
+func NewTxnEventEncoderBuilder(_ *common.Config) codec.TxnEventEncoderBuilder {
+	return &txnEventEncoderBuilder{}
+}
+
+// Build a parquet TxnEventEncoder.
+func (b *txnEventEncoderBuilder) Build() codec.TxnEventEncoder {
+	return newTxnEventEncoder()
+}
+
+func rowChangedEventToRecord(e *model.RowChangedEvent) (*rowRecord, error) {
+	record := &rowRecord{
+		CommitTs: e.CommitTs,
+	}
+	columns := e.Columns
+	switch {
+	case e.IsDelete():
+		record.Op = operationDelete
+		columns = e.PreColumns
+	case e.PreColumns == nil:
+		record.Op = operationInsert
+	default:
+		// for update operation, we only record the after columns.
+		record.Op = operationUpdate
+	}
+
+	record.Values = make(map[string]interface{}, len(columns))
+	for i, col := range columns {
+		if col == nil {
+			continue
+		}
+		var ft *types.FieldType
+		if i < len(e.ColInfos) {
+			ft = e.ColInfos[i].Ft
+		}
+		value, err := columnToRecordValue(col, ft)
+		if err != nil {
+			return nil, err
+		}
+		record.Values[col.Name] = value
+	}
+	return record, nil
+}
+
+// columnToRecordValue converts the column value to a value which can be
+// encoded in JSON without losing precision. The binary values are kept in
+// []byte, which are encoded in base64 by JSON.
+func columnToRecordValue(col *model.Column, ft *types.FieldType) (interface{}, error) {
+	if col.Value == nil {
+		return nil, nil
+	}
+
+	switch col.Type {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if col.Flag.IsBinary() {
+			if v, ok := col.Value.(string); ok {
+				return []byte(v), nil
+			}
+			return col.Value, nil
+		}
+		if v, ok := col.Value.([]byte); ok {
+			return string(v), nil
+		}
+		return col.Value, nil
+	case mysql.TypeEnum:
+		v, ok := col.Value.(uint64)
+		if !ok || ft == nil {
+			return col.Value, nil
+		}
+		enumVar, err := types.ParseEnumValue(ft.GetElems(), v)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		return enumVar.Name, nil
+	case mysql.TypeSet:
+		v, ok := col.Value.(uint64)
+		if !ok || ft == nil {
+			return col.Value, nil
+		}
+		setVar, err := types.ParseSetValue(ft.GetElems(), v)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		return setVar.Name, nil
+	default:
+		return col.Value, nil
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParquetTxnEventEncoder(t *testing.T) {
+	t.Parallel()
+
+	enumFt := types.NewFieldType(mysql.TypeEnum)
+	enumFt.SetElems([]string{"a", "b"})
+	colInfos := []rowcodec.ColInfo{
+		{ID: 1, Ft: types.NewFieldType(mysql.TypeLonglong)},
+		{ID: 2, Ft: types.NewFieldType(mysql.TypeBlob)},
+		{ID: 3, Ft: enumFt},
+	}
+	table := &model.TableName{Schema: "test", Table: "t"}
+	txn := &model.SingleTableTxn{
+		Table:    table,
+		CommitTs: 2,
+		Rows: []*model.RowChangedEvent{
+			{
+				CommitTs: 2,
+				Table:    table,
+				Columns: []*model.Column{
+					{Name: "id", Type: mysql.TypeLonglong, Value: int64(1)},
+					{Name: "data", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte{1, 2}},
+					{Name: "e", Type: mysql.TypeEnum, Value: uint64(2)},
+				},
+				ColInfos: colInfos,
+			},
+			{
+				CommitTs: 2,
+				Table:    table,
+				PreColumns: []*model.Column{
+					{Name: "id", Type: mysql.TypeLonglong, Value: int64(1)},
+					{Name: "data", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: nil},
+					{Name: "e", Type: mysql.TypeEnum, Value: uint64(1)},
+				},
+				Columns: []*model.Column{
+					{Name: "id", Type: mysql.TypeLonglong, Value: int64(2)},
+					{Name: "data", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: nil},
+					{Name: "e", Type: mysql.TypeEnum, Value: uint64(1)},
+				},
+				ColInfos: colInfos,
+			},
+			{
+				CommitTs: 2,
+				Table:    table,
+				PreColumns: []*model.Column{
+					{Name: "id", Type: mysql.TypeLonglong, Value: int64(2)},
+				},
+				ColInfos: colInfos,
+			},
+		},
+	}
+
+	builder := NewTxnEventEncoderBuilder(common.NewConfig(config.ProtocolParquet))
+	encoder := builder.Build()
+	require.Empty(t, encoder.Build())
+
+	callbackCalled := false
+	err := encoder.AppendTxnEvent(txn, func() { callbackCalled = true })
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 3, messages[0].GetRowsCount())
+	require.Equal(t, `{"op":"I","commitTs":2,"values":{"data":"AQI=","e":"b","id":1}}
+{"op":"U","commitTs":2,"values":{"data":null,"e":"a","id":2}}
+{"op":"D","commitTs":2,"values":{"id":2}}
+`, string(messages[0].Value))
+	messages[0].Callback()
+	require.True(t, callbackCalled)
+	// the encoder is reset after build.
+	require.Empty(t, encoder.Build())
+}
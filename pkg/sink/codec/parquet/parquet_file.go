@@ -0,0 +1,300 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	ptypes "github.com/xitongsys/parquet-go/types"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+)
+
+const (
+	// OpColumnName is the name of the column which records the operation
+	// type of the row, the value is one of `I`, `U` and `D`.
+	OpColumnName = "_tidb_op"
+	// CommitTsColumnName is the name of the column which records the
+	// commit ts of the row.
+	CommitTsColumnName = "_tidb_commit_ts"
+
+	// parallelNumber is the number of goroutines used to marshal the rows.
+	parallelNumber = 4
+	dateLayout     = "2006-01-02"
+)
+
+// physicalType is the parquet physical type of a column.
+type physicalType string
+
+const (
+	typeInt32     physicalType = "INT32"
+	typeInt64     physicalType = "INT64"
+	typeFloat     physicalType = "FLOAT"
+	typeDouble    physicalType = "DOUBLE"
+	typeByteArray physicalType = "BYTE_ARRAY"
+)
+
+// columnSchema describes how a column is stored in the parquet file.
+type columnSchema struct {
+	name          string
+	physicalType  physicalType
+	convertedType string
+	precision     int
+	scale         int
+	// binary indicates the values are encoded in base64 in the rows.
+	binary bool
+}
+
+// newColumnSchema maps the column type of the table definition to the
+// parquet type. The time types except DATE are stored as strings, since
+// their values are formatted in the time zone of the changefeed.
+func newColumnSchema(col cloudstorage.TableCol) (*columnSchema, error) {
+	c := &columnSchema{name: col.Name}
+	tp := strings.TrimSuffix(col.Tp, " UNSIGNED")
+	unsigned := tp != col.Tp
+	switch tp {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "YEAR":
+		c.physicalType = typeInt64
+		if unsigned && tp == "BIGINT" {
+			c.convertedType = "UINT_64"
+		}
+	case "BIT":
+		c.physicalType = typeInt64
+		c.convertedType = "UINT_64"
+	case "FLOAT":
+		c.physicalType = typeFloat
+	case "DOUBLE":
+		c.physicalType = typeDouble
+	case "DECIMAL":
+		precision, err := strconv.Atoi(col.Precision)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		scale, err := strconv.Atoi(col.Scale)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+		c.physicalType = typeByteArray
+		c.convertedType = "DECIMAL"
+		c.precision = precision
+		c.scale = scale
+	case "DATE":
+		c.physicalType = typeInt32
+		c.convertedType = "DATE"
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
+		c.physicalType = typeByteArray
+		c.binary = true
+	default:
+		c.physicalType = typeByteArray
+		c.convertedType = "UTF8"
+	}
+	return c, nil
+}
+
+// metadata returns the metadata used to create the parquet writer.
+// The name in the metadata is only used internally, because the
+// original column name may contain characters which are not allowed
+// in the metadata.
+func (c *columnSchema) metadata(index int) string {
+	md := fmt.Sprintf("name=c%d, type=%s, repetitiontype=OPTIONAL", index, c.physicalType)
+	if c.convertedType != "" {
+		md += ", convertedtype=" + c.convertedType
+	}
+	if c.convertedType == "DECIMAL" {
+		md += fmt.Sprintf(", precision=%d, scale=%d", c.precision, c.scale)
+	}
+	return md
+}
+
+// convert converts the value decoded from the intermediate rows
+// to the value of the parquet type.
+func (c *columnSchema) convert(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	var str string
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	default:
+		str = fmt.Sprint(v)
+	}
+
+	switch c.physicalType {
+	case typeInt64:
+		if c.convertedType == "UINT_64" {
+			v, err := strconv.ParseUint(str, 10, 64)
+			return int64(v), errors.Trace(err)
+		}
+		v, err := strconv.ParseInt(str, 10, 64)
+		return v, errors.Trace(err)
+	case typeInt32:
+		// DATE is the only type stored in INT32, which is the number
+		// of days from the Unix epoch. The zero date is stored as NULL.
+		t, err := time.Parse(dateLayout, str)
+		if err != nil {
+			log.Warn("invalid date value, store it as null",
+				zap.String("column", c.name), zap.String("value", str))
+			return nil, nil
+		}
+		return int32(t.Unix() / int64(24*time.Hour/time.Second)), nil
+	case typeFloat:
+		v, err := strconv.ParseFloat(str, 32)
+		return float32(v), errors.Trace(err)
+	case typeDouble:
+		v, err := strconv.ParseFloat(str, 64)
+		return v, errors.Trace(err)
+	}
+
+	if c.binary {
+		v, err := base64.StdEncoding.DecodeString(str)
+		return string(v), errors.Trace(err)
+	}
+	if c.convertedType == "DECIMAL" {
+		return ptypes.StrIntToBinary(unscaledDecimal(str, c.scale), "BigEndian", 0, true), nil
+	}
+	return str, nil
+}
+
+// unscaledDecimal returns the unscaled integer of the decimal string.
+func unscaledDecimal(str string, scale int) string {
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if len(fracPart) > scale {
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return "0"
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// EncodeFile converts the intermediate rows of a data file into a parquet
+// file. The schema of the parquet file is derived from the table definition,
+// which contains the operation type, the commit ts and all table columns.
+func EncodeFile(def *cloudstorage.TableDefinition, data []byte) ([]byte, error) {
+	columns := make([]*columnSchema, 0, len(def.Columns)+2)
+	columns = append(columns,
+		&columnSchema{name: OpColumnName, physicalType: typeByteArray, convertedType: "UTF8"},
+		&columnSchema{name: CommitTsColumnName, physicalType: typeInt64, convertedType: "UINT_64"})
+	for _, col := range def.Columns {
+		c, err := newColumnSchema(col)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	metadata := make([]string, 0, len(columns))
+	for i, c := range columns {
+		metadata = append(metadata, c.metadata(i))
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := writer.NewCSVWriterFromWriter(metadata, buf, parallelNumber)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+	}
+	// Rename the columns to the original names, the first
+	// element of the infos is the root of the schema.
+	for i, c := range columns {
+		w.SchemaHandler.Infos[i+1].ExName = c.name
+	}
+	w.SchemaHandler.CreateInExMap()
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		record := &rowRecord{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(record); err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+
+		values := make([]interface{}, len(columns))
+		values[0] = record.Op
+		values[1] = int64(record.CommitTs)
+		for i, c := range columns[2:] {
+			v, err := c.convert(record.Values[c.name])
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+			}
+			values[i+2] = v
+		}
+		if err := w.Write(values); err != nil {
+			return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+	}
+	if err := w.WriteStop(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrParquetEncodeFailed, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// memoryFile is a read only parquet file in memory.
+type memoryFile struct {
+	*bytes.Reader
+	data []byte
+}
+
+func (f *memoryFile) Open(string) (source.ParquetFile, error) {
+	return &memoryFile{Reader: bytes.NewReader(f.data), data: f.data}, nil
+}
+
+func (f *memoryFile) Create(string) (source.ParquetFile, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (f *memoryFile) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func (f *memoryFile) Close() error {
+	return nil
+}
+
+// NewColumnReaderForTests returns a column reader of the parquet file content.
+func NewColumnReaderForTests(content []byte) (*reader.ParquetReader, error) {
+	pf := &memoryFile{Reader: bytes.NewReader(content), data: content}
+	return reader.NewParquetColumnReader(pf, 1)
+}
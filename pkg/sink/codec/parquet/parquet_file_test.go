@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/xitongsys/parquet-go/types"
+)
+
+func TestUnscaledDecimal(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value    string
+		scale    int
+		expected string
+	}{
+		{"123.45", 2, "12345"},
+		{"123.4", 2, "12340"},
+		{"123", 2, "12300"},
+		{"-0.05", 2, "-5"},
+		{"0.00", 2, "0"},
+		{"12", 0, "12"},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, unscaledDecimal(c.value, c.scale), c)
+	}
+}
+
+func TestEncodeFile(t *testing.T) {
+	t.Parallel()
+
+	def := &cloudstorage.TableDefinition{
+		Table:  "t",
+		Schema: "test",
+		Columns: []cloudstorage.TableCol{
+			{Name: "id", Tp: "BIGINT UNSIGNED", IsPK: "true"},
+			{Name: "user name", Tp: "VARCHAR"},
+			{Name: "price", Tp: "DECIMAL", Precision: "10", Scale: "2"},
+			{Name: "birthday", Tp: "DATE"},
+			{Name: "score", Tp: "DOUBLE"},
+			{Name: "data", Tp: "BLOB"},
+		},
+	}
+	data := []byte(`{"op":"I","commitTs":100,"values":{"id":18446744073709551615,` +
+		`"user name":"alice","price":"12.30","birthday":"1970-01-02","score":1.5,"data":"AQI="}}
+{"op":"D","commitTs":101,"values":{"id":2,"user name":null,"birthday":"0000-00-00"}}
+`)
+	content, err := EncodeFile(def, data)
+	require.NoError(t, err)
+
+	pr, err := NewColumnReaderForTests(content)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(2), pr.GetNumRows())
+
+	// the names of the footer are converted by the reader,
+	// the original names are kept in the schema handler.
+	names := make([]string, 0, len(pr.SchemaHandler.Infos))
+	for _, info := range pr.SchemaHandler.Infos[1:] {
+		names = append(names, info.ExName)
+	}
+	require.Equal(t, []string{
+		OpColumnName, CommitTsColumnName,
+		"id", "user name", "price", "birthday", "score", "data",
+	}, names)
+
+	expected := [][]interface{}{
+		{"I", "D"},
+		{int64(100), int64(101)},
+		{int64(-1), int64(2)},
+		{"alice", nil},
+		{"1230", nil},
+		{int32(1), nil},
+		{float64(1.5), nil},
+		{string([]byte{1, 2}), nil},
+	}
+	for i, values := range expected {
+		actual, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		require.NoError(t, err)
+		if i == 4 {
+			require.Equal(t, "12.30",
+				ptypes.DECIMAL_BYTE_ARRAY_ToString([]byte(actual[0].(string)), 10, 2))
+			require.Nil(t, actual[1])
+			continue
+		}
+		require.Equal(t, values, actual, i)
+	}
+
+	_, err = EncodeFile(def, []byte("{"))
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/proto/ticdc"
+)
+
+// batchDecoder decodes the protobuf messages into the original events.
+type batchDecoder struct {
+	data  []byte
+	event *ticdc.Event
+}
+
+// NewBatchDecoder returns a decoder for protobuf.
+func NewBatchDecoder() codec.RowEventDecoder {
+	return &batchDecoder{}
+}
+
+// AddKeyValue implements the RowEventDecoder interface
+func (b *batchDecoder) AddKeyValue(_, value []byte) error {
+	b.data = value
+	return nil
+}
+
+// HasNext implements the RowEventDecoder interface
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	if len(b.data) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+	event := &ticdc.Event{}
+	err := event.Unmarshal(b.data)
+	b.data = nil
+	if err != nil {
+		return model.MessageTypeUnknown, false,
+			cerror.WrapError(cerror.ErrProtobufDecodeFailed, err)
+	}
+	// The messages of the newer versions are still decodable, since the
+	// schema is only evolved in a backward compatible way.
+	if event.Version < Version1 {
+		return model.MessageTypeUnknown, false, cerror.ErrProtobufDecodeFailed.
+			GenWithStack("unexpected protobuf protocol version %d", event.Version)
+	}
+	b.event = event
+	return messageType(event), true, nil
+}
+
+// NextResolvedEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	if b.event == nil || messageType(b.event) != model.MessageTypeResolved {
+		return 0, cerror.ErrProtobufDecodeFailed.
+			GenWithStack("not found resolved event message")
+	}
+	ts := b.event.CommitTs
+	b.event = nil
+	return ts, nil
+}
+
+// NextRowChangedEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, bool, error) {
+	if b.event == nil || messageType(b.event) != model.MessageTypeRow {
+		return nil, false, cerror.ErrProtobufDecodeFailed.
+			GenWithStack("not found row changed event message")
+	}
+	result, err := eventToRowChange(b.event)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	b.event = nil
+	return result, false, nil
+}
+
+// NextDDLEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	if b.event == nil || messageType(b.event) != model.MessageTypeDDL {
+		return nil, cerror.ErrProtobufDecodeFailed.
+			GenWithStack("not found ddl event message")
+	}
+	result, err := eventToDDL(b.event)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b.event = nil
+	return result, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/proto/ticdc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(common.NewConfig(config.ProtocolProtobuf))
+	decoder := NewBatchDecoder()
+
+	events := []*model.RowChangedEvent{
+		{
+			CommitTs: 1,
+			Table:    &model.TableName{Schema: "test", Table: "t", TableID: 100},
+			Columns:  testColumns,
+		},
+		{
+			CommitTs: 2,
+			Table: &model.TableName{
+				Schema: "test", Table: "t", TableID: 101, IsPartition: true,
+			},
+			PreColumns: testPreColumns,
+			Columns:    testColumns,
+		},
+		{
+			CommitTs:   3,
+			Table:      &model.TableName{Schema: "test", Table: "t", TableID: 100},
+			PreColumns: testPreColumns,
+		},
+	}
+	for _, e := range events {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", e, nil)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, len(events))
+
+	for i, message := range messages {
+		require.NoError(t, decoder.AddKeyValue(message.Key, message.Value))
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		_, err = decoder.NextDDLEvent()
+		require.Error(t, err)
+
+		event, onlyHandleKey, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.False(t, onlyHandleKey)
+		require.Equal(t, events[i].CommitTs, event.CommitTs)
+		require.Equal(t, events[i].Table, event.Table)
+		require.Equal(t, events[i].PreColumns, event.PreColumns)
+		require.Equal(t, events[i].Columns, event.Columns)
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+}
+
+func TestDecodeDDLAndResolvedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(common.NewConfig(config.ProtocolProtobuf))
+	decoder := NewBatchDecoder()
+
+	message, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 5,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test"},
+		},
+		Query: "CREATE DATABASE test",
+		Type:  timodel.ActionCreateSchema,
+	})
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(message.Key, message.Value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	_, _, err = decoder.NextRowChangedEvent()
+	require.Error(t, err)
+	ddl, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), ddl.CommitTs)
+	require.Equal(t, "test", ddl.TableInfo.TableName.Schema)
+	require.Equal(t, "CREATE DATABASE test", ddl.Query)
+	require.Equal(t, timodel.ActionCreateSchema, ddl.Type)
+
+	message, err = encoder.EncodeCheckpointEvent(10)
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(message.Key, message.Value))
+	tp, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), ts)
+}
+
+func TestDecodeInvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	decoder := NewBatchDecoder()
+	require.NoError(t, decoder.AddKeyValue(nil, []byte{0xff, 0xff}))
+	_, _, err := decoder.HasNext()
+	require.ErrorContains(t, err, "ErrProtobufDecodeFailed")
+
+	// the version must be set.
+	value, err := (&ticdc.Event{Type: ticdc.EventType_EVENT_RESOLVED, CommitTs: 1}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(nil, value))
+	_, _, err = decoder.HasNext()
+	require.True(t, cerror.ErrProtobufDecodeFailed.Equal(err))
+
+	// the row changed event message must carry the row.
+	value, err = (&ticdc.Event{
+		Version: Version1, Type: ticdc.EventType_EVENT_ROW, CommitTs: 1,
+	}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(nil, value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	_, _, err = decoder.NextRowChangedEvent()
+	require.True(t, cerror.ErrProtobufDecodeFailed.Equal(err))
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/proto/ticdc"
+	"go.uber.org/zap"
+)
+
+func marshalEvent(e *ticdc.Event) ([]byte, error) {
+	value, err := e.Marshal()
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrProtobufEncodeFailed, err)
+	}
+	return value, nil
+}
+
+// RowEventEncoder encodes events into the protobuf messages defined in
+// `proto/TiCDCProtocol.proto`, every event is encoded into one message.
+type RowEventEncoder struct {
+	messages []*common.Message
+
+	config *common.Config
+}
+
+// newRowEventEncoder creates a new RowEventEncoder.
+func newRowEventEncoder(config *common.Config) codec.RowEventEncoder {
+	return &RowEventEncoder{
+		messages: make([]*common.Message, 0, 1),
+		config:   config,
+	}
+}
+
+// EncodeCheckpointEvent implements the RowEventEncoder interface.
+func (d *RowEventEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	value, err := marshalEvent(&ticdc.Event{
+		Version:  Version1,
+		Type:     ticdc.EventType_EVENT_RESOLVED,
+		CommitTs: ts,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewResolvedMsg(config.ProtocolProtobuf, nil, value, ts), nil
+}
+
+// AppendRowChangedEvent implements the RowEventEncoder interface.
+func (d *RowEventEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	event, err := rowChangeToEvent(e, d.config.DeleteOnlyHandleKeyColumns)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	value, err := marshalEvent(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	length := len(value) + common.MaxRecordOverhead
+	// For single message that is longer than max-message-bytes, do not send it.
+	if length > d.config.MaxMessageBytes {
+		log.Warn("Single message is too large for protobuf",
+			zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", e.Table))
+		return cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	m := &common.Message{
+		Value:    value,
+		Ts:       e.CommitTs,
+		Schema:   &e.Table.Schema,
+		Table:    &e.Table.Table,
+		Type:     model.MessageTypeRow,
+		Protocol: config.ProtocolProtobuf,
+		Callback: callback,
+	}
+	m.IncRowsCount()
+
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// EncodeDDLEvent implements the RowEventEncoder interface.
+func (d *RowEventEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
+	value, err := marshalEvent(ddlToEvent(e))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewDDLMsg(config.ProtocolProtobuf, nil, value, e), nil
+}
+
+// Build implements the RowEventEncoder interface.
+func (d *RowEventEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a protobuf batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.RowEventEncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a `RowEventEncoder`
+func (b *batchEncoderBuilder) Build() codec.RowEventEncoder {
+	return newRowEventEncoder(b.config)
+}
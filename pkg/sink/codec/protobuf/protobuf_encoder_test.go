@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/proto/ticdc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testColumns = []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("tidb")},
+		{
+			Name:  "amount",
+			Type:  mysql.TypeLong,
+			Flag:  model.UnsignedFlag,
+			Value: uint64(100),
+		},
+		{Name: "ratio", Type: mysql.TypeFloat, Value: float32(0.5)},
+		{Name: "price", Type: mysql.TypeDouble, Value: 3.14},
+		{Name: "created", Type: mysql.TypeDatetime, Value: "2023-01-01 00:00:00"},
+		{Name: "nullable", Type: mysql.TypeVarchar, Value: nil},
+	}
+	testPreColumns = []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("tikv")},
+		{
+			Name:  "amount",
+			Type:  mysql.TypeLong,
+			Flag:  model.UnsignedFlag,
+			Value: uint64(99),
+		},
+		{Name: "ratio", Type: mysql.TypeFloat, Value: float32(0.25)},
+		{Name: "price", Type: mysql.TypeDouble, Value: 2.71},
+		{Name: "created", Type: mysql.TypeDatetime, Value: "2022-01-01 00:00:00"},
+		{Name: "nullable", Type: mysql.TypeVarchar, Value: []byte("x")},
+	}
+)
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(common.NewConfig(config.ProtocolProtobuf))
+	update := &model.RowChangedEvent{
+		CommitTs:   424316552636792833,
+		Table:      &model.TableName{Schema: "test", Table: "t", TableID: 100},
+		PreColumns: testPreColumns,
+		Columns:    testColumns,
+	}
+	count := 0
+	err := encoder.AppendRowChangedEvent(context.Background(), "", update, func() { count++ })
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, config.ProtocolProtobuf, messages[0].Protocol)
+	messages[0].Callback()
+	require.Equal(t, 1, count)
+	require.Nil(t, encoder.Build())
+
+	event := &ticdc.Event{}
+	require.NoError(t, event.Unmarshal(messages[0].Value))
+	require.Equal(t, uint32(Version1), event.Version)
+	require.Equal(t, ticdc.EventType_EVENT_ROW, event.Type)
+	require.Equal(t, uint64(424316552636792833), event.CommitTs)
+	require.Equal(t, "test", event.Schema)
+	require.Equal(t, "t", event.Table)
+	require.Equal(t, ticdc.RowOp_OP_UPDATE, event.Row.Op)
+	require.Equal(t, int64(100), event.Row.TableId)
+	require.Len(t, event.Row.PreColumns, len(testPreColumns))
+	require.Len(t, event.Row.Columns, len(testColumns))
+
+	columns := event.Row.Columns
+	require.Equal(t, "id", columns[0].Name)
+	require.Equal(t, uint32(mysql.TypeLonglong), columns[0].MysqlType)
+	require.Equal(t, uint64(model.HandleKeyFlag|model.PrimaryKeyFlag), columns[0].Flag)
+	require.Equal(t, int64(1), columns[0].GetIntValue())
+	require.Equal(t, []byte("tidb"), columns[1].GetBytesValue())
+	require.Equal(t, uint64(100), columns[2].GetUintValue())
+	require.Equal(t, float32(0.5), columns[3].GetFloatValue())
+	require.Equal(t, 3.14, columns[4].GetDoubleValue())
+	require.Equal(t, "2023-01-01 00:00:00", columns[5].GetStringValue())
+	// NULL is encoded as an unset value.
+	require.Nil(t, columns[6].Value)
+}
+
+func TestEncodeDeleteOnlyHandleKeyColumns(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolProtobuf)
+	c.DeleteOnlyHandleKeyColumns = true
+	encoder := newRowEventEncoder(c)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs:   1,
+		Table:      &model.TableName{Schema: "test", Table: "t"},
+		PreColumns: testPreColumns,
+	}, nil)
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	event := &ticdc.Event{}
+	require.NoError(t, event.Unmarshal(messages[0].Value))
+	require.Equal(t, ticdc.RowOp_OP_DELETE, event.Row.Op)
+	require.Empty(t, event.Row.Columns)
+	require.Len(t, event.Row.PreColumns, 1)
+	require.Equal(t, "id", event.Row.PreColumns[0].Name)
+}
+
+func TestEncodeInvalidEvent(t *testing.T) {
+	t.Parallel()
+
+	c := common.NewConfig(config.ProtocolProtobuf)
+	c.MaxMessageBytes = 64
+	encoder := newRowEventEncoder(c)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns:  testColumns,
+	}, nil)
+	require.True(t, cerror.ErrMessageTooLarge.Equal(err))
+
+	encoder = newRowEventEncoder(common.NewConfig(config.ProtocolProtobuf))
+	err = encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: struct{}{}},
+		},
+	}, nil)
+	require.True(t, cerror.ErrProtobufEncodeFailed.Equal(err))
+}
+
+func TestEncodeDDLAndCheckpointEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newRowEventEncoder(common.NewConfig(config.ProtocolProtobuf))
+	message, err := encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: 5,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: "t"},
+		},
+		Query: "CREATE TABLE test.t(id int primary key)",
+		Type:  timodel.ActionCreateTable,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, message.Type)
+	event := &ticdc.Event{}
+	require.NoError(t, event.Unmarshal(message.Value))
+	require.Equal(t, ticdc.EventType_EVENT_DDL, event.Type)
+	require.Equal(t, uint64(5), event.CommitTs)
+	require.Equal(t, "test", event.Schema)
+	require.Equal(t, "t", event.Table)
+	require.Equal(t, "CREATE TABLE test.t(id int primary key)", event.Ddl.Query)
+	require.Equal(t, uint32(timodel.ActionCreateTable), event.Ddl.Type)
+
+	message, err = encoder.EncodeCheckpointEvent(10)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeResolved, message.Type)
+	event = &ticdc.Event{}
+	require.NoError(t, event.Unmarshal(message.Value))
+	require.Equal(t, ticdc.EventType_EVENT_RESOLVED, event.Type)
+	require.Equal(t, uint64(10), event.CommitTs)
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/proto/ticdc"
+)
+
+// Version1 is the first version of the protobuf protocol,
+// see `proto/TiCDCProtocol.proto`.
+const Version1 = 1
+
+func columnToProto(col *model.Column) (*ticdc.Column, error) {
+	result := &ticdc.Column{
+		Name:      col.Name,
+		MysqlType: uint32(col.Type),
+		Flag:      uint64(col.Flag),
+	}
+	switch v := col.Value.(type) {
+	case nil:
+	case int64:
+		result.Value = &ticdc.Column_IntValue{IntValue: v}
+	case int:
+		result.Value = &ticdc.Column_IntValue{IntValue: int64(v)}
+	case int32:
+		result.Value = &ticdc.Column_IntValue{IntValue: int64(v)}
+	case int16:
+		result.Value = &ticdc.Column_IntValue{IntValue: int64(v)}
+	case int8:
+		result.Value = &ticdc.Column_IntValue{IntValue: int64(v)}
+	case uint64:
+		result.Value = &ticdc.Column_UintValue{UintValue: v}
+	case uint:
+		result.Value = &ticdc.Column_UintValue{UintValue: uint64(v)}
+	case uint32:
+		result.Value = &ticdc.Column_UintValue{UintValue: uint64(v)}
+	case uint16:
+		result.Value = &ticdc.Column_UintValue{UintValue: uint64(v)}
+	case uint8:
+		result.Value = &ticdc.Column_UintValue{UintValue: uint64(v)}
+	case float32:
+		result.Value = &ticdc.Column_FloatValue{FloatValue: v}
+	case float64:
+		result.Value = &ticdc.Column_DoubleValue{DoubleValue: v}
+	case string:
+		result.Value = &ticdc.Column_StringValue{StringValue: v}
+	case []byte:
+		result.Value = &ticdc.Column_BytesValue{BytesValue: v}
+	default:
+		return nil, cerror.ErrProtobufEncodeFailed.GenWithStack(
+			"unsupported value type %T of column %s", v, col.Name)
+	}
+	return result, nil
+}
+
+func columnsToProto(
+	cols []*model.Column, onlyHandleKey bool,
+) ([]*ticdc.Column, error) {
+	result := make([]*ticdc.Column, 0, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			continue
+		}
+		if onlyHandleKey && !col.Flag.IsHandleKey() {
+			continue
+		}
+		c, err := columnToProto(col)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func protoToColumns(cols []*ticdc.Column) []*model.Column {
+	if len(cols) == 0 {
+		return nil
+	}
+	result := make([]*model.Column, 0, len(cols))
+	for _, col := range cols {
+		c := &model.Column{
+			Name: col.Name,
+			Type: byte(col.MysqlType),
+			Flag: model.ColumnFlagType(col.Flag),
+		}
+		switch v := col.Value.(type) {
+		case *ticdc.Column_IntValue:
+			c.Value = v.IntValue
+		case *ticdc.Column_UintValue:
+			c.Value = v.UintValue
+		case *ticdc.Column_FloatValue:
+			c.Value = v.FloatValue
+		case *ticdc.Column_DoubleValue:
+			c.Value = v.DoubleValue
+		case *ticdc.Column_StringValue:
+			c.Value = v.StringValue
+		case *ticdc.Column_BytesValue:
+			c.Value = v.BytesValue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func rowChangeToEvent(
+	e *model.RowChangedEvent, deleteOnlyHandleKeyColumns bool,
+) (*ticdc.Event, error) {
+	row := &ticdc.RowChanged{
+		TableId:     e.Table.TableID,
+		IsPartition: e.Table.IsPartition,
+	}
+	var err error
+	switch {
+	case e.IsDelete():
+		row.Op = ticdc.RowOp_OP_DELETE
+		row.PreColumns, err = columnsToProto(e.PreColumns, deleteOnlyHandleKeyColumns)
+	case e.IsUpdate():
+		row.Op = ticdc.RowOp_OP_UPDATE
+		if row.PreColumns, err = columnsToProto(e.PreColumns, false); err != nil {
+			return nil, err
+		}
+		row.Columns, err = columnsToProto(e.Columns, false)
+	default:
+		row.Op = ticdc.RowOp_OP_INSERT
+		row.Columns, err = columnsToProto(e.Columns, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ticdc.Event{
+		Version:  Version1,
+		Type:     ticdc.EventType_EVENT_ROW,
+		CommitTs: e.CommitTs,
+		Schema:   e.Table.Schema,
+		Table:    e.Table.Table,
+		Row:      row,
+	}, nil
+}
+
+func eventToRowChange(e *ticdc.Event) (*model.RowChangedEvent, error) {
+	if e.Row == nil {
+		return nil, cerror.ErrProtobufDecodeFailed.GenWithStack(
+			"row changed event message has no row")
+	}
+	return &model.RowChangedEvent{
+		CommitTs: e.CommitTs,
+		Table: &model.TableName{
+			Schema:      e.Schema,
+			Table:       e.Table,
+			TableID:     e.Row.TableId,
+			IsPartition: e.Row.IsPartition,
+		},
+		PreColumns: protoToColumns(e.Row.PreColumns),
+		Columns:    protoToColumns(e.Row.Columns),
+	}, nil
+}
+
+func ddlToEvent(e *model.DDLEvent) *ticdc.Event {
+	result := &ticdc.Event{
+		Version:  Version1,
+		Type:     ticdc.EventType_EVENT_DDL,
+		CommitTs: e.CommitTs,
+		Ddl: &ticdc.DDL{
+			Query: e.Query,
+			Type:  uint32(e.Type),
+		},
+	}
+	if e.TableInfo != nil {
+		result.Schema = e.TableInfo.TableName.Schema
+		result.Table = e.TableInfo.TableName.Table
+	}
+	return result
+}
+
+func eventToDDL(e *ticdc.Event) (*model.DDLEvent, error) {
+	if e.Ddl == nil {
+		return nil, cerror.ErrProtobufDecodeFailed.GenWithStack(
+			"ddl event message has no ddl")
+	}
+	return &model.DDLEvent{
+		CommitTs: e.CommitTs,
+		Query:    e.Ddl.Query,
+		Type:     timodel.ActionType(e.Ddl.Type),
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: e.Schema,
+				Table:  e.Table,
+			},
+		},
+	}, nil
+}
+
+func messageType(e *ticdc.Event) model.MessageType {
+	switch e.Type {
+	case ticdc.EventType_EVENT_ROW:
+		return model.MessageTypeRow
+	case ticdc.EventType_EVENT_DDL:
+		return model.MessageTypeDDL
+	case ticdc.EventType_EVENT_RESOLVED:
+		return model.MessageTypeResolved
+	default:
+		return model.MessageTypeUnknown
+	}
+}
@@ -0,0 +1,469 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/rowcodec"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// redactedValue is the placeholder of the redacted string and binary values.
+const redactedValue = "******"
+
+type mask struct {
+	filter tfilter.ColumnFilter
+	action string
+	salt   string
+	length int
+}
+
+type selector struct {
+	tableFilter tfilter.Filter
+	// columnFilter is nil if all the columns are selected.
+	columnFilter tfilter.ColumnFilter
+	masks        []*mask
+}
+
+// plan is the decision of the columns of a table, the key is the lower
+// case column name, and the columns not in the plan are kept unchanged.
+type plan struct {
+	source *model.TableInfo
+	// target is the projected table info, it's the source table info
+	// if no column is dropped.
+	target  *model.TableInfo
+	actions map[string]*mask
+	dropped bool
+}
+
+// dropMask is the decision of the columns which are not selected.
+var dropMask = &mask{action: config.ColumnMaskActionDrop}
+
+// Selector selects the columns of the rows sent to the downstream, and
+// masks the values of the sensitive columns by the column selectors.
+// The first matched selector of a table takes effect, and the tables which
+// do not match any selector are kept unchanged. It is thread-safe.
+type Selector struct {
+	selectors []*selector
+
+	mu sync.Mutex
+	// plans is keyed by the ID of the table info, only the plan of
+	// the latest version of the table info is cached.
+	plans map[int64]*plan
+}
+
+// New creates a Selector with the given column selectors.
+func New(caseSensitive bool, selectors []*config.ColumnSelector) (*Selector, error) {
+	s := &Selector{
+		selectors: make([]*selector, 0, len(selectors)),
+		plans:     make(map[int64]*plan),
+	}
+	for _, selectorConfig := range selectors {
+		f, err := tfilter.Parse(selectorConfig.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		sel := &selector{tableFilter: f}
+		if len(selectorConfig.Columns) != 0 {
+			sel.columnFilter, err = tfilter.ParseColumnFilter(selectorConfig.Columns)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+			}
+		}
+		for _, maskConfig := range selectorConfig.Masks {
+			columnFilter, err := tfilter.ParseColumnFilter(maskConfig.Columns)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+			}
+			sel.masks = append(sel.masks, &mask{
+				filter: columnFilter,
+				action: maskConfig.Action,
+				salt:   maskConfig.Salt,
+				length: maskConfig.Length,
+			})
+		}
+		s.selectors = append(s.selectors, sel)
+	}
+	return s, nil
+}
+
+// Enabled returns true if there is any column selector.
+func (s *Selector) Enabled() bool {
+	return s != nil && len(s.selectors) > 0
+}
+
+// VerifyTables checks the column selectors against the tables to be
+// replicated. Only the string columns can be masked, since the masked values
+// of other columns can not be represented in their original types.
+func (s *Selector) VerifyTables(infos []*model.TableInfo) error {
+	if !s.Enabled() {
+		return nil
+	}
+	for _, info := range infos {
+		if info.TableInfo == nil {
+			continue
+		}
+		for _, sel := range s.selectors {
+			if !sel.tableFilter.MatchTable(info.TableName.Schema, info.TableName.Table) {
+				continue
+			}
+			for _, col := range info.Columns {
+				flag := info.ColumnsFlag[col.ID]
+				if flag.IsHandleKey() {
+					continue
+				}
+				action := sel.actionOf(col.Name.O)
+				if action == nil || action.action == config.ColumnMaskActionDrop {
+					continue
+				}
+				if !isStringType(col.GetType()) {
+					return cerror.ErrSinkInvalidConfig.GenWithStack(
+						"column %s of table %s can not be masked, "+
+							"only the string columns can be masked",
+						col.Name.O, info.TableName.String())
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// SelectRowChangedEvent returns the row with the selected and masked
+// columns. The given event is shared by other components, so it is never
+// modified, a copy is returned if any column is dropped or masked.
+func (s *Selector) SelectRowChangedEvent(row *model.RowChangedEvent) *model.RowChangedEvent {
+	if !s.Enabled() || row.TableInfo == nil {
+		return row
+	}
+	p := s.getPlan(row.TableInfo)
+	if len(p.actions) == 0 {
+		return row
+	}
+
+	selected := *row
+	selected.TableInfo = p.target
+	// offsets maps the offsets of the source columns to the selected ones,
+	// it's -1 if the column is dropped.
+	var offsets []int
+	selected.Columns, offsets = p.selectColumns(row.Columns)
+	selected.PreColumns, _ = p.selectColumns(row.PreColumns)
+	if p.dropped {
+		if offsets == nil {
+			_, offsets = p.selectColumns(row.PreColumns)
+		}
+		selected.ColInfos = selectColInfos(row.ColInfos, offsets)
+		selected.IndexColumns = selectIndexColumns(row.IndexColumns, offsets)
+	}
+	// The checksum is calculated by the upstream values, it can not
+	// be verified after the values are dropped or masked.
+	selected.Checksum = nil
+	return &selected
+}
+
+// SelectTableInfos returns the table infos without the dropped columns.
+func (s *Selector) SelectTableInfos(tables []*model.TableInfo) []*model.TableInfo {
+	if !s.Enabled() {
+		return tables
+	}
+	result := make([]*model.TableInfo, 0, len(tables))
+	for _, table := range tables {
+		result = append(result, s.SelectTableInfo(table))
+	}
+	return result
+}
+
+// SelectTableInfo returns the table info without the dropped columns.
+func (s *Selector) SelectTableInfo(info *model.TableInfo) *model.TableInfo {
+	if !s.Enabled() || info == nil || info.TableInfo == nil {
+		return info
+	}
+	return s.getPlan(info).target
+}
+
+// SelectDDLEvent returns the DDL event with the table infos without the
+// dropped columns, the query is kept unchanged. The given event is never
+// modified, a copy is returned if any column is dropped.
+func (s *Selector) SelectDDLEvent(ddl *model.DDLEvent) *model.DDLEvent {
+	if !s.Enabled() {
+		return ddl
+	}
+	tableInfo := s.SelectTableInfo(ddl.TableInfo)
+	preTableInfo := s.SelectTableInfo(ddl.PreTableInfo)
+	if tableInfo == ddl.TableInfo && preTableInfo == ddl.PreTableInfo {
+		return ddl
+	}
+	return &model.DDLEvent{
+		StartTs:      ddl.StartTs,
+		CommitTs:     ddl.CommitTs,
+		Query:        ddl.Query,
+		TableInfo:    tableInfo,
+		PreTableInfo: preTableInfo,
+		Type:         ddl.Type,
+		Charset:      ddl.Charset,
+		Collate:      ddl.Collate,
+	}
+}
+
+// getPlan returns the cached plan of the table info, the plan of the
+// table which does not match any selector keeps all the columns unchanged.
+func (s *Selector) getPlan(info *model.TableInfo) *plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.plans[info.ID]; ok && cached.source == info {
+		return cached
+	}
+	p := &plan{source: info, target: info}
+	for _, sel := range s.selectors {
+		if sel.tableFilter.MatchTable(info.TableName.Schema, info.TableName.Table) {
+			p = sel.newPlan(info)
+			break
+		}
+	}
+	s.plans[info.ID] = p
+	return p
+}
+
+func (sel *selector) newPlan(info *model.TableInfo) *plan {
+	p := &plan{
+		source:  info,
+		target:  info,
+		actions: make(map[string]*mask),
+	}
+	if info.TableInfo == nil {
+		return p
+	}
+	for _, col := range info.Columns {
+		flag := info.ColumnsFlag[col.ID]
+		if flag.IsHandleKey() {
+			continue
+		}
+		action := sel.actionOf(col.Name.O)
+		if action == nil {
+			continue
+		}
+		p.actions[col.Name.L] = action
+		if action.action == config.ColumnMaskActionDrop {
+			p.dropped = true
+		} else if !isStringType(col.GetType()) {
+			// The column type may be changed by DDL after the column
+			// selectors are verified.
+			log.Warn("column selector masks a non-string column, the masked values are NULL",
+				zap.String("schema", info.TableName.Schema),
+				zap.String("table", info.TableName.Table),
+				zap.String("column", col.Name.O))
+		}
+	}
+	if p.dropped {
+		p.target = projectTableInfo(info, p.actions)
+	}
+	log.Info("column selector plan created",
+		zap.String("schema", info.TableName.Schema),
+		zap.String("table", info.TableName.Table),
+		zap.Uint64("tableInfoVersion", info.Version),
+		zap.Int("affectedColumns", len(p.actions)))
+	return p
+}
+
+// actionOf returns the mask of the column, it returns nil if the column
+// is selected and not masked.
+func (sel *selector) actionOf(column string) *mask {
+	if sel.columnFilter != nil && !sel.columnFilter.MatchColumn(column) {
+		return dropMask
+	}
+	for _, m := range sel.masks {
+		if m.filter.MatchColumn(column) {
+			return m
+		}
+	}
+	return nil
+}
+
+// selectColumns returns the selected and masked columns, and the offsets
+// of the given columns in the result.
+func (p *plan) selectColumns(columns []*model.Column) ([]*model.Column, []int) {
+	if len(columns) == 0 {
+		return columns, nil
+	}
+	result := make([]*model.Column, 0, len(columns))
+	offsets := make([]int, len(columns))
+	for i, col := range columns {
+		var action *mask
+		if col != nil {
+			action = p.actions[strings.ToLower(col.Name)]
+		}
+		switch {
+		case action == nil:
+			result = append(result, col)
+		case action.action == config.ColumnMaskActionDrop:
+			offsets[i] = -1
+			continue
+		default:
+			masked := *col
+			masked.Value = action.apply(col)
+			result = append(result, &masked)
+		}
+		offsets[i] = len(result) - 1
+	}
+	return result, offsets
+}
+
+func (m *mask) apply(col *model.Column) interface{} {
+	if col.Value == nil {
+		return nil
+	}
+	var value []byte
+	switch v := col.Value.(type) {
+	case []byte:
+		value = v
+	case string:
+		value = []byte(v)
+	}
+	// The masked values of the non-string columns can not be represented
+	// in their original types, so they are set to NULL.
+	if value == nil || !isStringType(col.Type) {
+		return nil
+	}
+
+	var masked []byte
+	switch m.action {
+	case config.ColumnMaskActionHash:
+		digest := sha256.Sum256(append([]byte(m.salt), value...))
+		masked = []byte(hex.EncodeToString(digest[:]))
+	case config.ColumnMaskActionRedact:
+		masked = []byte(redactedValue)
+	case config.ColumnMaskActionTruncate:
+		masked = truncate(value, m.length, col.Flag.IsBinary())
+	default:
+		log.Panic("unknown column mask action", zap.String("action", m.action))
+	}
+	if _, ok := col.Value.(string); ok {
+		return string(masked)
+	}
+	return masked
+}
+
+// truncate keeps the leading length characters of the text value,
+// or the leading length bytes of the binary value.
+func truncate(value []byte, length int, binary bool) []byte {
+	if binary {
+		if len(value) > length {
+			return value[:length]
+		}
+		return value
+	}
+	runes := []rune(string(value))
+	if len(runes) > length {
+		return []byte(string(runes[:length]))
+	}
+	return value
+}
+
+func isStringType(tp byte) bool {
+	switch tp {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return true
+	}
+	return false
+}
+
+func selectColInfos(colInfos []rowcodec.ColInfo, offsets []int) []rowcodec.ColInfo {
+	if len(colInfos) != len(offsets) {
+		return colInfos
+	}
+	result := make([]rowcodec.ColInfo, 0, len(colInfos))
+	for i, colInfo := range colInfos {
+		if offsets[i] >= 0 {
+			result = append(result, colInfo)
+		}
+	}
+	return result
+}
+
+// selectIndexColumns removes the indexes which contain the dropped columns,
+// and maps the offsets of the columns of the remaining indexes.
+func selectIndexColumns(indexColumns [][]int, offsets []int) [][]int {
+	if len(indexColumns) == 0 {
+		return indexColumns
+	}
+	result := make([][]int, 0, len(indexColumns))
+	for _, index := range indexColumns {
+		selected := make([]int, 0, len(index))
+		for _, offset := range index {
+			if offset < 0 || offset >= len(offsets) || offsets[offset] < 0 {
+				selected = nil
+				break
+			}
+			selected = append(selected, offsets[offset])
+		}
+		if selected != nil {
+			result = append(result, selected)
+		}
+	}
+	return result
+}
+
+// projectTableInfo returns a copy of the table info without the dropped
+// columns and the indexes on them.
+func projectTableInfo(info *model.TableInfo, actions map[string]*mask) *model.TableInfo {
+	tableInfo := info.TableInfo.Clone()
+	tableInfo.Columns = make([]*timodel.ColumnInfo, 0, len(info.Columns))
+	offsets := make(map[int]int, len(info.Columns))
+	for _, col := range info.Columns {
+		if action, ok := actions[col.Name.L]; ok &&
+			action.action == config.ColumnMaskActionDrop {
+			continue
+		}
+		col = col.Clone()
+		offsets[col.Offset] = len(tableInfo.Columns)
+		col.Offset = len(tableInfo.Columns)
+		tableInfo.Columns = append(tableInfo.Columns, col)
+	}
+	tableInfo.Indices = make([]*timodel.IndexInfo, 0, len(info.Indices))
+	for _, index := range info.Indices {
+		index = index.Clone()
+		dropped := false
+		for _, col := range index.Columns {
+			offset, ok := offsets[col.Offset]
+			if !ok {
+				dropped = true
+				break
+			}
+			col.Offset = offset
+		}
+		if !dropped {
+			tableInfo.Indices = append(tableInfo.Indices, index)
+		}
+	}
+
+	projected := model.WrapTableInfo(
+		info.SchemaID, info.TableName.Schema, info.Version, tableInfo)
+	projected.TableName = info.TableName
+	return projected
+}
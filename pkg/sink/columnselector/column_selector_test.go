@@ -0,0 +1,312 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTableInfo(schema, table string) *model.TableInfo {
+	newColumn := func(id int64, name string, tp byte, offset int) *timodel.ColumnInfo {
+		return &timodel.ColumnInfo{
+			ID:        id,
+			Name:      timodel.NewCIStr(name),
+			FieldType: *types.NewFieldType(tp),
+			Offset:    offset,
+			State:     timodel.StatePublic,
+		}
+	}
+	id := newColumn(1, "id", mysql.TypeLong, 0)
+	id.AddFlag(mysql.PriKeyFlag)
+	info := &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr(table),
+		PKIsHandle: true,
+		Columns: []*timodel.ColumnInfo{
+			id,
+			newColumn(2, "name", mysql.TypeVarchar, 1),
+			newColumn(3, "email", mysql.TypeVarchar, 2),
+			newColumn(4, "phone", mysql.TypeVarchar, 3),
+			newColumn(5, "age", mysql.TypeLong, 4),
+		},
+		Indices: []*timodel.IndexInfo{
+			{
+				ID:      1,
+				Name:    timodel.NewCIStr("uk_email"),
+				Unique:  true,
+				State:   timodel.StatePublic,
+				Columns: []*timodel.IndexColumn{{Name: timodel.NewCIStr("email"), Offset: 2}},
+			},
+			{
+				ID:      2,
+				Name:    timodel.NewCIStr("uk_name"),
+				Unique:  true,
+				State:   timodel.StatePublic,
+				Columns: []*timodel.IndexColumn{{Name: timodel.NewCIStr("name"), Offset: 1}},
+			},
+		},
+	}
+	return model.WrapTableInfo(1, schema, 10, info)
+}
+
+func newTestRow(info *model.TableInfo) *model.RowChangedEvent {
+	columns := func(name, email string) []*model.Column {
+		return []*model.Column{
+			{
+				Name:  "id",
+				Type:  mysql.TypeLong,
+				Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+				Value: int64(1),
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Flag: model.UniqueKeyFlag, Value: []byte(name)},
+			{Name: "email", Type: mysql.TypeVarchar, Flag: model.UniqueKeyFlag, Value: []byte(email)},
+			{Name: "phone", Type: mysql.TypeVarchar, Value: "13800138000"},
+			{Name: "age", Type: mysql.TypeLong, Value: int64(18)},
+		}
+	}
+	colInfos := make([]rowcodec.ColInfo, 0, 5)
+	for i := int64(1); i <= 5; i++ {
+		colInfos = append(colInfos, rowcodec.ColInfo{ID: i})
+	}
+	return &model.RowChangedEvent{
+		CommitTs:     2,
+		Table:        &info.TableName,
+		TableInfo:    info,
+		ColInfos:     colInfos,
+		PreColumns:   columns("张三丰", "old@pingcap.com"),
+		Columns:      columns("李四", "new@pingcap.com"),
+		IndexColumns: [][]int{{0}, {2}, {1}},
+		Checksum:     &integrity.Checksum{Current: 1},
+	}
+}
+
+func TestSelectRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(false, []*config.ColumnSelector{
+		{
+			Matcher: []string{"test.users"},
+			Columns: []string{"*", "!email"},
+			Masks: []*config.ColumnMask{
+				{Columns: []string{"id", "name"}, Action: config.ColumnMaskActionTruncate, Length: 1},
+				{Columns: []string{"phone"}, Action: config.ColumnMaskActionHash, Salt: "salt"},
+				{Columns: []string{"age"}, Action: config.ColumnMaskActionRedact},
+			},
+		},
+		{Matcher: []string{"test.*"}, Columns: []string{"id"}},
+	})
+	require.NoError(t, err)
+	require.True(t, s.Enabled())
+
+	info := newTestTableInfo("TEST", "Users")
+	row := newTestRow(info)
+	selected := s.SelectRowChangedEvent(row)
+	require.NotSame(t, row, selected)
+	require.Nil(t, selected.Checksum)
+
+	digest := sha256.Sum256([]byte("salt13800138000"))
+	require.Equal(t, []*model.Column{
+		// the handle key column is never masked.
+		{
+			Name:  "id",
+			Type:  mysql.TypeLong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{Name: "name", Type: mysql.TypeVarchar, Flag: model.UniqueKeyFlag, Value: []byte("李")},
+		{Name: "phone", Type: mysql.TypeVarchar, Value: hex.EncodeToString(digest[:])},
+		// the non-string column is redacted to NULL.
+		{Name: "age", Type: mysql.TypeLong, Value: nil},
+	}, selected.Columns)
+	require.Equal(t, []byte("张"), selected.PreColumns[1].Value)
+	require.Equal(t, []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 4}, {ID: 5}}, selected.ColInfos)
+	// the index on the dropped column is removed.
+	require.Equal(t, [][]int{{0}, {1}}, selected.IndexColumns)
+
+	// the dropped column is removed from the table info.
+	require.Len(t, selected.TableInfo.Columns, 4)
+	require.Equal(t, "phone", selected.TableInfo.Columns[2].Name.O)
+	require.Equal(t, 2, selected.TableInfo.Columns[2].Offset)
+	require.Len(t, selected.TableInfo.Indices, 1)
+	require.Equal(t, "uk_name", selected.TableInfo.Indices[0].Name.O)
+	require.Equal(t, info.TableName, selected.TableInfo.TableName)
+	require.Equal(t, info.Version, selected.TableInfo.Version)
+	// the projected table info is cached.
+	require.Same(t, selected.TableInfo, s.SelectTableInfo(info))
+
+	// the original row is not modified.
+	require.Len(t, row.Columns, 5)
+	require.Equal(t, []byte("李四"), row.Columns[1].Value)
+	require.NotNil(t, row.Checksum)
+	require.Len(t, info.Columns, 5)
+
+	// the second selector only keeps the handle key column.
+	other := newTestRow(newTestTableInfo("test", "other"))
+	selected = s.SelectRowChangedEvent(other)
+	require.Len(t, selected.Columns, 1)
+	require.Len(t, selected.PreColumns, 1)
+	require.Equal(t, [][]int{{0}}, selected.IndexColumns)
+
+	// the tables which do not match any selector are kept unchanged.
+	unmatched := newTestRow(newTestTableInfo("other", "users"))
+	require.Same(t, unmatched, s.SelectRowChangedEvent(unmatched))
+	require.Same(t, unmatched.TableInfo, s.SelectTableInfo(unmatched.TableInfo))
+}
+
+func TestSelectDeleteEvent(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(true, []*config.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"*", "!phone"}},
+	})
+	require.NoError(t, err)
+
+	row := newTestRow(newTestTableInfo("test", "users"))
+	row.Columns = nil
+	selected := s.SelectRowChangedEvent(row)
+	require.True(t, selected.IsDelete())
+	require.Len(t, selected.PreColumns, 4)
+	require.Equal(t, []rowcodec.ColInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 5}}, selected.ColInfos)
+	require.Equal(t, [][]int{{0}, {2}, {1}}, selected.IndexColumns)
+
+	// case sensitive
+	row = newTestRow(newTestTableInfo("TEST", "users"))
+	require.Same(t, row, s.SelectRowChangedEvent(row))
+}
+
+func TestMaskValue(t *testing.T) {
+	t.Parallel()
+
+	binary := model.BinaryFlag
+	cases := []struct {
+		mask     *mask
+		column   *model.Column
+		expected interface{}
+	}{
+		{
+			mask:     &mask{action: config.ColumnMaskActionTruncate, length: 2},
+			column:   &model.Column{Type: mysql.TypeVarchar, Value: "你好世界"},
+			expected: "你好",
+		},
+		{
+			mask:     &mask{action: config.ColumnMaskActionTruncate, length: 2},
+			column:   &model.Column{Type: mysql.TypeBlob, Flag: binary, Value: []byte("你好")},
+			expected: []byte("你好")[:2],
+		},
+		{
+			mask:     &mask{action: config.ColumnMaskActionTruncate, length: 10},
+			column:   &model.Column{Type: mysql.TypeString, Value: []byte("short")},
+			expected: []byte("short"),
+		},
+		{
+			mask:     &mask{action: config.ColumnMaskActionRedact},
+			column:   &model.Column{Type: mysql.TypeVarString, Value: []byte("secret")},
+			expected: []byte(redactedValue),
+		},
+		{
+			mask:     &mask{action: config.ColumnMaskActionHash},
+			column:   &model.Column{Type: mysql.TypeVarchar, Value: nil},
+			expected: nil,
+		},
+		{
+			mask:     &mask{action: config.ColumnMaskActionHash},
+			column:   &model.Column{Type: mysql.TypeDatetime, Value: "2023-01-01 00:00:00"},
+			expected: nil,
+		},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, c.mask.apply(c.column), c.column)
+	}
+}
+
+func TestVerifyTables(t *testing.T) {
+	t.Parallel()
+
+	newSelector := func(columns ...string) *Selector {
+		s, err := New(false, []*config.ColumnSelector{{
+			Matcher: []string{"test.*"},
+			Columns: []string{"*", "!phone"},
+			Masks: []*config.ColumnMask{
+				{Columns: columns, Action: config.ColumnMaskActionRedact},
+			},
+		}})
+		require.NoError(t, err)
+		return s
+	}
+	infos := []*model.TableInfo{
+		newTestTableInfo("test", "t1"),
+		// The column selectors do not match the table.
+		newTestTableInfo("other", "t1"),
+	}
+	// The dropped columns and the handle key columns are not checked.
+	require.NoError(t, newSelector("email", "id").VerifyTables(infos))
+	require.ErrorContains(t, newSelector("email", "age").VerifyTables(infos),
+		"column age of table test.t1 can not be masked")
+	require.NoError(t, newSelector("email", "age").VerifyTables(infos[1:]))
+}
+
+func TestSelectDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(true, []*config.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"id", "name"}},
+	})
+	require.NoError(t, err)
+
+	info := newTestTableInfo("test", "users")
+	ddl := &model.DDLEvent{
+		CommitTs:  10,
+		Query:     "ALTER TABLE test.users ADD COLUMN age INT",
+		TableInfo: info,
+		Type:      timodel.ActionAddColumn,
+	}
+	selected := s.SelectDDLEvent(ddl)
+	require.NotSame(t, ddl, selected)
+	require.Equal(t, ddl.Query, selected.Query)
+	require.Equal(t, ddl.CommitTs, selected.CommitTs)
+	require.Len(t, selected.TableInfo.Columns, 2)
+	require.Nil(t, selected.PreTableInfo)
+
+	tables := s.SelectTableInfos([]*model.TableInfo{info, newTestTableInfo("other", "t")})
+	require.Len(t, tables[0].Columns, 2)
+	require.Len(t, tables[1].Columns, 5)
+
+	// the DDL of the schema is kept unchanged.
+	ddl = &model.DDLEvent{
+		Query: "CREATE DATABASE test",
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test"},
+		},
+		Type: timodel.ActionCreateSchema,
+	}
+	require.Same(t, ddl, s.SelectDDLEvent(ddl))
+
+	var nilSelector *Selector
+	require.False(t, nilSelector.Enabled())
+	require.Same(t, ddl, nilSelector.SelectDDLEvent(ddl))
+
+	_, err = New(true, []*config.ColumnSelector{{Matcher: []string{"[a"}}})
+	require.Error(t, err)
+}
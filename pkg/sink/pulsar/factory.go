@@ -0,0 +1,180 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Factory is used to produce all pulsar components.
+type Factory interface {
+	// TopicPartitions returns the names of all partitions of the topic.
+	// A non-partitioned topic is returned as the only partition of itself.
+	TopicPartitions(topic string) ([]string, error)
+	// Producer creates a producer which writes messages to the given
+	// topic, which is usually one partition returned by TopicPartitions.
+	Producer(topic string) (Producer, error)
+	// Close closes the underlying client,
+	// it must be called after all producers are closed.
+	Close()
+}
+
+// FactoryCreator defines the type of factory creator.
+type FactoryCreator func(*Options, model.ChangeFeedID) (Factory, error)
+
+// Producer is the pulsar producer of a single topic.
+type Producer interface {
+	// SendMessage produces a given message, and returns only when it either has
+	// succeeded or failed to produce.
+	SendMessage(ctx context.Context, key []byte, value []byte) error
+	// AsyncSendMessage produces a given message asynchronously, the callback
+	// is called with the result once the message is persisted or failed.
+	AsyncSendMessage(ctx context.Context, key []byte, value []byte,
+		callback func(error))
+	// Close flushes the buffered messages and shuts down the producer.
+	Close()
+}
+
+type pulsarFactory struct {
+	id      model.ChangeFeedID
+	options *Options
+	client  pulsar.Client
+}
+
+// NewFactory constructs a Factory backed by the pulsar go client.
+func NewFactory(o *Options, changefeedID model.ChangeFeedID) (Factory, error) {
+	clientOptions := pulsar.ClientOptions{
+		URL:                        o.URL,
+		ConnectionTimeout:          o.ConnectionTimeout,
+		OperationTimeout:           o.OperationTimeout,
+		TLSTrustCertsFilePath:      o.TLSTrustCertsFilePath,
+		TLSAllowInsecureConnection: o.TLSAllowInsecureConnection,
+	}
+	switch {
+	case o.AuthenticationToken != "":
+		clientOptions.Authentication = pulsar.NewAuthenticationToken(o.AuthenticationToken)
+	case o.TokenFromFile != "":
+		clientOptions.Authentication = pulsar.NewAuthenticationTokenFromFile(o.TokenFromFile)
+	case o.BasicUserName != "":
+		auth, err := pulsar.NewAuthenticationBasic(o.BasicUserName, o.BasicPassword)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+		}
+		clientOptions.Authentication = auth
+	}
+
+	client, err := pulsar.NewClient(clientOptions)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	return &pulsarFactory{
+		id:      changefeedID,
+		options: o,
+		client:  client,
+	}, nil
+}
+
+func (f *pulsarFactory) TopicPartitions(topic string) ([]string, error) {
+	partitions, err := f.client.TopicPartitions(topic)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarTopicPartitions, err, topic)
+	}
+	return partitions, nil
+}
+
+func (f *pulsarFactory) Producer(topic string) (Producer, error) {
+	producerOptions := pulsar.ProducerOptions{
+		Topic:                   topic,
+		SendTimeout:             f.options.SendTimeout,
+		CompressionType:         compressionType(f.options.Compression),
+		BatchingMaxMessages:     f.options.BatchingMaxMessages,
+		BatchingMaxPublishDelay: f.options.BatchingMaxPublishDelay,
+		BatchingMaxSize:         uint(f.options.MaxMessageBytes),
+	}
+	producer, err := f.client.CreateProducer(producerOptions)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	log.Info("pulsar producer created",
+		zap.String("namespace", f.id.Namespace),
+		zap.String("changefeed", f.id.ID),
+		zap.String("topic", topic))
+	return &pulsarProducer{id: f.id, producer: producer}, nil
+}
+
+func (f *pulsarFactory) Close() {
+	start := time.Now()
+	f.client.Close()
+	log.Info("pulsar client closed",
+		zap.String("namespace", f.id.Namespace),
+		zap.String("changefeed", f.id.ID),
+		zap.Duration("duration", time.Since(start)))
+}
+
+func compressionType(compression string) pulsar.CompressionType {
+	switch compression {
+	case CompressionLZ4:
+		return pulsar.LZ4
+	case CompressionZLib:
+		return pulsar.ZLib
+	case CompressionZSTD:
+		return pulsar.ZSTD
+	default:
+		return pulsar.NoCompression
+	}
+}
+
+type pulsarProducer struct {
+	id       model.ChangeFeedID
+	producer pulsar.Producer
+}
+
+func (p *pulsarProducer) SendMessage(
+	ctx context.Context, key []byte, value []byte,
+) error {
+	_, err := p.producer.Send(ctx, &pulsar.ProducerMessage{
+		Key:     string(key),
+		Payload: value,
+	})
+	return err
+}
+
+func (p *pulsarProducer) AsyncSendMessage(
+	ctx context.Context, key []byte, value []byte, callback func(error),
+) {
+	p.producer.SendAsync(ctx, &pulsar.ProducerMessage{
+		Key:     string(key),
+		Payload: value,
+	}, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		callback(err)
+	})
+}
+
+func (p *pulsarProducer) Close() {
+	start := time.Now()
+	// Close waits until all pending messages are persisted.
+	p.producer.Close()
+	log.Info("pulsar producer closed",
+		zap.String("namespace", p.id.Namespace),
+		zap.String("changefeed", p.id.ID),
+		zap.String("topic", p.producer.Topic()),
+		zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// DefaultMockPartitionNum is the number of partitions of
+// every topic created by the MockFactory.
+const DefaultMockPartitionNum = 3
+
+// MockMessage is a message received by the MockFactory.
+type MockMessage struct {
+	Key   []byte
+	Value []byte
+}
+
+// MockFactory is an in-process fake implementation of Factory interface.
+// Every topic is treated as a partitioned topic with
+// DefaultMockPartitionNum partitions, and all messages sent
+// to it are kept in memory.
+type MockFactory struct {
+	mu       sync.Mutex
+	messages map[string][]*MockMessage
+	closed   bool
+}
+
+// NewMockFactory constructs a Factory with mock implementation.
+func NewMockFactory(_ *Options, _ model.ChangeFeedID) (Factory, error) {
+	return &MockFactory{
+		messages: make(map[string][]*MockMessage),
+	}, nil
+}
+
+// TopicPartitions returns the mocked partitions of the topic.
+func (f *MockFactory) TopicPartitions(topic string) ([]string, error) {
+	partitions := make([]string, 0, DefaultMockPartitionNum)
+	for i := 0; i < DefaultMockPartitionNum; i++ {
+		partitions = append(partitions, MockPartitionTopic(topic, int32(i)))
+	}
+	return partitions, nil
+}
+
+// Producer creates a mock producer of the topic.
+func (f *MockFactory) Producer(topic string) (Producer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, cerror.ErrPulsarNewProducer.GenWithStack("pulsar client is closed")
+	}
+	return &mockProducer{topic: topic, factory: f}, nil
+}
+
+// Close marks the mock factory as closed.
+func (f *MockFactory) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+}
+
+// GetMessages returns the messages received by the given partition topic.
+func (f *MockFactory) GetMessages(topic string) []*MockMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.messages[topic]
+}
+
+// GetAllMessages returns the messages received by all topics.
+func (f *MockFactory) GetAllMessages() []*MockMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var messages []*MockMessage
+	for _, v := range f.messages {
+		messages = append(messages, v...)
+	}
+	return messages
+}
+
+func (f *MockFactory) append(topic string, key, value []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages[topic] = append(f.messages[topic], &MockMessage{Key: key, Value: value})
+}
+
+// MockPartitionTopic returns the name of the partition topic,
+// which follows the naming convention of pulsar.
+func MockPartitionTopic(topic string, partition int32) string {
+	return fmt.Sprintf("%s-partition-%d", topic, partition)
+}
+
+type mockProducer struct {
+	topic   string
+	factory *MockFactory
+}
+
+func (p *mockProducer) SendMessage(_ context.Context, key []byte, value []byte) error {
+	p.factory.append(p.topic, key, value)
+	return nil
+}
+
+func (p *mockProducer) AsyncSendMessage(
+	_ context.Context, key []byte, value []byte, callback func(error),
+) {
+	p.factory.append(p.topic, key, value)
+	callback(nil)
+}
+
+func (p *mockProducer) Close() {}
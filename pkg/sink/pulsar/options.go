@@ -0,0 +1,239 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/imdario/mergo"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+)
+
+const (
+	// defaultMaxMessageBytes is the default value of max-message-bytes,
+	// which is the same as the default `maxMessageSize` of the pulsar broker.
+	defaultMaxMessageBytes = 5 * 1024 * 1024
+	// defaultBatchingMaxMessages is the default value of batching-max-messages.
+	defaultBatchingMaxMessages = 1000
+)
+
+// Compression types supported by the pulsar sink.
+const (
+	CompressionNone = "none"
+	CompressionLZ4  = "lz4"
+	CompressionZLib = "zlib"
+	CompressionZSTD = "zstd"
+)
+
+type urlConfig struct {
+	MaxMessageBytes            *int    `form:"max-message-bytes"`
+	Compression                *string `form:"compression"`
+	ConnectionTimeout          *string `form:"connection-timeout"`
+	OperationTimeout           *string `form:"operation-timeout"`
+	SendTimeout                *string `form:"send-timeout"`
+	BatchingMaxMessages        *uint   `form:"batching-max-messages"`
+	BatchingMaxPublishDelay    *string `form:"batching-max-publish-delay"`
+	AuthenticationToken        *string `form:"authentication-token"`
+	TokenFromFile              *string `form:"token-from-file"`
+	BasicUserName              *string `form:"basic-user-name"`
+	BasicPassword              *string `form:"basic-password"`
+	TLSTrustCertsFilePath      *string `form:"tls-trust-certs-file-path"`
+	TLSAllowInsecureConnection *bool   `form:"tls-allow-insecure-connection"`
+}
+
+// Options stores user specified configurations of the pulsar sink.
+type Options struct {
+	// URL is the service url of the pulsar cluster,
+	// e.g. pulsar://127.0.0.1:6650 or pulsar+ssl://127.0.0.1:6651.
+	URL string
+
+	MaxMessageBytes int
+	Compression     string
+
+	// Timeout for network configurations, zero means the default value
+	// of the pulsar client is used.
+	ConnectionTimeout time.Duration
+	OperationTimeout  time.Duration
+	SendTimeout       time.Duration
+
+	BatchingMaxMessages     uint
+	BatchingMaxPublishDelay time.Duration
+
+	// Authentication, at most one of token, token file and basic auth
+	// can be specified.
+	AuthenticationToken string
+	TokenFromFile       string
+	BasicUserName       string
+	BasicPassword       string
+
+	TLSTrustCertsFilePath      string
+	TLSAllowInsecureConnection bool
+}
+
+// NewOptions returns a default pulsar configuration.
+func NewOptions() *Options {
+	return &Options{
+		MaxMessageBytes:     defaultMaxMessageBytes,
+		Compression:         CompressionNone,
+		BatchingMaxMessages: defaultBatchingMaxMessages,
+	}
+}
+
+// Apply the sinkURI to update Options.
+func (o *Options) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) error {
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !psink.IsPulsarScheme(scheme) {
+		return cerror.ErrPulsarInvalidConfig.GenWithStack(
+			"can't create pulsar sink with unsupported scheme: %s", scheme)
+	}
+	if sinkURI.Host == "" {
+		return cerror.ErrPulsarInvalidConfig.GenWithStack(
+			"no pulsar broker is specified in sink-uri")
+	}
+	o.URL = fmt.Sprintf("%s://%s", scheme, sinkURI.Host)
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err := binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+	}
+	urlParameter, err := mergeConfig(replicaConfig, urlParameter)
+	if err != nil {
+		return err
+	}
+
+	if urlParameter.MaxMessageBytes != nil {
+		if *urlParameter.MaxMessageBytes <= 0 {
+			return cerror.ErrPulsarInvalidConfig.GenWithStack(
+				"invalid max-message-bytes %d", *urlParameter.MaxMessageBytes)
+		}
+		o.MaxMessageBytes = *urlParameter.MaxMessageBytes
+	}
+
+	if urlParameter.Compression != nil && *urlParameter.Compression != "" {
+		compression := strings.ToLower(*urlParameter.Compression)
+		switch compression {
+		case CompressionNone, CompressionLZ4, CompressionZLib, CompressionZSTD:
+		default:
+			return cerror.ErrPulsarInvalidConfig.GenWithStack(
+				"unsupported compression %s", compression)
+		}
+		o.Compression = compression
+	}
+
+	for _, d := range []struct {
+		name  string
+		value *string
+		dest  *time.Duration
+	}{
+		{"connection-timeout", urlParameter.ConnectionTimeout, &o.ConnectionTimeout},
+		{"operation-timeout", urlParameter.OperationTimeout, &o.OperationTimeout},
+		{"send-timeout", urlParameter.SendTimeout, &o.SendTimeout},
+		{"batching-max-publish-delay", urlParameter.BatchingMaxPublishDelay, &o.BatchingMaxPublishDelay},
+	} {
+		if d.value == nil || *d.value == "" {
+			continue
+		}
+		a, err := time.ParseDuration(*d.value)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrPulsarInvalidConfig,
+				errors.Annotatef(err, "invalid %s", d.name))
+		}
+		*d.dest = a
+	}
+
+	if urlParameter.BatchingMaxMessages != nil {
+		o.BatchingMaxMessages = *urlParameter.BatchingMaxMessages
+	}
+
+	return o.applyAuthAndTLS(urlParameter)
+}
+
+func (o *Options) applyAuthAndTLS(params *urlConfig) error {
+	if params.AuthenticationToken != nil {
+		o.AuthenticationToken = *params.AuthenticationToken
+	}
+	if params.TokenFromFile != nil {
+		o.TokenFromFile = *params.TokenFromFile
+	}
+	if params.BasicUserName != nil {
+		o.BasicUserName = *params.BasicUserName
+	}
+	if params.BasicPassword != nil {
+		o.BasicPassword = *params.BasicPassword
+	}
+
+	authMethods := 0
+	if o.AuthenticationToken != "" {
+		authMethods++
+	}
+	if o.TokenFromFile != "" {
+		authMethods++
+	}
+	if o.BasicUserName != "" || o.BasicPassword != "" {
+		if o.BasicUserName == "" || o.BasicPassword == "" {
+			return cerror.ErrPulsarInvalidConfig.GenWithStack(
+				"basic-user-name and basic-password should be supplied together")
+		}
+		authMethods++
+	}
+	if authMethods > 1 {
+		return cerror.ErrPulsarInvalidConfig.GenWithStack(
+			"only one of authentication-token, token-from-file and " +
+				"basic authentication can be specified")
+	}
+
+	if params.TLSTrustCertsFilePath != nil {
+		o.TLSTrustCertsFilePath = *params.TLSTrustCertsFilePath
+	}
+	if params.TLSAllowInsecureConnection != nil {
+		o.TLSAllowInsecureConnection = *params.TLSAllowInsecureConnection
+	}
+	return nil
+}
+
+func mergeConfig(
+	replicaConfig *config.ReplicaConfig,
+	urlParameters *urlConfig,
+) (*urlConfig, error) {
+	dest := &urlConfig{}
+	if replicaConfig.Sink != nil && replicaConfig.Sink.PulsarConfig != nil {
+		fileConfig := replicaConfig.Sink.PulsarConfig
+		dest.MaxMessageBytes = fileConfig.MaxMessageBytes
+		dest.Compression = fileConfig.Compression
+		dest.ConnectionTimeout = fileConfig.ConnectionTimeout
+		dest.OperationTimeout = fileConfig.OperationTimeout
+		dest.SendTimeout = fileConfig.SendTimeout
+		dest.BatchingMaxMessages = fileConfig.BatchingMaxMessages
+		dest.BatchingMaxPublishDelay = fileConfig.BatchingMaxPublishDelay
+		dest.AuthenticationToken = fileConfig.AuthenticationToken
+		dest.TokenFromFile = fileConfig.TokenFromFile
+		dest.BasicUserName = fileConfig.BasicUserName
+		dest.BasicPassword = fileConfig.BasicPassword
+		dest.TLSTrustCertsFilePath = fileConfig.TLSTrustCertsFilePath
+		dest.TLSAllowInsecureConnection = fileConfig.TLSAllowInsecureConnection
+	}
+	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+	}
+	return dest, nil
+}
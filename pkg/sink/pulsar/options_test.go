@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOptions(t *testing.T) {
+	t.Parallel()
+
+	uri := "pulsar+ssl://127.0.0.1:6651/persistent://public/default/test?" +
+		"max-message-bytes=1048576&compression=LZ4&connection-timeout=5s" +
+		"&send-timeout=10s&batching-max-messages=200" +
+		"&batching-max-publish-delay=20ms&authentication-token=abc" +
+		"&tls-allow-insecure-connection=true"
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+
+	o := NewOptions()
+	require.Nil(t, o.Apply(sinkURI, config.GetDefaultReplicaConfig()))
+	require.Equal(t, "pulsar+ssl://127.0.0.1:6651", o.URL)
+	require.Equal(t, 1048576, o.MaxMessageBytes)
+	require.Equal(t, CompressionLZ4, o.Compression)
+	require.Equal(t, 5*time.Second, o.ConnectionTimeout)
+	require.Equal(t, time.Duration(0), o.OperationTimeout)
+	require.Equal(t, 10*time.Second, o.SendTimeout)
+	require.Equal(t, uint(200), o.BatchingMaxMessages)
+	require.Equal(t, 20*time.Millisecond, o.BatchingMaxPublishDelay)
+	require.Equal(t, "abc", o.AuthenticationToken)
+	require.True(t, o.TLSAllowInsecureConnection)
+}
+
+func TestApplyOptionsWithReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	maxMessageBytes := 2048
+	compression := "zstd"
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.PulsarConfig = &config.PulsarConfig{
+		MaxMessageBytes: &maxMessageBytes,
+		Compression:     &compression,
+	}
+
+	// The url parameters take precedence over the replica config.
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test?max-message-bytes=4096")
+	require.Nil(t, err)
+	o := NewOptions()
+	require.Nil(t, o.Apply(sinkURI, replicaConfig))
+	require.Equal(t, 4096, o.MaxMessageBytes)
+	require.Equal(t, CompressionZSTD, o.Compression)
+}
+
+func TestApplyInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		uri    string
+		errMsg string
+	}{
+		{
+			uri:    "kafka://127.0.0.1:9092/test",
+			errMsg: "unsupported scheme",
+		},
+		{
+			uri:    "pulsar:///test",
+			errMsg: "no pulsar broker is specified",
+		},
+		{
+			uri:    "pulsar://127.0.0.1:6650/test?max-message-bytes=0",
+			errMsg: "invalid max-message-bytes",
+		},
+		{
+			uri:    "pulsar://127.0.0.1:6650/test?compression=gzip",
+			errMsg: "unsupported compression gzip",
+		},
+		{
+			uri:    "pulsar://127.0.0.1:6650/test?send-timeout=abc",
+			errMsg: "invalid send-timeout",
+		},
+		{
+			uri:    "pulsar://127.0.0.1:6650/test?basic-user-name=root",
+			errMsg: "should be supplied together",
+		},
+		{
+			uri: "pulsar://127.0.0.1:6650/test?authentication-token=abc" +
+				"&token-from-file=/tmp/token",
+			errMsg: "only one of",
+		},
+	} {
+		sinkURI, err := url.Parse(tc.uri)
+		require.Nil(t, err)
+		err = NewOptions().Apply(sinkURI, config.GetDefaultReplicaConfig())
+		require.ErrorContains(t, err, tc.errMsg, tc.uri)
+	}
+}
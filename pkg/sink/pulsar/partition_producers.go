@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"sync"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// PartitionProducers lazily creates and caches one producer for every
+// partition of the topics, so that a message can be sent to the partition
+// chosen by the event router instead of the one chosen by the pulsar client.
+type PartitionProducers struct {
+	factory Factory
+
+	mu     sync.Mutex
+	topics map[string]*topicProducers
+}
+
+type topicProducers struct {
+	partitions []string
+	producers  []Producer
+}
+
+// NewPartitionProducers creates a new PartitionProducers.
+func NewPartitionProducers(factory Factory) *PartitionProducers {
+	return &PartitionProducers{
+		factory: factory,
+		topics:  make(map[string]*topicProducers),
+	}
+}
+
+// Get returns the producer of the partition of the topic.
+func (p *PartitionProducers) Get(topic string, partition int32) (Producer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, ok := p.topics[topic]
+	// The number of partitions may be increased since the last query.
+	if !ok || int(partition) >= len(t.partitions) {
+		partitions, err := p.factory.TopicPartitions(topic)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if t == nil {
+			t = &topicProducers{}
+			p.topics[topic] = t
+		}
+		t.partitions = partitions
+		for len(t.producers) < len(partitions) {
+			t.producers = append(t.producers, nil)
+		}
+	}
+	if partition < 0 || int(partition) >= len(t.partitions) {
+		return nil, cerror.ErrPulsarSendMessage.GenWithStack(
+			"partition %d of topic %s does not exist, the topic has %d partitions",
+			partition, topic, len(t.partitions))
+	}
+
+	if t.producers[partition] == nil {
+		producer, err := p.factory.Producer(t.partitions[partition])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		t.producers[partition] = producer
+	}
+	return t.producers[partition], nil
+}
+
+// Close closes all producers and then the underlying factory.
+func (p *PartitionProducers) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range p.topics {
+		for _, producer := range t.producers {
+			if producer != nil {
+				producer.Close()
+			}
+		}
+	}
+	p.topics = make(map[string]*topicProducers)
+	p.factory.Close()
+}
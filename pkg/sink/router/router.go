@@ -0,0 +1,322 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	timodel "github.com/pingcap/tidb/parser/model"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	schemaPlaceholder = "{schema}"
+	tablePlaceholder  = "{table}"
+)
+
+type rule struct {
+	filter       tfilter.Filter
+	targetSchema string
+	targetTable  string
+}
+
+// routedTableInfo caches the routed copy of a table info.
+type routedTableInfo struct {
+	source *model.TableInfo
+	target *model.TableInfo
+}
+
+// Router routes the upstream tables to the downstream schemas and tables
+// by the route rules. The first matched rule takes effect, and the tables
+// which do not match any rule keep their upstream names.
+// It is thread-safe.
+type Router struct {
+	rules []*rule
+
+	mu sync.Mutex
+	// tableInfos is keyed by the ID of the upstream table info, only the
+	// latest version of the table info is cached.
+	tableInfos map[int64]routedTableInfo
+}
+
+// NewRouter creates a Router with the given route rules.
+func NewRouter(caseSensitive bool, rules []*config.RouteRule) (*Router, error) {
+	r := &Router{
+		rules:      make([]*rule, 0, len(rules)),
+		tableInfos: make(map[int64]routedTableInfo),
+	}
+	for _, ruleConfig := range rules {
+		f, err := tfilter.Parse(ruleConfig.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if !caseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		r.rules = append(r.rules, &rule{
+			filter:       f,
+			targetSchema: ruleConfig.TargetSchema,
+			targetTable:  ruleConfig.TargetTable,
+		})
+	}
+	return r, nil
+}
+
+// Enabled returns true if there is any route rule.
+func (r *Router) Enabled() bool {
+	return r != nil && len(r.rules) > 0
+}
+
+// Route returns the downstream schema and table name of the given table.
+func (r *Router) Route(schema, table string) (string, string) {
+	if !r.Enabled() {
+		return schema, table
+	}
+	for _, rule := range r.rules {
+		if !rule.filter.MatchTable(schema, table) {
+			continue
+		}
+		targetSchema, targetTable := schema, table
+		if rule.targetSchema != "" {
+			targetSchema = substitute(rule.targetSchema, schema, table)
+		}
+		if rule.targetTable != "" {
+			targetTable = substitute(rule.targetTable, schema, table)
+		}
+		return targetSchema, targetTable
+	}
+	return schema, table
+}
+
+// RouteSchema returns the downstream schema name of the given schema,
+// it is used by the schema level DDLs, such as `CREATE DATABASE`.
+// The rules whose target schema depends on the table name are skipped.
+func (r *Router) RouteSchema(schema string) string {
+	if !r.Enabled() {
+		return schema
+	}
+	for _, rule := range r.rules {
+		if rule.targetSchema == "" ||
+			strings.Contains(rule.targetSchema, tablePlaceholder) {
+			continue
+		}
+		if rule.filter.MatchSchema(schema) {
+			return substitute(rule.targetSchema, schema, "")
+		}
+	}
+	return schema
+}
+
+// RouteRowChangedEvent returns the routed row changed event. The given
+// event is shared by other components, so it is never modified, a shallow
+// copy with the routed table name is returned if the table is routed.
+func (r *Router) RouteRowChangedEvent(row *model.RowChangedEvent) *model.RowChangedEvent {
+	if !r.Enabled() || row.Table == nil {
+		return row
+	}
+	schema, table := r.Route(row.Table.Schema, row.Table.Table)
+	if schema == row.Table.Schema && table == row.Table.Table {
+		return row
+	}
+	routed := *row
+	routed.Table = &model.TableName{
+		Schema:      schema,
+		Table:       table,
+		TableID:     row.Table.TableID,
+		IsPartition: row.Table.IsPartition,
+	}
+	if row.TableInfo != nil {
+		routed.TableInfo = r.routeTableInfo(row.TableInfo)
+	}
+	return &routed
+}
+
+// RouteTableInfos returns the routed table infos.
+func (r *Router) RouteTableInfos(tables []*model.TableInfo) []*model.TableInfo {
+	if !r.Enabled() {
+		return tables
+	}
+	result := make([]*model.TableInfo, 0, len(tables))
+	for _, table := range tables {
+		result = append(result, r.routeTableInfo(table))
+	}
+	return result
+}
+
+// RouteDDLEvent returns the routed DDL event, the table names in the
+// query are rewritten to the downstream names. The given event is never
+// modified, a new event is returned if anything is routed.
+func (r *Router) RouteDDLEvent(ddl *model.DDLEvent) (*model.DDLEvent, error) {
+	if !r.Enabled() || ddl.TableInfo == nil {
+		return ddl, nil
+	}
+
+	query, changed, err := r.rewriteQuery(ddl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !changed {
+		return ddl, nil
+	}
+
+	routed := &model.DDLEvent{
+		StartTs:      ddl.StartTs,
+		CommitTs:     ddl.CommitTs,
+		Query:        query,
+		TableInfo:    r.routeDDLTableInfo(ddl.TableInfo),
+		PreTableInfo: r.routeDDLTableInfo(ddl.PreTableInfo),
+		Type:         ddl.Type,
+		Charset:      ddl.Charset,
+		Collate:      ddl.Collate,
+	}
+	log.Info("route ddl event",
+		zap.String("query", ddl.Query),
+		zap.String("routedQuery", routed.Query))
+	return routed, nil
+}
+
+// routeDDLTableInfo routes the table info of a DDL event, the table
+// info of a schema level DDL only contains the schema name.
+func (r *Router) routeDDLTableInfo(info *model.TableInfo) *model.TableInfo {
+	if info == nil {
+		return nil
+	}
+	if info.TableName.Table == "" {
+		routed := *info
+		routed.TableName.Schema = r.RouteSchema(info.TableName.Schema)
+		return &routed
+	}
+	return r.newRoutedTableInfo(info)
+}
+
+// routeTableInfo returns the routed copy of the given table info,
+// the copy is cached to avoid copying it for every row.
+func (r *Router) routeTableInfo(info *model.TableInfo) *model.TableInfo {
+	if info == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cached, ok := r.tableInfos[info.ID]; ok && cached.source == info {
+		return cached.target
+	}
+	routed := r.newRoutedTableInfo(info)
+	r.tableInfos[info.ID] = routedTableInfo{source: info, target: routed}
+	return routed
+}
+
+func (r *Router) newRoutedTableInfo(info *model.TableInfo) *model.TableInfo {
+	schema, table := r.Route(info.TableName.Schema, info.TableName.Table)
+	if schema == info.TableName.Schema && table == info.TableName.Table {
+		return info
+	}
+	routed := *info
+	routed.TableName.Schema = schema
+	routed.TableName.Table = table
+	if info.TableInfo != nil {
+		tableInfo := *info.TableInfo
+		tableInfo.Name = timodel.NewCIStr(table)
+		routed.TableInfo = &tableInfo
+	}
+	return &routed
+}
+
+// rewriteQuery rewrites the table names in the DDL query to the downstream
+// names. The unqualified table names are qualified with the schema of the
+// DDL, since the downstream schema may be different from the upstream one.
+func (r *Router) rewriteQuery(ddl *model.DDLEvent) (string, bool, error) {
+	stmt, err := parser.New().ParseOneStmt(ddl.Query, ddl.Charset, ddl.Collate)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+
+	v := &tableNameVisitor{router: r, defaultSchema: ddl.TableInfo.TableName.Schema}
+	switch s := stmt.(type) {
+	case *ast.CreateDatabaseStmt:
+		s.Name, v.changed = r.routeSchemaName(s.Name)
+	case *ast.AlterDatabaseStmt:
+		s.Name, v.changed = r.routeSchemaName(s.Name)
+	case *ast.DropDatabaseStmt:
+		s.Name, v.changed = r.routeSchemaName(s.Name)
+	default:
+		stmt.Accept(v)
+	}
+	if !v.changed {
+		return ddl.Query, false, nil
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment
+	if err = stmt.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return "", false, errors.Trace(err)
+	}
+	return sb.String(), true, nil
+}
+
+func (r *Router) routeSchemaName(name timodel.CIStr) (timodel.CIStr, bool) {
+	routed := r.RouteSchema(name.O)
+	if routed == name.O {
+		return name, false
+	}
+	return timodel.NewCIStr(routed), true
+}
+
+// tableNameVisitor rewrites all table names in a statement.
+type tableNameVisitor struct {
+	router        *Router
+	defaultSchema string
+	changed       bool
+}
+
+// Enter implements ast.Visitor.
+func (v *tableNameVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	tableName, ok := in.(*ast.TableName)
+	if !ok {
+		return in, false
+	}
+	// The downstream schema of the DDL may be routed, so the unqualified
+	// table names are always qualified with the upstream schema.
+	schema := tableName.Schema.O
+	if schema == "" {
+		schema = v.defaultSchema
+		tableName.Schema = timodel.NewCIStr(schema)
+	}
+	targetSchema, targetTable := v.router.Route(schema, tableName.Name.O)
+	if targetSchema != schema || targetTable != tableName.Name.O {
+		tableName.Schema = timodel.NewCIStr(targetSchema)
+		tableName.Name = timodel.NewCIStr(targetTable)
+		v.changed = true
+	}
+	return in, true
+}
+
+// Leave implements ast.Visitor.
+func (v *tableNameVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func substitute(expr, schema, table string) string {
+	result := strings.ReplaceAll(expr, schemaPlaceholder, schema)
+	return strings.ReplaceAll(result, tablePlaceholder, table)
+}
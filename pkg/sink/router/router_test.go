@@ -0,0 +1,209 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, caseSensitive bool) *Router {
+	r, err := NewRouter(caseSensitive, []*config.RouteRule{
+		{Matcher: []string{"shard_*.order_*"}, TargetSchema: "shard", TargetTable: "order"},
+		{Matcher: []string{"test.*"}, TargetSchema: "test_bak"},
+		{Matcher: []string{"rename.*"}, TargetTable: "{schema}_{table}"},
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestRoute(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRouter(t, true)
+	require.True(t, r.Enabled())
+
+	cases := []struct {
+		schema, table                 string
+		expectedSchema, expectedTable string
+	}{
+		{"shard_1", "order_1", "shard", "order"},
+		{"shard_2", "order_10", "shard", "order"},
+		{"test", "t", "test_bak", "t"},
+		{"rename", "t", "rename", "rename_t"},
+		{"other", "t", "other", "t"},
+		// case sensitive
+		{"TEST", "t", "TEST", "t"},
+	}
+	for _, c := range cases {
+		schema, table := r.Route(c.schema, c.table)
+		require.Equal(t, c.expectedSchema, schema, c)
+		require.Equal(t, c.expectedTable, table, c)
+	}
+
+	r = newTestRouter(t, false)
+	schema, table := r.Route("TEST", "t")
+	require.Equal(t, "test_bak", schema)
+	require.Equal(t, "t", table)
+
+	require.Equal(t, "shard", r.RouteSchema("shard_1"))
+	require.Equal(t, "test_bak", r.RouteSchema("test"))
+	// the target schema of the rule is empty.
+	require.Equal(t, "rename", r.RouteSchema("rename"))
+
+	_, err := NewRouter(true, []*config.RouteRule{{Matcher: []string{"[a"}}})
+	require.Error(t, err)
+
+	var nilRouter *Router
+	require.False(t, nilRouter.Enabled())
+	schema, table = nilRouter.Route("test", "t")
+	require.Equal(t, "test", schema)
+	require.Equal(t, "t", table)
+}
+
+func TestRouteRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRouter(t, true)
+	tableInfo := &model.TableInfo{
+		TableName: model.TableName{Schema: "shard_1", Table: "order_1", TableID: 1},
+		TableInfo: &timodel.TableInfo{ID: 1, Name: timodel.NewCIStr("order_1")},
+	}
+	row := &model.RowChangedEvent{
+		CommitTs:  1,
+		Table:     &model.TableName{Schema: "shard_1", Table: "order_1", TableID: 1},
+		TableInfo: tableInfo,
+		Columns:   []*model.Column{{Name: "id", Value: 1}},
+	}
+	routed := r.RouteRowChangedEvent(row)
+	require.Equal(t, &model.TableName{Schema: "shard", Table: "order", TableID: 1}, routed.Table)
+	require.Equal(t, "shard", routed.TableInfo.TableName.Schema)
+	require.Equal(t, "order", routed.TableInfo.TableName.Table)
+	require.Equal(t, "order", routed.TableInfo.Name.O)
+	require.Equal(t, row.Columns, routed.Columns)
+	// the original event is not modified.
+	require.Equal(t, "shard_1", row.Table.Schema)
+	require.Equal(t, "order_1", row.TableInfo.TableName.Table)
+	require.Equal(t, "order_1", row.TableInfo.Name.O)
+	// the routed table info is cached.
+	require.Same(t, routed.TableInfo, r.RouteRowChangedEvent(row).TableInfo)
+
+	unrouted := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "other", Table: "t"},
+	}
+	require.Same(t, unrouted, r.RouteRowChangedEvent(unrouted))
+
+	tables := r.RouteTableInfos([]*model.TableInfo{tableInfo})
+	require.Len(t, tables, 1)
+	require.Equal(t, "shard", tables[0].TableName.Schema)
+}
+
+func TestRouteDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRouter(t, true)
+	newTableInfo := func(schema, table string) *model.TableInfo {
+		return &model.TableInfo{
+			TableName: model.TableName{Schema: schema, Table: table},
+			TableInfo: &timodel.TableInfo{Name: timodel.NewCIStr(table)},
+		}
+	}
+
+	cases := []struct {
+		ddl           *model.DDLEvent
+		expectedQuery string
+		expectedTable model.TableName
+	}{
+		{
+			ddl: &model.DDLEvent{
+				Query:     "CREATE TABLE order_1 (id INT PRIMARY KEY)",
+				TableInfo: newTableInfo("shard_1", "order_1"),
+				Type:      timodel.ActionCreateTable,
+			},
+			expectedQuery: "CREATE TABLE `shard`.`order` (`id` INT PRIMARY KEY)",
+			expectedTable: model.TableName{Schema: "shard", Table: "order"},
+		},
+		{
+			ddl: &model.DDLEvent{
+				Query:     "ALTER TABLE `test`.`t` ADD COLUMN `c` INT",
+				TableInfo: newTableInfo("test", "t"),
+				Type:      timodel.ActionAddColumn,
+			},
+			expectedQuery: "ALTER TABLE `test_bak`.`t` ADD COLUMN `c` INT",
+			expectedTable: model.TableName{Schema: "test_bak", Table: "t"},
+		},
+		{
+			ddl: &model.DDLEvent{
+				Query:     "CREATE TABLE t1 LIKE other.t2",
+				TableInfo: newTableInfo("test", "t1"),
+				Type:      timodel.ActionCreateTable,
+			},
+			expectedQuery: "CREATE TABLE `test_bak`.`t1` LIKE `other`.`t2`",
+			expectedTable: model.TableName{Schema: "test_bak", Table: "t1"},
+		},
+		{
+			ddl: &model.DDLEvent{
+				Query:        "RENAME TABLE t1 TO t2",
+				TableInfo:    newTableInfo("rename", "t2"),
+				PreTableInfo: newTableInfo("rename", "t1"),
+				Type:         timodel.ActionRenameTable,
+			},
+			expectedQuery: "RENAME TABLE `rename`.`rename_t1` TO `rename`.`rename_t2`",
+			expectedTable: model.TableName{Schema: "rename", Table: "rename_t2"},
+		},
+		{
+			ddl: &model.DDLEvent{
+				Query:     "CREATE DATABASE test",
+				TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test"}},
+				Type:      timodel.ActionCreateSchema,
+			},
+			expectedQuery: "CREATE DATABASE `test_bak`",
+			expectedTable: model.TableName{Schema: "test_bak"},
+		},
+		{
+			ddl: &model.DDLEvent{
+				Query:     "CREATE TABLE t (id INT PRIMARY KEY)",
+				TableInfo: newTableInfo("other", "t"),
+				Type:      timodel.ActionCreateTable,
+			},
+			expectedQuery: "CREATE TABLE t (id INT PRIMARY KEY)",
+			expectedTable: model.TableName{Schema: "other", Table: "t"},
+		},
+	}
+	for _, c := range cases {
+		routed, err := r.RouteDDLEvent(c.ddl)
+		require.NoError(t, err)
+		require.Equal(t, c.expectedQuery, routed.Query)
+		require.Equal(t, c.expectedTable, routed.TableInfo.TableName)
+		require.Equal(t, c.ddl.Type, routed.Type)
+	}
+
+	ddl := cases[3].ddl
+	routed, err := r.RouteDDLEvent(ddl)
+	require.NoError(t, err)
+	require.Equal(t, "rename_t1", routed.PreTableInfo.TableName.Table)
+	// the original event is not modified.
+	require.Equal(t, "RENAME TABLE t1 TO t2", ddl.Query)
+	require.Equal(t, "t1", ddl.PreTableInfo.TableName.Table)
+
+	_, err = r.RouteDDLEvent(&model.DDLEvent{
+		Query:     "CREATE TABLE",
+		TableInfo: newTableInfo("test", "t"),
+	})
+	require.Error(t, err)
+}
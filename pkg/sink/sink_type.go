@@ -63,13 +63,36 @@ const (
 	AzureScheme = "azure"
 	// CloudStorageNoopScheme indicates the scheme is noop.
 	CloudStorageNoopScheme = "noop"
+	// PulsarScheme indicates the scheme is pulsar.
+	PulsarScheme = "pulsar"
+	// PulsarSSLScheme indicates the scheme is pulsar+ssl.
+	PulsarSSLScheme = "pulsar+ssl"
+	// HTTPScheme indicates the scheme is http, which is used by the webhook sink.
+	HTTPScheme = "http"
+	// HTTPSScheme indicates the scheme is https, which is used by the webhook sink.
+	HTTPSScheme = "https"
 )
 
 // IsMQScheme returns true if the scheme belong to mq scheme.
 func IsMQScheme(scheme string) bool {
+	return IsKafkaScheme(scheme) || IsPulsarScheme(scheme)
+}
+
+// IsKafkaScheme returns true if the scheme belong to kafka scheme.
+func IsKafkaScheme(scheme string) bool {
 	return scheme == KafkaScheme || scheme == KafkaSSLScheme
 }
 
+// IsPulsarScheme returns true if the scheme belong to pulsar scheme.
+func IsPulsarScheme(scheme string) bool {
+	return scheme == PulsarScheme || scheme == PulsarSSLScheme
+}
+
+// IsWebhookScheme returns true if the scheme belong to webhook scheme.
+func IsWebhookScheme(scheme string) bool {
+	return scheme == HTTPScheme || scheme == HTTPSScheme
+}
+
 // IsMySQLCompatibleScheme returns true if the scheme is compatible with MySQL.
 func IsMySQLCompatibleScheme(scheme string) bool {
 	return scheme == MySQLScheme || scheme == MySQLSSLScheme ||
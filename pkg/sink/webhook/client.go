@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader is the header of the idempotency key, the key is
+	// derived from the txn and the rows of the events, so a request which is sent
+	// more than once, e.g. retried after a timeout, carries the same key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// maxResponseBytes is the max size of the response body kept in the error.
+	maxResponseBytes = 1024
+)
+
+// Request is a request which is posted to the webhook endpoint.
+type Request struct {
+	IdempotencyKey string
+	Header         http.Header
+	Body           []byte
+}
+
+// Client posts requests to the webhook endpoint with retries.
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a webhook Client.
+func NewClient(config *Config) *Client {
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.RequestTimeout},
+	}
+}
+
+// permanentError is an error which should not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Post posts the request to the endpoint. The request is retried if it
+// fails because of network errors, 5xx or 429 status codes.
+func (c *Client) Post(ctx context.Context, req *Request) error {
+	err := retry.Do(ctx, func() error {
+		return c.post(ctx, req)
+	},
+		retry.WithMaxTries(c.config.MaxRetries+1),
+		retry.WithBackoffBaseDelay(c.config.RetryBackoff.Milliseconds()),
+		retry.WithBackoffMaxDelay(maxRetryBackoff.Milliseconds()),
+		retry.WithIsRetryableErr(isRetryableError))
+	if e, ok := err.(*permanentError); ok {
+		return e.err
+	}
+	return err
+}
+
+func (c *Client) post(ctx context.Context, req *Request) error {
+	httpReq, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(req.Body))
+	if err != nil {
+		return &permanentError{err: cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)}
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
+	}
+	if req.IdempotencyKey != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, req.IdempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		log.Warn("webhook request failed",
+			zap.String("idempotencyKey", req.IdempotencyKey), zap.Error(err))
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	err = cerror.ErrWebhookRequestFailed.GenWithStackByArgs(resp.StatusCode, string(body))
+	log.Warn("webhook request failed",
+		zap.String("idempotencyKey", req.IdempotencyKey),
+		zap.Int("statusCode", resp.StatusCode),
+		zap.ByteString("response", body))
+	if resp.StatusCode >= http.StatusInternalServerError ||
+		resp.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+func isRetryableError(err error) bool {
+	if _, ok := err.(*permanentError); ok {
+		return false
+	}
+	switch errors.Cause(err) {
+	case context.Canceled, context.DeadlineExceeded:
+		return false
+	}
+	return true
+}
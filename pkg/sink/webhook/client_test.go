@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(endpoint string, maxRetries uint64) *Client {
+	c := NewConfig()
+	c.Endpoint = endpoint
+	c.MaxRetries = maxRetries
+	c.RetryBackoff = time.Millisecond
+	return NewClient(c)
+}
+
+func TestClientPost(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		statuses = []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK}
+		keys     []string
+		bodies   []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		bodies = append(bodies, string(body))
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "canal-json", r.Header.Get(ProtocolHeader))
+		w.WriteHeader(statuses[0])
+		statuses = statuses[1:]
+	}))
+	defer server.Close()
+
+	header := make(http.Header)
+	header.Set(ProtocolHeader, "canal-json")
+	req := &Request{IdempotencyKey: "key", Header: header, Body: []byte("data")}
+	err := newTestClient(server.URL, 3).Post(context.Background(), req)
+	require.NoError(t, err)
+	// the request is retried with the same idempotency key.
+	require.Equal(t, []string{"key", "key", "key"}, keys)
+	require.Equal(t, []string{"data", "data", "data"}, bodies)
+}
+
+func TestClientPostFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		status int
+		count  int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("bad request"))
+	}))
+	defer server.Close()
+
+	// 4xx is not retried.
+	status = http.StatusBadRequest
+	err := newTestClient(server.URL, 3).Post(context.Background(), &Request{})
+	require.True(t, cerror.ErrWebhookRequestFailed.Equal(err))
+	require.ErrorContains(t, err, "bad request")
+	require.Equal(t, 1, count)
+
+	// 5xx is retried until the max retries is reached.
+	mu.Lock()
+	status = http.StatusServiceUnavailable
+	count = 0
+	mu.Unlock()
+	err = newTestClient(server.URL, 2).Post(context.Background(), &Request{})
+	require.Error(t, err)
+	require.Equal(t, 3, count)
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+)
+
+const (
+	// defaultWorkerCount is the default number of workers which send requests.
+	defaultWorkerCount = 16
+	// defaultMaxRequestBytes is the default max size of a request body.
+	defaultMaxRequestBytes = 10 * 1024 * 1024
+	// defaultRequestTimeout is the default timeout of a request.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultMaxRetries is the default max number of retries of a request.
+	defaultMaxRetries = 3
+	// defaultRetryBackoff is the default base backoff of the retries.
+	defaultRetryBackoff = 500 * time.Millisecond
+	// maxRetryBackoff is the max backoff of the retries.
+	maxRetryBackoff = 30 * time.Second
+)
+
+// consumedQueries are the query parameters of the sink uri which are
+// consumed by TiCDC, they are removed from the endpoint url.
+var consumedQueries = []string{
+	// webhook sink
+	"worker-count",
+	"max-request-bytes",
+	"request-timeout",
+	"max-retries",
+	"retry-backoff",
+	// encoder
+	"protocol",
+	"enable-tidb-extension",
+	"max-batch-size",
+	"max-message-bytes",
+	"avro-decimal-handling-mode",
+	"avro-bigint-unsigned-handling-mode",
+	"avro-enable-watermark",
+	"schema-registry",
+	"only-output-updated-columns",
+}
+
+type urlConfig struct {
+	WorkerCount     *int    `form:"worker-count"`
+	MaxRequestBytes *int    `form:"max-request-bytes"`
+	RequestTimeout  *string `form:"request-timeout"`
+	MaxRetries      *uint64 `form:"max-retries"`
+	RetryBackoff    *string `form:"retry-backoff"`
+}
+
+// Config is the configuration of the webhook sink.
+type Config struct {
+	// Endpoint is the url which the events are posted to, the query
+	// parameters consumed by TiCDC are removed from it.
+	Endpoint string
+	// WorkerCount is the number of workers, the events of a table are
+	// always sent by the same worker to keep them in order.
+	WorkerCount int
+	// MaxRequestBytes is the max size of a request body, the messages
+	// are split into multiple requests if they exceed the limit.
+	MaxRequestBytes int
+	RequestTimeout  time.Duration
+	// MaxRetries is the max number of retries of a failed request,
+	// zero means the request is never retried.
+	MaxRetries   uint64
+	RetryBackoff time.Duration
+}
+
+// NewConfig returns the default webhook configuration.
+func NewConfig() *Config {
+	return &Config{
+		WorkerCount:     defaultWorkerCount,
+		MaxRequestBytes: defaultMaxRequestBytes,
+		RequestTimeout:  defaultRequestTimeout,
+		MaxRetries:      defaultMaxRetries,
+		RetryBackoff:    defaultRetryBackoff,
+	}
+}
+
+// Apply the sinkURI to update Config.
+func (c *Config) Apply(sinkURI *url.URL) error {
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !psink.IsWebhookScheme(scheme) {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"can't create webhook sink with unsupported scheme: %s", scheme)
+	}
+	if sinkURI.Host == "" {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"no webhook host is specified in sink-uri")
+	}
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err := binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+
+	if urlParameter.WorkerCount != nil {
+		if *urlParameter.WorkerCount <= 0 {
+			return cerror.ErrWebhookInvalidConfig.GenWithStack(
+				"invalid worker-count %d", *urlParameter.WorkerCount)
+		}
+		c.WorkerCount = *urlParameter.WorkerCount
+	}
+	if urlParameter.MaxRequestBytes != nil {
+		if *urlParameter.MaxRequestBytes <= 0 {
+			return cerror.ErrWebhookInvalidConfig.GenWithStack(
+				"invalid max-request-bytes %d", *urlParameter.MaxRequestBytes)
+		}
+		c.MaxRequestBytes = *urlParameter.MaxRequestBytes
+	}
+	if urlParameter.MaxRetries != nil {
+		c.MaxRetries = *urlParameter.MaxRetries
+	}
+	for _, d := range []struct {
+		name  string
+		value *string
+		dest  *time.Duration
+	}{
+		{"request-timeout", urlParameter.RequestTimeout, &c.RequestTimeout},
+		{"retry-backoff", urlParameter.RetryBackoff, &c.RetryBackoff},
+	} {
+		if d.value == nil || *d.value == "" {
+			continue
+		}
+		a, err := time.ParseDuration(*d.value)
+		if err != nil || a <= 0 {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+				errors.Errorf("invalid %s %s", d.name, *d.value))
+		}
+		*d.dest = a
+	}
+
+	endpoint := *sinkURI
+	endpoint.Scheme = scheme
+	queries := endpoint.Query()
+	for _, key := range consumedQueries {
+		queries.Del(key)
+	}
+	endpoint.RawQuery = queries.Encode()
+	c.Endpoint = endpoint.String()
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+// The headers of the requests sent by the webhook sink.
+const (
+	ProtocolHeader = "X-TiCDC-Protocol"
+	SchemaHeader   = "X-TiCDC-Schema"
+	TableHeader    = "X-TiCDC-Table"
+	CommitTsHeader = "X-TiCDC-Commit-Ts"
+	// MessageKeyHeader carries the base64 encoded key of the message,
+	// it is only set for the protocols which encode messages with keys,
+	// such as open-protocol.
+	MessageKeyHeader = "X-TiCDC-Message-Key"
+)
+
+// BuildRequests groups the encoded messages into requests.
+// A message with a key is sent in a request alone, since the key is carried
+// by the header. The messages without keys are joined by newlines and sent
+// in batches, the body of a batch never exceeds maxRequestBytes unless it
+// contains only one message.
+// The idempotency key of a request is keyPrefix followed by the index of
+// the request, so the requests built from the same messages have the same
+// idempotency keys.
+func BuildRequests(
+	keyPrefix string,
+	protocol config.Protocol,
+	msgs []*common.Message,
+	maxRequestBytes int,
+) []*Request {
+	var (
+		requests []*Request
+		buf      bytes.Buffer
+		first    *common.Message
+	)
+	newRequest := func(msg *common.Message, body []byte) *Request {
+		header := make(http.Header)
+		header.Set("Content-Type", contentType(protocol))
+		header.Set(ProtocolHeader, protocol.String())
+		header.Set(CommitTsHeader, strconv.FormatUint(msg.Ts, 10))
+		if msg.Schema != nil {
+			header.Set(SchemaHeader, *msg.Schema)
+		}
+		if msg.Table != nil {
+			header.Set(TableHeader, *msg.Table)
+		}
+		return &Request{
+			IdempotencyKey: fmt.Sprintf("%s/%d", keyPrefix, len(requests)),
+			Header:         header,
+			Body:           body,
+		}
+	}
+	flush := func() {
+		if first == nil {
+			return
+		}
+		body := make([]byte, buf.Len())
+		copy(body, buf.Bytes())
+		requests = append(requests, newRequest(first, body))
+		buf.Reset()
+		first = nil
+	}
+
+	for _, msg := range msgs {
+		if len(msg.Key) > 0 {
+			flush()
+			req := newRequest(msg, msg.Value)
+			req.Header.Set(MessageKeyHeader, base64.StdEncoding.EncodeToString(msg.Key))
+			requests = append(requests, req)
+			continue
+		}
+		if first != nil && buf.Len()+len(msg.Value)+1 > maxRequestBytes {
+			flush()
+		}
+		if first == nil {
+			first = msg
+		}
+		buf.Write(msg.Value)
+		buf.WriteByte('\n')
+	}
+	flush()
+	return requests
+}
+
+func contentType(protocol config.Protocol) string {
+	switch protocol {
+	case config.ProtocolCanalJSON, config.ProtocolMaxwell, config.ProtocolDebezium:
+		return "application/x-ndjson"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRequests(t *testing.T) {
+	t.Parallel()
+
+	schema, table := "test", "t"
+	newMsg := func(key, value string) *common.Message {
+		var k []byte
+		if key != "" {
+			k = []byte(key)
+		}
+		return common.NewMsg(config.ProtocolCanalJSON, k, []byte(value),
+			100, model.MessageTypeRow, &schema, &table)
+	}
+
+	requests := BuildRequests("cf/1/100", config.ProtocolCanalJSON, []*common.Message{
+		newMsg("", "a1"),
+		newMsg("", "a2"),
+		newMsg("", "a3"),
+		newMsg("k", "b"),
+		newMsg("", "c"),
+	}, 6)
+	require.Len(t, requests, 4)
+
+	require.Equal(t, "a1\na2\n", string(requests[0].Body))
+	require.Equal(t, "cf/1/100/0", requests[0].IdempotencyKey)
+	require.Equal(t, "application/x-ndjson", requests[0].Header.Get("Content-Type"))
+	require.Equal(t, "canal-json", requests[0].Header.Get(ProtocolHeader))
+	require.Equal(t, "100", requests[0].Header.Get(CommitTsHeader))
+	require.Equal(t, "test", requests[0].Header.Get(SchemaHeader))
+	require.Equal(t, "t", requests[0].Header.Get(TableHeader))
+	require.Empty(t, requests[0].Header.Get(MessageKeyHeader))
+
+	require.Equal(t, "a3\n", string(requests[1].Body))
+	require.Equal(t, "cf/1/100/1", requests[1].IdempotencyKey)
+
+	require.Equal(t, "b", string(requests[2].Body))
+	require.Equal(t, "aw==", requests[2].Header.Get(MessageKeyHeader))
+
+	require.Equal(t, "c\n", string(requests[3].Body))
+	require.Equal(t, "cf/1/100/3", requests[3].IdempotencyKey)
+
+	// a message larger than the limit is sent alone.
+	requests = BuildRequests("cf/1/100", config.ProtocolOpen, []*common.Message{
+		newMsg("", "abcdefgh"),
+	}, 6)
+	require.Len(t, requests, 1)
+	require.Equal(t, "abcdefgh\n", string(requests[0].Body))
+	require.Equal(t, "application/octet-stream", requests[0].Header.Get("Content-Type"))
+}
@@ -41,8 +41,7 @@ func TestSpanMap(t *testing.T) {
 	require.True(t, ok)
 
 	// Overwrite then get.
-	old, ok := m.ReplaceOrInsert(
-		tablepb.Span{TableID: 1, StartKey: []byte{1}, EndKey: []byte{1}}, 3)
+	old, ok := m.ReplaceOrInsert(tablepb.Span{TableID: 1, StartKey: []byte{1}}, 3)
 	require.Equal(t, old, 2)
 	require.True(t, ok)
 	require.Equal(t, 2, m.Len())
@@ -51,6 +50,17 @@ func TestSpanMap(t *testing.T) {
 	require.Equal(t, v, 3)
 	require.True(t, ok)
 
+	// Spans with the same start key and different end keys are different.
+	_, ok = m.ReplaceOrInsert(
+		tablepb.Span{TableID: 1, StartKey: []byte{1}, EndKey: []byte{2}}, 4)
+	require.False(t, ok)
+	require.Equal(t, 3, m.Len())
+	v = m.GetV(tablepb.Span{TableID: 1, StartKey: []byte{1}, EndKey: []byte{2}})
+	require.Equal(t, v, 4)
+	v = m.GetV(tablepb.Span{TableID: 1, StartKey: []byte{1}})
+	require.Equal(t, v, 3)
+	m.Delete(tablepb.Span{TableID: 1, StartKey: []byte{1}, EndKey: []byte{2}})
+
 	// get value
 	v = m.GetV(tablepb.Span{TableID: 1, StartKey: []byte{1}})
 	require.Equal(t, v, 3)
@@ -180,6 +180,17 @@ func Intersect(lhs tablepb.Span, rhs tablepb.Span) (span tablepb.Span, err error
 	return tablepb.Span{StartKey: start, EndKey: end}, nil
 }
 
+// Overlap returns true if lhs and rhs belong to the same table and have any
+// common key.
+func Overlap(lhs tablepb.Span, rhs tablepb.Span) bool {
+	// Nil start key means negative infinity, nil end key means positive infinity.
+	startsBefore := func(start, end []byte) bool {
+		return len(start) == 0 || len(end) == 0 || bytes.Compare(start, end) < 0
+	}
+	return lhs.TableID == rhs.TableID &&
+		startsBefore(lhs.StartKey, rhs.EndKey) && startsBefore(rhs.StartKey, lhs.EndKey)
+}
+
 // IsSubSpan returns true if the sub span is parents spans
 func IsSubSpan(sub tablepb.Span, parents ...tablepb.Span) bool {
 	if bytes.Compare(sub.StartKey, sub.EndKey) >= 0 {
@@ -121,6 +121,51 @@ func TestIntersect(t *testing.T) {
 	}
 }
 
+func TestOverlap(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		lhs     tablepb.Span
+		rhs     tablepb.Span
+		overlap bool
+	}{
+		{
+			lhs:     tablepb.Span{StartKey: []byte("a"), EndKey: []byte("c")},
+			rhs:     tablepb.Span{StartKey: []byte("b"), EndKey: []byte("d")},
+			overlap: true,
+		},
+		{
+			lhs:     tablepb.Span{StartKey: []byte("a"), EndKey: []byte("d")},
+			rhs:     tablepb.Span{StartKey: []byte("b"), EndKey: []byte("c")},
+			overlap: true,
+		},
+		{
+			lhs:     tablepb.Span{StartKey: []byte("a"), EndKey: []byte("b")},
+			rhs:     tablepb.Span{StartKey: []byte("b"), EndKey: []byte("c")},
+			overlap: false,
+		},
+		{
+			lhs:     tablepb.Span{StartKey: []byte("c"), EndKey: []byte("d")},
+			rhs:     tablepb.Span{StartKey: []byte("a"), EndKey: []byte("b")},
+			overlap: false,
+		},
+		{
+			lhs:     tablepb.Span{StartKey: nil, EndKey: []byte("b")},
+			rhs:     tablepb.Span{StartKey: []byte("a"), EndKey: nil},
+			overlap: true,
+		},
+		{
+			lhs:     tablepb.Span{TableID: 1, StartKey: []byte("a"), EndKey: []byte("c")},
+			rhs:     tablepb.Span{TableID: 2, StartKey: []byte("b"), EndKey: []byte("d")},
+			overlap: false,
+		},
+	}
+	for _, tc := range tcs {
+		require.Equal(t, tc.overlap, Overlap(tc.lhs, tc.rhs), "%v", tc)
+		require.Equal(t, tc.overlap, Overlap(tc.rhs, tc.lhs), "%v", tc)
+	}
+}
+
 func TestGetTableRange(t *testing.T) {
 	t.Parallel()
 
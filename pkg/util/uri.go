@@ -74,9 +74,24 @@ func MaskSinkURI(uri string) (string, error) {
 		return "", err
 	}
 	queries := uriParsed.Query()
-	if queries.Has("sasl-password") {
-		queries.Set("sasl-password", "xxxxx")
+	masked := false
+	for _, key := range sensitiveSinkURIQueries {
+		if queries.Has(key) {
+			queries.Set(key, "xxxxx")
+			masked = true
+		}
+	}
+	if masked {
 		uriParsed.RawQuery = queries.Encode()
 	}
 	return uriParsed.Redacted(), nil
 }
+
+// sensitiveSinkURIQueries are the query parameters of a sink uri
+// which must be masked before being printed.
+var sensitiveSinkURIQueries = []string{
+	"sasl-password",
+	// pulsar sink
+	"authentication-token",
+	"basic-password",
+}
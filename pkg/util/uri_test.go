@@ -80,6 +80,14 @@ func TestMaskSinkURI(t *testing.T) {
 			"kafka://127.0.0.1:9093/cdc?sasl-mechanism=SCRAM-SHA-256&sasl-user=ticdc&sasl-password=verysecure",
 			"kafka://127.0.0.1:9093/cdc?sasl-mechanism=SCRAM-SHA-256&sasl-password=xxxxx&sasl-user=ticdc",
 		},
+		{
+			"pulsar://127.0.0.1:6650/cdc?basic-user-name=ticdc&basic-password=verysecure",
+			"pulsar://127.0.0.1:6650/cdc?basic-password=xxxxx&basic-user-name=ticdc",
+		},
+		{
+			"pulsar+ssl://127.0.0.1:6651/cdc?authentication-token=token",
+			"pulsar+ssl://127.0.0.1:6651/cdc?authentication-token=xxxxx",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,1862 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: TiCDCProtocol.proto
+
+package ticdc
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type EventType int32
+
+const (
+	EventType_EVENT_UNKNOWN  EventType = 0
+	EventType_EVENT_ROW      EventType = 1
+	EventType_EVENT_DDL      EventType = 2
+	EventType_EVENT_RESOLVED EventType = 3
+)
+
+var EventType_name = map[int32]string{
+	0: "EVENT_UNKNOWN",
+	1: "EVENT_ROW",
+	2: "EVENT_DDL",
+	3: "EVENT_RESOLVED",
+}
+
+var EventType_value = map[string]int32{
+	"EVENT_UNKNOWN":  0,
+	"EVENT_ROW":      1,
+	"EVENT_DDL":      2,
+	"EVENT_RESOLVED": 3,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{0}
+}
+
+type RowOp int32
+
+const (
+	RowOp_OP_UNKNOWN RowOp = 0
+	RowOp_OP_INSERT  RowOp = 1
+	RowOp_OP_UPDATE  RowOp = 2
+	RowOp_OP_DELETE  RowOp = 3
+)
+
+var RowOp_name = map[int32]string{
+	0: "OP_UNKNOWN",
+	1: "OP_INSERT",
+	2: "OP_UPDATE",
+	3: "OP_DELETE",
+}
+
+var RowOp_value = map[string]int32{
+	"OP_UNKNOWN": 0,
+	"OP_INSERT":  1,
+	"OP_UPDATE":  2,
+	"OP_DELETE":  3,
+}
+
+func (x RowOp) String() string {
+	return proto.EnumName(RowOp_name, int32(x))
+}
+
+func (RowOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{1}
+}
+
+type Column struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MysqlType uint32 `protobuf:"varint,2,opt,name=mysql_type,json=mysqlType,proto3" json:"mysql_type,omitempty"`
+	Flag      uint64 `protobuf:"varint,3,opt,name=flag,proto3" json:"flag,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*Column_IntValue
+	//	*Column_UintValue
+	//	*Column_FloatValue
+	//	*Column_DoubleValue
+	//	*Column_StringValue
+	//	*Column_BytesValue
+	Value isColumn_Value `protobuf_oneof:"value"`
+}
+
+func (m *Column) Reset()         { *m = Column{} }
+func (m *Column) String() string { return proto.CompactTextString(m) }
+func (*Column) ProtoMessage()    {}
+func (*Column) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{0}
+}
+func (m *Column) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Column) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Column.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Column) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Column.Merge(m, src)
+}
+func (m *Column) XXX_Size() int {
+	return m.Size()
+}
+func (m *Column) XXX_DiscardUnknown() {
+	xxx_messageInfo_Column.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Column proto.InternalMessageInfo
+
+type isColumn_Value interface {
+	isColumn_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Column_IntValue struct {
+	IntValue int64 `protobuf:"varint,4,opt,name=int_value,json=intValue,proto3,oneof" json:"int_value,omitempty"`
+}
+type Column_UintValue struct {
+	UintValue uint64 `protobuf:"varint,5,opt,name=uint_value,json=uintValue,proto3,oneof" json:"uint_value,omitempty"`
+}
+type Column_FloatValue struct {
+	FloatValue float32 `protobuf:"fixed32,6,opt,name=float_value,json=floatValue,proto3,oneof" json:"float_value,omitempty"`
+}
+type Column_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,7,opt,name=double_value,json=doubleValue,proto3,oneof" json:"double_value,omitempty"`
+}
+type Column_StringValue struct {
+	StringValue string `protobuf:"bytes,8,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"`
+}
+type Column_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,9,opt,name=bytes_value,json=bytesValue,proto3,oneof" json:"bytes_value,omitempty"`
+}
+
+func (*Column_IntValue) isColumn_Value()    {}
+func (*Column_UintValue) isColumn_Value()   {}
+func (*Column_FloatValue) isColumn_Value()  {}
+func (*Column_DoubleValue) isColumn_Value() {}
+func (*Column_StringValue) isColumn_Value() {}
+func (*Column_BytesValue) isColumn_Value()  {}
+
+func (m *Column) GetValue() isColumn_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Column) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Column) GetMysqlType() uint32 {
+	if m != nil {
+		return m.MysqlType
+	}
+	return 0
+}
+
+func (m *Column) GetFlag() uint64 {
+	if m != nil {
+		return m.Flag
+	}
+	return 0
+}
+
+func (m *Column) GetIntValue() int64 {
+	if x, ok := m.GetValue().(*Column_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (m *Column) GetUintValue() uint64 {
+	if x, ok := m.GetValue().(*Column_UintValue); ok {
+		return x.UintValue
+	}
+	return 0
+}
+
+func (m *Column) GetFloatValue() float32 {
+	if x, ok := m.GetValue().(*Column_FloatValue); ok {
+		return x.FloatValue
+	}
+	return 0
+}
+
+func (m *Column) GetDoubleValue() float64 {
+	if x, ok := m.GetValue().(*Column_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (m *Column) GetStringValue() string {
+	if x, ok := m.GetValue().(*Column_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *Column) GetBytesValue() []byte {
+	if x, ok := m.GetValue().(*Column_BytesValue); ok {
+		return x.BytesValue
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Column) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Column_IntValue)(nil),
+		(*Column_UintValue)(nil),
+		(*Column_FloatValue)(nil),
+		(*Column_DoubleValue)(nil),
+		(*Column_StringValue)(nil),
+		(*Column_BytesValue)(nil),
+	}
+}
+
+type RowChanged struct {
+	Op          RowOp     `protobuf:"varint,1,opt,name=op,proto3,enum=ticdc.RowOp" json:"op,omitempty"`
+	TableId     int64     `protobuf:"varint,2,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	IsPartition bool      `protobuf:"varint,3,opt,name=is_partition,json=isPartition,proto3" json:"is_partition,omitempty"`
+	PreColumns  []*Column `protobuf:"bytes,4,rep,name=pre_columns,json=preColumns,proto3" json:"pre_columns,omitempty"`
+	Columns     []*Column `protobuf:"bytes,5,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (m *RowChanged) Reset()         { *m = RowChanged{} }
+func (m *RowChanged) String() string { return proto.CompactTextString(m) }
+func (*RowChanged) ProtoMessage()    {}
+func (*RowChanged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{1}
+}
+func (m *RowChanged) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RowChanged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RowChanged.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RowChanged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowChanged.Merge(m, src)
+}
+func (m *RowChanged) XXX_Size() int {
+	return m.Size()
+}
+func (m *RowChanged) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowChanged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowChanged proto.InternalMessageInfo
+
+func (m *RowChanged) GetOp() RowOp {
+	if m != nil {
+		return m.Op
+	}
+	return RowOp_OP_UNKNOWN
+}
+
+func (m *RowChanged) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *RowChanged) GetIsPartition() bool {
+	if m != nil {
+		return m.IsPartition
+	}
+	return false
+}
+
+func (m *RowChanged) GetPreColumns() []*Column {
+	if m != nil {
+		return m.PreColumns
+	}
+	return nil
+}
+
+func (m *RowChanged) GetColumns() []*Column {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type DDL struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type  uint32 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (m *DDL) Reset()         { *m = DDL{} }
+func (m *DDL) String() string { return proto.CompactTextString(m) }
+func (*DDL) ProtoMessage()    {}
+func (*DDL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{2}
+}
+func (m *DDL) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DDL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DDL.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DDL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DDL.Merge(m, src)
+}
+func (m *DDL) XXX_Size() int {
+	return m.Size()
+}
+func (m *DDL) XXX_DiscardUnknown() {
+	xxx_messageInfo_DDL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DDL proto.InternalMessageInfo
+
+func (m *DDL) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *DDL) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+type Event struct {
+	Version  uint32      `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Type     EventType   `protobuf:"varint,2,opt,name=type,proto3,enum=ticdc.EventType" json:"type,omitempty"`
+	CommitTs uint64      `protobuf:"varint,3,opt,name=commit_ts,json=commitTs,proto3" json:"commit_ts,omitempty"`
+	Schema   string      `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	Table    string      `protobuf:"bytes,5,opt,name=table,proto3" json:"table,omitempty"`
+	Row      *RowChanged `protobuf:"bytes,6,opt,name=row,proto3" json:"row,omitempty"`
+	Ddl      *DDL        `protobuf:"bytes,7,opt,name=ddl,proto3" json:"ddl,omitempty"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e74a2f8d1beb4a73, []int{3}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return m.Size()
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Event) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_EVENT_UNKNOWN
+}
+
+func (m *Event) GetCommitTs() uint64 {
+	if m != nil {
+		return m.CommitTs
+	}
+	return 0
+}
+
+func (m *Event) GetSchema() string {
+	if m != nil {
+		return m.Schema
+	}
+	return ""
+}
+
+func (m *Event) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *Event) GetRow() *RowChanged {
+	if m != nil {
+		return m.Row
+	}
+	return nil
+}
+
+func (m *Event) GetDdl() *DDL {
+	if m != nil {
+		return m.Ddl
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("ticdc.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("ticdc.RowOp", RowOp_name, RowOp_value)
+	proto.RegisterType((*Column)(nil), "ticdc.Column")
+	proto.RegisterType((*RowChanged)(nil), "ticdc.RowChanged")
+	proto.RegisterType((*DDL)(nil), "ticdc.DDL")
+	proto.RegisterType((*Event)(nil), "ticdc.Event")
+}
+
+func init() { proto.RegisterFile("TiCDCProtocol.proto", fileDescriptor_e74a2f8d1beb4a73) }
+
+var fileDescriptor_e74a2f8d1beb4a73 = []byte{
+	// 629 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xc1, 0x6e, 0x9b, 0x4c,
+	0x10, 0x66, 0xc1, 0xd8, 0x66, 0xb0, 0x2d, 0xb2, 0xff, 0xaf, 0xca, 0x6d, 0x13, 0x97, 0x38, 0x95,
+	0x8a, 0x72, 0x70, 0x25, 0xf7, 0x09, 0x1a, 0x83, 0xe4, 0xa8, 0x96, 0x6d, 0x6d, 0x68, 0x72, 0x44,
+	0x04, 0x88, 0x83, 0x04, 0x2c, 0x01, 0x9c, 0xc8, 0x6f, 0xd1, 0xc7, 0xe8, 0x6b, 0xf4, 0xd6, 0x63,
+	0x2e, 0x95, 0x7a, 0xac, 0x92, 0x17, 0xa9, 0x76, 0x59, 0x3b, 0x89, 0xd4, 0xdb, 0xcc, 0xf7, 0x7d,
+	0xb3, 0x33, 0xcc, 0x37, 0xc0, 0x7f, 0x6e, 0x3c, 0xb1, 0x27, 0xcb, 0x82, 0x56, 0x34, 0xa0, 0xc9,
+	0x28, 0x67, 0x01, 0x56, 0xab, 0x38, 0x08, 0x83, 0xe1, 0x77, 0x19, 0x9a, 0x13, 0x9a, 0xac, 0xd3,
+	0x0c, 0x63, 0x68, 0x64, 0x7e, 0x1a, 0xf5, 0x91, 0x89, 0x2c, 0x8d, 0xf0, 0x18, 0x1f, 0x00, 0xa4,
+	0x9b, 0xf2, 0x26, 0xf1, 0xaa, 0x4d, 0x1e, 0xf5, 0x65, 0x13, 0x59, 0x5d, 0xa2, 0x71, 0xc4, 0xdd,
+	0xe4, 0x11, 0x2b, 0xb9, 0x4a, 0xfc, 0x55, 0x5f, 0x31, 0x91, 0xd5, 0x20, 0x3c, 0xc6, 0x07, 0xa0,
+	0xc5, 0x59, 0xe5, 0xdd, 0xfa, 0xc9, 0x3a, 0xea, 0x37, 0x4c, 0x64, 0x29, 0x53, 0x89, 0xb4, 0xe3,
+	0xac, 0x3a, 0x67, 0x08, 0x7e, 0x07, 0xb0, 0x7e, 0xe2, 0x55, 0x56, 0x38, 0x95, 0x88, 0xb6, 0xde,
+	0x09, 0x0e, 0x41, 0xbf, 0x4a, 0xa8, 0xbf, 0x55, 0x34, 0x4d, 0x64, 0xc9, 0x53, 0x89, 0x00, 0x07,
+	0x6b, 0xc9, 0x11, 0x74, 0x42, 0xba, 0xbe, 0x4c, 0x22, 0xa1, 0x69, 0x99, 0xc8, 0x42, 0x53, 0x89,
+	0xe8, 0x35, 0xba, 0x13, 0x95, 0x55, 0x11, 0x67, 0x2b, 0x21, 0x6a, 0xb3, 0xcf, 0x62, 0xa2, 0x1a,
+	0xdd, 0x35, 0xbb, 0xdc, 0x54, 0x51, 0x29, 0x34, 0x9a, 0x89, 0xac, 0x0e, 0x6b, 0xc6, 0x41, 0x2e,
+	0x39, 0x69, 0x81, 0xca, 0xc9, 0xe1, 0x0f, 0x04, 0x40, 0xe8, 0xdd, 0xe4, 0xda, 0xcf, 0x56, 0x51,
+	0x88, 0xf7, 0x41, 0xa6, 0x39, 0x5f, 0x56, 0x6f, 0xdc, 0x19, 0xf1, 0x6d, 0x8e, 0x08, 0xbd, 0x5b,
+	0xe4, 0x44, 0xa6, 0x39, 0x7e, 0x0d, 0xed, 0xca, 0x67, 0x13, 0xc6, 0x21, 0x5f, 0x9b, 0x42, 0x5a,
+	0x3c, 0x3f, 0x0d, 0xf1, 0x21, 0x74, 0xe2, 0xd2, 0xcb, 0xfd, 0xa2, 0x8a, 0xab, 0x98, 0x66, 0x7c,
+	0x79, 0x6d, 0xa2, 0xc7, 0xe5, 0x72, 0x0b, 0xe1, 0x11, 0xe8, 0x79, 0x11, 0x79, 0x01, 0x37, 0xa6,
+	0xec, 0x37, 0x4c, 0xc5, 0xd2, 0xc7, 0x5d, 0xd1, 0xa4, 0xb6, 0x8b, 0x40, 0x5e, 0x44, 0x75, 0x58,
+	0xe2, 0x0f, 0xd0, 0xda, 0x6a, 0xd5, 0x7f, 0x69, 0xb7, 0xec, 0xf0, 0x23, 0x28, 0xb6, 0x3d, 0xc3,
+	0xff, 0x83, 0x7a, 0xb3, 0x8e, 0x8a, 0x8d, 0xf0, 0xba, 0x4e, 0x98, 0x9b, 0xcf, 0x6c, 0xe6, 0xf1,
+	0xf0, 0x17, 0x02, 0xd5, 0xb9, 0x8d, 0xb2, 0x0a, 0xf7, 0xa1, 0x75, 0x1b, 0x15, 0x25, 0x9b, 0x18,
+	0x71, 0xc1, 0x36, 0xc5, 0xef, 0x9f, 0xd5, 0xf5, 0xc6, 0x86, 0x68, 0xcd, 0xab, 0xd8, 0x95, 0xd4,
+	0x2f, 0xe1, 0xb7, 0xa0, 0x05, 0x34, 0x4d, 0xe3, 0xca, 0xab, 0x4a, 0x71, 0x30, 0xed, 0x1a, 0x70,
+	0x4b, 0xfc, 0x0a, 0x9a, 0x65, 0x70, 0x1d, 0xa5, 0x3e, 0xbf, 0x18, 0x8d, 0x88, 0x8c, 0x0d, 0xca,
+	0xd7, 0xc6, 0x0f, 0x45, 0x23, 0x75, 0x82, 0x8f, 0x40, 0x29, 0xe8, 0x1d, 0x3f, 0x0d, 0x7d, 0xbc,
+	0xf7, 0xb4, 0x7b, 0x61, 0x0d, 0x61, 0x2c, 0xde, 0x07, 0x25, 0x0c, 0x13, 0x7e, 0x1b, 0xfa, 0x18,
+	0x84, 0xc8, 0xb6, 0x67, 0x84, 0xc1, 0xc7, 0x4b, 0xd0, 0x76, 0x03, 0xe2, 0x3d, 0xe8, 0x3a, 0xe7,
+	0xce, 0xdc, 0xf5, 0xbe, 0xce, 0xbf, 0xcc, 0x17, 0x17, 0x73, 0x43, 0xc2, 0x5d, 0xd0, 0x6a, 0x88,
+	0x2c, 0x2e, 0x0c, 0xf4, 0x94, 0xda, 0xf6, 0xcc, 0x90, 0x31, 0x86, 0x9e, 0x60, 0x9d, 0xb3, 0xc5,
+	0xec, 0xdc, 0xb1, 0x0d, 0xe5, 0xd8, 0x06, 0x95, 0xdb, 0x8f, 0x7b, 0x00, 0x8b, 0xe5, 0xcb, 0xa7,
+	0x16, 0x4b, 0xef, 0x74, 0x7e, 0xe6, 0x10, 0xb7, 0x7e, 0x8a, 0xd1, 0x4b, 0xfb, 0xb3, 0xeb, 0x18,
+	0xb2, 0x48, 0x6d, 0x67, 0xe6, 0xb8, 0x8e, 0xa1, 0x9c, 0x38, 0x3f, 0x1f, 0x06, 0xe8, 0xfe, 0x61,
+	0x80, 0xfe, 0x3c, 0x0c, 0xd0, 0xb7, 0xc7, 0x81, 0x74, 0xff, 0x38, 0x90, 0x7e, 0x3f, 0x0e, 0x24,
+	0x78, 0x13, 0xd0, 0x74, 0x94, 0xc7, 0xd9, 0x2a, 0xf0, 0x73, 0xf1, 0x35, 0xb9, 0xf8, 0xa5, 0x4f,
+	0xba, 0x2f, 0xfe, 0xf0, 0x29, 0xba, 0x6c, 0x72, 0xea, 0xd3, 0xdf, 0x01, 0x00, 0x4c, 0x34, 0xab,
+	0xbe, 0xfb, 0x03, 0x00, 0x00,
+}
+
+func (m *Column) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Column) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.Flag != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.Flag))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MysqlType != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.MysqlType))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Column_IntValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_IntValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.IntValue))
+	i--
+	dAtA[i] = 0x20
+	return len(dAtA) - i, nil
+}
+func (m *Column_UintValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_UintValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.UintValue))
+	i--
+	dAtA[i] = 0x28
+	return len(dAtA) - i, nil
+}
+func (m *Column_FloatValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_FloatValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= 4
+	encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.FloatValue))))
+	i--
+	dAtA[i] = 0x35
+	return len(dAtA) - i, nil
+}
+func (m *Column_DoubleValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_DoubleValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= 8
+	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.DoubleValue))))
+	i--
+	dAtA[i] = 0x39
+	return len(dAtA) - i, nil
+}
+func (m *Column_StringValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_StringValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.StringValue)
+	copy(dAtA[i:], m.StringValue)
+	i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.StringValue)))
+	i--
+	dAtA[i] = 0x42
+	return len(dAtA) - i, nil
+}
+func (m *Column_BytesValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Column_BytesValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BytesValue != nil {
+		i -= len(m.BytesValue)
+		copy(dAtA[i:], m.BytesValue)
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.BytesValue)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RowChanged) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowChanged) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Columns) > 0 {
+		for iNdEx := len(m.Columns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Columns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTiCDCProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.PreColumns) > 0 {
+		for iNdEx := len(m.PreColumns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PreColumns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTiCDCProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.IsPartition {
+		i--
+		if m.IsPartition {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TableId != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Op != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.Op))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DDL) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DDL) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DDL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ddl != nil {
+		{
+			size, err := m.Ddl.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTiCDCProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Row != nil {
+		{
+			size, err := m.Row.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTiCDCProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Schema) > 0 {
+		i -= len(m.Schema)
+		copy(dAtA[i:], m.Schema)
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(len(m.Schema)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.CommitTs != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.CommitTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Type != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Version != 0 {
+		i = encodeVarintTiCDCProtocol(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTiCDCProtocol(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTiCDCProtocol(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Column) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	if m.MysqlType != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.MysqlType))
+	}
+	if m.Flag != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.Flag))
+	}
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *Column_IntValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovTiCDCProtocol(uint64(m.IntValue))
+	return n
+}
+func (m *Column_UintValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovTiCDCProtocol(uint64(m.UintValue))
+	return n
+}
+func (m *Column_FloatValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 5
+	return n
+}
+func (m *Column_DoubleValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *Column_StringValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.StringValue)
+	n += 1 + l + sovTiCDCProtocol(uint64(l))
+	return n
+}
+func (m *Column_BytesValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BytesValue != nil {
+		l = len(m.BytesValue)
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	return n
+}
+func (m *RowChanged) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Op != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.Op))
+	}
+	if m.TableId != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.TableId))
+	}
+	if m.IsPartition {
+		n += 2
+	}
+	if len(m.PreColumns) > 0 {
+		for _, e := range m.PreColumns {
+			l = e.Size()
+			n += 1 + l + sovTiCDCProtocol(uint64(l))
+		}
+	}
+	if len(m.Columns) > 0 {
+		for _, e := range m.Columns {
+			l = e.Size()
+			n += 1 + l + sovTiCDCProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DDL) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.Version))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.Type))
+	}
+	if m.CommitTs != 0 {
+		n += 1 + sovTiCDCProtocol(uint64(m.CommitTs))
+	}
+	l = len(m.Schema)
+	if l > 0 {
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	l = len(m.Table)
+	if l > 0 {
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	if m.Row != nil {
+		l = m.Row.Size()
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	if m.Ddl != nil {
+		l = m.Ddl.Size()
+		n += 1 + l + sovTiCDCProtocol(uint64(l))
+	}
+	return n
+}
+
+func sovTiCDCProtocol(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTiCDCProtocol(x uint64) (n int) {
+	return sovTiCDCProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Column) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTiCDCProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Column: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Column: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MysqlType", wireType)
+			}
+			m.MysqlType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MysqlType |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
+			}
+			m.Flag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Flag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntValue", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &Column_IntValue{v}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UintValue", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &Column_UintValue{v}
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatValue", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.Value = &Column_FloatValue{float32(math.Float32frombits(v))}
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleValue", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = &Column_DoubleValue{float64(math.Float64frombits(v))}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = &Column_StringValue{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Value = &Column_BytesValue{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTiCDCProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RowChanged) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTiCDCProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowChanged: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowChanged: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= RowOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
+			}
+			m.TableId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableId |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsPartition", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsPartition = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreColumns", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreColumns = append(m.PreColumns, &Column{})
+			if err := m.PreColumns[len(m.PreColumns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Columns = append(m.Columns, &Column{})
+			if err := m.Columns[len(m.Columns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTiCDCProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DDL) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTiCDCProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DDL: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DDL: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTiCDCProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Event) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTiCDCProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EventType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			}
+			m.CommitTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schema = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Row == nil {
+				m.Row = &RowChanged{}
+			}
+			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ddl", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ddl == nil {
+				m.Ddl = &DDL{}
+			}
+			if err := m.Ddl.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTiCDCProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTiCDCProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTiCDCProtocol(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTiCDCProtocol
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTiCDCProtocol
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTiCDCProtocol
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTiCDCProtocol
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTiCDCProtocol
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTiCDCProtocol        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTiCDCProtocol          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTiCDCProtocol = fmt.Errorf("proto: unexpected end of group")
+)